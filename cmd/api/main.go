@@ -6,6 +6,9 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"strconv"
+	"time"
+	_ "time/tzdata" // the runtime image ships without zoneinfo
 
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
@@ -14,8 +17,15 @@ import (
 	accountapp "github.com/raihanstark/trade-journal/internal/application/account"
 	analyticsapp "github.com/raihanstark/trade-journal/internal/application/analytics"
 	"github.com/raihanstark/trade-journal/internal/application/auth"
+	fxapp "github.com/raihanstark/trade-journal/internal/application/fx"
+	gradeapp "github.com/raihanstark/trade-journal/internal/application/grade"
+	instrumentapp "github.com/raihanstark/trade-journal/internal/application/instrument"
+	mistakeapp "github.com/raihanstark/trade-journal/internal/application/mistake"
+	ratingapp "github.com/raihanstark/trade-journal/internal/application/rating"
 	strategyapp "github.com/raihanstark/trade-journal/internal/application/strategy"
 	tradeapp "github.com/raihanstark/trade-journal/internal/application/trade"
+	tradegroupapp "github.com/raihanstark/trade-journal/internal/application/tradegroup"
+	tradetemplateapp "github.com/raihanstark/trade-journal/internal/application/tradetemplate"
 	"github.com/raihanstark/trade-journal/internal/db"
 	"github.com/raihanstark/trade-journal/internal/infrastructure/http/handlers"
 	custommiddleware "github.com/raihanstark/trade-journal/internal/infrastructure/http/middleware"
@@ -38,6 +48,7 @@ func main() {
 	minioAccessKey := os.Getenv("MINIO_ACCESS_KEY")
 	minioSecretKey := os.Getenv("MINIO_SECRET_KEY")
 	minioBucket := os.Getenv("MINIO_BUCKET")
+	trashRetentionDays := os.Getenv("TRASH_RETENTION_DAYS")
 
 	if databaseURL == "" {
 		log.Fatal("DATABASE_URL environment variable is required")
@@ -60,6 +71,14 @@ func main() {
 	if minioBucket == "" {
 		minioBucket = "trade-journal"
 	}
+	retentionDays := 30
+	if trashRetentionDays != "" {
+		days, err := strconv.Atoi(trashRetentionDays)
+		if err != nil || days < 1 {
+			log.Fatal("TRASH_RETENTION_DAYS must be a positive number of days")
+		}
+		retentionDays = days
+	}
 
 	// Connect to database
 	dbConn, err := sql.Open("postgres", databaseURL)
@@ -79,16 +98,35 @@ func main() {
 	userRepository := persistence.NewUserRepository(queries)
 	accountRepository := persistence.NewAccountRepository(queries)
 	strategyRepository := persistence.NewStrategyRepository(queries)
-	tradeRepository := persistence.NewTradeRepository(queries)
+	tradeRepository := persistence.NewTradeRepository(dbConn, queries)
 	analyticsRepository := persistence.NewAnalyticsRepository(queries)
+	instrumentRepository := persistence.NewInstrumentRepository(queries)
+	fxRateRepository := persistence.NewFXRateRepository(queries)
+	mistakeRepository := persistence.NewMistakeRepository(queries)
+	ratingRepository := persistence.NewRatingRepository(queries)
+	gradeRepository := persistence.NewGradeRepository(queries)
+	tradeGroupRepository := persistence.NewTradeGroupRepository(queries)
+	tradeTemplateRepository := persistence.NewTradeTemplateRepository(queries)
+	auditRepository := persistence.NewAuditRepository(queries)
+	transactor := persistence.NewTransactor(dbConn, queries)
 	tokenGenerator := security.NewJWTTokenGenerator(jwtSecret)
 
 	// Initialize application layer
 	authService := auth.NewService(userRepository, tokenGenerator)
-	accountService := accountapp.NewService(accountRepository)
-	strategyService := strategyapp.NewService(strategyRepository)
-	tradeService := tradeapp.NewService(tradeRepository, accountRepository)
-	analyticsService := analyticsapp.NewService(analyticsRepository)
+	strategyService := strategyapp.NewService(strategyRepository, auditRepository)
+	tradeService := tradeapp.NewService(tradeRepository, accountRepository, instrumentRepository, fxRateRepository, userRepository, auditRepository, transactor)
+	accountService := accountapp.NewService(accountRepository, auditRepository, transactor, tradeService)
+	analyticsService := analyticsapp.NewService(analyticsRepository, userRepository)
+	instrumentService := instrumentapp.NewService(instrumentRepository)
+	fxService := fxapp.NewService(fxRateRepository)
+	mistakeService := mistakeapp.NewService(mistakeRepository)
+	ratingService := ratingapp.NewService(ratingRepository)
+	gradeService := gradeapp.NewService(gradeRepository)
+	tradeGroupService := tradegroupapp.NewService(tradeGroupRepository, tradeRepository, transactor)
+	tradeTemplateService := tradetemplateapp.NewService(tradeTemplateRepository)
+
+	// Permanently remove trashed trades and accounts once the retention period has passed
+	go purgeTrash(tradeService, accountService, time.Duration(retentionDays)*24*time.Hour)
 
 	// Initialize storage (MinIO)
 	minioStorage, err := storage.NewMinIOStorage(minioEndpoint, minioAccessKey, minioSecretKey, minioBucket, false)
@@ -110,6 +148,14 @@ func main() {
 	strategyHandler := handlers.NewStrategyHandler(strategyService)
 	tradeHandler := handlers.NewTradeHandler(tradeService, minioStorage)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	instrumentHandler := handlers.NewInstrumentHandler(instrumentService)
+	fxRateHandler := handlers.NewFXRateHandler(fxService)
+	mistakeHandler := handlers.NewMistakeHandler(mistakeService)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+	gradeHandler := handlers.NewGradeHandler(gradeService)
+	tradeGroupHandler := handlers.NewTradeGroupHandler(tradeGroupService)
+	tradeTemplateHandler := handlers.NewTradeTemplateHandler(tradeTemplateService)
+	trashHandler := handlers.NewTrashHandler(tradeService, accountService)
 
 	// Create Echo instance
 	e := echo.New()
@@ -119,8 +165,9 @@ func main() {
 	e.Use(middleware.Recover())
 	e.Use(middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: []string{"http://localhost:5173", "http://localhost:5174", "http://localhost:3000"},
-		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.DELETE, echo.OPTIONS},
-		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization},
+		AllowMethods: []string{echo.GET, echo.POST, echo.PUT, echo.PATCH, echo.DELETE, echo.OPTIONS},
+		AllowHeaders: []string{echo.HeaderOrigin, echo.HeaderContentType, echo.HeaderAccept, echo.HeaderAuthorization, "If-Match"},
+		ExposeHeaders: []string{"ETag"},
 		AllowCredentials: true,
 	}))
 
@@ -141,6 +188,8 @@ func main() {
 			"email":   email,
 		})
 	})
+	protected.GET("/me/settings", authHandler.GetSettings)
+	protected.PUT("/me/settings", authHandler.UpdateSettings)
 
 	// Account routes
 	protected.POST("/accounts", accountHandler.CreateAccount)
@@ -148,6 +197,7 @@ func main() {
 	protected.GET("/accounts/:id", accountHandler.GetAccount)
 	protected.PUT("/accounts/:id", accountHandler.UpdateAccount)
 	protected.DELETE("/accounts/:id", accountHandler.DeleteAccount)
+	protected.POST("/accounts/:id/restore", accountHandler.RestoreAccount)
 
 	// Strategy routes
 	protected.POST("/strategies", strategyHandler.CreateStrategy)
@@ -159,10 +209,73 @@ func main() {
 	// Trade routes
 	protected.POST("/trades", tradeHandler.CreateTrade)
 	protected.GET("/trades", tradeHandler.GetTrades)
+	protected.POST("/trades/bulk", tradeHandler.BulkTrades)
+	protected.GET("/trades/search", tradeHandler.SearchTrades)
 	protected.GET("/trades/:id", tradeHandler.GetTrade)
 	protected.PUT("/trades/:id", tradeHandler.UpdateTrade)
+	protected.PATCH("/trades/:id", tradeHandler.PatchTrade)
 	protected.DELETE("/trades/:id", tradeHandler.DeleteTrade)
+	protected.POST("/trades/:id/restore", tradeHandler.RestoreTrade)
+	protected.POST("/trades/:id/duplicate", tradeHandler.DuplicateTrade)
+	protected.GET("/trades/:id/history", tradeHandler.GetTradeHistory)
 	protected.POST("/trades/:id/chart/:type", tradeHandler.UploadChart)
+	protected.GET("/trades/:id/executions", tradeHandler.GetExecutions)
+	protected.POST("/trades/:id/executions", tradeHandler.AddExecution)
+	protected.DELETE("/trades/:id/executions/:executionId", tradeHandler.DeleteExecution)
+
+	// Tag routes
+	protected.GET("/tags", tradeHandler.GetTags)
+
+	// Mistake type routes
+	protected.POST("/mistake-types", mistakeHandler.CreateMistakeType)
+	protected.GET("/mistake-types", mistakeHandler.GetMistakeTypes)
+	protected.GET("/mistake-types/:id", mistakeHandler.GetMistakeType)
+	protected.PUT("/mistake-types/:id", mistakeHandler.UpdateMistakeType)
+	protected.DELETE("/mistake-types/:id", mistakeHandler.DeleteMistakeType)
+
+	// Rating dimension routes
+	protected.POST("/rating-dimensions", ratingHandler.CreateDimension)
+	protected.GET("/rating-dimensions", ratingHandler.GetDimensions)
+	protected.GET("/rating-dimensions/:id", ratingHandler.GetDimension)
+	protected.PUT("/rating-dimensions/:id", ratingHandler.UpdateDimension)
+	protected.DELETE("/rating-dimensions/:id", ratingHandler.DeleteDimension)
+
+	// Grade scale routes
+	protected.GET("/grades", gradeHandler.GetScale)
+	protected.PUT("/grades", gradeHandler.UpdateScale)
+
+	// Trade group routes
+	protected.POST("/trade-groups", tradeGroupHandler.CreateTradeGroup)
+	protected.GET("/trade-groups", tradeGroupHandler.GetTradeGroups)
+	protected.GET("/trade-groups/:id", tradeGroupHandler.GetTradeGroup)
+	protected.PUT("/trade-groups/:id", tradeGroupHandler.UpdateTradeGroup)
+	protected.DELETE("/trade-groups/:id", tradeGroupHandler.DeleteTradeGroup)
+
+	// Trade template routes
+	protected.POST("/trade-templates", tradeTemplateHandler.CreateTradeTemplate)
+	protected.GET("/trade-templates", tradeTemplateHandler.GetTradeTemplates)
+	protected.GET("/trade-templates/:id", tradeTemplateHandler.GetTradeTemplate)
+	protected.PUT("/trade-templates/:id", tradeTemplateHandler.UpdateTradeTemplate)
+	protected.DELETE("/trade-templates/:id", tradeTemplateHandler.DeleteTradeTemplate)
+
+	// Trash routes
+	protected.GET("/trash", trashHandler.GetTrash)
+
+	// Tool routes
+	protected.POST("/tools/position-size", tradeHandler.CalculatePositionSize)
+
+	// Instrument routes
+	protected.POST("/instruments", instrumentHandler.CreateInstrument)
+	protected.GET("/instruments", instrumentHandler.GetInstruments)
+	protected.GET("/instruments/:id", instrumentHandler.GetInstrument)
+	protected.PUT("/instruments/:id", instrumentHandler.UpdateInstrument)
+	protected.DELETE("/instruments/:id", instrumentHandler.DeleteInstrument)
+
+	// FX rate routes
+	protected.POST("/fx-rates", fxRateHandler.CreateRate)
+	protected.POST("/fx-rates/import", fxRateHandler.ImportRates)
+	protected.GET("/fx-rates", fxRateHandler.GetRates)
+	protected.DELETE("/fx-rates/:id", fxRateHandler.DeleteRate)
 
 	// Analytics routes
 	protected.GET("/analytics", analyticsHandler.GetUserAnalytics)
@@ -173,3 +286,29 @@ func main() {
 		log.Fatalf("Failed to start server: %v", err)
 	}
 }
+
+// purgeTrash runs once at startup and then daily, removing trades before accounts so that
+// no trashed trade outlives its account
+func purgeTrash(tradeService *tradeapp.Service, accountService *accountapp.Service, retention time.Duration) {
+	ticker := time.NewTicker(24 * time.Hour)
+	defer ticker.Stop()
+
+	for {
+		ctx := context.Background()
+		before := time.Now().Add(-retention)
+
+		trades, err := tradeService.PurgeDeletedTrades(ctx, before)
+		if err != nil {
+			log.Printf("Warning: Failed to purge deleted trades: %v", err)
+		}
+		accounts, err := accountService.PurgeDeletedAccounts(ctx, before)
+		if err != nil {
+			log.Printf("Warning: Failed to purge deleted accounts: %v", err)
+		}
+		if trades > 0 || accounts > 0 {
+			log.Printf("Purged %d trades and %d accounts from the trash", trades, accounts)
+		}
+
+		<-ticker.C
+	}
+}
@@ -0,0 +1,54 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS instruments (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    symbol VARCHAR(20) NOT NULL,
+    description VARCHAR(255),
+    asset_class VARCHAR(20) NOT NULL CHECK (asset_class IN ('forex', 'metal', 'index', 'crypto', 'commodity')),
+    pip_size DECIMAL(20, 10) NOT NULL,
+    tick_size DECIMAL(20, 10) NOT NULL,
+    contract_size DECIMAL(20, 4) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    digits INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Built-in instruments have no owner; a user-owned row with the same symbol overrides them
+CREATE UNIQUE INDEX idx_instruments_user_symbol ON instruments(COALESCE(user_id, 0), symbol);
+CREATE INDEX idx_instruments_user_id ON instruments(user_id);
+
+INSERT INTO instruments (symbol, description, asset_class, pip_size, tick_size, contract_size, quote_currency, digits) VALUES
+    ('EURUSD', 'Euro / US Dollar', 'forex', 0.0001, 0.00001, 100000, 'USD', 5),
+    ('GBPUSD', 'British Pound / US Dollar', 'forex', 0.0001, 0.00001, 100000, 'USD', 5),
+    ('AUDUSD', 'Australian Dollar / US Dollar', 'forex', 0.0001, 0.00001, 100000, 'USD', 5),
+    ('NZDUSD', 'New Zealand Dollar / US Dollar', 'forex', 0.0001, 0.00001, 100000, 'USD', 5),
+    ('USDCAD', 'US Dollar / Canadian Dollar', 'forex', 0.0001, 0.00001, 100000, 'CAD', 5),
+    ('USDCHF', 'US Dollar / Swiss Franc', 'forex', 0.0001, 0.00001, 100000, 'CHF', 5),
+    ('USDJPY', 'US Dollar / Japanese Yen', 'forex', 0.01, 0.001, 100000, 'JPY', 3),
+    ('EURJPY', 'Euro / Japanese Yen', 'forex', 0.01, 0.001, 100000, 'JPY', 3),
+    ('GBPJPY', 'British Pound / Japanese Yen', 'forex', 0.01, 0.001, 100000, 'JPY', 3),
+    ('AUDJPY', 'Australian Dollar / Japanese Yen', 'forex', 0.01, 0.001, 100000, 'JPY', 3),
+    ('EURGBP', 'Euro / British Pound', 'forex', 0.0001, 0.00001, 100000, 'GBP', 5),
+    ('EURCHF', 'Euro / Swiss Franc', 'forex', 0.0001, 0.00001, 100000, 'CHF', 5),
+    ('EURAUD', 'Euro / Australian Dollar', 'forex', 0.0001, 0.00001, 100000, 'AUD', 5),
+    ('GBPCHF', 'British Pound / Swiss Franc', 'forex', 0.0001, 0.00001, 100000, 'CHF', 5),
+    ('USDZAR', 'US Dollar / South African Rand', 'forex', 0.0001, 0.00001, 100000, 'ZAR', 5),
+    ('USDMXN', 'US Dollar / Mexican Peso', 'forex', 0.0001, 0.00001, 100000, 'MXN', 5),
+    ('USDTRY', 'US Dollar / Turkish Lira', 'forex', 0.0001, 0.00001, 100000, 'TRY', 5),
+    ('XAUUSD', 'Gold / US Dollar', 'metal', 0.1, 0.01, 100, 'USD', 2),
+    ('XAGUSD', 'Silver / US Dollar', 'metal', 0.01, 0.001, 5000, 'USD', 3),
+    ('US30', 'Dow Jones Industrial Average', 'index', 1, 0.1, 1, 'USD', 1),
+    ('NAS100', 'Nasdaq 100', 'index', 1, 0.1, 1, 'USD', 1),
+    ('SPX500', 'S&P 500', 'index', 1, 0.1, 1, 'USD', 1),
+    ('GER40', 'DAX 40', 'index', 1, 0.1, 1, 'EUR', 1),
+    ('UK100', 'FTSE 100', 'index', 1, 0.1, 1, 'GBP', 1),
+    ('JPN225', 'Nikkei 225', 'index', 1, 1, 1, 'JPY', 0),
+    ('USOIL', 'WTI Crude Oil', 'commodity', 0.01, 0.001, 1000, 'USD', 3),
+    ('BTCUSD', 'Bitcoin / US Dollar', 'crypto', 1, 0.01, 1, 'USD', 2),
+    ('ETHUSD', 'Ethereum / US Dollar', 'crypto', 0.1, 0.01, 1, 'USD', 2);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_instruments_user_id;
+DROP INDEX IF EXISTS idx_instruments_user_symbol;
+DROP TABLE IF EXISTS instruments;
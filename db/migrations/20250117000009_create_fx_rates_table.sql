@@ -0,0 +1,18 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS fx_rates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    base_currency VARCHAR(10) NOT NULL,
+    quote_currency VARCHAR(10) NOT NULL,
+    rate_date DATE NOT NULL,
+    rate DECIMAL(20, 10) NOT NULL CHECK (rate > 0),
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One rate per currency pair per day; re-importing a day replaces it
+CREATE UNIQUE INDEX idx_fx_rates_user_pair_date ON fx_rates(user_id, base_currency, quote_currency, rate_date);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_fx_rates_user_pair_date;
+DROP TABLE IF EXISTS fx_rates;
@@ -0,0 +1,6 @@
+-- migrate:up
+-- Rate used to convert P/L from the instrument's quote currency into the account currency
+ALTER TABLE trades ADD COLUMN fx_rate DECIMAL(20, 10);
+
+-- migrate:down
+ALTER TABLE trades DROP COLUMN fx_rate;
@@ -0,0 +1,16 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS executions (
+    id SERIAL PRIMARY KEY,
+    trade_id INTEGER NOT NULL REFERENCES trades(id) ON DELETE CASCADE,
+    side trade_type NOT NULL CHECK (side IN ('BUY', 'SELL')),
+    price DECIMAL(20, 8) NOT NULL CHECK (price > 0),
+    lots DECIMAL(10, 2) NOT NULL CHECK (lots > 0),
+    executed_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_executions_trade_id ON executions(trade_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_executions_trade_id;
+DROP TABLE IF EXISTS executions;
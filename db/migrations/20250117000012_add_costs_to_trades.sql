@@ -0,0 +1,15 @@
+-- migrate:up
+-- Trading costs in the account currency; swap is signed (negative when charged)
+ALTER TABLE trades ADD COLUMN commission DECIMAL(20, 2) NOT NULL DEFAULT 0;
+ALTER TABLE trades ADD COLUMN swap DECIMAL(20, 2) NOT NULL DEFAULT 0;
+ALTER TABLE trades ADD COLUMN fees DECIMAL(20, 2) NOT NULL DEFAULT 0;
+-- P/L after costs; pl remains the gross P/L
+ALTER TABLE trades ADD COLUMN net_pl DECIMAL(20, 2);
+
+UPDATE trades SET net_pl = pl WHERE pl IS NOT NULL;
+
+-- migrate:down
+ALTER TABLE trades DROP COLUMN net_pl;
+ALTER TABLE trades DROP COLUMN fees;
+ALTER TABLE trades DROP COLUMN swap;
+ALTER TABLE trades DROP COLUMN commission;
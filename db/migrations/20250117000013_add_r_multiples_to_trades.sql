@@ -0,0 +1,34 @@
+-- migrate:up
+-- Reward-to-risk to take profit, R-multiple realized, and money at risk between entry and stop loss
+ALTER TABLE trades ADD COLUMN planned_rr DECIMAL(10, 2);
+ALTER TABLE trades ADD COLUMN realized_r DECIMAL(10, 2);
+ALTER TABLE trades ADD COLUMN risk_amount DECIMAL(20, 2);
+
+-- Backfill from prices; the risk of closed trades follows from their P/L and R-multiple.
+-- Open trades get their risk amount the next time they are saved.
+UPDATE trades
+SET planned_rr = ROUND(
+        CASE WHEN type = 'BUY'
+            THEN (take_profit - entry) / (entry - stop_loss)
+            ELSE (entry - take_profit) / (stop_loss - entry)
+        END, 2)
+WHERE take_profit IS NOT NULL
+    AND ((type = 'BUY' AND entry > stop_loss) OR (type = 'SELL' AND stop_loss > entry));
+
+UPDATE trades
+SET realized_r = ROUND(
+        CASE WHEN type = 'BUY'
+            THEN (exit - entry) / (entry - stop_loss)
+            ELSE (entry - exit) / (stop_loss - entry)
+        END, 2),
+    risk_amount = CASE WHEN exit <> entry
+        THEN ROUND(ABS(pl) * ABS(entry - stop_loss) / ABS(exit - entry), 2)
+    END
+WHERE exit IS NOT NULL
+    AND pl IS NOT NULL
+    AND ((type = 'BUY' AND entry > stop_loss) OR (type = 'SELL' AND stop_loss > entry));
+
+-- migrate:down
+ALTER TABLE trades DROP COLUMN risk_amount;
+ALTER TABLE trades DROP COLUMN realized_r;
+ALTER TABLE trades DROP COLUMN planned_rr;
@@ -0,0 +1,34 @@
+-- migrate:up
+-- IANA timezone used to interpret trade dates and date range filters
+ALTER TABLE users ADD COLUMN timezone VARCHAR(64) NOT NULL DEFAULT 'UTC';
+
+-- Instants at which a trade was opened and closed; date and time keep the wall clock in the user's timezone
+ALTER TABLE trades ADD COLUMN opened_at TIMESTAMPTZ;
+ALTER TABLE trades ADD COLUMN closed_at TIMESTAMPTZ;
+
+-- Fills were parsed from RFC3339 and stored as UTC
+ALTER TABLE executions ALTER COLUMN executed_at TYPE TIMESTAMPTZ USING executed_at AT TIME ZONE 'UTC';
+
+-- Existing dates and times were entered without a timezone, so read them as UTC
+UPDATE trades SET opened_at = (date + time) AT TIME ZONE 'UTC';
+
+-- Trades built from executions were closed by their last exit fill
+UPDATE trades t
+SET closed_at = (
+    SELECT MAX(e.executed_at)
+    FROM executions e
+    WHERE e.trade_id = t.id AND e.side <> t.type
+)
+WHERE t.status = 'closed'
+    AND EXISTS (SELECT 1 FROM executions e WHERE e.trade_id = t.id);
+
+ALTER TABLE trades ALTER COLUMN opened_at SET NOT NULL;
+
+CREATE INDEX idx_trades_user_opened_at ON trades(user_id, opened_at);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trades_user_opened_at;
+ALTER TABLE trades DROP COLUMN closed_at;
+ALTER TABLE trades DROP COLUMN opened_at;
+ALTER TABLE executions ALTER COLUMN executed_at TYPE TIMESTAMP USING executed_at AT TIME ZONE 'UTC';
+ALTER TABLE users DROP COLUMN timezone;
@@ -0,0 +1,15 @@
+-- migrate:up
+-- Wall-clock close date and time in the user's timezone, alongside the closed_at instant
+ALTER TABLE trades ADD COLUMN close_date DATE;
+ALTER TABLE trades ADD COLUMN close_time TIME;
+
+UPDATE trades t
+SET close_date = (t.closed_at AT TIME ZONE u.timezone)::date,
+    close_time = date_trunc('minute', t.closed_at AT TIME ZONE u.timezone)::time
+FROM users u
+WHERE u.id = t.user_id
+    AND t.closed_at IS NOT NULL;
+
+-- migrate:down
+ALTER TABLE trades DROP COLUMN close_time;
+ALTER TABLE trades DROP COLUMN close_date;
@@ -0,0 +1,29 @@
+-- migrate:up
+-- Limit and stop orders are journaled while pending and end up filled, cancelled or expired
+ALTER TYPE trade_status ADD VALUE IF NOT EXISTS 'pending';
+ALTER TYPE trade_status ADD VALUE IF NOT EXISTS 'cancelled';
+ALTER TYPE trade_status ADD VALUE IF NOT EXISTS 'expired';
+
+CREATE TYPE order_type AS ENUM ('market', 'limit', 'stop');
+ALTER TABLE trades ADD COLUMN order_type order_type NOT NULL DEFAULT 'market';
+
+-- migrate:down
+-- Enum values cannot be dropped, so the type is rebuilt. Unfilled orders have no status to
+-- fall back to, so rolling back is refused while any are journaled rather than losing them.
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM trades WHERE status IN ('pending', 'cancelled', 'expired')) THEN
+        RAISE EXCEPTION 'cannot roll back order lifecycle: trades with pending, cancelled or expired status exist';
+    END IF;
+END
+$$;
+
+ALTER TABLE trades DROP COLUMN order_type;
+DROP TYPE IF EXISTS order_type;
+
+ALTER TABLE trades ALTER COLUMN status DROP DEFAULT;
+ALTER TYPE trade_status RENAME TO trade_status_old;
+CREATE TYPE trade_status AS ENUM ('open', 'closed');
+ALTER TABLE trades ALTER COLUMN status TYPE trade_status USING status::text::trade_status;
+ALTER TABLE trades ALTER COLUMN status SET DEFAULT 'open';
+DROP TYPE trade_status_old;
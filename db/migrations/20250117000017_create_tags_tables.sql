@@ -0,0 +1,21 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS tags (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS trade_tags (
+    trade_id INTEGER NOT NULL REFERENCES trades(id) ON DELETE CASCADE,
+    tag_id INTEGER NOT NULL REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (trade_id, tag_id)
+);
+
+CREATE INDEX idx_trade_tags_tag_id ON trade_tags(tag_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trade_tags_tag_id;
+DROP TABLE IF EXISTS trade_tags;
+DROP TABLE IF EXISTS tags;
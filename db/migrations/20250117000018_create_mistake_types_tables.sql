@@ -0,0 +1,40 @@
+-- migrate:up
+CREATE TYPE mistake_severity AS ENUM ('low', 'medium', 'high');
+
+CREATE TABLE IF NOT EXISTS mistake_types (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    severity mistake_severity NOT NULL DEFAULT 'medium',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS trade_mistakes (
+    trade_id INTEGER NOT NULL REFERENCES trades(id) ON DELETE CASCADE,
+    mistake_type_id INTEGER NOT NULL REFERENCES mistake_types(id) ON DELETE CASCADE,
+    PRIMARY KEY (trade_id, mistake_type_id)
+);
+
+CREATE INDEX idx_trade_mistakes_mistake_type_id ON trade_mistakes(mistake_type_id);
+
+-- File the free-text mistakes recorded so far under an "uncategorized" type; the text itself stays on the trade
+INSERT INTO mistake_types (user_id, name, description)
+SELECT DISTINCT user_id, 'uncategorized', 'Mistakes recorded before mistake types existed'
+FROM trades
+WHERE TRIM(COALESCE(mistakes, '')) <> '';
+
+INSERT INTO trade_mistakes (trade_id, mistake_type_id)
+SELECT t.id, mt.id
+FROM
+    trades t
+    INNER JOIN mistake_types mt ON mt.user_id = t.user_id AND mt.name = 'uncategorized'
+WHERE TRIM(COALESCE(t.mistakes, '')) <> '';
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trade_mistakes_mistake_type_id;
+DROP TABLE IF EXISTS trade_mistakes;
+DROP TABLE IF EXISTS mistake_types;
+DROP TYPE IF EXISTS mistake_severity;
@@ -0,0 +1,28 @@
+-- migrate:up
+CREATE TYPE rating_phase AS ENUM ('before', 'after');
+
+CREATE TABLE IF NOT EXISTS rating_dimensions (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(50) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+CREATE TABLE IF NOT EXISTS trade_ratings (
+    trade_id INTEGER NOT NULL REFERENCES trades(id) ON DELETE CASCADE,
+    dimension_id INTEGER NOT NULL REFERENCES rating_dimensions(id) ON DELETE CASCADE,
+    phase rating_phase NOT NULL,
+    value SMALLINT NOT NULL CHECK (value BETWEEN 1 AND 5),
+    PRIMARY KEY (trade_id, dimension_id, phase)
+);
+
+CREATE INDEX idx_trade_ratings_dimension_id ON trade_ratings(dimension_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trade_ratings_dimension_id;
+DROP TABLE IF EXISTS trade_ratings;
+DROP TABLE IF EXISTS rating_dimensions;
+DROP TYPE IF EXISTS rating_phase;
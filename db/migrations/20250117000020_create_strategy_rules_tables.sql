@@ -0,0 +1,25 @@
+-- migrate:up
+CREATE TABLE IF NOT EXISTS strategy_rules (
+    id SERIAL PRIMARY KEY,
+    strategy_id INTEGER NOT NULL REFERENCES strategies(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    description VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_strategy_rules_strategy_id ON strategy_rules(strategy_id, position);
+
+-- Rules a trade satisfied for each strategy it was linked to; rules of the strategy missing here were broken
+CREATE TABLE IF NOT EXISTS trade_strategy_rules (
+    trade_id INTEGER NOT NULL,
+    strategy_id INTEGER NOT NULL,
+    rule_id INTEGER NOT NULL REFERENCES strategy_rules(id) ON DELETE CASCADE,
+    PRIMARY KEY (trade_id, rule_id),
+    FOREIGN KEY (trade_id, strategy_id) REFERENCES trade_strategies(trade_id, strategy_id) ON DELETE CASCADE
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS trade_strategy_rules;
+DROP INDEX IF EXISTS idx_strategy_rules_strategy_id;
+DROP TABLE IF EXISTS strategy_rules;
@@ -0,0 +1,19 @@
+-- migrate:up
+-- Each user's setup quality scale, best grade first; users without rows use the default A+/A/B/C scale
+CREATE TABLE IF NOT EXISTS grades (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(10) NOT NULL,
+    position INTEGER NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+ALTER TABLE trades ADD COLUMN grade VARCHAR(10);
+
+CREATE INDEX idx_trades_user_grade ON trades(user_id, grade);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trades_user_grade;
+ALTER TABLE trades DROP COLUMN grade;
+DROP TABLE IF EXISTS grades;
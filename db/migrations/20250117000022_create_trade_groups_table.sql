@@ -0,0 +1,22 @@
+-- migrate:up
+-- Groups link trades that form one position, such as a hedge pair, a pyramided entry or a basket
+CREATE TABLE IF NOT EXISTS trade_groups (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    description TEXT,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_trade_groups_user_id ON trade_groups(user_id);
+
+ALTER TABLE trades ADD COLUMN group_id INTEGER REFERENCES trade_groups(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_trades_group_id ON trades(group_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trades_group_id;
+ALTER TABLE trades DROP COLUMN group_id;
+DROP INDEX IF EXISTS idx_trade_groups_user_id;
+DROP TABLE IF EXISTS trade_groups;
@@ -0,0 +1,13 @@
+-- migrate:up
+-- Deleted trades and accounts stay in the trash until they are restored or purged
+ALTER TABLE trades ADD COLUMN deleted_at TIMESTAMPTZ;
+ALTER TABLE accounts ADD COLUMN deleted_at TIMESTAMPTZ;
+
+CREATE INDEX idx_trades_deleted_at ON trades(deleted_at) WHERE deleted_at IS NOT NULL;
+CREATE INDEX idx_accounts_deleted_at ON accounts(deleted_at) WHERE deleted_at IS NOT NULL;
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_accounts_deleted_at;
+DROP INDEX IF EXISTS idx_trades_deleted_at;
+ALTER TABLE accounts DROP COLUMN deleted_at;
+ALTER TABLE trades DROP COLUMN deleted_at;
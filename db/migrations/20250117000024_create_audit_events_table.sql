@@ -0,0 +1,23 @@
+-- migrate:up
+CREATE TYPE audit_entity_type AS ENUM ('trade', 'account', 'strategy');
+CREATE TYPE audit_action AS ENUM ('create', 'update', 'delete', 'restore');
+
+-- One row per change; changes maps each changed field to its {"from", "to"} values.
+-- entity_id has no foreign key so the history outlives purged rows.
+CREATE TABLE IF NOT EXISTS audit_events (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    entity_type audit_entity_type NOT NULL,
+    entity_id INTEGER NOT NULL,
+    action audit_action NOT NULL,
+    changes JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMPTZ DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX idx_audit_events_entity ON audit_events(user_id, entity_type, entity_id);
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_audit_events_entity;
+DROP TABLE IF EXISTS audit_events;
+DROP TYPE IF EXISTS audit_action;
+DROP TYPE IF EXISTS audit_entity_type;
@@ -0,0 +1,11 @@
+-- migrate:up
+-- Row versions for optimistic concurrency; every write bumps the version and
+-- writes carrying a stale version are rejected
+ALTER TABLE trades ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE accounts ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+ALTER TABLE strategies ADD COLUMN version INTEGER NOT NULL DEFAULT 1;
+
+-- migrate:down
+ALTER TABLE strategies DROP COLUMN version;
+ALTER TABLE accounts DROP COLUMN version;
+ALTER TABLE trades DROP COLUMN version;
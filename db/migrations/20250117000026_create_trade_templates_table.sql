@@ -0,0 +1,14 @@
+-- migrate:up
+-- defaults holds any of the fields of a create trade request, keyed by their JSON names
+CREATE TABLE IF NOT EXISTS trade_templates (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    name VARCHAR(100) NOT NULL,
+    defaults JSONB NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (user_id, name)
+);
+
+-- migrate:down
+DROP TABLE IF EXISTS trade_templates;
@@ -0,0 +1,20 @@
+-- migrate:up
+-- Full-text document of a trade's own text. The pair is indexed both whole and
+-- split on the slash so "EUR/USD", "eur" and "usd" all match, and weighs more
+-- than the notes and mistakes.
+CREATE FUNCTION trade_search_document(pair VARCHAR, notes TEXT, mistakes TEXT)
+RETURNS tsvector
+LANGUAGE sql
+IMMUTABLE
+AS $$
+    SELECT
+        setweight(to_tsvector('english'::regconfig, coalesce(pair, '') || ' ' || replace(coalesce(pair, ''), '/', ' ')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(notes, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(mistakes, '')), 'B')
+$$;
+
+CREATE INDEX idx_trades_search ON trades USING GIN (trade_search_document(pair, notes, mistakes));
+
+-- migrate:down
+DROP INDEX IF EXISTS idx_trades_search;
+DROP FUNCTION IF EXISTS trade_search_document(VARCHAR, TEXT, TEXT);
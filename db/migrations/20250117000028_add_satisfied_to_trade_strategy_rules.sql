@@ -0,0 +1,18 @@
+-- migrate:up
+-- Record every rule a trade was checked against, satisfied or not, so rules added to a
+-- strategy later do not count as broken by the trades logged before them
+ALTER TABLE trade_strategy_rules ADD COLUMN satisfied BOOLEAN NOT NULL DEFAULT TRUE;
+
+INSERT INTO trade_strategy_rules (trade_id, strategy_id, rule_id, satisfied)
+SELECT ts.trade_id, ts.strategy_id, sr.id, FALSE
+FROM
+    trade_strategies ts
+    INNER JOIN strategy_rules sr ON sr.strategy_id = ts.strategy_id
+ON CONFLICT DO NOTHING;
+
+ALTER TABLE trade_strategy_rules ALTER COLUMN satisfied DROP DEFAULT;
+
+-- migrate:down
+-- Only satisfied rules were recorded before; the broken ones are implied again by their absence
+DELETE FROM trade_strategy_rules WHERE NOT satisfied;
+ALTER TABLE trade_strategy_rules DROP COLUMN satisfied;
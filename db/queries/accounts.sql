@@ -1,17 +1,17 @@
 -- name: CreateAccount :one
 INSERT INTO accounts (user_id, name, broker, account_number, account_type, currency, is_active)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at;
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version;
 
 -- name: GetAccountByID :one
-SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 FROM accounts
-WHERE id = $1 AND user_id = $2;
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL;
 
 -- name: GetAccountsByUserID :many
-SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 FROM accounts
-WHERE user_id = $1
+WHERE user_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC;
 
 -- name: UpdateAccount :one
@@ -22,17 +22,45 @@ SET name = $3,
     account_type = $6,
     currency = $7,
     is_active = $8,
+    version = version + 1,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at;
+WHERE id = $1 AND user_id = $2 AND version = $9 AND deleted_at IS NULL
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version;
 
 -- name: UpdateAccountBalance :one
 UPDATE accounts
 SET current_balance = COALESCE(current_balance, 0) + sqlc.arg(amount)::decimal,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at;
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version;
+
+-- name: SoftDeleteAccount :execrows
+UPDATE accounts
+SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+WHERE id = $1 AND user_id = $2 AND version = $3 AND deleted_at IS NULL;
+
+-- name: SoftDeleteAccountTrades :exec
+UPDATE trades
+SET deleted_at = (SELECT a.deleted_at FROM accounts a WHERE a.id = $1 AND a.user_id = $2), version = version + 1
+WHERE account_id = $1 AND user_id = $2 AND deleted_at IS NULL;
+
+-- name: GetDeletedAccountsByUserID :many
+SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, deleted_at, version
+FROM accounts
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: RestoreAccount :one
+UPDATE accounts
+SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version;
+
+-- name: RestoreAccountTrades :exec
+UPDATE trades
+SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE account_id = $1 AND user_id = $2
+    AND deleted_at = (SELECT a.deleted_at FROM accounts a WHERE a.id = $1 AND a.user_id = $2);
 
--- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1 AND user_id = $2;
+-- name: PurgeDeletedAccounts :execrows
+DELETE FROM accounts WHERE deleted_at < $1;
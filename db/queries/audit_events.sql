@@ -0,0 +1,8 @@
+-- name: CreateAuditEvent :exec
+INSERT INTO audit_events (user_id, entity_type, entity_id, action, changes)
+VALUES ($1, $2, $3, $4, $5);
+
+-- name: GetAuditEventsByEntity :many
+SELECT * FROM audit_events
+WHERE user_id = $1 AND entity_type = $2 AND entity_id = $3
+ORDER BY created_at ASC, id ASC;
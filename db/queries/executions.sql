@@ -0,0 +1,13 @@
+-- name: CreateExecution :one
+INSERT INTO executions (trade_id, side, price, lots, executed_at)
+VALUES ($1, $2, $3, $4, $5)
+RETURNING *;
+
+-- name: GetTradeExecutions :many
+SELECT * FROM executions
+WHERE trade_id = $1
+ORDER BY executed_at ASC, id ASC;
+
+-- name: DeleteExecution :execresult
+DELETE FROM executions
+WHERE id = $1 AND trade_id = $2;
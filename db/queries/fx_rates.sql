@@ -0,0 +1,24 @@
+-- name: UpsertFXRate :one
+INSERT INTO fx_rates (user_id, base_currency, quote_currency, rate_date, rate)
+VALUES ($1, $2, $3, $4, $5)
+ON CONFLICT (user_id, base_currency, quote_currency, rate_date)
+DO UPDATE SET rate = EXCLUDED.rate, updated_at = CURRENT_TIMESTAMP
+RETURNING *;
+
+-- name: GetFXRateOnOrBefore :one
+SELECT * FROM fx_rates
+WHERE user_id = $1
+    AND base_currency = $2
+    AND quote_currency = $3
+    AND rate_date <= $4
+ORDER BY rate_date DESC
+LIMIT 1;
+
+-- name: GetFXRatesByUserID :many
+SELECT * FROM fx_rates
+WHERE user_id = $1
+ORDER BY base_currency ASC, quote_currency ASC, rate_date DESC;
+
+-- name: DeleteFXRate :execresult
+DELETE FROM fx_rates
+WHERE id = $1 AND user_id = $2;
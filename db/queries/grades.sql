@@ -0,0 +1,18 @@
+-- name: CreateGrade :one
+INSERT INTO grades (user_id, name, position)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetGradesByUserID :many
+SELECT * FROM grades
+WHERE user_id = $1
+ORDER BY position ASC;
+
+-- name: DeleteGradesByUserID :exec
+DELETE FROM grades
+WHERE user_id = $1;
+
+-- name: ClearTradeGrade :exec
+UPDATE trades
+SET grade = NULL, updated_at = CURRENT_TIMESTAMP
+WHERE user_id = $1 AND grade = $2;
@@ -0,0 +1,37 @@
+-- name: CreateInstrument :one
+INSERT INTO instruments (user_id, symbol, description, asset_class, pip_size, tick_size, contract_size, quote_currency, digits)
+VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+RETURNING *;
+
+-- name: GetInstrumentByID :one
+SELECT * FROM instruments
+WHERE id = $1 AND (user_id = $2 OR user_id IS NULL);
+
+-- name: GetInstrumentBySymbol :one
+SELECT * FROM instruments
+WHERE symbol = $1 AND (user_id = $2 OR user_id IS NULL)
+ORDER BY user_id NULLS LAST
+LIMIT 1;
+
+-- name: GetInstrumentsByUserID :many
+SELECT DISTINCT ON (symbol) * FROM instruments
+WHERE user_id = $1 OR user_id IS NULL
+ORDER BY symbol ASC, user_id NULLS LAST;
+
+-- name: UpdateInstrument :one
+UPDATE instruments
+SET symbol = $3,
+    description = $4,
+    asset_class = $5,
+    pip_size = $6,
+    tick_size = $7,
+    contract_size = $8,
+    quote_currency = $9,
+    digits = $10,
+    updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2
+RETURNING *;
+
+-- name: DeleteInstrument :execresult
+DELETE FROM instruments
+WHERE id = $1 AND user_id = $2;
@@ -0,0 +1,52 @@
+-- name: CreateMistakeType :one
+INSERT INTO mistake_types (user_id, name, description, severity)
+VALUES ($1, $2, $3, $4)
+RETURNING *;
+
+-- name: GetMistakeTypeByID :one
+SELECT * FROM mistake_types
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetMistakeTypeByName :one
+SELECT * FROM mistake_types
+WHERE user_id = $1 AND name = $2;
+
+-- name: GetMistakeTypesByUserID :many
+SELECT * FROM mistake_types
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateMistakeType :one
+UPDATE mistake_types
+SET name = $2, description = $3, severity = $4, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $5
+RETURNING *;
+
+-- name: DeleteMistakeType :execresult
+DELETE FROM mistake_types
+WHERE id = $1 AND user_id = $2;
+
+-- name: AddTradeMistake :exec
+INSERT INTO trade_mistakes (trade_id, mistake_type_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: GetTradeMistakes :many
+SELECT mt.*
+FROM
+    mistake_types mt
+    INNER JOIN trade_mistakes tm ON mt.id = tm.mistake_type_id
+WHERE
+    tm.trade_id = $1
+ORDER BY mt.name ASC;
+
+-- name: GetTradeMistakesByUserID :many
+SELECT tm.trade_id, mt.name
+FROM
+    mistake_types mt
+    INNER JOIN trade_mistakes tm ON mt.id = tm.mistake_type_id
+WHERE
+    mt.user_id = $1
+ORDER BY mt.name ASC;
+
+-- name: DeleteTradeMistakes :exec
+DELETE FROM trade_mistakes WHERE trade_id = $1;
@@ -0,0 +1,52 @@
+-- name: CreateRatingDimension :one
+INSERT INTO rating_dimensions (user_id, name, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetRatingDimensionByID :one
+SELECT * FROM rating_dimensions
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetRatingDimensionByName :one
+SELECT * FROM rating_dimensions
+WHERE user_id = $1 AND name = $2;
+
+-- name: GetRatingDimensionsByUserID :many
+SELECT * FROM rating_dimensions
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateRatingDimension :one
+UPDATE rating_dimensions
+SET name = $2, description = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $4
+RETURNING *;
+
+-- name: DeleteRatingDimension :execresult
+DELETE FROM rating_dimensions
+WHERE id = $1 AND user_id = $2;
+
+-- name: AddTradeRating :exec
+INSERT INTO trade_ratings (trade_id, dimension_id, phase, value) VALUES ($1, $2, $3, $4)
+ON CONFLICT (trade_id, dimension_id, phase) DO UPDATE SET value = EXCLUDED.value;
+
+-- name: GetTradeRatings :many
+SELECT tr.dimension_id, rd.name, tr.phase, tr.value
+FROM
+    trade_ratings tr
+    INNER JOIN rating_dimensions rd ON rd.id = tr.dimension_id
+WHERE
+    tr.trade_id = $1
+ORDER BY rd.name ASC, tr.phase ASC;
+
+-- name: GetTradeRatingsByUserID :many
+SELECT tr.trade_id, rd.name, tr.phase, tr.value
+FROM
+    trade_ratings tr
+    INNER JOIN rating_dimensions rd ON rd.id = tr.dimension_id
+WHERE
+    rd.user_id = $1
+ORDER BY rd.name ASC, tr.phase ASC, tr.value ASC;
+
+-- name: DeleteTradeRatings :exec
+DELETE FROM trade_ratings WHERE trade_id = $1;
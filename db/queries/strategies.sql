@@ -14,10 +14,30 @@ ORDER BY name ASC;
 
 -- name: UpdateStrategy :one
 UPDATE strategies
-SET name = $2, description = $3, updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $4
+SET name = $2, description = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $4 AND version = $5
 RETURNING *;
 
 -- name: DeleteStrategy :execresult
 DELETE FROM strategies
-WHERE id = $1 AND user_id = $2;
+WHERE id = $1 AND user_id = $2 AND version = $3;
+
+-- name: CreateStrategyRule :one
+INSERT INTO strategy_rules (strategy_id, position, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetStrategyRules :many
+SELECT * FROM strategy_rules
+WHERE strategy_id = $1
+ORDER BY position ASC;
+
+-- name: UpdateStrategyRule :one
+UPDATE strategy_rules
+SET position = $2, description = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND strategy_id = $4
+RETURNING *;
+
+-- name: DeleteStrategyRule :exec
+DELETE FROM strategy_rules
+WHERE id = $1 AND strategy_id = $2;
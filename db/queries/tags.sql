@@ -0,0 +1,35 @@
+-- name: UpsertTag :one
+INSERT INTO tags (user_id, name)
+VALUES ($1, $2)
+ON CONFLICT (user_id, name) DO UPDATE SET name = EXCLUDED.name
+RETURNING *;
+
+-- name: GetTagsByUserID :many
+SELECT * FROM tags
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: AddTradeTag :exec
+INSERT INTO trade_tags (trade_id, tag_id) VALUES ($1, $2)
+ON CONFLICT DO NOTHING;
+
+-- name: GetTradeTags :many
+SELECT t.*
+FROM
+    tags t
+    INNER JOIN trade_tags tt ON t.id = tt.tag_id
+WHERE
+    tt.trade_id = $1
+ORDER BY t.name ASC;
+
+-- name: GetTradeTagsByUserID :many
+SELECT tt.trade_id, t.name
+FROM
+    tags t
+    INNER JOIN trade_tags tt ON t.id = tt.tag_id
+WHERE
+    t.user_id = $1
+ORDER BY t.name ASC;
+
+-- name: DeleteTradeTags :exec
+DELETE FROM trade_tags WHERE trade_id = $1;
@@ -0,0 +1,33 @@
+-- name: CreateTradeGroup :one
+INSERT INTO trade_groups (user_id, name, description)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetTradeGroupByID :one
+SELECT * FROM trade_groups
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetTradeGroupsByUserID :many
+SELECT * FROM trade_groups
+WHERE user_id = $1
+ORDER BY created_at DESC;
+
+-- name: UpdateTradeGroup :one
+UPDATE trade_groups
+SET name = $2, description = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $4
+RETURNING *;
+
+-- name: DeleteTradeGroup :execresult
+DELETE FROM trade_groups
+WHERE id = $1 AND user_id = $2;
+
+-- name: AssignTradeGroup :execrows
+UPDATE trades
+SET group_id = $1, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL;
+
+-- name: ClearTradeGroup :exec
+UPDATE trades
+SET group_id = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE group_id = $1 AND deleted_at IS NULL;
@@ -0,0 +1,27 @@
+-- name: CreateTradeTemplate :one
+INSERT INTO trade_templates (user_id, name, defaults)
+VALUES ($1, $2, $3)
+RETURNING *;
+
+-- name: GetTradeTemplateByID :one
+SELECT * FROM trade_templates
+WHERE id = $1 AND user_id = $2;
+
+-- name: GetTradeTemplateByName :one
+SELECT * FROM trade_templates
+WHERE user_id = $1 AND name = $2;
+
+-- name: GetTradeTemplatesByUserID :many
+SELECT * FROM trade_templates
+WHERE user_id = $1
+ORDER BY name ASC;
+
+-- name: UpdateTradeTemplate :one
+UPDATE trade_templates
+SET name = $2, defaults = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $4
+RETURNING *;
+
+-- name: DeleteTradeTemplate :execresult
+DELETE FROM trade_templates
+WHERE id = $1 AND user_id = $2;
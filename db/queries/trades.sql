@@ -18,7 +18,21 @@ INSERT INTO
         take_profit,
         notes,
         mistakes,
-        amount
+        amount,
+        fx_rate,
+        commission,
+        swap,
+        fees,
+        net_pl,
+        planned_rr,
+        realized_r,
+        risk_amount,
+        opened_at,
+        closed_at,
+        close_date,
+        close_time,
+        order_type,
+        grade
     )
 VALUES (
         $1,
@@ -38,7 +52,21 @@ VALUES (
         $15,
         $16,
         $17,
-        $18
+        $18,
+        $19,
+        $20,
+        $21,
+        $22,
+        $23,
+        $24,
+        $25,
+        $26,
+        $27,
+        $28,
+        $29,
+        $30,
+        $31,
+        $32
     )
 RETURNING
     *;
@@ -51,10 +79,20 @@ SELECT *
 FROM trades
 WHERE
     user_id = $1
-ORDER BY date DESC, time DESC;
+    AND deleted_at IS NULL
+ORDER BY opened_at DESC;
+
+-- name: GetTradesByGroupID :many
+SELECT *
+FROM trades
+WHERE
+    group_id = $1
+    AND user_id = $2
+    AND deleted_at IS NULL
+ORDER BY opened_at ASC;
 
 -- name: GetTradeByID :one
-SELECT * FROM trades WHERE id = $1 AND user_id = $2;
+SELECT * FROM trades WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL;
 
 -- name: GetTradeStrategies :many
 SELECT s.*
@@ -64,6 +102,29 @@ FROM
 WHERE
     ts.trade_id = $1;
 
+-- name: AddTradeStrategyRule :exec
+INSERT INTO trade_strategy_rules (trade_id, strategy_id, rule_id, satisfied) VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING;
+
+-- name: GetTradeStrategyRules :many
+SELECT sr.id, sr.strategy_id, sr.description, tsr.satisfied
+FROM
+    trade_strategy_rules tsr
+    INNER JOIN strategy_rules sr ON sr.id = tsr.rule_id
+WHERE
+    tsr.trade_id = $1
+ORDER BY sr.strategy_id ASC, sr.position ASC;
+
+-- name: GetTradeRuleAdherenceByUserID :many
+SELECT tsr.trade_id, COUNT(*) AS rules, COUNT(*) FILTER (WHERE tsr.satisfied) AS satisfied_rules
+FROM
+    trade_strategy_rules tsr
+    INNER JOIN trades t ON t.id = tsr.trade_id
+WHERE
+    t.user_id = $1
+    AND t.deleted_at IS NULL
+GROUP BY tsr.trade_id;
+
 -- name: UpdateTrade :one
 UPDATE trades
 SET
@@ -84,54 +145,117 @@ SET
     notes = $16,
     mistakes = $17,
     amount = $18,
+    fx_rate = $19,
+    commission = $20,
+    swap = $21,
+    fees = $22,
+    net_pl = $23,
+    planned_rr = $24,
+    realized_r = $25,
+    risk_amount = $26,
+    opened_at = $27,
+    closed_at = $28,
+    close_date = $29,
+    close_time = $30,
+    order_type = $31,
+    grade = $32,
+    version = version + 1,
     updated_at = CURRENT_TIMESTAMP
 WHERE
     id = $1
-    AND user_id = $19
+    AND user_id = $33
+    AND version = $34
+    AND deleted_at IS NULL
 RETURNING
     *;
 
 -- name: DeleteTradeStrategies :exec
 DELETE FROM trade_strategies WHERE trade_id = $1;
 
--- name: DeleteTrade :exec
-DELETE FROM trades WHERE id = $1 AND user_id = $2;
+-- name: SoftDeleteTrade :execrows
+UPDATE trades
+SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+WHERE id = $1 AND user_id = $2 AND version = $3 AND deleted_at IS NULL;
 
--- name: GetTradesByAccountID :many
+-- name: GetDeletedTradeByID :one
+SELECT * FROM trades WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL;
+
+-- name: GetDeletedTradesByUserID :many
 SELECT *
 FROM trades
 WHERE
-    account_id = $1
-    AND user_id = $2
-ORDER BY date DESC, time DESC;
+    user_id = $1
+    AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC;
+
+-- name: RestoreTrade :one
+UPDATE trades
+SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+RETURNING *;
+
+-- name: PurgeDeletedTrades :execrows
+DELETE FROM trades WHERE deleted_at < $1;
 
 -- name: GetTradesByUserIDAndDateRange :many
 SELECT *
 FROM trades
 WHERE
     user_id = $1
-    AND date >= $2
-    AND date <= $3
-ORDER BY date DESC, time DESC;
+    AND opened_at >= $2
+    AND opened_at < $3
+    AND deleted_at IS NULL
+ORDER BY opened_at DESC;
 
--- name: GetTradesByAccountIDAndDateRange :many
-SELECT *
+-- name: SearchTrades :many
+WITH
+    search AS (
+        SELECT websearch_to_tsquery('english', sqlc.arg(query)::text) AS query
+    ),
+    strategy_documents AS (
+        SELECT ts.trade_id, setweight(to_tsvector('english', string_agg(s.name, ' ')), 'A') AS document
+        FROM trade_strategies ts
+            INNER JOIN strategies s ON s.id = ts.strategy_id
+        WHERE s.user_id = sqlc.arg(user_id)
+        GROUP BY ts.trade_id
+    )
+SELECT
+    sqlc.embed(trades),
+    ts_rank(
+        trade_search_document(trades.pair, trades.notes, trades.mistakes) || coalesce(sd.document, ''::tsvector),
+        search.query
+    )::real AS rank,
+    CASE
+        WHEN to_tsvector('english', coalesce(trades.notes, '')) @@ search.query
+        THEN ts_headline('english', trades.notes, search.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, FragmentDelimiter=" ... "')
+        ELSE ''
+    END::text AS notes_snippet,
+    CASE
+        WHEN to_tsvector('english', coalesce(trades.mistakes, '')) @@ search.query
+        THEN ts_headline('english', trades.mistakes, search.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, FragmentDelimiter=" ... "')
+        ELSE ''
+    END::text AS mistakes_snippet
 FROM trades
+    CROSS JOIN search
+    LEFT JOIN strategy_documents sd ON sd.trade_id = trades.id
 WHERE
-    account_id = $1
-    AND user_id = $2
-    AND date >= $3
-    AND date <= $4
-ORDER BY date DESC, time DESC;
+    trades.user_id = sqlc.arg(user_id)
+    AND trades.deleted_at IS NULL
+    AND (
+        trade_search_document(trades.pair, trades.notes, trades.mistakes) @@ search.query
+        OR sd.document @@ search.query
+    )
+ORDER BY rank DESC, trades.opened_at DESC, trades.id DESC
+LIMIT sqlc.arg(result_limit);
 
 -- name: UpdateTradeChartBefore :one
 UPDATE trades
-SET chart_before = $1, updated_at = NOW()
-WHERE id = $2 AND user_id = $3
+SET chart_before = $1, version = version + 1, updated_at = NOW()
+WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
 RETURNING *;
 
 -- name: UpdateTradeChartAfter :one
 UPDATE trades
-SET chart_after = $1, updated_at = NOW()
-WHERE id = $2 AND user_id = $3
-RETURNING *;
\ No newline at end of file
+SET chart_after = $1, version = version + 1, updated_at = NOW()
+WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+RETURNING *;
@@ -1,14 +1,20 @@
 -- name: CreateUser :one
 INSERT INTO users (email, password_hash)
 VALUES ($1, $2)
-RETURNING id, email, created_at, updated_at;
+RETURNING id, email, created_at, updated_at, timezone;
 
 -- name: GetUserByEmail :one
-SELECT id, email, password_hash, created_at, updated_at
+SELECT id, email, password_hash, created_at, updated_at, timezone
 FROM users
 WHERE email = $1;
 
 -- name: GetUserByID :one
-SELECT id, email, created_at, updated_at
+SELECT id, email, created_at, updated_at, timezone
 FROM users
 WHERE id = $1;
+
+-- name: UpdateUserTimezone :one
+UPDATE users
+SET timezone = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, email, created_at, updated_at, timezone;
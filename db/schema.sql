@@ -10,13 +10,71 @@ SET xmloption = content;
 SET client_min_messages = warning;
 SET row_security = off;
 
+--
+-- Name: audit_action; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.audit_action AS ENUM (
+    'create',
+    'update',
+    'delete',
+    'restore'
+);
+
+
+--
+-- Name: audit_entity_type; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.audit_entity_type AS ENUM (
+    'trade',
+    'account',
+    'strategy'
+);
+
+
+--
+-- Name: mistake_severity; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.mistake_severity AS ENUM (
+    'low',
+    'medium',
+    'high'
+);
+
+
+--
+-- Name: order_type; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.order_type AS ENUM (
+    'market',
+    'limit',
+    'stop'
+);
+
+
+--
+-- Name: rating_phase; Type: TYPE; Schema: public; Owner: -
+--
+
+CREATE TYPE public.rating_phase AS ENUM (
+    'before',
+    'after'
+);
+
+
 --
 -- Name: trade_status; Type: TYPE; Schema: public; Owner: -
 --
 
 CREATE TYPE public.trade_status AS ENUM (
     'open',
-    'closed'
+    'closed',
+    'pending',
+    'cancelled',
+    'expired'
 );
 
 
@@ -32,6 +90,20 @@ CREATE TYPE public.trade_type AS ENUM (
 );
 
 
+--
+-- Name: trade_search_document(character varying, text, text); Type: FUNCTION; Schema: public; Owner: -
+--
+
+CREATE FUNCTION public.trade_search_document(pair character varying, notes text, mistakes text) RETURNS tsvector
+    LANGUAGE sql IMMUTABLE
+    AS $$
+    SELECT
+        setweight(to_tsvector('english'::regconfig, coalesce(pair, '') || ' ' || replace(coalesce(pair, ''), '/', ' ')), 'A') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(notes, '')), 'B') ||
+        setweight(to_tsvector('english'::regconfig, coalesce(mistakes, '')), 'B')
+$$;
+
+
 SET default_tablespace = '';
 
 SET default_table_access_method = heap;
@@ -52,6 +124,8 @@ CREATE TABLE public.accounts (
     created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
     updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
     current_balance numeric(20,2) DEFAULT 0,
+    deleted_at timestamp with time zone,
+    version integer DEFAULT 1 NOT NULL,
     CONSTRAINT accounts_account_type_check CHECK (((account_type)::text = ANY ((ARRAY['demo'::character varying, 'live'::character varying])::text[])))
 );
 
@@ -77,33 +151,63 @@ ALTER SEQUENCE public.accounts_id_seq OWNED BY public.accounts.id;
 
 
 --
--- Name: schema_migrations; Type: TABLE; Schema: public; Owner: -
+-- Name: audit_events; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.schema_migrations (
-    version character varying NOT NULL
+CREATE TABLE public.audit_events (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    entity_type public.audit_entity_type NOT NULL,
+    entity_id integer NOT NULL,
+    action public.audit_action NOT NULL,
+    changes jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp with time zone DEFAULT CURRENT_TIMESTAMP
 );
 
 
 --
--- Name: strategies; Type: TABLE; Schema: public; Owner: -
+-- Name: audit_events_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.strategies (
+CREATE SEQUENCE public.audit_events_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: audit_events_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.audit_events_id_seq OWNED BY public.audit_events.id;
+
+
+--
+-- Name: executions; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.executions (
     id integer NOT NULL,
-    user_id integer NOT NULL,
-    name character varying(255) NOT NULL,
-    description text,
+    trade_id integer NOT NULL,
+    side public.trade_type NOT NULL,
+    price numeric(20,8) NOT NULL,
+    lots numeric(10,2) NOT NULL,
+    executed_at timestamp with time zone NOT NULL,
     created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
-    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+    CONSTRAINT executions_lots_check CHECK ((lots > (0)::numeric)),
+    CONSTRAINT executions_price_check CHECK ((price > (0)::numeric)),
+    CONSTRAINT executions_side_check CHECK ((side = ANY (ARRAY['BUY'::public.trade_type, 'SELL'::public.trade_type])))
 );
 
 
 --
--- Name: strategies_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: executions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.strategies_id_seq
+CREATE SEQUENCE public.executions_id_seq
     AS integer
     START WITH 1
     INCREMENT BY 1
@@ -113,58 +217,67 @@ CREATE SEQUENCE public.strategies_id_seq
 
 
 --
--- Name: strategies_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: executions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.strategies_id_seq OWNED BY public.strategies.id;
+ALTER SEQUENCE public.executions_id_seq OWNED BY public.executions.id;
 
 
 --
--- Name: trade_strategies; Type: TABLE; Schema: public; Owner: -
+-- Name: fx_rates; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.trade_strategies (
-    trade_id integer NOT NULL,
-    strategy_id integer NOT NULL
+CREATE TABLE public.fx_rates (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    base_currency character varying(10) NOT NULL,
+    quote_currency character varying(10) NOT NULL,
+    rate_date date NOT NULL,
+    rate numeric(20,10) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT fx_rates_rate_check CHECK ((rate > (0)::numeric))
 );
 
 
 --
--- Name: trades; Type: TABLE; Schema: public; Owner: -
+-- Name: fx_rates_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.trades (
+CREATE SEQUENCE public.fx_rates_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: fx_rates_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.fx_rates_id_seq OWNED BY public.fx_rates.id;
+
+
+--
+-- Name: grades; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.grades (
     id integer NOT NULL,
     user_id integer NOT NULL,
-    account_id integer,
-    date date NOT NULL,
-    "time" time without time zone NOT NULL,
-    pair character varying(20),
-    type public.trade_type NOT NULL,
-    entry numeric(20,8),
-    exit numeric(20,8),
-    lots numeric(10,2),
-    pips numeric(10,2),
-    pl numeric(20,2),
-    rr character varying(20),
-    status public.trade_status DEFAULT 'open'::public.trade_status NOT NULL,
-    stop_loss numeric(20,8),
-    take_profit numeric(20,8),
-    notes text,
-    mistakes text,
-    amount numeric(20,2),
-    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
-    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
-    chart_before text,
-    chart_after text
+    name character varying(10) NOT NULL,
+    "position" integer NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
 );
 
 
 --
--- Name: trades_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: grades_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.trades_id_seq
+CREATE SEQUENCE public.grades_id_seq
     AS integer
     START WITH 1
     INCREMENT BY 1
@@ -174,30 +287,38 @@ CREATE SEQUENCE public.trades_id_seq
 
 
 --
--- Name: trades_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: grades_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.trades_id_seq OWNED BY public.trades.id;
+ALTER SEQUENCE public.grades_id_seq OWNED BY public.grades.id;
 
 
 --
--- Name: users; Type: TABLE; Schema: public; Owner: -
+-- Name: instruments; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE TABLE public.users (
+CREATE TABLE public.instruments (
     id integer NOT NULL,
-    email character varying(255) NOT NULL,
-    password_hash character varying(255) NOT NULL,
+    user_id integer,
+    symbol character varying(20) NOT NULL,
+    description character varying(255),
+    asset_class character varying(20) NOT NULL,
+    pip_size numeric(20,10) NOT NULL,
+    tick_size numeric(20,10) NOT NULL,
+    contract_size numeric(20,4) NOT NULL,
+    quote_currency character varying(10) NOT NULL,
+    digits integer NOT NULL,
     created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
-    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    CONSTRAINT instruments_asset_class_check CHECK (((asset_class)::text = ANY ((ARRAY['forex'::character varying, 'metal'::character varying, 'index'::character varying, 'crypto'::character varying, 'commodity'::character varying])::text[])))
 );
 
 
 --
--- Name: users_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+-- Name: instruments_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE SEQUENCE public.users_id_seq
+CREATE SEQUENCE public.instruments_id_seq
     AS integer
     START WITH 1
     INCREMENT BY 1
@@ -207,164 +328,1077 @@ CREATE SEQUENCE public.users_id_seq
 
 
 --
--- Name: users_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+-- Name: instruments_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+ALTER SEQUENCE public.instruments_id_seq OWNED BY public.instruments.id;
 
 
 --
--- Name: accounts id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: mistake_types; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts ALTER COLUMN id SET DEFAULT nextval('public.accounts_id_seq'::regclass);
+CREATE TABLE public.mistake_types (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    description text,
+    severity public.mistake_severity DEFAULT 'medium'::public.mistake_severity NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+);
 
 
 --
--- Name: strategies id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: mistake_types_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.strategies ALTER COLUMN id SET DEFAULT nextval('public.strategies_id_seq'::regclass);
+CREATE SEQUENCE public.mistake_types_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
 
 
 --
--- Name: trades id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: mistake_types_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.trades ALTER COLUMN id SET DEFAULT nextval('public.trades_id_seq'::regclass);
+ALTER SEQUENCE public.mistake_types_id_seq OWNED BY public.mistake_types.id;
 
 
 --
--- Name: users id; Type: DEFAULT; Schema: public; Owner: -
+-- Name: rating_dimensions; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+CREATE TABLE public.rating_dimensions (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    name character varying(50) NOT NULL,
+    description text,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+);
 
 
 --
--- Name: accounts accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: rating_dimensions_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+CREATE SEQUENCE public.rating_dimensions_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
 
 
 --
--- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: rating_dimensions_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.schema_migrations
-    ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
+ALTER SEQUENCE public.rating_dimensions_id_seq OWNED BY public.rating_dimensions.id;
 
 
 --
--- Name: strategies strategies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: schema_migrations; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.strategies
-    ADD CONSTRAINT strategies_pkey PRIMARY KEY (id);
+CREATE TABLE public.schema_migrations (
+    version character varying NOT NULL
+);
 
 
 --
--- Name: trade_strategies trade_strategies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: strategies; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.trade_strategies
-    ADD CONSTRAINT trade_strategies_pkey PRIMARY KEY (trade_id, strategy_id);
+CREATE TABLE public.strategies (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    name character varying(255) NOT NULL,
+    description text,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    version integer DEFAULT 1 NOT NULL
+);
 
 
 --
--- Name: trades trades_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: strategies_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.trades
-    ADD CONSTRAINT trades_pkey PRIMARY KEY (id);
+CREATE SEQUENCE public.strategies_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
 
 
 --
--- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: strategies_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_email_key UNIQUE (email);
+ALTER SEQUENCE public.strategies_id_seq OWNED BY public.strategies.id;
 
 
 --
--- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+-- Name: strategy_rules; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.users
-    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+CREATE TABLE public.strategy_rules (
+    id integer NOT NULL,
+    strategy_id integer NOT NULL,
+    "position" integer NOT NULL,
+    description character varying(255) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+);
 
 
 --
--- Name: idx_accounts_is_active; Type: INDEX; Schema: public; Owner: -
+-- Name: strategy_rules_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_accounts_is_active ON public.accounts USING btree (is_active);
+CREATE SEQUENCE public.strategy_rules_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
 
 
 --
--- Name: idx_accounts_user_id; Type: INDEX; Schema: public; Owner: -
+-- Name: strategy_rules_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_accounts_user_id ON public.accounts USING btree (user_id);
+ALTER SEQUENCE public.strategy_rules_id_seq OWNED BY public.strategy_rules.id;
 
 
 --
--- Name: idx_strategies_user_id; Type: INDEX; Schema: public; Owner: -
+-- Name: tags; Type: TABLE; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_strategies_user_id ON public.strategies USING btree (user_id);
+CREATE TABLE public.tags (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    name character varying(50) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+);
 
 
 --
--- Name: idx_users_email; Type: INDEX; Schema: public; Owner: -
+-- Name: tags_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-CREATE INDEX idx_users_email ON public.users USING btree (email);
+CREATE SEQUENCE public.tags_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
 
 
 --
--- Name: accounts accounts_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: tags_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.accounts
-    ADD CONSTRAINT accounts_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+ALTER SEQUENCE public.tags_id_seq OWNED BY public.tags.id;
 
 
 --
--- Name: strategies strategies_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: trade_groups; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.strategies
-    ADD CONSTRAINT strategies_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+CREATE TABLE public.trade_groups (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    description text,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+);
 
 
 --
--- Name: trade_strategies trade_strategies_strategy_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: trade_groups_id_seq; Type: SEQUENCE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.trade_strategies
-    ADD CONSTRAINT trade_strategies_strategy_id_fkey FOREIGN KEY (strategy_id) REFERENCES public.strategies(id) ON DELETE CASCADE;
+CREATE SEQUENCE public.trade_groups_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
 
 
 --
--- Name: trade_strategies trade_strategies_trade_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: trade_groups_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.trade_strategies
-    ADD CONSTRAINT trade_strategies_trade_id_fkey FOREIGN KEY (trade_id) REFERENCES public.trades(id) ON DELETE CASCADE;
+ALTER SEQUENCE public.trade_groups_id_seq OWNED BY public.trade_groups.id;
 
 
 --
--- Name: trades trades_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+-- Name: trade_mistakes; Type: TABLE; Schema: public; Owner: -
 --
 
-ALTER TABLE ONLY public.trades
+CREATE TABLE public.trade_mistakes (
+    trade_id integer NOT NULL,
+    mistake_type_id integer NOT NULL
+);
+
+
+--
+-- Name: trade_ratings; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trade_ratings (
+    trade_id integer NOT NULL,
+    dimension_id integer NOT NULL,
+    phase public.rating_phase NOT NULL,
+    value smallint NOT NULL,
+    CONSTRAINT trade_ratings_value_check CHECK (((value >= 1) AND (value <= 5)))
+);
+
+
+--
+-- Name: trade_strategies; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trade_strategies (
+    trade_id integer NOT NULL,
+    strategy_id integer NOT NULL
+);
+
+
+--
+-- Name: trade_strategy_rules; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trade_strategy_rules (
+    trade_id integer NOT NULL,
+    strategy_id integer NOT NULL,
+    rule_id integer NOT NULL,
+    satisfied boolean NOT NULL
+);
+
+
+--
+-- Name: trade_tags; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trade_tags (
+    trade_id integer NOT NULL,
+    tag_id integer NOT NULL
+);
+
+
+--
+-- Name: trade_templates; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trade_templates (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    name character varying(100) NOT NULL,
+    defaults jsonb DEFAULT '{}'::jsonb NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP
+);
+
+
+--
+-- Name: trade_templates_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.trade_templates_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: trade_templates_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.trade_templates_id_seq OWNED BY public.trade_templates.id;
+
+
+--
+-- Name: trades; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.trades (
+    id integer NOT NULL,
+    user_id integer NOT NULL,
+    account_id integer,
+    date date NOT NULL,
+    "time" time without time zone NOT NULL,
+    pair character varying(20),
+    type public.trade_type NOT NULL,
+    entry numeric(20,8),
+    exit numeric(20,8),
+    lots numeric(10,2),
+    pips numeric(10,2),
+    pl numeric(20,2),
+    rr character varying(20),
+    status public.trade_status DEFAULT 'open'::public.trade_status NOT NULL,
+    stop_loss numeric(20,8),
+    take_profit numeric(20,8),
+    notes text,
+    mistakes text,
+    amount numeric(20,2),
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    chart_before text,
+    chart_after text,
+    fx_rate numeric(20,10),
+    commission numeric(20,2) DEFAULT 0 NOT NULL,
+    swap numeric(20,2) DEFAULT 0 NOT NULL,
+    fees numeric(20,2) DEFAULT 0 NOT NULL,
+    net_pl numeric(20,2),
+    planned_rr numeric(10,2),
+    realized_r numeric(10,2),
+    risk_amount numeric(20,2),
+    opened_at timestamp with time zone NOT NULL,
+    closed_at timestamp with time zone,
+    close_date date,
+    close_time time without time zone,
+    order_type public.order_type DEFAULT 'market'::public.order_type NOT NULL,
+    grade character varying(10),
+    group_id integer,
+    deleted_at timestamp with time zone,
+    version integer DEFAULT 1 NOT NULL
+);
+
+
+--
+-- Name: trades_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.trades_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: trades_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.trades_id_seq OWNED BY public.trades.id;
+
+
+--
+-- Name: users; Type: TABLE; Schema: public; Owner: -
+--
+
+CREATE TABLE public.users (
+    id integer NOT NULL,
+    email character varying(255) NOT NULL,
+    password_hash character varying(255) NOT NULL,
+    created_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    updated_at timestamp without time zone DEFAULT CURRENT_TIMESTAMP,
+    timezone character varying(64) DEFAULT 'UTC'::character varying NOT NULL
+);
+
+
+--
+-- Name: users_id_seq; Type: SEQUENCE; Schema: public; Owner: -
+--
+
+CREATE SEQUENCE public.users_id_seq
+    AS integer
+    START WITH 1
+    INCREMENT BY 1
+    NO MINVALUE
+    NO MAXVALUE
+    CACHE 1;
+
+
+--
+-- Name: users_id_seq; Type: SEQUENCE OWNED BY; Schema: public; Owner: -
+--
+
+ALTER SEQUENCE public.users_id_seq OWNED BY public.users.id;
+
+
+--
+-- Name: accounts id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts ALTER COLUMN id SET DEFAULT nextval('public.accounts_id_seq'::regclass);
+
+
+--
+-- Name: audit_events id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_events ALTER COLUMN id SET DEFAULT nextval('public.audit_events_id_seq'::regclass);
+
+
+--
+-- Name: executions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.executions ALTER COLUMN id SET DEFAULT nextval('public.executions_id_seq'::regclass);
+
+
+--
+-- Name: fx_rates id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.fx_rates ALTER COLUMN id SET DEFAULT nextval('public.fx_rates_id_seq'::regclass);
+
+
+--
+-- Name: grades id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.grades ALTER COLUMN id SET DEFAULT nextval('public.grades_id_seq'::regclass);
+
+
+--
+-- Name: instruments id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.instruments ALTER COLUMN id SET DEFAULT nextval('public.instruments_id_seq'::regclass);
+
+
+--
+-- Name: mistake_types id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mistake_types ALTER COLUMN id SET DEFAULT nextval('public.mistake_types_id_seq'::regclass);
+
+
+--
+-- Name: rating_dimensions id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.rating_dimensions ALTER COLUMN id SET DEFAULT nextval('public.rating_dimensions_id_seq'::regclass);
+
+
+--
+-- Name: strategies id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.strategies ALTER COLUMN id SET DEFAULT nextval('public.strategies_id_seq'::regclass);
+
+
+--
+-- Name: strategy_rules id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.strategy_rules ALTER COLUMN id SET DEFAULT nextval('public.strategy_rules_id_seq'::regclass);
+
+
+--
+-- Name: tags id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags ALTER COLUMN id SET DEFAULT nextval('public.tags_id_seq'::regclass);
+
+
+--
+-- Name: trade_groups id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_groups ALTER COLUMN id SET DEFAULT nextval('public.trade_groups_id_seq'::regclass);
+
+
+--
+-- Name: trade_templates id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_templates ALTER COLUMN id SET DEFAULT nextval('public.trade_templates_id_seq'::regclass);
+
+
+--
+-- Name: trades id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trades ALTER COLUMN id SET DEFAULT nextval('public.trades_id_seq'::regclass);
+
+
+--
+-- Name: users id; Type: DEFAULT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users ALTER COLUMN id SET DEFAULT nextval('public.users_id_seq'::regclass);
+
+
+--
+-- Name: accounts accounts_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT accounts_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: audit_events audit_events_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_events
+    ADD CONSTRAINT audit_events_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: executions executions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.executions
+    ADD CONSTRAINT executions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: fx_rates fx_rates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.fx_rates
+    ADD CONSTRAINT fx_rates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: grades grades_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.grades
+    ADD CONSTRAINT grades_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: grades grades_user_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.grades
+    ADD CONSTRAINT grades_user_id_name_key UNIQUE (user_id, name);
+
+
+--
+-- Name: instruments instruments_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.instruments
+    ADD CONSTRAINT instruments_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: mistake_types mistake_types_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mistake_types
+    ADD CONSTRAINT mistake_types_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: mistake_types mistake_types_user_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mistake_types
+    ADD CONSTRAINT mistake_types_user_id_name_key UNIQUE (user_id, name);
+
+
+--
+-- Name: rating_dimensions rating_dimensions_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.rating_dimensions
+    ADD CONSTRAINT rating_dimensions_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: rating_dimensions rating_dimensions_user_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.rating_dimensions
+    ADD CONSTRAINT rating_dimensions_user_id_name_key UNIQUE (user_id, name);
+
+
+--
+-- Name: schema_migrations schema_migrations_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.schema_migrations
+    ADD CONSTRAINT schema_migrations_pkey PRIMARY KEY (version);
+
+
+--
+-- Name: strategies strategies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.strategies
+    ADD CONSTRAINT strategies_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: strategy_rules strategy_rules_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.strategy_rules
+    ADD CONSTRAINT strategy_rules_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: tags tags_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: tags tags_user_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_user_id_name_key UNIQUE (user_id, name);
+
+
+--
+-- Name: trade_groups trade_groups_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_groups
+    ADD CONSTRAINT trade_groups_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: trade_mistakes trade_mistakes_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_mistakes
+    ADD CONSTRAINT trade_mistakes_pkey PRIMARY KEY (trade_id, mistake_type_id);
+
+
+--
+-- Name: trade_ratings trade_ratings_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_ratings
+    ADD CONSTRAINT trade_ratings_pkey PRIMARY KEY (trade_id, dimension_id, phase);
+
+
+--
+-- Name: trade_strategies trade_strategies_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_strategies
+    ADD CONSTRAINT trade_strategies_pkey PRIMARY KEY (trade_id, strategy_id);
+
+
+--
+-- Name: trade_strategy_rules trade_strategy_rules_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_strategy_rules
+    ADD CONSTRAINT trade_strategy_rules_pkey PRIMARY KEY (trade_id, rule_id);
+
+
+--
+-- Name: trade_tags trade_tags_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_tags
+    ADD CONSTRAINT trade_tags_pkey PRIMARY KEY (trade_id, tag_id);
+
+
+--
+-- Name: trade_templates trade_templates_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_templates
+    ADD CONSTRAINT trade_templates_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: trade_templates trade_templates_user_id_name_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_templates
+    ADD CONSTRAINT trade_templates_user_id_name_key UNIQUE (user_id, name);
+
+
+--
+-- Name: trades trades_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trades
+    ADD CONSTRAINT trades_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: users users_email_key; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_email_key UNIQUE (email);
+
+
+--
+-- Name: users users_pkey; Type: CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.users
+    ADD CONSTRAINT users_pkey PRIMARY KEY (id);
+
+
+--
+-- Name: idx_accounts_deleted_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_accounts_deleted_at ON public.accounts USING btree (deleted_at) WHERE (deleted_at IS NOT NULL);
+
+
+--
+-- Name: idx_accounts_is_active; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_accounts_is_active ON public.accounts USING btree (is_active);
+
+
+--
+-- Name: idx_accounts_user_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_accounts_user_id ON public.accounts USING btree (user_id);
+
+
+--
+-- Name: idx_audit_events_entity; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_audit_events_entity ON public.audit_events USING btree (user_id, entity_type, entity_id);
+
+
+--
+-- Name: idx_executions_trade_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_executions_trade_id ON public.executions USING btree (trade_id);
+
+
+--
+-- Name: idx_fx_rates_user_pair_date; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX idx_fx_rates_user_pair_date ON public.fx_rates USING btree (user_id, base_currency, quote_currency, rate_date);
+
+
+--
+-- Name: idx_instruments_user_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_instruments_user_id ON public.instruments USING btree (user_id);
+
+
+--
+-- Name: idx_instruments_user_symbol; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE UNIQUE INDEX idx_instruments_user_symbol ON public.instruments USING btree (COALESCE(user_id, 0), symbol);
+
+
+--
+-- Name: idx_strategies_user_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_strategies_user_id ON public.strategies USING btree (user_id);
+
+
+--
+-- Name: idx_strategy_rules_strategy_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_strategy_rules_strategy_id ON public.strategy_rules USING btree (strategy_id, "position");
+
+
+--
+-- Name: idx_trade_groups_user_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trade_groups_user_id ON public.trade_groups USING btree (user_id);
+
+
+--
+-- Name: idx_trade_mistakes_mistake_type_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trade_mistakes_mistake_type_id ON public.trade_mistakes USING btree (mistake_type_id);
+
+
+--
+-- Name: idx_trade_ratings_dimension_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trade_ratings_dimension_id ON public.trade_ratings USING btree (dimension_id);
+
+
+--
+-- Name: idx_trade_tags_tag_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trade_tags_tag_id ON public.trade_tags USING btree (tag_id);
+
+
+--
+-- Name: idx_trades_deleted_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trades_deleted_at ON public.trades USING btree (deleted_at) WHERE (deleted_at IS NOT NULL);
+
+
+--
+-- Name: idx_trades_group_id; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trades_group_id ON public.trades USING btree (group_id);
+
+
+--
+-- Name: idx_trades_search; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trades_search ON public.trades USING gin (public.trade_search_document(pair, notes, mistakes));
+
+
+--
+-- Name: idx_trades_user_grade; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trades_user_grade ON public.trades USING btree (user_id, grade);
+
+
+--
+-- Name: idx_trades_user_opened_at; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_trades_user_opened_at ON public.trades USING btree (user_id, opened_at);
+
+
+--
+-- Name: idx_users_email; Type: INDEX; Schema: public; Owner: -
+--
+
+CREATE INDEX idx_users_email ON public.users USING btree (email);
+
+
+--
+-- Name: accounts accounts_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.accounts
+    ADD CONSTRAINT accounts_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: audit_events audit_events_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.audit_events
+    ADD CONSTRAINT audit_events_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: executions executions_trade_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.executions
+    ADD CONSTRAINT executions_trade_id_fkey FOREIGN KEY (trade_id) REFERENCES public.trades(id) ON DELETE CASCADE;
+
+
+--
+-- Name: fx_rates fx_rates_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.fx_rates
+    ADD CONSTRAINT fx_rates_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: grades grades_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.grades
+    ADD CONSTRAINT grades_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: instruments instruments_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.instruments
+    ADD CONSTRAINT instruments_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: mistake_types mistake_types_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.mistake_types
+    ADD CONSTRAINT mistake_types_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: rating_dimensions rating_dimensions_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.rating_dimensions
+    ADD CONSTRAINT rating_dimensions_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: strategies strategies_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.strategies
+    ADD CONSTRAINT strategies_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: strategy_rules strategy_rules_strategy_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.strategy_rules
+    ADD CONSTRAINT strategy_rules_strategy_id_fkey FOREIGN KEY (strategy_id) REFERENCES public.strategies(id) ON DELETE CASCADE;
+
+
+--
+-- Name: tags tags_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.tags
+    ADD CONSTRAINT tags_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_groups trade_groups_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_groups
+    ADD CONSTRAINT trade_groups_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_mistakes trade_mistakes_mistake_type_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_mistakes
+    ADD CONSTRAINT trade_mistakes_mistake_type_id_fkey FOREIGN KEY (mistake_type_id) REFERENCES public.mistake_types(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_mistakes trade_mistakes_trade_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_mistakes
+    ADD CONSTRAINT trade_mistakes_trade_id_fkey FOREIGN KEY (trade_id) REFERENCES public.trades(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_ratings trade_ratings_dimension_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_ratings
+    ADD CONSTRAINT trade_ratings_dimension_id_fkey FOREIGN KEY (dimension_id) REFERENCES public.rating_dimensions(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_ratings trade_ratings_trade_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_ratings
+    ADD CONSTRAINT trade_ratings_trade_id_fkey FOREIGN KEY (trade_id) REFERENCES public.trades(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_strategies trade_strategies_strategy_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_strategies
+    ADD CONSTRAINT trade_strategies_strategy_id_fkey FOREIGN KEY (strategy_id) REFERENCES public.strategies(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_strategies trade_strategies_trade_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_strategies
+    ADD CONSTRAINT trade_strategies_trade_id_fkey FOREIGN KEY (trade_id) REFERENCES public.trades(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_strategy_rules trade_strategy_rules_rule_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_strategy_rules
+    ADD CONSTRAINT trade_strategy_rules_rule_id_fkey FOREIGN KEY (rule_id) REFERENCES public.strategy_rules(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_strategy_rules trade_strategy_rules_trade_id_strategy_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_strategy_rules
+    ADD CONSTRAINT trade_strategy_rules_trade_id_strategy_id_fkey FOREIGN KEY (trade_id, strategy_id) REFERENCES public.trade_strategies(trade_id, strategy_id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_tags trade_tags_tag_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_tags
+    ADD CONSTRAINT trade_tags_tag_id_fkey FOREIGN KEY (tag_id) REFERENCES public.tags(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_tags trade_tags_trade_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_tags
+    ADD CONSTRAINT trade_tags_trade_id_fkey FOREIGN KEY (trade_id) REFERENCES public.trades(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trade_templates trade_templates_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trade_templates
+    ADD CONSTRAINT trade_templates_user_id_fkey FOREIGN KEY (user_id) REFERENCES public.users(id) ON DELETE CASCADE;
+
+
+--
+-- Name: trades trades_account_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trades
     ADD CONSTRAINT trades_account_id_fkey FOREIGN KEY (account_id) REFERENCES public.accounts(id) ON DELETE SET NULL;
 
 
+--
+-- Name: trades trades_group_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
+--
+
+ALTER TABLE ONLY public.trades
+    ADD CONSTRAINT trades_group_id_fkey FOREIGN KEY (group_id) REFERENCES public.trade_groups(id) ON DELETE SET NULL;
+
+
 --
 -- Name: trades trades_user_id_fkey; Type: FK CONSTRAINT; Schema: public; Owner: -
 --
@@ -389,4 +1423,25 @@ INSERT INTO public.schema_migrations (version) VALUES
     ('20250115000004'),
     ('20250115000005'),
     ('20250115000006'),
-    ('20250116000007');
+    ('20250116000007'),
+    ('20250117000008'),
+    ('20250117000009'),
+    ('20250117000010'),
+    ('20250117000011'),
+    ('20250117000012'),
+    ('20250117000013'),
+    ('20250117000014'),
+    ('20250117000015'),
+    ('20250117000016'),
+    ('20250117000017'),
+    ('20250117000018'),
+    ('20250117000019'),
+    ('20250117000020'),
+    ('20250117000021'),
+    ('20250117000022'),
+    ('20250117000023'),
+    ('20250117000024'),
+    ('20250117000025'),
+    ('20250117000026'),
+    ('20250117000027'),
+    ('20250117000028');
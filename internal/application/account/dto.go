@@ -32,4 +32,6 @@ type AccountDTO struct {
 	IsActive       bool    `json:"is_active"`
 	CreatedAt      string  `json:"created_at"`
 	UpdatedAt      string  `json:"updated_at"`
+	DeletedAt      *string `json:"deleted_at"`
+	Version        int64   `json:"version"`
 }
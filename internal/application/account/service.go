@@ -3,24 +3,39 @@ package account
 import (
 	"context"
 	"errors"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/domain/account"
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
 )
 
 var (
 	ErrAccountNotFound = errors.New("account not found")
 	ErrUnauthorized    = errors.New("unauthorized to access this account")
+	ErrVersionConflict = errors.New("account was modified by another request")
 )
 
 // Service handles account use cases
 type Service struct {
-	accountRepo account.Repository
+	accountRepo   account.Repository
+	auditRepo     audit.Repository
+	transactor    trade.Transactor
+	tradeRecorder TradeRecorder
+}
+
+// TradeRecorder adds the trades that move to and from the trash with an account to their audit log
+type TradeRecorder interface {
+	RecordTrade(ctx context.Context, userID int64, action audit.Action, before, after *trade.Trade)
 }
 
 // NewService creates a new account service
-func NewService(accountRepo account.Repository) *Service {
+func NewService(accountRepo account.Repository, auditRepo audit.Repository, transactor trade.Transactor, tradeRecorder TradeRecorder) *Service {
 	return &Service{
-		accountRepo: accountRepo,
+		accountRepo:   accountRepo,
+		auditRepo:     auditRepo,
+		transactor:    transactor,
+		tradeRecorder: tradeRecorder,
 	}
 }
 
@@ -43,7 +58,10 @@ func (s *Service) CreateAccount(ctx context.Context, userID int64, req CreateAcc
 		return nil, err
 	}
 
-	return toDTO(createdAccount), nil
+	dto := toDTO(createdAccount)
+	s.record(ctx, userID, createdAccount.ID, audit.ActionCreate, nil, dto)
+
+	return dto, nil
 }
 
 // GetAccount retrieves an account by ID
@@ -71,13 +89,18 @@ func (s *Service) GetUserAccounts(ctx context.Context, userID int64) ([]*Account
 	return dtos, nil
 }
 
-// UpdateAccount updates an existing account
-func (s *Service) UpdateAccount(ctx context.Context, id int64, userID int64, req UpdateAccountRequest) (*AccountDTO, error) {
+// UpdateAccount updates an existing account, provided it is still at the version the caller read
+func (s *Service) UpdateAccount(ctx context.Context, id int64, userID int64, req UpdateAccountRequest, version int64) (*AccountDTO, error) {
 	// Get existing account
 	existingAccount, err := s.accountRepo.GetByID(ctx, id, userID)
 	if err != nil {
 		return nil, ErrAccountNotFound
 	}
+	if existingAccount.Version != version {
+		return nil, ErrVersionConflict
+	}
+
+	before := toDTO(existingAccount)
 
 	// Update fields
 	existingAccount.Name = req.Name
@@ -90,19 +113,149 @@ func (s *Service) UpdateAccount(ctx context.Context, id int64, userID int64, req
 	// Save to repository
 	updatedAccount, err := s.accountRepo.Update(ctx, existingAccount)
 	if err != nil {
+		return nil, mapRepoError(err)
+	}
+
+	dto := toDTO(updatedAccount)
+	s.record(ctx, userID, id, audit.ActionUpdate, before, dto)
+
+	return dto, nil
+}
+
+// DeleteAccount moves an account and its trades to the trash, provided it is still at the version the caller read
+func (s *Service) DeleteAccount(ctx context.Context, id int64, userID int64, version int64) error {
+	existingAccount, err := s.accountRepo.GetByID(ctx, id, userID)
+	if err != nil {
+		return ErrAccountNotFound
+	}
+	if existingAccount.Version != version {
+		return ErrVersionConflict
+	}
+
+	// The account and its trades go to the trash together or not at all
+	var trashed []*trade.Trade
+	err = s.transactor.WithinTx(ctx, func(trades trade.Repository, accounts account.Repository) error {
+		var err error
+		trashed, _, err = trades.Find(ctx, userID, trade.Filter{AccountID: &id}, trade.Page{})
+		if err != nil {
+			return err
+		}
+		return accounts.Delete(ctx, id, userID, version)
+	})
+	if err != nil {
+		return mapRepoError(err)
+	}
+
+	s.record(ctx, userID, id, audit.ActionDelete, toDTO(existingAccount), nil)
+	for _, t := range trashed {
+		s.tradeRecorder.RecordTrade(ctx, userID, audit.ActionDelete, t, nil)
+	}
+	return nil
+}
+
+// GetDeletedAccounts retrieves the accounts in the user's trash
+func (s *Service) GetDeletedAccounts(ctx context.Context, userID int64) ([]*AccountDTO, error) {
+	accounts, err := s.accountRepo.GetDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*AccountDTO, len(accounts))
+	for i, acc := range accounts {
+		dtos[i] = toDTO(acc)
+	}
+
+	return dtos, nil
+}
+
+// restoredTrade holds a trade as it was in the trash and as it was restored with its account
+type restoredTrade struct {
+	before *trade.Trade
+	after  *trade.Trade
+}
+
+// RestoreAccount takes an account out of the trash along with the trades deleted with it
+func (s *Service) RestoreAccount(ctx context.Context, id int64, userID int64) (*AccountDTO, error) {
+	var acc *account.Account
+	var restored []restoredTrade
+	err := s.transactor.WithinTx(ctx, func(trades trade.Repository, accounts account.Repository) error {
+		deleted, err := trades.GetDeletedByUserID(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		acc, err = accounts.Restore(ctx, id, userID)
+		if err != nil {
+			return err
+		}
+
+		// Of the account's trades in the trash, those deleted with it are now back
+		live, _, err := trades.Find(ctx, userID, trade.Filter{AccountID: &id}, trade.Page{})
+		if err != nil {
+			return err
+		}
+		byID := make(map[int64]*trade.Trade, len(live))
+		for _, t := range live {
+			byID[t.ID] = t
+		}
+		for _, t := range deleted {
+			if after, ok := byID[t.ID]; ok {
+				restored = append(restored, restoredTrade{before: t, after: after})
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		if errors.Is(err, account.ErrNotFound) {
+			return nil, ErrAccountNotFound
+		}
 		return nil, err
 	}
 
-	return toDTO(updatedAccount), nil
+	dto := toDTO(acc)
+	s.record(ctx, userID, id, audit.ActionRestore, nil, dto)
+	for _, t := range restored {
+		s.tradeRecorder.RecordTrade(ctx, userID, audit.ActionRestore, t.before, t.after)
+	}
+
+	return dto, nil
+}
+
+// PurgeDeletedAccounts permanently removes accounts that have been in the trash since before the given instant
+func (s *Service) PurgeDeletedAccounts(ctx context.Context, before time.Time) (int64, error) {
+	return s.accountRepo.PurgeDeleted(ctx, before)
+}
+
+// mapRepoError translates the repository's errors for a versioned write into the service's
+func mapRepoError(err error) error {
+	switch {
+	case errors.Is(err, account.ErrNotFound):
+		return ErrAccountNotFound
+	case errors.Is(err, account.ErrVersionConflict):
+		return ErrVersionConflict
+	}
+	return err
 }
 
-// DeleteAccount deletes an account
-func (s *Service) DeleteAccount(ctx context.Context, id int64, userID int64) error {
-	return s.accountRepo.Delete(ctx, id, userID)
+// record adds a change to the account's audit log without failing the change itself
+func (s *Service) record(ctx context.Context, userID int64, accountID int64, action audit.Action, before, after *AccountDTO) {
+	event, err := audit.NewEvent(userID, audit.EntityAccount, accountID, action, before, after)
+	if err != nil {
+		return
+	}
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		// Log error but don't fail the change
+	}
 }
 
 // toDTO converts domain entity to DTO
 func toDTO(acc *account.Account) *AccountDTO {
+	var deletedAt *string
+	if acc.DeletedAt != nil {
+		formatted := acc.DeletedAt.Format("2006-01-02T15:04:05Z07:00")
+		deletedAt = &formatted
+	}
+
 	return &AccountDTO{
 		ID:             acc.ID,
 		Name:           acc.Name,
@@ -114,5 +267,7 @@ func toDTO(acc *account.Account) *AccountDTO {
 		IsActive:       acc.IsActive,
 		CreatedAt:      acc.CreatedAt.Format("2006-01-02T15:04:05Z07:00"),
 		UpdatedAt:      acc.UpdatedAt.Format("2006-01-02T15:04:05Z07:00"),
+		DeletedAt:      deletedAt,
+		Version:        acc.Version,
 	}
 }
@@ -3,7 +3,10 @@ package account
 import (
 	"context"
 	"testing"
+	"time"
 
+	tradeapp "github.com/raihanstark/trade-journal/internal/application/trade"
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
 	"github.com/raihanstark/trade-journal/internal/domain/user"
 	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
 	"github.com/raihanstark/trade-journal/internal/testutil"
@@ -17,7 +20,11 @@ func TestAccountService_CreateAccount_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(accountRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	transactor := persistence.NewTransactor(pg.DB, pg.Queries)
+	tradeService := tradeapp.NewService(persistence.NewTradeRepository(pg.DB, pg.Queries), accountRepo, persistence.NewInstrumentRepository(pg.Queries),
+		persistence.NewFXRateRepository(pg.Queries), userRepo, auditRepo, transactor)
+	service := NewService(accountRepo, auditRepo, transactor, tradeService)
 
 	ctx := context.Background()
 
@@ -84,7 +91,11 @@ func TestAccountRepository_UpdateBalance_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(accountRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	transactor := persistence.NewTransactor(pg.DB, pg.Queries)
+	tradeService := tradeapp.NewService(persistence.NewTradeRepository(pg.DB, pg.Queries), accountRepo, persistence.NewInstrumentRepository(pg.Queries),
+		persistence.NewFXRateRepository(pg.Queries), userRepo, auditRepo, transactor)
+	service := NewService(accountRepo, auditRepo, transactor, tradeService)
 
 	ctx := context.Background()
 
@@ -226,7 +237,11 @@ func TestAccountService_GetUserAccounts_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(accountRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	transactor := persistence.NewTransactor(pg.DB, pg.Queries)
+	tradeService := tradeapp.NewService(persistence.NewTradeRepository(pg.DB, pg.Queries), accountRepo, persistence.NewInstrumentRepository(pg.Queries),
+		persistence.NewFXRateRepository(pg.Queries), userRepo, auditRepo, transactor)
+	service := NewService(accountRepo, auditRepo, transactor, tradeService)
 
 	ctx := context.Background()
 
@@ -314,7 +329,11 @@ func TestAccountService_UpdateAccount_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(accountRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	transactor := persistence.NewTransactor(pg.DB, pg.Queries)
+	tradeService := tradeapp.NewService(persistence.NewTradeRepository(pg.DB, pg.Queries), accountRepo, persistence.NewInstrumentRepository(pg.Queries),
+		persistence.NewFXRateRepository(pg.Queries), userRepo, auditRepo, transactor)
+	service := NewService(accountRepo, auditRepo, transactor, tradeService)
 
 	ctx := context.Background()
 
@@ -346,7 +365,7 @@ func TestAccountService_UpdateAccount_Integration(t *testing.T) {
 			IsActive:      false,
 		}
 
-		updated, err := service.UpdateAccount(ctx, created.ID, createdUser.ID, updateReq)
+		updated, err := service.UpdateAccount(ctx, created.ID, createdUser.ID, updateReq, created.Version)
 
 		// Verify no error
 		if err != nil {
@@ -390,11 +409,15 @@ func TestAccountService_DeleteAccount_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(accountRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	transactor := persistence.NewTransactor(pg.DB, pg.Queries)
+	tradeService := tradeapp.NewService(persistence.NewTradeRepository(pg.DB, pg.Queries), accountRepo, persistence.NewInstrumentRepository(pg.Queries),
+		persistence.NewFXRateRepository(pg.Queries), userRepo, auditRepo, transactor)
+	service := NewService(accountRepo, auditRepo, transactor, tradeService)
 
 	ctx := context.Background()
 
-	t.Run("deletes account from database", func(t *testing.T) {
+	t.Run("moves account to the trash", func(t *testing.T) {
 		testutil.TruncateTables(t, pg.DB)
 
 		// Create user
@@ -413,22 +436,176 @@ func TestAccountService_DeleteAccount_Integration(t *testing.T) {
 		created, _ := service.CreateAccount(ctx, createdUser.ID, req)
 
 		// Delete account
-		err := service.DeleteAccount(ctx, created.ID, createdUser.ID)
+		err := service.DeleteAccount(ctx, created.ID, createdUser.ID, created.Version)
 
 		// Verify no error
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
 
-		// Verify account no longer exists in database
+		// Verify account is kept but marked as deleted
 		var count int
-		err = pg.DB.QueryRow("SELECT COUNT(*) FROM accounts WHERE id = $1", created.ID).Scan(&count)
+		err = pg.DB.QueryRow("SELECT COUNT(*) FROM accounts WHERE id = $1 AND deleted_at IS NOT NULL", created.ID).Scan(&count)
 		if err != nil {
 			t.Fatalf("failed to query account count: %v", err)
 		}
 
-		if count != 0 {
-			t.Errorf("expected account to be deleted, but found %d accounts", count)
+		if count != 1 {
+			t.Errorf("expected account to be in the trash, but found %d accounts", count)
+		}
+
+		// Verify account is hidden from normal reads
+		if _, err := service.GetAccount(ctx, created.ID, createdUser.ID); err != ErrAccountNotFound {
+			t.Errorf("expected ErrAccountNotFound, got %v", err)
+		}
+
+		// Deleting again reports the account as missing
+		if err := service.DeleteAccount(ctx, created.ID, createdUser.ID, created.Version); err != ErrAccountNotFound {
+			t.Errorf("expected ErrAccountNotFound, got %v", err)
+		}
+	})
+}
+
+func TestAccountService_RestoreAccount_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	transactor := persistence.NewTransactor(pg.DB, pg.Queries)
+	tradeService := tradeapp.NewService(persistence.NewTradeRepository(pg.DB, pg.Queries), accountRepo, persistence.NewInstrumentRepository(pg.Queries),
+		persistence.NewFXRateRepository(pg.Queries), userRepo, auditRepo, transactor)
+	service := NewService(accountRepo, auditRepo, transactor, tradeService)
+
+	ctx := context.Background()
+
+	testutil.TruncateTables(t, pg.DB)
+
+	testUser := user.NewUser("restoretest@example.com", "hashedpass")
+	createdUser, _ := userRepo.Create(ctx, testUser)
+
+	created, _ := service.CreateAccount(ctx, createdUser.ID, CreateAccountRequest{
+		Name:          "Trashed",
+		Broker:        "Test Broker",
+		AccountNumber: "111",
+		AccountType:   "demo",
+		Currency:      "USD",
+		IsActive:      true,
+	})
+
+	var tradeID int64
+	err := pg.DB.QueryRow(
+		"INSERT INTO trades (user_id, account_id, date, time, pair, type, entry, lots, opened_at) VALUES ($1, $2, '2025-01-15', '10:00', 'EURUSD', 'BUY', 1.1, 1, '2025-01-15T10:00:00Z') RETURNING id",
+		createdUser.ID, created.ID,
+	).Scan(&tradeID)
+	if err != nil {
+		t.Fatalf("failed to insert trade: %v", err)
+	}
+
+	if err := service.DeleteAccount(ctx, created.ID, createdUser.ID, created.Version); err != nil {
+		t.Fatalf("failed to delete account: %v", err)
+	}
+
+	t.Run("trashes the account's trades with it", func(t *testing.T) {
+		var deleted bool
+		var version int64
+		err := pg.DB.QueryRow("SELECT deleted_at IS NOT NULL, version FROM trades WHERE id = $1", tradeID).Scan(&deleted, &version)
+		if err != nil {
+			t.Fatalf("failed to query trade: %v", err)
+		}
+		if !deleted {
+			t.Error("expected trade to be in the trash")
+		}
+		if version != 2 {
+			t.Errorf("expected the trade's version to be bumped to 2, got %d", version)
+		}
+
+		events, err := auditRepo.GetByEntity(ctx, createdUser.ID, audit.EntityTrade, tradeID)
+		if err != nil {
+			t.Fatalf("failed to load trade history: %v", err)
+		}
+		if len(events) != 1 || events[0].Action != audit.ActionDelete {
+			t.Errorf("expected the trade's deletion to be audited, got %+v", events)
+		}
+	})
+
+	t.Run("lists the account in the trash", func(t *testing.T) {
+		trashed, err := service.GetDeletedAccounts(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(trashed) != 1 || trashed[0].ID != created.ID {
+			t.Fatalf("expected the deleted account in the trash, got %+v", trashed)
+		}
+		if trashed[0].DeletedAt == nil {
+			t.Error("expected deleted_at to be set")
+		}
+	})
+
+	t.Run("restores the account and its trades", func(t *testing.T) {
+		restored, err := service.RestoreAccount(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if restored.DeletedAt != nil {
+			t.Error("expected restored account to have no deleted_at")
+		}
+
+		var deleted bool
+		var version int64
+		err = pg.DB.QueryRow("SELECT deleted_at IS NOT NULL, version FROM trades WHERE id = $1", tradeID).Scan(&deleted, &version)
+		if err != nil {
+			t.Fatalf("failed to query trade: %v", err)
+		}
+		if deleted {
+			t.Error("expected trade to be restored with its account")
+		}
+		if version != 3 {
+			t.Errorf("expected the trade's version to be bumped to 3, got %d", version)
+		}
+
+		events, err := auditRepo.GetByEntity(ctx, createdUser.ID, audit.EntityTrade, tradeID)
+		if err != nil {
+			t.Fatalf("failed to load trade history: %v", err)
+		}
+		if len(events) != 2 || events[1].Action != audit.ActionRestore {
+			t.Errorf("expected the trade's restore to be audited, got %+v", events)
+		}
+	})
+
+	t.Run("restoring an account outside the trash fails", func(t *testing.T) {
+		_, err := service.RestoreAccount(ctx, created.ID, createdUser.ID)
+		if err != ErrAccountNotFound {
+			t.Errorf("expected ErrAccountNotFound, got %v", err)
+		}
+	})
+
+	t.Run("purges accounts past the retention period", func(t *testing.T) {
+		current, err := service.GetAccount(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("failed to get account: %v", err)
+		}
+		if err := service.DeleteAccount(ctx, created.ID, createdUser.ID, current.Version); err != nil {
+			t.Fatalf("failed to delete account: %v", err)
+		}
+
+		purged, err := service.PurgeDeletedAccounts(ctx, time.Now().Add(-time.Hour))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if purged != 0 {
+			t.Errorf("expected recently deleted account to be kept, purged %d", purged)
+		}
+
+		purged, err = service.PurgeDeletedAccounts(ctx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if purged != 1 {
+			t.Errorf("expected 1 account purged, got %d", purged)
 		}
 	})
 }
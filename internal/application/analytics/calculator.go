@@ -4,11 +4,20 @@ import (
 	"database/sql"
 	"fmt"
 	"math"
+	"slices"
+	"sort"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 	"github.com/raihanstark/trade-journal/internal/domain/analytics"
 )
 
+// Range of the R-multiple distribution, in whole R
+const (
+	rBucketMin = -3
+	rBucketMax = 5
+)
+
 // Calculator handles all analytics calculations
 type Calculator struct{}
 
@@ -21,6 +30,9 @@ func NewCalculator() *Calculator {
 func (c *Calculator) CalculateAnalytics(trades []db.Trade) *analytics.Analytics {
 	result := &analytics.Analytics{}
 
+	// Orders that never filled count towards the fill rate only
+	result.PendingOrders, result.FilledOrders, result.UnfilledOrders, result.FillRate = c.calculateFillRate(trades)
+
 	// Filter only BUY and SELL trades with P/L (closed trades)
 	closedTrades := c.filterClosedTrades(trades)
 
@@ -31,6 +43,9 @@ func (c *Calculator) CalculateAnalytics(trades []db.Trade) *analytics.Analytics
 	// Calculate basic metrics
 	totalPL, winningTrades, losingTrades, totalWinPL, totalLossPL, largestWin, largestLoss := c.calculateBasicMetrics(closedTrades)
 
+	result.GrossPL, result.TotalCommission, result.TotalSwap, result.TotalFees = c.calculateCosts(closedTrades)
+	result.TotalCosts = roundMoney(result.TotalCommission + result.TotalFees - result.TotalSwap)
+
 	result.TotalTrades = int64(len(closedTrades))
 	result.WinningTrades = winningTrades
 	result.LosingTrades = losingTrades
@@ -56,6 +71,12 @@ func (c *Calculator) CalculateAnalytics(trades []db.Trade) *analytics.Analytics
 		result.ProfitFactor = totalWinPL / totalLossPL
 	}
 
+	// Calculate R-multiple metrics
+	result.AvgRR, result.TotalR, result.RDistribution = c.calculateRMultiples(closedTrades)
+
+	// Calculate holding times
+	result.AvgHoldWinners, result.MedianHoldWinners, result.AvgHoldLosers, result.MedianHoldLosers = c.calculateHoldingTimes(closedTrades)
+
 	// Calculate streaks
 	result.ConsecutiveWins, result.ConsecutiveLosses, result.BestStreak, result.WorstStreak = c.calculateStreaks(closedTrades)
 
@@ -68,17 +89,154 @@ func (c *Calculator) CalculateAnalytics(trades []db.Trade) *analytics.Analytics
 	return result
 }
 
-// filterClosedTrades filters only BUY and SELL trades with valid P/L
+// filterClosedTrades filters only filled BUY and SELL trades with valid P/L
 func (c *Calculator) filterClosedTrades(trades []db.Trade) []db.Trade {
 	var closedTrades []db.Trade
 	for _, trade := range trades {
-		if (trade.Type == db.TradeTypeBUY || trade.Type == db.TradeTypeSELL) && trade.Pl.Valid {
+		if (trade.Type == db.TradeTypeBUY || trade.Type == db.TradeTypeSELL) && !isUnfilled(trade.Status) && trade.Pl.Valid {
 			closedTrades = append(closedTrades, trade)
 		}
 	}
 	return closedTrades
 }
 
+// MergeGroups folds the filled BUY and SELL trades of each trade group into one trade so a group
+// counts once, like a single position. The combined trade takes the ID, open time and grade of
+// the earliest member; P/L and costs are summed, R is weighted by risk, and it is closed only once
+// every member is. The returned map points every merged member at that ID.
+func (c *Calculator) MergeGroups(trades []db.Trade) ([]db.Trade, map[int32]int32) {
+	members := make(map[int32][]db.Trade)
+	for _, trade := range trades {
+		if isGroupable(trade) {
+			members[trade.GroupID.Int32] = append(members[trade.GroupID.Int32], trade)
+		}
+	}
+
+	merged := make([]db.Trade, 0, len(trades))
+	representatives := make(map[int32]int32)
+	for _, trade := range trades {
+		if !isGroupable(trade) {
+			merged = append(merged, trade)
+			continue
+		}
+
+		group := members[trade.GroupID.Int32]
+		if group == nil {
+			continue // Already merged into an earlier trade
+		}
+		delete(members, trade.GroupID.Int32)
+
+		combined := mergeTrades(group)
+		for _, member := range group {
+			representatives[member.ID] = combined.ID
+		}
+		merged = append(merged, combined)
+	}
+	return merged, representatives
+}
+
+// isGroupable reports whether a trade is a filled position belonging to a trade group
+func isGroupable(trade db.Trade) bool {
+	return trade.GroupID.Valid && (trade.Type == db.TradeTypeBUY || trade.Type == db.TradeTypeSELL) && !isUnfilled(trade.Status)
+}
+
+// mergeTrades combines the members of a trade group into one trade
+func mergeTrades(group []db.Trade) db.Trade {
+	combined := group[0]
+	for _, member := range group[1:] {
+		if member.OpenedAt.Before(combined.OpenedAt) {
+			combined = member
+		}
+	}
+
+	var pl, net, commission, swap, fees, risk, weightedR float64
+	settled, rated, closed := true, true, true
+	var closedAt time.Time
+	for _, member := range group {
+		if member.Pl.Valid {
+			pl += parseFloatFromNullString(member.Pl)
+			net += netPL(member)
+		} else {
+			settled = false
+		}
+		commission += parseFloatFromString(member.Commission)
+		swap += parseFloatFromString(member.Swap)
+		fees += parseFloatFromString(member.Fees)
+		if member.RiskAmount.Valid && member.RealizedR.Valid {
+			memberRisk := parseFloatFromNullString(member.RiskAmount)
+			risk += memberRisk
+			weightedR += parseFloatFromNullString(member.RealizedR) * memberRisk
+		} else {
+			rated = false
+		}
+		if member.Status != db.TradeStatusClosed || !member.ClosedAt.Valid {
+			closed = false
+		} else if member.ClosedAt.Time.After(closedAt) {
+			closedAt = member.ClosedAt.Time
+		}
+	}
+
+	combined.Pl, combined.NetPl = sql.NullString{}, sql.NullString{}
+	if settled {
+		combined.Pl = formatMoney(pl)
+		combined.NetPl = formatMoney(net)
+	}
+	combined.Commission = formatMoney(commission).String
+	combined.Swap = formatMoney(swap).String
+	combined.Fees = formatMoney(fees).String
+	combined.RiskAmount, combined.RealizedR = sql.NullString{}, sql.NullString{}
+	if rated && risk != 0 {
+		combined.RiskAmount = formatMoney(risk)
+		combined.RealizedR = formatMoney(weightedR / risk)
+	}
+	combined.Status, combined.ClosedAt = db.TradeStatusOpen, sql.NullTime{}
+	if closed {
+		combined.Status = db.TradeStatusClosed
+		combined.ClosedAt = sql.NullTime{Time: closedAt, Valid: true}
+	}
+	return combined
+}
+
+// calculateFillRate counts limit and stop orders by outcome. The fill rate is the percentage
+// of orders that filled among those that are no longer pending.
+func (c *Calculator) calculateFillRate(trades []db.Trade) (pending, filled, unfilled int64, fillRate float64) {
+	for _, trade := range trades {
+		if trade.OrderType != db.OrderTypeLimit && trade.OrderType != db.OrderTypeStop {
+			continue
+		}
+
+		switch trade.Status {
+		case db.TradeStatusPending:
+			pending++
+		case db.TradeStatusCancelled, db.TradeStatusExpired:
+			unfilled++
+		default:
+			filled++
+		}
+	}
+
+	if resolved := filled + unfilled; resolved > 0 {
+		fillRate = float64(filled) / float64(resolved) * 100
+	}
+	return pending, filled, unfilled, fillRate
+}
+
+// isUnfilled reports whether a trade is an order that is pending, cancelled or expired
+func isUnfilled(status db.TradeStatus) bool {
+	return status == db.TradeStatusPending || status == db.TradeStatusCancelled || status == db.TradeStatusExpired
+}
+
+// calculateCosts totals gross P/L and the trading costs recorded on the trades
+func (c *Calculator) calculateCosts(trades []db.Trade) (grossPL, commission, swap, fees float64) {
+	for _, trade := range trades {
+		grossPL += parseFloatFromNullString(trade.Pl)
+		commission += parseFloatFromString(trade.Commission)
+		swap += parseFloatFromString(trade.Swap)
+		fees += parseFloatFromString(trade.Fees)
+	}
+	return roundMoney(grossPL), roundMoney(commission), roundMoney(swap), roundMoney(fees)
+}
+
 // calculateBasicMetrics calculates basic P/L metrics
 func (c *Calculator) calculateBasicMetrics(trades []db.Trade) (
 	totalPL float64,
@@ -87,7 +245,7 @@ func (c *Calculator) calculateBasicMetrics(trades []db.Trade) (
 	largestWin, largestLoss float64,
 ) {
 	for _, trade := range trades {
-		pl := parseFloatFromNullString(trade.Pl)
+		pl := netPL(trade)
 		totalPL += pl
 
 		if pl > 0 {
@@ -107,6 +265,282 @@ func (c *Calculator) calculateBasicMetrics(trades []db.Trade) (
 	return
 }
 
+// calculateRMultiples averages and sums the realized R-multiples of trades that had a
+// stop loss, and counts them in 1R buckets from -3R to +5R. R-multiples beyond the
+// range are counted in the outermost buckets.
+func (c *Calculator) calculateRMultiples(trades []db.Trade) (avgR, totalR float64, distribution []analytics.RBucket) {
+	distribution = make([]analytics.RBucket, 0, rBucketMax-rBucketMin)
+	for from := rBucketMin; from < rBucketMax; from++ {
+		distribution = append(distribution, analytics.RBucket{From: float64(from), To: float64(from + 1)})
+	}
+
+	var count int64
+	for _, trade := range trades {
+		if !trade.RealizedR.Valid {
+			continue
+		}
+
+		r := parseFloatFromNullString(trade.RealizedR)
+		totalR += r
+		count++
+
+		bucket := int(math.Floor(r)) - rBucketMin
+		bucket = max(0, min(bucket, len(distribution)-1))
+		distribution[bucket].Count++
+	}
+
+	if count > 0 {
+		avgR = math.Round(totalR/float64(count)*100) / 100
+	}
+	return avgR, roundMoney(totalR), distribution
+}
+
+// CalculateTagBreakdown reports the performance of the closed trades carrying each tag, in tag
+// name order. A trade with several tags counts towards each of them.
+func (c *Calculator) CalculateTagBreakdown(trades []db.Trade, tags []db.GetTradeTagsByUserIDRow) []analytics.GroupStats {
+	tagsByTrade := make(map[int32][]string)
+	for _, tag := range tags {
+		tagsByTrade[tag.TradeID] = append(tagsByTrade[tag.TradeID], tag.Name)
+	}
+	return c.calculateGroupStats(trades, tagsByTrade)
+}
+
+// CalculateMistakeBreakdown reports how often each mistake type was made on closed trades and
+// what those trades made or lost, in mistake type name order
+func (c *Calculator) CalculateMistakeBreakdown(trades []db.Trade, mistakes []db.GetTradeMistakesByUserIDRow) []analytics.GroupStats {
+	mistakesByTrade := make(map[int32][]string)
+	for _, m := range mistakes {
+		mistakesByTrade[m.TradeID] = append(mistakesByTrade[m.TradeID], m.Name)
+	}
+	return c.calculateGroupStats(trades, mistakesByTrade)
+}
+
+// Rule adherence groups
+const (
+	RuleAdherenceCompliant  = "compliant"
+	RuleAdherenceBrokeRules = "broke_rules"
+)
+
+// CalculateRuleAdherence compares closed trades that satisfied every rule of their strategies with
+// closed trades that broke at least one. Trades without strategy rules are left out; rows sharing
+// a trade ID, as for the members of a merged trade group, are counted together.
+func (c *Calculator) CalculateRuleAdherence(trades []db.Trade, adherence []db.GetTradeRuleAdherenceByUserIDRow) []analytics.GroupStats {
+	rules := make(map[int32]int64)
+	broken := make(map[int32]int64)
+	for _, a := range adherence {
+		rules[a.TradeID] += a.Rules
+		broken[a.TradeID] += a.Rules - a.SatisfiedRules
+	}
+
+	groupByTrade := make(map[int32][]string)
+	for tradeID := range rules {
+		if broken[tradeID] == 0 {
+			groupByTrade[tradeID] = []string{RuleAdherenceCompliant}
+		} else {
+			groupByTrade[tradeID] = []string{RuleAdherenceBrokeRules}
+		}
+	}
+	return c.calculateGroupStats(trades, groupByTrade)
+}
+
+// CalculateGradeBreakdown reports the win rate, average R and total P/L of the closed trades
+// given each setup grade. Grades follow the order of scale; grades dropped from the scale come
+// last in name order, and ungraded trades are left out.
+func (c *Calculator) CalculateGradeBreakdown(trades []db.Trade, scale []string) []analytics.GradeStats {
+	byGrade := make(map[string]*analytics.GradeStats)
+	totalR := make(map[string]float64)
+	countR := make(map[string]int64)
+	for _, trade := range c.filterClosedTrades(trades) {
+		if !trade.Grade.Valid {
+			continue
+		}
+
+		name := trade.Grade.String
+		stats, ok := byGrade[name]
+		if !ok {
+			stats = &analytics.GradeStats{Grade: name}
+			byGrade[name] = stats
+		}
+
+		pl := netPL(trade)
+		stats.Trades++
+		stats.TotalPL += pl
+		if pl > 0 {
+			stats.WinningTrades++
+		}
+		if trade.RealizedR.Valid {
+			totalR[name] += parseFloatFromNullString(trade.RealizedR)
+			countR[name]++
+		}
+	}
+
+	grades := make([]analytics.GradeStats, 0, len(byGrade))
+	for name, stats := range byGrade {
+		stats.WinRate = float64(stats.WinningTrades) / float64(stats.Trades) * 100
+		stats.TotalPL = roundMoney(stats.TotalPL)
+		if countR[name] > 0 {
+			stats.AvgR = math.Round(totalR[name]/float64(countR[name])*100) / 100
+		}
+		grades = append(grades, *stats)
+	}
+
+	rank := func(name string) int {
+		if i := slices.Index(scale, name); i >= 0 {
+			return i
+		}
+		return len(scale)
+	}
+	sort.Slice(grades, func(i, j int) bool {
+		a, b := rank(grades[i].Grade), rank(grades[j].Grade)
+		if a != b {
+			return a < b
+		}
+		return grades[i].Grade < grades[j].Grade
+	})
+	return grades
+}
+
+// CalculateRatingBreakdown correlates psychology ratings with results: for every dimension, phase
+// and score it reports the win rate and average P/L of the closed trades rated that way.
+// Buckets are ordered by dimension name, then before ahead of after, then score.
+func (c *Calculator) CalculateRatingBreakdown(trades []db.Trade, ratings []db.GetTradeRatingsByUserIDRow) []analytics.RatingStats {
+	type bucket struct {
+		dimension string
+		phase     db.RatingPhase
+		rating    int16
+	}
+
+	bucketsByTrade := make(map[int32][]bucket)
+	for _, r := range ratings {
+		bucketsByTrade[r.TradeID] = append(bucketsByTrade[r.TradeID], bucket{r.Name, r.Phase, r.Value})
+	}
+
+	byBucket := make(map[bucket]*analytics.RatingStats)
+	totals := make(map[bucket]float64)
+	for _, trade := range c.filterClosedTrades(trades) {
+		pl := netPL(trade)
+		seen := make(map[bucket]bool)
+		for _, b := range bucketsByTrade[trade.ID] {
+			if seen[b] {
+				continue // Several members of a merged trade group rated alike
+			}
+			seen[b] = true
+
+			stats, ok := byBucket[b]
+			if !ok {
+				stats = &analytics.RatingStats{Dimension: b.dimension, Phase: string(b.phase), Rating: int(b.rating)}
+				byBucket[b] = stats
+			}
+
+			stats.Trades++
+			totals[b] += pl
+			if pl > 0 {
+				stats.WinningTrades++
+			}
+		}
+	}
+
+	buckets := make([]analytics.RatingStats, 0, len(byBucket))
+	for b, stats := range byBucket {
+		stats.WinRate = float64(stats.WinningTrades) / float64(stats.Trades) * 100
+		stats.AvgPL = roundMoney(totals[b] / float64(stats.Trades))
+		buckets = append(buckets, *stats)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		a, b := buckets[i], buckets[j]
+		if a.Dimension != b.Dimension {
+			return a.Dimension < b.Dimension
+		}
+		if a.Phase != b.Phase {
+			return a.Phase == string(db.RatingPhaseBefore)
+		}
+		return a.Rating < b.Rating
+	})
+	return buckets
+}
+
+// calculateGroupStats groups closed trades by the names each trade is filed under and
+// summarizes every group, in name order. Trades filed under no name are left out, and a
+// name listed more than once for a trade, as by several members of a trade group, counts once.
+func (c *Calculator) calculateGroupStats(trades []db.Trade, groupsByTrade map[int32][]string) []analytics.GroupStats {
+	byName := make(map[string]*analytics.GroupStats)
+	for _, trade := range c.filterClosedTrades(trades) {
+		pl := netPL(trade)
+		names := slices.Clone(groupsByTrade[trade.ID])
+		slices.Sort(names)
+		for _, name := range slices.Compact(names) {
+			group, ok := byName[name]
+			if !ok {
+				group = &analytics.GroupStats{Name: name}
+				byName[name] = group
+			}
+
+			group.Trades++
+			group.TotalPL += pl
+			if pl > 0 {
+				group.WinningTrades++
+			}
+		}
+	}
+
+	groups := make([]analytics.GroupStats, 0, len(byName))
+	for _, group := range byName {
+		group.WinRate = float64(group.WinningTrades) / float64(group.Trades) * 100
+		group.Expectancy = roundMoney(group.TotalPL / float64(group.Trades))
+		group.TotalPL = roundMoney(group.TotalPL)
+		groups = append(groups, *group)
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		return groups[i].Name < groups[j].Name
+	})
+	return groups
+}
+
+// calculateHoldingTimes returns the average and median holding time, in seconds, of winning
+// and losing trades. Trades that are still open or have no recorded close are skipped.
+func (c *Calculator) calculateHoldingTimes(trades []db.Trade) (avgWin, medianWin, avgLoss, medianLoss float64) {
+	var winners, losers []float64
+	for _, trade := range trades {
+		if trade.Status != db.TradeStatusClosed || !trade.ClosedAt.Valid {
+			continue
+		}
+
+		held := trade.ClosedAt.Time.Sub(trade.OpenedAt).Seconds()
+		if pl := netPL(trade); pl > 0 {
+			winners = append(winners, held)
+		} else if pl < 0 {
+			losers = append(losers, held)
+		}
+	}
+
+	avgWin, medianWin = averageAndMedian(winners)
+	avgLoss, medianLoss = averageAndMedian(losers)
+	return
+}
+
+// averageAndMedian returns the mean and median of values rounded to whole units, or zeros when empty
+func averageAndMedian(values []float64) (avg, median float64) {
+	if len(values) == 0 {
+		return 0, 0
+	}
+
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+
+	var sum float64
+	for _, v := range sorted {
+		sum += v
+	}
+	avg = sum / float64(len(sorted))
+
+	mid := len(sorted) / 2
+	median = sorted[mid]
+	if len(sorted)%2 == 0 {
+		median = (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return math.Round(avg), math.Round(median)
+}
+
 // calculateStreaks calculates current and best/worst streaks
 func (c *Calculator) calculateStreaks(trades []db.Trade) (currentWins, currentLosses, bestStreak, worstStreak int64) {
 	if len(trades) == 0 {
@@ -117,7 +551,7 @@ func (c *Calculator) calculateStreaks(trades []db.Trade) (currentWins, currentLo
 	var maxWinStreak, maxLossStreak int64
 
 	for _, trade := range trades {
-		pl := parseFloatFromNullString(trade.Pl)
+		pl := netPL(trade)
 
 		if pl > 0 {
 			if currentStreak > 0 {
@@ -164,7 +598,7 @@ func (c *Calculator) calculateSharpeRatio(trades []db.Trade) float64 {
 	var sum float64
 
 	for _, trade := range trades {
-		pl := parseFloatFromNullString(trade.Pl)
+		pl := netPL(trade)
 		returns = append(returns, pl)
 		sum += pl
 	}
@@ -198,7 +632,7 @@ func (c *Calculator) calculateMaxDrawdown(trades []db.Trade) float64 {
 	var runningBalance float64
 
 	for _, trade := range trades {
-		pl := parseFloatFromNullString(trade.Pl)
+		pl := netPL(trade)
 		runningBalance += pl
 
 		if runningBalance > peak {
@@ -227,3 +661,27 @@ func parseFloatFromNullString(ns sql.NullString) float64 {
 	}
 	return f
 }
+
+// parseFloatFromString converts a NOT NULL decimal column to float64
+func parseFloatFromString(s string) float64 {
+	return parseFloatFromNullString(sql.NullString{String: s, Valid: true})
+}
+
+// formatMoney formats an amount as a two-decimal numeric column
+func formatMoney(amount float64) sql.NullString {
+	return sql.NullString{String: fmt.Sprintf("%.2f", amount), Valid: true}
+}
+
+// roundMoney rounds an amount to cents
+func roundMoney(amount float64) float64 {
+	return math.Round(amount*100) / 100
+}
+
+// netPL returns the P/L of a trade after costs, falling back to the gross P/L
+// for trades recorded before costs were tracked
+func netPL(trade db.Trade) float64 {
+	if trade.NetPl.Valid {
+		return parseFloatFromNullString(trade.NetPl)
+	}
+	return parseFloatFromNullString(trade.Pl)
+}
@@ -4,6 +4,7 @@ import (
 	"database/sql"
 	"math"
 	"testing"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 	domain "github.com/raihanstark/trade-journal/internal/domain/analytics"
@@ -200,6 +201,21 @@ func TestCalculateBasicMetrics(t *testing.T) {
 			expectedLargestW:  100,
 			expectedLargestL:  -50,
 		},
+		{
+			name: "uses net P/L when recorded",
+			trades: []db.Trade{
+				{Pl: nullString("100"), NetPl: nullString("90")},
+				{Pl: nullString("5"), NetPl: nullString("-2")},
+				{Pl: nullString("-50")},
+			},
+			expectedTotalPL:   38,
+			expectedWinning:   1,
+			expectedLosing:    2,
+			expectedTotalWin:  90,
+			expectedTotalLoss: 52,
+			expectedLargestW:  90,
+			expectedLargestL:  -50,
+		},
 		{
 			name:              "empty trades",
 			trades:            []db.Trade{},
@@ -242,6 +258,43 @@ func TestCalculateBasicMetrics(t *testing.T) {
 	}
 }
 
+func TestCalculateCosts(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{Type: db.TradeTypeBUY, Pl: nullString("100"), Commission: "7", Swap: "-1.5", Fees: "0", NetPl: nullString("91.5")},
+		{Type: db.TradeTypeSELL, Pl: nullString("-40"), Commission: "3.5", Swap: "2.25", Fees: "1", NetPl: nullString("-42.25")},
+		{Type: db.TradeTypeBUY, Pl: nullString("20"), Commission: "0", Swap: "0", Fees: "0"},
+	}
+
+	grossPL, commission, swap, fees := calc.calculateCosts(trades)
+
+	if grossPL != 80 {
+		t.Errorf("grossPL = %v, want 80", grossPL)
+	}
+	if commission != 10.5 {
+		t.Errorf("commission = %v, want 10.5", commission)
+	}
+	if swap != 0.75 {
+		t.Errorf("swap = %v, want 0.75", swap)
+	}
+	if fees != 1 {
+		t.Errorf("fees = %v, want 1", fees)
+	}
+
+	result := calc.CalculateAnalytics(trades)
+
+	if result.TotalCosts != 10.75 {
+		t.Errorf("TotalCosts = %v, want 10.75", result.TotalCosts)
+	}
+	if result.TotalPL != 69.25 {
+		t.Errorf("TotalPL = %v, want 69.25", result.TotalPL)
+	}
+	if result.GrossPL != 80 {
+		t.Errorf("GrossPL = %v, want 80", result.GrossPL)
+	}
+}
+
 func TestCalculateStreaks(t *testing.T) {
 	calc := NewCalculator()
 
@@ -615,3 +668,363 @@ func TestCalculateAnalytics(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculateRMultiples(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{Pl: nullString("200"), RealizedR: nullString("2")},
+		{Pl: nullString("-100"), RealizedR: nullString("-1")},
+		{Pl: nullString("50"), RealizedR: nullString("0.5")},
+		{Pl: nullString("800"), RealizedR: nullString("8")},
+		{Pl: nullString("30")}, // no stop loss
+	}
+
+	avgR, totalR, distribution := calc.calculateRMultiples(trades)
+
+	if totalR != 9.5 {
+		t.Errorf("totalR = %v, want 9.5", totalR)
+	}
+	if avgR != 2.38 {
+		t.Errorf("avgR = %v, want 2.38", avgR)
+	}
+
+	if len(distribution) != 8 {
+		t.Fatalf("expected 8 buckets, got %d", len(distribution))
+	}
+	if distribution[0].From != -3 || distribution[7].To != 5 {
+		t.Errorf("expected buckets from -3R to 5R, got %v to %v", distribution[0].From, distribution[7].To)
+	}
+
+	counts := map[float64]int64{}
+	for _, b := range distribution {
+		counts[b.From] = b.Count
+	}
+	// -1R, 0.5R, 2R and 8R (counted in the last bucket)
+	expected := map[float64]int64{-3: 0, -2: 0, -1: 1, 0: 1, 1: 0, 2: 1, 3: 0, 4: 1}
+	for from, want := range expected {
+		if counts[from] != want {
+			t.Errorf("bucket %vR: count = %d, want %d", from, counts[from], want)
+		}
+	}
+}
+
+func TestCalculateHoldingTimes(t *testing.T) {
+	calc := NewCalculator()
+	opened := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	closedAfter := func(d time.Duration) sql.NullTime {
+		return sql.NullTime{Time: opened.Add(d), Valid: true}
+	}
+
+	trades := []db.Trade{
+		{Status: db.TradeStatusClosed, Pl: nullString("100"), OpenedAt: opened, ClosedAt: closedAfter(time.Hour)},
+		{Status: db.TradeStatusClosed, Pl: nullString("50"), OpenedAt: opened, ClosedAt: closedAfter(2 * time.Hour)},
+		{Status: db.TradeStatusClosed, Pl: nullString("80"), OpenedAt: opened, ClosedAt: closedAfter(6 * time.Hour)},
+		{Status: db.TradeStatusClosed, Pl: nullString("-40"), OpenedAt: opened, ClosedAt: closedAfter(30 * time.Minute)},
+		{Status: db.TradeStatusClosed, Pl: nullString("-60"), OpenedAt: opened, ClosedAt: closedAfter(90 * time.Minute)},
+		// Loser after costs
+		{Status: db.TradeStatusClosed, Pl: nullString("5"), NetPl: nullString("-2"), OpenedAt: opened, ClosedAt: closedAfter(10 * time.Hour)},
+		// Close not recorded
+		{Status: db.TradeStatusClosed, Pl: nullString("-10"), OpenedAt: opened},
+		// Partially closed
+		{Status: db.TradeStatusOpen, Pl: nullString("20"), OpenedAt: opened},
+	}
+
+	avgWin, medianWin, avgLoss, medianLoss := calc.calculateHoldingTimes(trades)
+
+	// Winners held 1h, 2h and 6h
+	if avgWin != 3*3600 {
+		t.Errorf("avgWin = %v, want %v", avgWin, 3*3600)
+	}
+	if medianWin != 2*3600 {
+		t.Errorf("medianWin = %v, want %v", medianWin, 2*3600)
+	}
+	// Losers held 30m, 90m and 10h
+	if avgLoss != 14400 {
+		t.Errorf("avgLoss = %v, want 14400", avgLoss)
+	}
+	if medianLoss != 5400 {
+		t.Errorf("medianLoss = %v, want 5400", medianLoss)
+	}
+}
+
+func TestAverageAndMedian(t *testing.T) {
+	tests := []struct {
+		name       string
+		values     []float64
+		wantAvg    float64
+		wantMedian float64
+	}{
+		{"empty", nil, 0, 0},
+		{"odd count", []float64{30, 10, 20}, 20, 20},
+		{"even count", []float64{40, 10, 20, 100}, 43, 30},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			avg, median := averageAndMedian(tt.values)
+			if avg != tt.wantAvg || median != tt.wantMedian {
+				t.Errorf("averageAndMedian(%v) = %v, %v, want %v, %v", tt.values, avg, median, tt.wantAvg, tt.wantMedian)
+			}
+		})
+	}
+}
+
+func TestCalculateFillRate(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{Type: db.TradeTypeBUY, OrderType: db.OrderTypeLimit, Status: db.TradeStatusClosed, Pl: nullString("100")},
+		{Type: db.TradeTypeBUY, OrderType: db.OrderTypeStop, Status: db.TradeStatusOpen},
+		{Type: db.TradeTypeBUY, OrderType: db.OrderTypeLimit, Status: db.TradeStatusClosed, Pl: nullString("-40")},
+		{Type: db.TradeTypeBUY, OrderType: db.OrderTypeLimit, Status: db.TradeStatusCancelled},
+		{Type: db.TradeTypeBUY, OrderType: db.OrderTypeStop, Status: db.TradeStatusPending},
+		// Market orders always fill and do not count
+		{Type: db.TradeTypeBUY, OrderType: db.OrderTypeMarket, Status: db.TradeStatusClosed, Pl: nullString("20")},
+	}
+
+	pending, filled, unfilled, fillRate := calc.calculateFillRate(trades)
+	if pending != 1 || filled != 3 || unfilled != 1 {
+		t.Errorf("pending, filled, unfilled = %d, %d, %d, want 1, 3, 1", pending, filled, unfilled)
+	}
+	if fillRate != 75 {
+		t.Errorf("fillRate = %v, want 75", fillRate)
+	}
+
+	t.Run("unfilled orders stay out of P/L analytics", func(t *testing.T) {
+		result := calc.CalculateAnalytics(append(trades,
+			db.Trade{Type: db.TradeTypeBUY, OrderType: db.OrderTypeLimit, Status: db.TradeStatusExpired},
+		))
+		if result.TotalTrades != 3 {
+			t.Errorf("TotalTrades = %d, want 3", result.TotalTrades)
+		}
+		if result.TotalPL != 80 {
+			t.Errorf("TotalPL = %v, want 80", result.TotalPL)
+		}
+		if result.UnfilledOrders != 2 {
+			t.Errorf("UnfilledOrders = %d, want 2", result.UnfilledOrders)
+		}
+		if math.Abs(result.FillRate-60) > 0.001 {
+			t.Errorf("FillRate = %v, want 60", result.FillRate)
+		}
+	})
+}
+
+func TestCalculateTagBreakdown(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{ID: 1, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("100")},
+		{ID: 2, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("-40")},
+		{ID: 3, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("60"), NetPl: nullString("55")},
+		// Still open, no P/L yet
+		{ID: 4, Type: db.TradeTypeBUY, Status: db.TradeStatusOpen},
+	}
+	tags := []db.GetTradeTagsByUserIDRow{
+		{TradeID: 1, Name: "breakout"},
+		{TradeID: 2, Name: "breakout"},
+		{TradeID: 3, Name: "breakout"},
+		{TradeID: 2, Name: "news"},
+		{TradeID: 4, Name: "news"},
+		{TradeID: 4, Name: "scalp"},
+	}
+
+	groups := calc.CalculateTagBreakdown(trades, tags)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 tags with closed trades, got %d", len(groups))
+	}
+
+	breakout := groups[0]
+	if breakout.Name != "breakout" || breakout.Trades != 3 || breakout.WinningTrades != 2 {
+		t.Errorf("unexpected breakout stats: %+v", breakout)
+	}
+	if breakout.TotalPL != 115 {
+		t.Errorf("breakout TotalPL = %v, want 115", breakout.TotalPL)
+	}
+	if math.Abs(breakout.WinRate-66.67) > 0.01 {
+		t.Errorf("breakout WinRate = %v, want 66.67", breakout.WinRate)
+	}
+	if breakout.Expectancy != 38.33 {
+		t.Errorf("breakout Expectancy = %v, want 38.33", breakout.Expectancy)
+	}
+
+	news := groups[1]
+	if news.Name != "news" || news.Trades != 1 || news.WinRate != 0 || news.Expectancy != -40 {
+		t.Errorf("unexpected news stats: %+v", news)
+	}
+}
+
+func TestCalculateRatingBreakdown(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{ID: 1, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("120")},
+		{ID: 2, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("-60")},
+		{ID: 3, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("30")},
+		// Still open, no P/L yet
+		{ID: 4, Type: db.TradeTypeBUY, Status: db.TradeStatusOpen},
+	}
+	ratings := []db.GetTradeRatingsByUserIDRow{
+		{TradeID: 1, Name: "confidence", Phase: db.RatingPhaseBefore, Value: 4},
+		{TradeID: 3, Name: "confidence", Phase: db.RatingPhaseBefore, Value: 4},
+		{TradeID: 2, Name: "confidence", Phase: db.RatingPhaseBefore, Value: 2},
+		{TradeID: 2, Name: "confidence", Phase: db.RatingPhaseAfter, Value: 1},
+		{TradeID: 2, Name: "fomo", Phase: db.RatingPhaseBefore, Value: 5},
+		{TradeID: 4, Name: "fomo", Phase: db.RatingPhaseBefore, Value: 5},
+	}
+
+	buckets := calc.CalculateRatingBreakdown(trades, ratings)
+	if len(buckets) != 4 {
+		t.Fatalf("expected 4 buckets with closed trades, got %d", len(buckets))
+	}
+
+	expected := []struct {
+		dimension string
+		phase     string
+		rating    int
+		trades    int64
+		winRate   float64
+		avgPL     float64
+	}{
+		{"confidence", "before", 2, 1, 0, -60},
+		{"confidence", "before", 4, 2, 100, 75},
+		{"confidence", "after", 1, 1, 0, -60},
+		{"fomo", "before", 5, 1, 0, -60},
+	}
+	for i, want := range expected {
+		got := buckets[i]
+		if got.Dimension != want.dimension || got.Phase != want.phase || got.Rating != want.rating {
+			t.Errorf("bucket %d = %s/%s/%d, want %s/%s/%d", i, got.Dimension, got.Phase, got.Rating, want.dimension, want.phase, want.rating)
+			continue
+		}
+		if got.Trades != want.trades || got.WinRate != want.winRate || got.AvgPL != want.avgPL {
+			t.Errorf("bucket %d stats = %+v, want trades %d win rate %v avg P/L %v", i, got, want.trades, want.winRate, want.avgPL)
+		}
+	}
+}
+
+func TestCalculateRuleAdherence(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{ID: 1, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("120")},
+		{ID: 2, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("-60")},
+		{ID: 3, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("30")},
+		// No strategy rules, left out
+		{ID: 4, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("500")},
+		// Still open, no P/L yet
+		{ID: 5, Type: db.TradeTypeBUY, Status: db.TradeStatusOpen},
+	}
+	adherence := []db.GetTradeRuleAdherenceByUserIDRow{
+		{TradeID: 1, Rules: 3, SatisfiedRules: 3},
+		{TradeID: 2, Rules: 3, SatisfiedRules: 1},
+		{TradeID: 3, Rules: 2, SatisfiedRules: 2},
+		{TradeID: 5, Rules: 2, SatisfiedRules: 0},
+	}
+
+	groups := calc.CalculateRuleAdherence(trades, adherence)
+	if len(groups) != 2 {
+		t.Fatalf("expected 2 groups, got %d", len(groups))
+	}
+
+	broke, compliant := groups[0], groups[1]
+	if broke.Name != RuleAdherenceBrokeRules || broke.Trades != 1 || broke.WinRate != 0 || broke.TotalPL != -60 {
+		t.Errorf("unexpected broke rules group: %+v", broke)
+	}
+	if compliant.Name != RuleAdherenceCompliant || compliant.Trades != 2 || compliant.WinRate != 100 || compliant.TotalPL != 150 {
+		t.Errorf("unexpected compliant group: %+v", compliant)
+	}
+	if compliant.Expectancy != 75 {
+		t.Errorf("expected compliant expectancy 75, got %v", compliant.Expectancy)
+	}
+}
+
+func TestCalculateGradeBreakdown(t *testing.T) {
+	calc := NewCalculator()
+
+	trades := []db.Trade{
+		{ID: 1, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("200"), RealizedR: nullString("2"), Grade: nullString("A+")},
+		{ID: 2, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("100"), RealizedR: nullString("1"), Grade: nullString("A+")},
+		{ID: 3, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("-100"), RealizedR: nullString("-1"), Grade: nullString("C")},
+		// No stop loss, so no R-multiple
+		{ID: 4, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("50"), Grade: nullString("C")},
+		// Ungraded and open trades are left out
+		{ID: 5, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("500")},
+		{ID: 6, Type: db.TradeTypeBUY, Status: db.TradeStatusOpen, Grade: nullString("B")},
+	}
+
+	grades := calc.CalculateGradeBreakdown(trades, []string{"A+", "A", "B", "C"})
+	if len(grades) != 2 {
+		t.Fatalf("expected 2 grades with closed trades, got %d", len(grades))
+	}
+
+	if a := grades[0]; a.Grade != "A+" || a.Trades != 2 || a.WinRate != 100 || a.AvgR != 1.5 || a.TotalPL != 300 {
+		t.Errorf("unexpected A+ stats: %+v", a)
+	}
+	if c := grades[1]; c.Grade != "C" || c.Trades != 2 || c.WinRate != 50 || c.AvgR != -1 || c.TotalPL != -50 {
+		t.Errorf("unexpected C stats: %+v", c)
+	}
+}
+
+func TestMergeGroups(t *testing.T) {
+	calc := NewCalculator()
+	opened := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+	at := func(d time.Duration) sql.NullTime {
+		return sql.NullTime{Time: opened.Add(d), Valid: true}
+	}
+	group := func(id int32) sql.NullInt32 {
+		return sql.NullInt32{Int32: id, Valid: true}
+	}
+
+	trades := []db.Trade{
+		{ID: 1, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("300"), NetPl: nullString("290"), Commission: "10",
+			RiskAmount: nullString("100"), RealizedR: nullString("3"), OpenedAt: opened, ClosedAt: at(2 * time.Hour), GroupID: group(7), Grade: nullString("A")},
+		{ID: 2, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("-100"), NetPl: nullString("-105"), Commission: "5",
+			RiskAmount: nullString("100"), RealizedR: nullString("-1"), OpenedAt: opened.Add(time.Hour), ClosedAt: at(3 * time.Hour), GroupID: group(7)},
+		// A cancelled order stays out of the group
+		{ID: 3, Type: db.TradeTypeBUY, Status: db.TradeStatusCancelled, OrderType: db.OrderTypeLimit, OpenedAt: opened, GroupID: group(7)},
+		{ID: 4, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("50"), OpenedAt: opened},
+		// A group with an open member stays open
+		{ID: 5, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("40"), OpenedAt: opened, ClosedAt: at(time.Hour), GroupID: group(8)},
+		{ID: 6, Type: db.TradeTypeSELL, Status: db.TradeStatusOpen, OpenedAt: opened.Add(time.Hour), GroupID: group(8)},
+	}
+
+	merged, representatives := calc.MergeGroups(trades)
+	if len(merged) != 4 {
+		t.Fatalf("expected 4 trades after merging, got %d", len(merged))
+	}
+
+	combined := merged[0]
+	if combined.ID != 1 || combined.Status != db.TradeStatusClosed || combined.Grade.String != "A" {
+		t.Errorf("unexpected combined trade: %+v", combined)
+	}
+	if combined.Pl.String != "200.00" || combined.NetPl.String != "185.00" || combined.Commission != "15.00" {
+		t.Errorf("expected summed P/L and costs, got pl=%s net=%s commission=%s", combined.Pl.String, combined.NetPl.String, combined.Commission)
+	}
+	if combined.RiskAmount.String != "200.00" || combined.RealizedR.String != "1.00" {
+		t.Errorf("expected risk 200 at 1R, got risk=%s r=%s", combined.RiskAmount.String, combined.RealizedR.String)
+	}
+	if !combined.ClosedAt.Valid || !combined.ClosedAt.Time.Equal(opened.Add(3*time.Hour)) {
+		t.Errorf("expected the group to close with its last member, got %v", combined.ClosedAt)
+	}
+
+	if merged[1].ID != 3 || merged[2].ID != 4 {
+		t.Errorf("expected ungrouped trades to be kept in order, got %d and %d", merged[1].ID, merged[2].ID)
+	}
+	if open := merged[3]; open.ID != 5 || open.Status != db.TradeStatusOpen || open.Pl.Valid || open.ClosedAt.Valid {
+		t.Errorf("expected an unsettled open group, got %+v", open)
+	}
+
+	if representatives[2] != 1 || representatives[6] != 5 {
+		t.Errorf("unexpected representatives: %v", representatives)
+	}
+	if _, ok := representatives[3]; ok {
+		t.Error("expected the cancelled order not to be merged")
+	}
+
+	// The merged group counts as one winning trade
+	result := calc.CalculateAnalytics(merged)
+	if result.TotalTrades != 2 || result.TotalPL != 235 {
+		t.Errorf("expected 2 closed trades totalling 235, got %d totalling %v", result.TotalTrades, result.TotalPL)
+	}
+}
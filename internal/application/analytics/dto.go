@@ -1,21 +1,78 @@
 package analytics
 
 type AnalyticsDTO struct {
-	TotalPL           float64 `json:"total_pl"`
-	WinRate           float64 `json:"win_rate"`
-	TotalTrades       int64   `json:"total_trades"`
-	WinningTrades     int64   `json:"winning_trades"`
-	LosingTrades      int64   `json:"losing_trades"`
-	AvgWin            float64 `json:"avg_win"`
-	AvgLoss           float64 `json:"avg_loss"`
-	ProfitFactor      float64 `json:"profit_factor"`
-	SharpeRatio       float64 `json:"sharpe_ratio"`
-	MaxDrawdown       float64 `json:"max_drawdown"`
-	LargestWin        float64 `json:"largest_win"`
-	LargestLoss       float64 `json:"largest_loss"`
-	AvgRR             float64 `json:"avg_rr"`
-	ConsecutiveWins   int64   `json:"consecutive_wins"`
-	ConsecutiveLosses int64   `json:"consecutive_losses"`
-	BestStreak        int64   `json:"best_streak"`
-	WorstStreak       int64   `json:"worst_streak"`
+	TotalPL           float64           `json:"total_pl"`
+	GrossPL           float64           `json:"gross_pl"`
+	WinRate           float64           `json:"win_rate"`
+	TotalTrades       int64             `json:"total_trades"`
+	WinningTrades     int64             `json:"winning_trades"`
+	LosingTrades      int64             `json:"losing_trades"`
+	AvgWin            float64           `json:"avg_win"`
+	AvgLoss           float64           `json:"avg_loss"`
+	ProfitFactor      float64           `json:"profit_factor"`
+	SharpeRatio       float64           `json:"sharpe_ratio"`
+	MaxDrawdown       float64           `json:"max_drawdown"`
+	LargestWin        float64           `json:"largest_win"`
+	LargestLoss       float64           `json:"largest_loss"`
+	AvgRR             float64           `json:"avg_rr"`
+	TotalR            float64           `json:"total_r"`
+	RDistribution     []RBucketDTO      `json:"r_distribution"`
+	ConsecutiveWins   int64             `json:"consecutive_wins"`
+	ConsecutiveLosses int64             `json:"consecutive_losses"`
+	BestStreak        int64             `json:"best_streak"`
+	WorstStreak       int64             `json:"worst_streak"`
+	AvgHoldWinners    float64           `json:"avg_hold_seconds_winners"`
+	MedianHoldWinners float64           `json:"median_hold_seconds_winners"`
+	AvgHoldLosers     float64           `json:"avg_hold_seconds_losers"`
+	MedianHoldLosers  float64           `json:"median_hold_seconds_losers"`
+	PendingOrders     int64             `json:"pending_orders"`
+	FilledOrders      int64             `json:"filled_orders"`
+	UnfilledOrders    int64             `json:"unfilled_orders"`
+	FillRate          float64           `json:"fill_rate"`
+	Tags              []GroupDTO        `json:"tags"`
+	Mistakes          []GroupDTO        `json:"mistakes"`
+	Ratings           []RatingBucketDTO `json:"ratings"`
+	RuleAdherence     []GroupDTO        `json:"rule_adherence"`
+	Grades            []GradeDTO        `json:"grades"`
+	TotalCommission   float64           `json:"total_commission"`
+	TotalSwap         float64           `json:"total_swap"`
+	TotalFees         float64           `json:"total_fees"`
+	TotalCosts        float64           `json:"total_costs"`
+}
+
+// GroupDTO is the performance of the closed trades sharing a label
+type GroupDTO struct {
+	Name          string  `json:"name"`
+	Trades        int64   `json:"trades"`
+	WinningTrades int64   `json:"winning_trades"`
+	WinRate       float64 `json:"win_rate"`
+	TotalPL       float64 `json:"total_pl"`
+	Expectancy    float64 `json:"expectancy"`
+}
+
+// GradeDTO is the performance of the closed trades given one setup grade
+type GradeDTO struct {
+	Grade         string  `json:"grade"`
+	Trades        int64   `json:"trades"`
+	WinningTrades int64   `json:"winning_trades"`
+	WinRate       float64 `json:"win_rate"`
+	AvgR          float64 `json:"avg_r"`
+	TotalPL       float64 `json:"total_pl"`
+}
+
+// RatingBucketDTO is the performance of the closed trades given one score on a rating dimension
+type RatingBucketDTO struct {
+	Dimension     string  `json:"dimension"`
+	Phase         string  `json:"phase"`
+	Rating        int     `json:"rating"`
+	Trades        int64   `json:"trades"`
+	WinningTrades int64   `json:"winning_trades"`
+	WinRate       float64 `json:"win_rate"`
+	AvgPL         float64 `json:"avg_pl"`
+}
+
+type RBucketDTO struct {
+	From  float64 `json:"from"`
+	To    float64 `json:"to"`
+	Count int64   `json:"count"`
 }
@@ -2,18 +2,30 @@ package analytics
 
 import (
 	"context"
+	"errors"
+	"time"
 
+	"github.com/raihanstark/trade-journal/internal/db"
 	"github.com/raihanstark/trade-journal/internal/domain/analytics"
+	"github.com/raihanstark/trade-journal/internal/domain/grade"
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+)
+
+var (
+	ErrInvalidStartDate = errors.New("invalid start_date format, expected YYYY-MM-DD")
+	ErrInvalidEndDate   = errors.New("invalid end_date format, expected YYYY-MM-DD")
 )
 
 type Service struct {
 	repo       analytics.Repository
+	userRepo   user.Repository
 	calculator *Calculator
 }
 
-func NewService(repo analytics.Repository) *Service {
+func NewService(repo analytics.Repository, userRepo user.Repository) *Service {
 	return &Service{
 		repo:       repo,
+		userRepo:   userRepo,
 		calculator: NewCalculator(),
 	}
 }
@@ -25,16 +37,131 @@ func (s *Service) GetUserAnalytics(ctx context.Context, userID int64) (*Analytic
 		return nil, err
 	}
 
-	// Calculate analytics using calculator
+	return s.analyze(ctx, userID, trades, nil)
+}
+
+// GetUserAnalyticsWithDateFilter calculates analytics over trades opened between two dates,
+// inclusive, where the dates are whole days in the user's timezone
+func (s *Service) GetUserAnalyticsWithDateFilter(ctx context.Context, userID int64, startDate, endDate *string) (*AnalyticsDTO, error) {
+	trades, err := s.loadTrades(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.analyze(ctx, userID, trades, nil)
+}
+
+// GetGroupedUserAnalytics calculates analytics like GetUserAnalyticsWithDateFilter, but the
+// filled trades of each trade group count as a single combined trade
+func (s *Service) GetGroupedUserAnalytics(ctx context.Context, userID int64, startDate, endDate *string) (*AnalyticsDTO, error) {
+	trades, err := s.loadTrades(ctx, userID, startDate, endDate)
+	if err != nil {
+		return nil, err
+	}
+
+	merged, representatives := s.calculator.MergeGroups(trades)
+	return s.analyze(ctx, userID, merged, representatives)
+}
+
+// loadTrades returns the user's trades opened between two dates, inclusive, or every trade
+// when either date is missing
+func (s *Service) loadTrades(ctx context.Context, userID int64, startDate, endDate *string) ([]db.Trade, error) {
+	// If no date filter provided, use all trades
+	if startDate == nil || endDate == nil {
+		return s.repo.GetUserTrades(ctx, userID)
+	}
+
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	loc := u.Location()
+
+	start, err := time.ParseInLocation("2006-01-02", *startDate, loc)
+	if err != nil {
+		return nil, ErrInvalidStartDate
+	}
+	end, err := time.ParseInLocation("2006-01-02", *endDate, loc)
+	if err != nil {
+		return nil, ErrInvalidEndDate
+	}
+
+	return s.repo.GetUserTradesByDateRange(ctx, userID, start, end.AddDate(0, 0, 1))
+}
+
+// analyze calculates analytics over the trades, broken down by the user's tags, mistake types,
+// psychology ratings, strategy rule adherence and setup grades. Representatives maps the members
+// of merged trade groups to the trade they were merged into, so their breakdowns follow the group.
+func (s *Service) analyze(ctx context.Context, userID int64, trades []db.Trade, representatives map[int32]int32) (*AnalyticsDTO, error) {
 	analyticsData := s.calculator.CalculateAnalytics(trades)
 
+	tags, err := s.repo.GetUserTradeTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range tags {
+		tags[i].TradeID = representative(representatives, tags[i].TradeID)
+	}
+	analyticsData.Tags = s.calculator.CalculateTagBreakdown(trades, tags)
+
+	mistakes, err := s.repo.GetUserTradeMistakes(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range mistakes {
+		mistakes[i].TradeID = representative(representatives, mistakes[i].TradeID)
+	}
+	analyticsData.Mistakes = s.calculator.CalculateMistakeBreakdown(trades, mistakes)
+
+	ratings, err := s.repo.GetUserTradeRatings(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range ratings {
+		ratings[i].TradeID = representative(representatives, ratings[i].TradeID)
+	}
+	analyticsData.Ratings = s.calculator.CalculateRatingBreakdown(trades, ratings)
+
+	adherence, err := s.repo.GetUserTradeRuleAdherence(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range adherence {
+		adherence[i].TradeID = representative(representatives, adherence[i].TradeID)
+	}
+	analyticsData.RuleAdherence = s.calculator.CalculateRuleAdherence(trades, adherence)
+
+	grades, err := s.repo.GetUserGrades(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	scale := make([]string, len(grades))
+	for i, g := range grades {
+		scale[i] = g.Name
+	}
+	analyticsData.Grades = s.calculator.CalculateGradeBreakdown(trades, grade.ScaleOrDefault(scale))
+
 	// Convert to DTO
 	return s.toDTO(analyticsData), nil
 }
 
+// representative returns the trade a trade group member was merged into, or the trade itself
+func representative(representatives map[int32]int32, tradeID int32) int32 {
+	if id, ok := representatives[tradeID]; ok {
+		return id
+	}
+	return tradeID
+}
+
 func (s *Service) toDTO(a *analytics.Analytics) *AnalyticsDTO {
+	distribution := make([]RBucketDTO, len(a.RDistribution))
+	for i, b := range a.RDistribution {
+		distribution[i] = RBucketDTO{From: b.From, To: b.To, Count: b.Count}
+	}
+
 	return &AnalyticsDTO{
 		TotalPL:           a.TotalPL,
+		GrossPL:           a.GrossPL,
 		WinRate:           a.WinRate,
 		TotalTrades:       a.TotalTrades,
 		WinningTrades:     a.WinningTrades,
@@ -47,9 +174,74 @@ func (s *Service) toDTO(a *analytics.Analytics) *AnalyticsDTO {
 		LargestWin:        a.LargestWin,
 		LargestLoss:       a.LargestLoss,
 		AvgRR:             a.AvgRR,
+		TotalR:            a.TotalR,
+		RDistribution:     distribution,
 		ConsecutiveWins:   a.ConsecutiveWins,
 		ConsecutiveLosses: a.ConsecutiveLosses,
 		BestStreak:        a.BestStreak,
 		WorstStreak:       a.WorstStreak,
+		AvgHoldWinners:    a.AvgHoldWinners,
+		MedianHoldWinners: a.MedianHoldWinners,
+		AvgHoldLosers:     a.AvgHoldLosers,
+		MedianHoldLosers:  a.MedianHoldLosers,
+		PendingOrders:     a.PendingOrders,
+		FilledOrders:      a.FilledOrders,
+		UnfilledOrders:    a.UnfilledOrders,
+		FillRate:          a.FillRate,
+		Tags:              toGroupDTOs(a.Tags),
+		Mistakes:          toGroupDTOs(a.Mistakes),
+		Ratings:           toRatingDTOs(a.Ratings),
+		RuleAdherence:     toGroupDTOs(a.RuleAdherence),
+		Grades:            toGradeDTOs(a.Grades),
+		TotalCommission:   a.TotalCommission,
+		TotalSwap:         a.TotalSwap,
+		TotalFees:         a.TotalFees,
+		TotalCosts:        a.TotalCosts,
+	}
+}
+
+func toRatingDTOs(buckets []analytics.RatingStats) []RatingBucketDTO {
+	dtos := make([]RatingBucketDTO, len(buckets))
+	for i, b := range buckets {
+		dtos[i] = RatingBucketDTO{
+			Dimension:     b.Dimension,
+			Phase:         b.Phase,
+			Rating:        b.Rating,
+			Trades:        b.Trades,
+			WinningTrades: b.WinningTrades,
+			WinRate:       b.WinRate,
+			AvgPL:         b.AvgPL,
+		}
+	}
+	return dtos
+}
+
+func toGradeDTOs(grades []analytics.GradeStats) []GradeDTO {
+	dtos := make([]GradeDTO, len(grades))
+	for i, g := range grades {
+		dtos[i] = GradeDTO{
+			Grade:         g.Grade,
+			Trades:        g.Trades,
+			WinningTrades: g.WinningTrades,
+			WinRate:       g.WinRate,
+			AvgR:          g.AvgR,
+			TotalPL:       g.TotalPL,
+		}
+	}
+	return dtos
+}
+
+func toGroupDTOs(groups []analytics.GroupStats) []GroupDTO {
+	dtos := make([]GroupDTO, len(groups))
+	for i, g := range groups {
+		dtos[i] = GroupDTO{
+			Name:          g.Name,
+			Trades:        g.Trades,
+			WinningTrades: g.WinningTrades,
+			WinRate:       g.WinRate,
+			TotalPL:       g.TotalPL,
+			Expectancy:    g.Expectancy,
+		}
 	}
+	return dtos
 }
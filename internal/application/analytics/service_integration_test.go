@@ -20,11 +20,14 @@ func TestAnalyticsService_GetUserAnalytics_Integration(t *testing.T) {
 	analyticsRepo := persistence.NewAnalyticsRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
-
-	analyticsService := NewService(analyticsRepo)
-	accountService := accountapp.NewService(accountRepo)
-	tradeService := tradeapp.NewService(tradeRepo, accountRepo)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	analyticsService := NewService(analyticsRepo, userRepo)
+	tradeService := tradeapp.NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountapp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -2,10 +2,13 @@ package analytics
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/user"
 )
 
 // AnalyticsRepositorySpy records calls to the analytics repository
@@ -13,6 +16,12 @@ type AnalyticsRepositorySpy struct {
 	GetUserTradesCalls []int64
 	GetUserTradesResult []db.Trade
 	GetUserTradesError error
+	DateRangeCalls     [][2]time.Time
+	TradeTagsResult    []db.GetTradeTagsByUserIDRow
+	TradeMistakesResult []db.GetTradeMistakesByUserIDRow
+	TradeRatingsResult  []db.GetTradeRatingsByUserIDRow
+	TradeRuleAdherenceResult []db.GetTradeRuleAdherenceByUserIDRow
+	GradesResult             []db.Grade
 }
 
 func (s *AnalyticsRepositorySpy) GetUserTrades(ctx context.Context, userID int64) ([]db.Trade, error) {
@@ -20,6 +29,52 @@ func (s *AnalyticsRepositorySpy) GetUserTrades(ctx context.Context, userID int64
 	return s.GetUserTradesResult, s.GetUserTradesError
 }
 
+func (s *AnalyticsRepositorySpy) GetUserTradesByDateRange(ctx context.Context, userID int64, start, end time.Time) ([]db.Trade, error) {
+	s.DateRangeCalls = append(s.DateRangeCalls, [2]time.Time{start, end})
+	return s.GetUserTradesResult, s.GetUserTradesError
+}
+
+func (s *AnalyticsRepositorySpy) GetUserTradeTags(ctx context.Context, userID int64) ([]db.GetTradeTagsByUserIDRow, error) {
+	return s.TradeTagsResult, nil
+}
+
+func (s *AnalyticsRepositorySpy) GetUserTradeMistakes(ctx context.Context, userID int64) ([]db.GetTradeMistakesByUserIDRow, error) {
+	return s.TradeMistakesResult, nil
+}
+
+func (s *AnalyticsRepositorySpy) GetUserTradeRatings(ctx context.Context, userID int64) ([]db.GetTradeRatingsByUserIDRow, error) {
+	return s.TradeRatingsResult, nil
+}
+
+func (s *AnalyticsRepositorySpy) GetUserTradeRuleAdherence(ctx context.Context, userID int64) ([]db.GetTradeRuleAdherenceByUserIDRow, error) {
+	return s.TradeRuleAdherenceResult, nil
+}
+
+func (s *AnalyticsRepositorySpy) GetUserGrades(ctx context.Context, userID int64) ([]db.Grade, error) {
+	return s.GradesResult, nil
+}
+
+// UserRepositorySpy serves a user with the configured timezone
+type UserRepositorySpy struct {
+	Timezone string
+}
+
+func (s *UserRepositorySpy) GetByID(ctx context.Context, id int64) (*user.User, error) {
+	return &user.User{ID: id, Timezone: s.Timezone}, nil
+}
+
+func (s *UserRepositorySpy) Create(ctx context.Context, u *user.User) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *UserRepositorySpy) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *UserRepositorySpy) UpdateTimezone(ctx context.Context, id int64, timezone string) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
 func TestService_GetUserAnalytics_Success(t *testing.T) {
 	ctx := context.Background()
 	userID := int64(1)
@@ -33,7 +88,7 @@ func TestService_GetUserAnalytics_Success(t *testing.T) {
 				{Type: db.TradeTypeSELL, Pl: nullString("-75")},
 			},
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		dto, err := service.GetUserAnalytics(ctx, userID)
 
@@ -101,7 +156,7 @@ func TestService_GetUserAnalytics_Success(t *testing.T) {
 		repoSpy := &AnalyticsRepositorySpy{
 			GetUserTradesResult: []db.Trade{},
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		dto, err := service.GetUserAnalytics(ctx, userID)
 
@@ -128,7 +183,7 @@ func TestService_GetUserAnalytics_Success(t *testing.T) {
 				{Type: db.TradeTypeBUY, Pl: nullString("50")},
 			},
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		dto, err := service.GetUserAnalytics(ctx, userID)
 
@@ -155,7 +210,7 @@ func TestService_GetUserAnalytics_Success(t *testing.T) {
 				{Type: db.TradeTypeSELL, Pl: nullString("50")},
 			},
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		dto, err := service.GetUserAnalytics(ctx, userID)
 
@@ -183,7 +238,7 @@ func TestService_GetUserAnalytics_Error(t *testing.T) {
 		repoSpy := &AnalyticsRepositorySpy{
 			GetUserTradesError: errors.New("database connection failed"),
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		_, err := service.GetUserAnalytics(ctx, userID)
 
@@ -197,6 +252,42 @@ func TestService_GetUserAnalytics_Error(t *testing.T) {
 	})
 }
 
+func TestService_GetUserAnalytics_Mistakes(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+
+	t.Run("reports count and P/L per mistake type", func(t *testing.T) {
+		repoSpy := &AnalyticsRepositorySpy{
+			GetUserTradesResult: []db.Trade{
+				{ID: 1, Type: db.TradeTypeBUY, Pl: nullString("-80")},
+				{ID: 2, Type: db.TradeTypeSELL, Pl: nullString("-20")},
+				{ID: 3, Type: db.TradeTypeBUY, Pl: nullString("150")},
+			},
+			TradeMistakesResult: []db.GetTradeMistakesByUserIDRow{
+				{TradeID: 1, Name: "moved stop"},
+				{TradeID: 2, Name: "moved stop"},
+				{TradeID: 2, Name: "oversized"},
+			},
+		}
+		service := NewService(repoSpy, &UserRepositorySpy{})
+
+		dto, err := service.GetUserAnalytics(ctx, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(dto.Mistakes) != 2 {
+			t.Fatalf("expected 2 mistake types, got %d", len(dto.Mistakes))
+		}
+		if m := dto.Mistakes[0]; m.Name != "moved stop" || m.Trades != 2 || m.TotalPL != -100 {
+			t.Errorf("unexpected moved stop stats: %+v", m)
+		}
+		if m := dto.Mistakes[1]; m.Name != "oversized" || m.Trades != 1 || m.TotalPL != -20 {
+			t.Errorf("unexpected oversized stats: %+v", m)
+		}
+	})
+}
+
 func TestService_GetUserAnalytics_OnlyWins(t *testing.T) {
 	ctx := context.Background()
 	userID := int64(1)
@@ -209,7 +300,7 @@ func TestService_GetUserAnalytics_OnlyWins(t *testing.T) {
 				{Type: db.TradeTypeBUY, Pl: nullString("75")},
 			},
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		dto, err := service.GetUserAnalytics(ctx, userID)
 
@@ -247,7 +338,7 @@ func TestService_GetUserAnalytics_OnlyLosses(t *testing.T) {
 				{Type: db.TradeTypeBUY, Pl: nullString("-75")},
 			},
 		}
-		service := NewService(repoSpy)
+		service := NewService(repoSpy, &UserRepositorySpy{})
 
 		dto, err := service.GetUserAnalytics(ctx, userID)
 
@@ -270,3 +361,96 @@ func TestService_GetUserAnalytics_OnlyLosses(t *testing.T) {
 	})
 }
 
+
+func TestService_GetUserAnalyticsWithDateFilter(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+
+	t.Run("interprets dates as whole days in the user's timezone", func(t *testing.T) {
+		repoSpy := &AnalyticsRepositorySpy{
+			GetUserTradesResult: []db.Trade{
+				{Type: db.TradeTypeBUY, Pl: nullString("100")},
+			},
+		}
+		service := NewService(repoSpy, &UserRepositorySpy{Timezone: "America/New_York"})
+
+		startDate, endDate := "2025-01-01", "2025-01-31"
+		dto, err := service.GetUserAnalyticsWithDateFilter(ctx, userID, &startDate, &endDate)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(repoSpy.GetUserTradesCalls) != 0 {
+			t.Error("expected the unfiltered query not to be used")
+		}
+		if len(repoSpy.DateRangeCalls) != 1 {
+			t.Fatalf("expected 1 date range call, got %d", len(repoSpy.DateRangeCalls))
+		}
+
+		// New York is UTC-5 in January
+		wantStart := time.Date(2025, 1, 1, 5, 0, 0, 0, time.UTC)
+		wantEnd := time.Date(2025, 2, 1, 5, 0, 0, 0, time.UTC)
+		if got := repoSpy.DateRangeCalls[0]; !got[0].Equal(wantStart) || !got[1].Equal(wantEnd) {
+			t.Errorf("expected range [%v, %v), got [%v, %v)", wantStart, wantEnd, got[0].UTC(), got[1].UTC())
+		}
+		if dto.TotalTrades != 1 {
+			t.Errorf("TotalTrades = %v, want 1", dto.TotalTrades)
+		}
+	})
+
+	t.Run("without dates uses all trades", func(t *testing.T) {
+		repoSpy := &AnalyticsRepositorySpy{}
+		service := NewService(repoSpy, &UserRepositorySpy{})
+
+		if _, err := service.GetUserAnalyticsWithDateFilter(ctx, userID, nil, nil); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(repoSpy.GetUserTradesCalls) != 1 || len(repoSpy.DateRangeCalls) != 0 {
+			t.Errorf("expected 1 unfiltered call, got %d unfiltered and %d ranged", len(repoSpy.GetUserTradesCalls), len(repoSpy.DateRangeCalls))
+		}
+	})
+
+	t.Run("rejects an invalid date", func(t *testing.T) {
+		service := NewService(&AnalyticsRepositorySpy{}, &UserRepositorySpy{})
+
+		startDate, endDate := "2025-01-01", "31/01/2025"
+		_, err := service.GetUserAnalyticsWithDateFilter(ctx, userID, &startDate, &endDate)
+		if err != ErrInvalidEndDate {
+			t.Errorf("expected ErrInvalidEndDate, got %v", err)
+		}
+	})
+}
+
+func TestService_GetGroupedUserAnalytics(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	group := sql.NullInt32{Int32: 5, Valid: true}
+	opened := time.Date(2025, 1, 10, 9, 0, 0, 0, time.UTC)
+
+	repoSpy := &AnalyticsRepositorySpy{
+		GetUserTradesResult: []db.Trade{
+			{ID: 1, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("-50"), OpenedAt: opened, GroupID: group},
+			{ID: 2, Type: db.TradeTypeBUY, Status: db.TradeStatusClosed, Pl: nullString("150"), OpenedAt: opened.Add(time.Hour), GroupID: group},
+			{ID: 3, Type: db.TradeTypeSELL, Status: db.TradeStatusClosed, Pl: nullString("-30"), OpenedAt: opened},
+		},
+		TradeTagsResult: []db.GetTradeTagsByUserIDRow{
+			{TradeID: 1, Name: "breakout"},
+			{TradeID: 2, Name: "breakout"},
+			{TradeID: 3, Name: "breakout"},
+		},
+	}
+	service := NewService(repoSpy, &UserRepositorySpy{})
+
+	dto, err := service.GetGroupedUserAnalytics(ctx, userID, nil, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if dto.TotalTrades != 2 || dto.WinningTrades != 1 || dto.TotalPL != 70 {
+		t.Errorf("expected the group to count as one winning trade, got %d trades, %d winners, P/L %v", dto.TotalTrades, dto.WinningTrades, dto.TotalPL)
+	}
+	if len(dto.Tags) != 1 || dto.Tags[0].Trades != 2 || dto.Tags[0].WinningTrades != 1 {
+		t.Errorf("expected the group to count once under its tag, got %+v", dto.Tags)
+	}
+}
@@ -23,3 +23,13 @@ type UserDTO struct {
 	ID    int64  `json:"id"`
 	Email string `json:"email"`
 }
+
+// SettingsDTO represents the user's preferences
+type SettingsDTO struct {
+	Timezone string `json:"timezone"`
+}
+
+// UpdateSettingsRequest represents the data required to change the user's preferences
+type UpdateSettingsRequest struct {
+	Timezone string `json:"timezone"`
+}
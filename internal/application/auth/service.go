@@ -14,6 +14,7 @@ var (
 	ErrEmailAlreadyExists = errors.New("email already exists")
 	ErrHashingPassword    = errors.New("failed to hash password")
 	ErrGeneratingToken    = errors.New("failed to generate token")
+	ErrUserNotFound       = errors.New("user not found")
 )
 
 // TokenGenerator defines the interface for generating JWT tokens
@@ -97,3 +98,37 @@ func (s *Service) Login(ctx context.Context, req LoginRequest) (*AuthResponse, e
 		},
 	}, nil
 }
+
+// GetSettings returns the user's preferences
+func (s *Service) GetSettings(ctx context.Context, userID int64) (*SettingsDTO, error) {
+	foundUser, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &SettingsDTO{Timezone: foundUser.Timezone}, nil
+}
+
+// UpdateSettings changes the user's preferences
+func (s *Service) UpdateSettings(ctx context.Context, userID int64, req UpdateSettingsRequest) (*SettingsDTO, error) {
+	// Validate timezone
+	if req.Timezone == "" {
+		return nil, user.ErrInvalidTimezone
+	}
+	if _, err := user.LoadLocation(req.Timezone); err != nil {
+		return nil, err
+	}
+
+	updatedUser, err := s.userRepo.UpdateTimezone(ctx, userID, req.Timezone)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, ErrUserNotFound
+		}
+		return nil, err
+	}
+
+	return &SettingsDTO{Timezone: updatedUser.Timezone}, nil
+}
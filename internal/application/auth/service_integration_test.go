@@ -266,3 +266,51 @@ func TestAuthService_Login_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestAuthService_Settings_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	pg := testutil.SetupTestDatabase(t)
+
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	tokenGen := security.NewJWTTokenGenerator("test-secret-key")
+
+	service := NewService(userRepo, tokenGen)
+
+	ctx := context.Background()
+
+	t.Run("new users default to UTC and can change timezone", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		registered, err := service.Register(ctx, RegisterRequest{
+			Email:    "settings@example.com",
+			Password: "mypassword123",
+		})
+		if err != nil {
+			t.Fatalf("failed to register: %v", err)
+		}
+
+		settings, err := service.GetSettings(ctx, registered.User.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if settings.Timezone != "UTC" {
+			t.Errorf("expected default timezone 'UTC', got %s", settings.Timezone)
+		}
+
+		_, err = service.UpdateSettings(ctx, registered.User.ID, UpdateSettingsRequest{Timezone: "Asia/Singapore"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var savedTimezone string
+		err = pg.DB.QueryRow("SELECT timezone FROM users WHERE id = $1", registered.User.ID).Scan(&savedTimezone)
+		if err != nil {
+			t.Fatalf("failed to query saved user: %v", err)
+		}
+		if savedTimezone != "Asia/Singapore" {
+			t.Errorf("expected timezone 'Asia/Singapore', got %s", savedTimezone)
+		}
+	})
+}
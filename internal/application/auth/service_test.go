@@ -12,17 +12,25 @@ import (
 // UserRepositorySpy is a spy implementation of user.Repository
 type UserRepositorySpy struct {
 	// Recorded calls
-	CreateCalls     []*user.User
-	GetByEmailCalls []string
-	GetByIDCalls    []int64
+	CreateCalls         []*user.User
+	GetByEmailCalls     []string
+	GetByIDCalls        []int64
+	UpdateTimezoneCalls []UpdateTimezoneCall
 
 	// Configured responses
-	CreateResult     *user.User
-	CreateError      error
-	GetByEmailResult *user.User
-	GetByEmailError  error
-	GetByIDResult    *user.User
-	GetByIDError     error
+	CreateResult         *user.User
+	CreateError          error
+	GetByEmailResult     *user.User
+	GetByEmailError      error
+	GetByIDResult        *user.User
+	GetByIDError         error
+	UpdateTimezoneResult *user.User
+	UpdateTimezoneError  error
+}
+
+type UpdateTimezoneCall struct {
+	ID       int64
+	Timezone string
 }
 
 func (s *UserRepositorySpy) Create(ctx context.Context, u *user.User) (*user.User, error) {
@@ -40,6 +48,11 @@ func (s *UserRepositorySpy) GetByID(ctx context.Context, id int64) (*user.User,
 	return s.GetByIDResult, s.GetByIDError
 }
 
+func (s *UserRepositorySpy) UpdateTimezone(ctx context.Context, id int64, timezone string) (*user.User, error) {
+	s.UpdateTimezoneCalls = append(s.UpdateTimezoneCalls, UpdateTimezoneCall{ID: id, Timezone: timezone})
+	return s.UpdateTimezoneResult, s.UpdateTimezoneError
+}
+
 // TokenGeneratorSpy is a spy implementation of TokenGenerator
 type TokenGeneratorSpy struct {
 	// Recorded calls
@@ -351,3 +364,44 @@ func TestService_Login(t *testing.T) {
 		}
 	})
 }
+
+func TestService_UpdateSettings(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("stores a valid timezone", func(t *testing.T) {
+		userSpy := &UserRepositorySpy{
+			UpdateTimezoneResult: &user.User{ID: 1, Timezone: "Europe/London"},
+		}
+		service := NewService(userSpy, &TokenGeneratorSpy{})
+
+		result, err := service.UpdateSettings(ctx, 1, UpdateSettingsRequest{Timezone: "Europe/London"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(userSpy.UpdateTimezoneCalls) != 1 {
+			t.Fatalf("expected 1 call to UpdateTimezone, got %d", len(userSpy.UpdateTimezoneCalls))
+		}
+		if call := userSpy.UpdateTimezoneCalls[0]; call.ID != 1 || call.Timezone != "Europe/London" {
+			t.Errorf("unexpected UpdateTimezone call: %+v", call)
+		}
+		if result.Timezone != "Europe/London" {
+			t.Errorf("expected timezone 'Europe/London', got %s", result.Timezone)
+		}
+	})
+
+	for _, timezone := range []string{"", "Mars/Olympus", "Local"} {
+		t.Run("rejects timezone "+timezone, func(t *testing.T) {
+			userSpy := &UserRepositorySpy{}
+			service := NewService(userSpy, &TokenGeneratorSpy{})
+
+			_, err := service.UpdateSettings(ctx, 1, UpdateSettingsRequest{Timezone: timezone})
+			if err != user.ErrInvalidTimezone {
+				t.Errorf("expected ErrInvalidTimezone, got %v", err)
+			}
+			if len(userSpy.UpdateTimezoneCalls) != 0 {
+				t.Error("UpdateTimezone should not be called for an invalid timezone")
+			}
+		})
+	}
+}
@@ -0,0 +1,34 @@
+package fx
+
+import "time"
+
+// CreateRateRequest represents a request to record a daily exchange rate
+type CreateRateRequest struct {
+	BaseCurrency  string  `json:"base_currency"`
+	QuoteCurrency string  `json:"quote_currency"`
+	Date          string  `json:"date"`
+	Rate          float64 `json:"rate"`
+}
+
+// RateDTO represents an FX rate data transfer object
+type RateDTO struct {
+	ID            int64     `json:"id"`
+	BaseCurrency  string    `json:"base_currency"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Date          string    `json:"date"`
+	Rate          float64   `json:"rate"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
+
+// ImportResultDTO summarizes a CSV import
+type ImportResultDTO struct {
+	Imported int              `json:"imported"`
+	Errors   []ImportRowError `json:"errors"`
+}
+
+// ImportRowError describes a CSV row that could not be imported
+type ImportRowError struct {
+	Line  int    `json:"line"`
+	Error string `json:"error"`
+}
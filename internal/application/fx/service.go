@@ -0,0 +1,164 @@
+package fx
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/fx"
+)
+
+var (
+	ErrRateNotFound    = errors.New("fx rate not found")
+	ErrInvalidRate     = errors.New("base_currency, quote_currency and a positive rate are required")
+	ErrInvalidRateDate = errors.New("invalid date format, expected YYYY-MM-DD")
+	ErrInvalidCSV      = errors.New("invalid CSV, expected columns date,base_currency,quote_currency,rate")
+)
+
+// Service handles FX rate business logic
+type Service struct {
+	repo fx.Repository
+}
+
+// NewService creates a new FX rate service
+func NewService(repo fx.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateRate records a rate, replacing any existing rate for the same pair and date
+func (s *Service) CreateRate(ctx context.Context, userID int64, req CreateRateRequest) (*RateDTO, error) {
+	rate, err := newRate(userID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	saved, err := s.repo.Upsert(ctx, rate)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDTO(saved), nil
+}
+
+// GetUserRates retrieves all rates for a user
+func (s *Service) GetUserRates(ctx context.Context, userID int64) ([]*RateDTO, error) {
+	rates, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*RateDTO, len(rates))
+	for i, rate := range rates {
+		dtos[i] = toDTO(rate)
+	}
+
+	return dtos, nil
+}
+
+// DeleteRate deletes a rate
+func (s *Service) DeleteRate(ctx context.Context, id int64, userID int64) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if errors.Is(err, fx.ErrNotFound) {
+		return ErrRateNotFound
+	}
+	return err
+}
+
+// ImportCSV imports daily rates from CSV rows of date,base_currency,quote_currency,rate.
+// A header row is optional. Every row is validated before any is stored, so an import with
+// invalid rows reports them all and stores nothing.
+func (s *Service) ImportCSV(ctx context.Context, userID int64, r io.Reader) (*ImportResultDTO, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = 4
+	reader.TrimLeadingSpace = true
+
+	result := &ImportResultDTO{Errors: []ImportRowError{}}
+	var rates []*fx.Rate
+	line := 0
+	for {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		line++
+		if err != nil {
+			return nil, ErrInvalidCSV
+		}
+
+		// Skip the header row
+		if line == 1 && strings.EqualFold(strings.TrimSpace(record[0]), "date") {
+			continue
+		}
+
+		value, err := strconv.ParseFloat(strings.TrimSpace(record[3]), 64)
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Line: line, Error: "invalid rate"})
+			continue
+		}
+
+		rate, err := newRate(userID, CreateRateRequest{
+			Date:          strings.TrimSpace(record[0]),
+			BaseCurrency:  record[1],
+			QuoteCurrency: record[2],
+			Rate:          value,
+		})
+		if err != nil {
+			result.Errors = append(result.Errors, ImportRowError{Line: line, Error: err.Error()})
+			continue
+		}
+		rates = append(rates, rate)
+	}
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	for _, rate := range rates {
+		if _, err := s.repo.Upsert(ctx, rate); err != nil {
+			return nil, err
+		}
+		result.Imported++
+	}
+
+	return result, nil
+}
+
+func newRate(userID int64, req CreateRateRequest) (*fx.Rate, error) {
+	base := strings.ToUpper(strings.TrimSpace(req.BaseCurrency))
+	quote := strings.ToUpper(strings.TrimSpace(req.QuoteCurrency))
+	if base == "" || quote == "" || base == quote || req.Rate <= 0 {
+		return nil, ErrInvalidRate
+	}
+
+	date, err := time.Parse("2006-01-02", req.Date)
+	if err != nil {
+		return nil, ErrInvalidRateDate
+	}
+
+	return &fx.Rate{
+		UserID:        userID,
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		Date:          date,
+		Rate:          req.Rate,
+	}, nil
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(rate *fx.Rate) *RateDTO {
+	return &RateDTO{
+		ID:            rate.ID,
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Date:          rate.Date.Format("2006-01-02"),
+		Rate:          rate.Rate,
+		CreatedAt:     rate.CreatedAt,
+		UpdatedAt:     rate.UpdatedAt,
+	}
+}
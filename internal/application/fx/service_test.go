@@ -0,0 +1,106 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/fx"
+)
+
+// FXRepositorySpy records calls to the FX rate repository
+type FXRepositorySpy struct {
+	UpsertCalls []*fx.Rate
+	UpsertError error
+}
+
+func (s *FXRepositorySpy) Upsert(ctx context.Context, rate *fx.Rate) (*fx.Rate, error) {
+	s.UpsertCalls = append(s.UpsertCalls, rate)
+	return rate, s.UpsertError
+}
+
+func (s *FXRepositorySpy) GetOnOrBefore(ctx context.Context, userID int64, base, quote string, date time.Time) (*fx.Rate, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *FXRepositorySpy) GetByUserID(ctx context.Context, userID int64) ([]*fx.Rate, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *FXRepositorySpy) Delete(ctx context.Context, id int64, userID int64) error {
+	return errors.New("not implemented")
+}
+
+func TestService_ImportCSV(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+
+	t.Run("imports rows and skips the header", func(t *testing.T) {
+		spy := &FXRepositorySpy{}
+		service := NewService(spy)
+
+		csv := "date,base_currency,quote_currency,rate\n" +
+			"2025-01-15,usd,jpy,156.25\n" +
+			"2025-01-16, EUR, USD, 1.0300\n"
+
+		result, err := service.ImportCSV(ctx, userID, strings.NewReader(csv))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Imported != 2 {
+			t.Errorf("expected 2 imported rows, got %d", result.Imported)
+		}
+		if len(result.Errors) != 0 {
+			t.Errorf("expected no row errors, got %+v", result.Errors)
+		}
+
+		first := spy.UpsertCalls[0]
+		if first.UserID != userID || first.BaseCurrency != "USD" || first.QuoteCurrency != "JPY" || first.Rate != 156.25 {
+			t.Errorf("unexpected first rate: %+v", first)
+		}
+		if first.Date.Format("2006-01-02") != "2025-01-15" {
+			t.Errorf("expected date 2025-01-15, got %s", first.Date.Format("2006-01-02"))
+		}
+	})
+
+	t.Run("reports every invalid row and imports nothing", func(t *testing.T) {
+		spy := &FXRepositorySpy{}
+		service := NewService(spy)
+
+		csv := "2025-01-15,USD,JPY,156.25\n" +
+			"15/01/2025,EUR,USD,1.03\n" +
+			"2025-01-15,GBP,USD,abc\n" +
+			"2025-01-15,GBP,USD,-1\n"
+
+		result, err := service.ImportCSV(ctx, userID, strings.NewReader(csv))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Imported != 0 || len(spy.UpsertCalls) != 0 {
+			t.Errorf("expected no rows imported, got %d imported and %d stored", result.Imported, len(spy.UpsertCalls))
+		}
+		if len(result.Errors) != 3 {
+			t.Fatalf("expected 3 row errors, got %+v", result.Errors)
+		}
+		if result.Errors[0].Line != 2 {
+			t.Errorf("expected first error on line 2, got %d", result.Errors[0].Line)
+		}
+	})
+
+	t.Run("rejects rows with the wrong number of columns", func(t *testing.T) {
+		spy := &FXRepositorySpy{}
+		service := NewService(spy)
+
+		_, err := service.ImportCSV(ctx, userID, strings.NewReader("2025-01-14,USD,JPY,156.00\n2025-01-15,USDJPY,156.25\n"))
+		if !errors.Is(err, ErrInvalidCSV) {
+			t.Errorf("expected ErrInvalidCSV, got %v", err)
+		}
+		if len(spy.UpsertCalls) != 0 {
+			t.Errorf("expected the valid row before it not to be stored, got %d", len(spy.UpsertCalls))
+		}
+	})
+}
@@ -0,0 +1,11 @@
+package grade
+
+// UpdateScaleRequest represents a request to replace the user's grade scale, best grade first
+type UpdateScaleRequest struct {
+	Grades []string `json:"grades"`
+}
+
+// ScaleDTO represents a user's grade scale, best grade first
+type ScaleDTO struct {
+	Grades []string `json:"grades"`
+}
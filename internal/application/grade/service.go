@@ -0,0 +1,61 @@
+package grade
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raihanstark/trade-journal/internal/domain/grade"
+)
+
+var (
+	ErrInvalidScale = errors.New("grades must be a non-empty list of unique names of at most 10 characters")
+)
+
+// Service handles the scale users grade their setups on
+type Service struct {
+	repo grade.Repository
+}
+
+// NewService creates a new grade service
+func NewService(repo grade.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// GetScale returns the user's grade scale, or the default scale when they have not set one
+func (s *Service) GetScale(ctx context.Context, userID int64) (*ScaleDTO, error) {
+	grades, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScaleDTO{Grades: grade.Scale(grades)}, nil
+}
+
+// UpdateScale replaces the user's grade scale. Trades carrying a grade that is dropped from
+// the scale become ungraded.
+func (s *Service) UpdateScale(ctx context.Context, userID int64, req UpdateScaleRequest) (*ScaleDTO, error) {
+	if len(req.Grades) == 0 {
+		return nil, ErrInvalidScale
+	}
+
+	names := make([]string, 0, len(req.Grades))
+	for _, name := range req.Grades {
+		name = strings.TrimSpace(name)
+		if name == "" || utf8.RuneCountInString(name) > grade.MaxNameLength || slices.Contains(names, name) {
+			return nil, ErrInvalidScale
+		}
+		names = append(names, name)
+	}
+
+	grades, err := s.repo.ReplaceScale(ctx, userID, names)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ScaleDTO{Grades: grade.Scale(grades)}, nil
+}
@@ -0,0 +1,87 @@
+package grade
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
+	"github.com/raihanstark/trade-journal/internal/testutil"
+)
+
+func TestGradeService_Scale_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	gradeRepo := persistence.NewGradeRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(gradeRepo)
+
+	ctx := context.Background()
+
+	t.Run("replaces the default scale and ungrades dropped grades", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("grades@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		scale, err := service.GetScale(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(scale.Grades, []string{"A+", "A", "B", "C"}) {
+			t.Errorf("expected the default scale, got %v", scale.Grades)
+		}
+
+		var aTradeID, cTradeID int64
+		insert := "INSERT INTO trades (user_id, date, time, type, opened_at, grade) VALUES ($1, '2025-01-15', '10:00', 'BUY', NOW(), $2) RETURNING id"
+		if err := pg.DB.QueryRow(insert, createdUser.ID, "A").Scan(&aTradeID); err != nil {
+			t.Fatalf("failed to insert trade: %v", err)
+		}
+		if err := pg.DB.QueryRow(insert, createdUser.ID, "C").Scan(&cTradeID); err != nil {
+			t.Fatalf("failed to insert trade: %v", err)
+		}
+
+		updated, err := service.UpdateScale(ctx, createdUser.ID, UpdateScaleRequest{Grades: []string{" A+ ", "A", "B"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(updated.Grades, []string{"A+", "A", "B"}) {
+			t.Errorf("expected the new scale, got %v", updated.Grades)
+		}
+
+		var aGrade, cGrade *string
+		pg.DB.QueryRow("SELECT grade FROM trades WHERE id = $1", aTradeID).Scan(&aGrade)
+		pg.DB.QueryRow("SELECT grade FROM trades WHERE id = $1", cTradeID).Scan(&cGrade)
+		if aGrade == nil || *aGrade != "A" {
+			t.Errorf("expected the A trade to keep its grade, got %v", aGrade)
+		}
+		if cGrade != nil {
+			t.Errorf("expected the C trade to be ungraded, got %s", *cGrade)
+		}
+
+		scale, _ = service.GetScale(ctx, createdUser.ID)
+		if !slices.Equal(scale.Grades, []string{"A+", "A", "B"}) {
+			t.Errorf("expected the stored scale, got %v", scale.Grades)
+		}
+	})
+
+	t.Run("rejects an invalid scale", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, _ := userRepo.Create(ctx, user.NewUser("badgrades@example.com", "hashedpass"))
+
+		for _, grades := range [][]string{nil, {"A", " "}, {"A", "A"}, {"EXCEPTIONAL+"}} {
+			_, err := service.UpdateScale(ctx, createdUser.ID, UpdateScaleRequest{Grades: grades})
+			if !errors.Is(err, ErrInvalidScale) {
+				t.Errorf("expected ErrInvalidScale for %v, got %v", grades, err)
+			}
+		}
+	})
+}
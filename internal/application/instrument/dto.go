@@ -0,0 +1,44 @@
+package instrument
+
+import "time"
+
+// CreateInstrumentRequest represents a request to add an instrument to the user's registry
+type CreateInstrumentRequest struct {
+	Symbol        string  `json:"symbol"`
+	Description   string  `json:"description"`
+	AssetClass    string  `json:"asset_class"`
+	PipSize       float64 `json:"pip_size"`
+	TickSize      float64 `json:"tick_size"`
+	ContractSize  float64 `json:"contract_size"`
+	QuoteCurrency string  `json:"quote_currency"`
+	Digits        int     `json:"digits"`
+}
+
+// UpdateInstrumentRequest represents a request to update an instrument specification
+type UpdateInstrumentRequest struct {
+	Symbol        string  `json:"symbol"`
+	Description   string  `json:"description"`
+	AssetClass    string  `json:"asset_class"`
+	PipSize       float64 `json:"pip_size"`
+	TickSize      float64 `json:"tick_size"`
+	ContractSize  float64 `json:"contract_size"`
+	QuoteCurrency string  `json:"quote_currency"`
+	Digits        int     `json:"digits"`
+}
+
+// InstrumentDTO represents an instrument data transfer object
+type InstrumentDTO struct {
+	ID            int64     `json:"id"`
+	Symbol        string    `json:"symbol"`
+	Description   string    `json:"description"`
+	AssetClass    string    `json:"asset_class"`
+	PipSize       float64   `json:"pip_size"`
+	TickSize      float64   `json:"tick_size"`
+	ContractSize  float64   `json:"contract_size"`
+	QuoteCurrency string    `json:"quote_currency"`
+	Digits        int       `json:"digits"`
+	PipValue      float64   `json:"pip_value"`
+	BuiltIn       bool      `json:"built_in"`
+	CreatedAt     time.Time `json:"created_at"`
+	UpdatedAt     time.Time `json:"updated_at"`
+}
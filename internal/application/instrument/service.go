@@ -0,0 +1,197 @@
+package instrument
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/raihanstark/trade-journal/internal/domain/instrument"
+)
+
+var (
+	ErrInstrumentNotFound = errors.New("instrument not found")
+	ErrInstrumentExists   = errors.New("instrument already exists for this symbol")
+	ErrBuiltInInstrument  = errors.New("built-in instruments cannot be deleted")
+	ErrInvalidInstrument  = errors.New("symbol, quote_currency, pip_size, tick_size and contract_size are required")
+	ErrInvalidAssetClass  = errors.New("asset_class must be one of forex, metal, index, crypto, commodity")
+)
+
+// Service handles instrument registry use cases
+type Service struct {
+	repo instrument.Repository
+}
+
+// NewService creates a new instrument service
+func NewService(repo instrument.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateInstrument adds a user-owned instrument to the registry
+func (s *Service) CreateInstrument(ctx context.Context, userID int64, req CreateInstrumentRequest) (*InstrumentDTO, error) {
+	entity := &instrument.Instrument{
+		UserID:        &userID,
+		Symbol:        instrument.NormalizeSymbol(req.Symbol),
+		Description:   req.Description,
+		AssetClass:    instrument.AssetClass(req.AssetClass),
+		PipSize:       req.PipSize,
+		TickSize:      req.TickSize,
+		ContractSize:  req.ContractSize,
+		QuoteCurrency: strings.ToUpper(req.QuoteCurrency),
+		Digits:        req.Digits,
+	}
+	if err := validate(entity); err != nil {
+		return nil, err
+	}
+
+	// A user may override a built-in symbol once, but not register it twice
+	existing, err := s.repo.GetBySymbol(ctx, userID, entity.Symbol)
+	if err == nil && !existing.IsBuiltIn() {
+		return nil, ErrInstrumentExists
+	}
+	if err != nil && !errors.Is(err, instrument.ErrNotFound) {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDTO(created), nil
+}
+
+// GetInstrument retrieves an instrument by ID
+func (s *Service) GetInstrument(ctx context.Context, id int64, userID int64) (*InstrumentDTO, error) {
+	entity, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrInstrumentNotFound
+	}
+
+	return toDTO(entity), nil
+}
+
+// GetUserInstruments retrieves the registry as seen by a user, with overrides replacing built-ins
+func (s *Service) GetUserInstruments(ctx context.Context, userID int64) ([]*InstrumentDTO, error) {
+	instruments, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*InstrumentDTO, len(instruments))
+	for i, entity := range instruments {
+		dtos[i] = toDTO(entity)
+	}
+
+	return dtos, nil
+}
+
+// UpdateInstrument updates a user-owned instrument.
+// Updating a built-in instrument creates a user-owned override instead of changing the shared row,
+// or updates the override when the user already has one.
+func (s *Service) UpdateInstrument(ctx context.Context, id int64, userID int64, req UpdateInstrumentRequest) (*InstrumentDTO, error) {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrInstrumentNotFound
+	}
+
+	if existing.IsBuiltIn() {
+		override, err := s.repo.GetBySymbol(ctx, userID, existing.Symbol)
+		if err != nil && !errors.Is(err, instrument.ErrNotFound) {
+			return nil, err
+		}
+		if err == nil && !override.IsBuiltIn() {
+			existing = override
+		}
+	}
+
+	// The new symbol may not belong to another of the user's instruments
+	symbol := instrument.NormalizeSymbol(req.Symbol)
+	clash, err := s.repo.GetBySymbol(ctx, userID, symbol)
+	if err == nil && !clash.IsBuiltIn() && clash.ID != existing.ID {
+		return nil, ErrInstrumentExists
+	}
+	if err != nil && !errors.Is(err, instrument.ErrNotFound) {
+		return nil, err
+	}
+
+	existing.Symbol = symbol
+	existing.Description = req.Description
+	existing.AssetClass = instrument.AssetClass(req.AssetClass)
+	existing.PipSize = req.PipSize
+	existing.TickSize = req.TickSize
+	existing.ContractSize = req.ContractSize
+	existing.QuoteCurrency = strings.ToUpper(req.QuoteCurrency)
+	existing.Digits = req.Digits
+	if err := validate(existing); err != nil {
+		return nil, err
+	}
+
+	var saved *instrument.Instrument
+	if existing.IsBuiltIn() {
+		existing.UserID = &userID
+		saved, err = s.repo.Create(ctx, existing)
+	} else {
+		saved, err = s.repo.Update(ctx, existing)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return toDTO(saved), nil
+}
+
+// DeleteInstrument deletes a user-owned instrument, restoring the built-in one if it was an override
+func (s *Service) DeleteInstrument(ctx context.Context, id int64, userID int64) error {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return ErrInstrumentNotFound
+	}
+	if existing.IsBuiltIn() {
+		return ErrBuiltInInstrument
+	}
+
+	err = s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, instrument.ErrNotFound) {
+			return ErrInstrumentNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func validate(i *instrument.Instrument) error {
+	if i.Symbol == "" || i.QuoteCurrency == "" || i.PipSize <= 0 || i.TickSize <= 0 || i.ContractSize <= 0 {
+		return ErrInvalidInstrument
+	}
+	switch i.AssetClass {
+	case "":
+		i.AssetClass = instrument.AssetClassForex
+	case instrument.AssetClassForex, instrument.AssetClassMetal, instrument.AssetClassIndex,
+		instrument.AssetClassCrypto, instrument.AssetClassCommodity:
+	default:
+		return ErrInvalidAssetClass
+	}
+	return nil
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(i *instrument.Instrument) *InstrumentDTO {
+	return &InstrumentDTO{
+		ID:            i.ID,
+		Symbol:        i.Symbol,
+		Description:   i.Description,
+		AssetClass:    string(i.AssetClass),
+		PipSize:       i.PipSize,
+		TickSize:      i.TickSize,
+		ContractSize:  i.ContractSize,
+		QuoteCurrency: i.QuoteCurrency,
+		Digits:        i.Digits,
+		PipValue:      i.PipValue(),
+		BuiltIn:       i.IsBuiltIn(),
+		CreatedAt:     i.CreatedAt,
+		UpdatedAt:     i.UpdatedAt,
+	}
+}
@@ -0,0 +1,257 @@
+package instrument
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
+	"github.com/raihanstark/trade-journal/internal/testutil"
+)
+
+func TestInstrumentService_BuiltIns_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(instrumentRepo)
+
+	ctx := context.Background()
+
+	t.Run("lists seeded built-in instruments", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("builtins@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		instruments, err := service.GetUserInstruments(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var gold *InstrumentDTO
+		for _, i := range instruments {
+			if i.Symbol == "XAUUSD" {
+				gold = i
+			}
+		}
+
+		if gold == nil {
+			t.Fatal("expected XAUUSD in built-in instruments")
+		}
+		if !gold.BuiltIn {
+			t.Error("expected XAUUSD to be built-in")
+		}
+		if gold.PipSize != 0.1 || gold.ContractSize != 100 {
+			t.Errorf("expected XAUUSD pip 0.1 contract 100, got pip %v contract %v", gold.PipSize, gold.ContractSize)
+		}
+	})
+
+	t.Run("updating a built-in creates a user override", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("override@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+		otherUser, err := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create other user: %v", err)
+		}
+
+		var builtInID int64
+		err = pg.DB.QueryRow("SELECT id FROM instruments WHERE symbol = 'XAUUSD' AND user_id IS NULL").Scan(&builtInID)
+		if err != nil {
+			t.Fatalf("failed to query built-in instrument: %v", err)
+		}
+
+		result, err := service.UpdateInstrument(ctx, builtInID, createdUser.ID, UpdateInstrumentRequest{
+			Symbol:        "XAUUSD",
+			AssetClass:    "metal",
+			PipSize:       0.01,
+			TickSize:      0.01,
+			ContractSize:  100,
+			QuoteCurrency: "USD",
+			Digits:        2,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if result.ID == builtInID || result.BuiltIn {
+			t.Error("expected a new user-owned instrument")
+		}
+
+		// The override is only visible to its owner
+		mine, err := service.GetUserInstruments(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for _, i := range mine {
+			if i.Symbol == "XAUUSD" && i.PipSize != 0.01 {
+				t.Errorf("expected overridden pip size 0.01, got %v", i.PipSize)
+			}
+		}
+
+		theirs, err := service.GetUserInstruments(ctx, otherUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		for _, i := range theirs {
+			if i.Symbol == "XAUUSD" && i.PipSize != 0.1 {
+				t.Errorf("expected built-in pip size 0.1 for other user, got %v", i.PipSize)
+			}
+		}
+	})
+
+	t.Run("editing a built-in twice updates the override", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("twice@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		var builtInID int64
+		err = pg.DB.QueryRow("SELECT id FROM instruments WHERE symbol = 'XAUUSD' AND user_id IS NULL").Scan(&builtInID)
+		if err != nil {
+			t.Fatalf("failed to query built-in instrument: %v", err)
+		}
+
+		req := UpdateInstrumentRequest{
+			Symbol:        "XAUUSD",
+			AssetClass:    "metal",
+			PipSize:       0.01,
+			TickSize:      0.01,
+			ContractSize:  100,
+			QuoteCurrency: "USD",
+			Digits:        2,
+		}
+		first, err := service.UpdateInstrument(ctx, builtInID, createdUser.ID, req)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		req.ContractSize = 50
+		second, err := service.UpdateInstrument(ctx, builtInID, createdUser.ID, req)
+		if err != nil {
+			t.Fatalf("expected no error on the second edit, got %v", err)
+		}
+
+		if second.ID != first.ID {
+			t.Errorf("expected the override %d to be updated, got instrument %d", first.ID, second.ID)
+		}
+		if second.ContractSize != 50 {
+			t.Errorf("expected contract size 50, got %v", second.ContractSize)
+		}
+	})
+
+	t.Run("renaming onto a symbol the user owns is rejected", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("rename@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		if _, err := service.CreateInstrument(ctx, createdUser.ID, CreateInstrumentRequest{
+			Symbol:        "SOLUSD",
+			AssetClass:    "crypto",
+			PipSize:       0.01,
+			TickSize:      0.001,
+			ContractSize:  1,
+			QuoteCurrency: "USD",
+		}); err != nil {
+			t.Fatalf("failed to create instrument: %v", err)
+		}
+
+		var builtInID int64
+		err = pg.DB.QueryRow("SELECT id FROM instruments WHERE symbol = 'XAUUSD' AND user_id IS NULL").Scan(&builtInID)
+		if err != nil {
+			t.Fatalf("failed to query built-in instrument: %v", err)
+		}
+
+		_, err = service.UpdateInstrument(ctx, builtInID, createdUser.ID, UpdateInstrumentRequest{
+			Symbol:        "SOLUSD",
+			AssetClass:    "crypto",
+			PipSize:       0.01,
+			TickSize:      0.01,
+			ContractSize:  1,
+			QuoteCurrency: "USD",
+		})
+		if !errors.Is(err, ErrInstrumentExists) {
+			t.Errorf("expected ErrInstrumentExists, got %v", err)
+		}
+	})
+
+	t.Run("cannot delete built-in instrument", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("delete@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		var builtInID int64
+		err = pg.DB.QueryRow("SELECT id FROM instruments WHERE symbol = 'EURUSD' AND user_id IS NULL").Scan(&builtInID)
+		if err != nil {
+			t.Fatalf("failed to query built-in instrument: %v", err)
+		}
+
+		err = service.DeleteInstrument(ctx, builtInID, createdUser.ID)
+		if !errors.Is(err, ErrBuiltInInstrument) {
+			t.Errorf("expected ErrBuiltInInstrument, got %v", err)
+		}
+	})
+}
+
+func TestInstrumentService_CreateInstrument_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(instrumentRepo)
+
+	ctx := context.Background()
+
+	t.Run("creates custom instrument and rejects duplicates", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("custom@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		req := CreateInstrumentRequest{
+			Symbol:        "sol/usd",
+			AssetClass:    "crypto",
+			PipSize:       0.01,
+			TickSize:      0.001,
+			ContractSize:  1,
+			QuoteCurrency: "USD",
+			Digits:        3,
+		}
+
+		result, err := service.CreateInstrument(ctx, createdUser.ID, req)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if result.Symbol != "SOLUSD" {
+			t.Errorf("expected normalized symbol SOLUSD, got %s", result.Symbol)
+		}
+
+		_, err = service.CreateInstrument(ctx, createdUser.ID, req)
+		if !errors.Is(err, ErrInstrumentExists) {
+			t.Errorf("expected ErrInstrumentExists, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,27 @@
+package mistake
+
+import "time"
+
+// CreateMistakeTypeRequest represents a request to add a mistake type to the user's catalogue
+type CreateMistakeTypeRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+}
+
+// UpdateMistakeTypeRequest represents a request to update a mistake type
+type UpdateMistakeTypeRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Severity    string `json:"severity"`
+}
+
+// MistakeTypeDTO represents a mistake type data transfer object
+type MistakeTypeDTO struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	Severity    string    `json:"severity"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,155 @@
+package mistake
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raihanstark/trade-journal/internal/domain/mistake"
+)
+
+// maxNameLength is the longest mistake type name accepted, in characters
+const maxNameLength = 100
+
+var (
+	ErrMistakeTypeNotFound = errors.New("mistake type not found")
+	ErrMistakeTypeExists   = errors.New("mistake type already exists with this name")
+	ErrInvalidMistakeType  = errors.New("name is required and must be at most 100 characters")
+	ErrInvalidSeverity     = errors.New("severity must be one of low, medium, high")
+)
+
+// Service handles the user's mistake catalogue
+type Service struct {
+	repo mistake.Repository
+}
+
+// NewService creates a new mistake service
+func NewService(repo mistake.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateMistakeType adds a mistake type to the user's catalogue
+func (s *Service) CreateMistakeType(ctx context.Context, userID int64, req CreateMistakeTypeRequest) (*MistakeTypeDTO, error) {
+	entity := &mistake.MistakeType{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+		Severity:    mistake.Severity(req.Severity),
+	}
+	if err := validate(entity); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.GetByName(ctx, userID, entity.Name)
+	if err == nil {
+		return nil, ErrMistakeTypeExists
+	}
+	if !errors.Is(err, mistake.ErrNotFound) {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDTO(created), nil
+}
+
+// GetMistakeType retrieves a mistake type by ID
+func (s *Service) GetMistakeType(ctx context.Context, id int64, userID int64) (*MistakeTypeDTO, error) {
+	entity, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrMistakeTypeNotFound
+	}
+
+	return toDTO(entity), nil
+}
+
+// GetUserMistakeTypes retrieves the user's catalogue, in name order
+func (s *Service) GetUserMistakeTypes(ctx context.Context, userID int64) ([]*MistakeTypeDTO, error) {
+	mistakeTypes, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*MistakeTypeDTO, len(mistakeTypes))
+	for i, entity := range mistakeTypes {
+		dtos[i] = toDTO(entity)
+	}
+
+	return dtos, nil
+}
+
+// UpdateMistakeType updates a mistake type; trades tagged with it keep the link
+func (s *Service) UpdateMistakeType(ctx context.Context, id int64, userID int64, req UpdateMistakeTypeRequest) (*MistakeTypeDTO, error) {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrMistakeTypeNotFound
+	}
+
+	existing.Name = strings.TrimSpace(req.Name)
+	existing.Description = req.Description
+	existing.Severity = mistake.Severity(req.Severity)
+	if err := validate(existing); err != nil {
+		return nil, err
+	}
+
+	other, err := s.repo.GetByName(ctx, userID, existing.Name)
+	if err == nil && other.ID != existing.ID {
+		return nil, ErrMistakeTypeExists
+	}
+	if err != nil && !errors.Is(err, mistake.ErrNotFound) {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		if errors.Is(err, mistake.ErrNotFound) {
+			return nil, ErrMistakeTypeNotFound
+		}
+		return nil, err
+	}
+
+	return toDTO(updated), nil
+}
+
+// DeleteMistakeType removes a mistake type from the catalogue and from every trade tagged with it
+func (s *Service) DeleteMistakeType(ctx context.Context, id int64, userID int64) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, mistake.ErrNotFound) {
+			return ErrMistakeTypeNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func validate(m *mistake.MistakeType) error {
+	if m.Name == "" || utf8.RuneCountInString(m.Name) > maxNameLength {
+		return ErrInvalidMistakeType
+	}
+	if m.Severity == "" {
+		m.Severity = mistake.SeverityMedium
+	}
+	if !m.Severity.IsValid() {
+		return ErrInvalidSeverity
+	}
+	return nil
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(m *mistake.MistakeType) *MistakeTypeDTO {
+	return &MistakeTypeDTO{
+		ID:          m.ID,
+		Name:        m.Name,
+		Description: m.Description,
+		Severity:    string(m.Severity),
+		CreatedAt:   m.CreatedAt,
+		UpdatedAt:   m.UpdatedAt,
+	}
+}
@@ -0,0 +1,116 @@
+package mistake
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
+	"github.com/raihanstark/trade-journal/internal/testutil"
+)
+
+func TestMistakeService_Catalogue_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	mistakeRepo := persistence.NewMistakeRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(mistakeRepo)
+
+	ctx := context.Background()
+
+	t.Run("creates, updates and deletes mistake types", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("mistakes@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		created, err := service.CreateMistakeType(ctx, createdUser.ID, CreateMistakeTypeRequest{
+			Name:        "  moved stop ",
+			Description: "Widened the stop loss after entry",
+			Severity:    "high",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if created.Name != "moved stop" || created.Severity != "high" {
+			t.Errorf("expected trimmed high severity mistake type, got %+v", created)
+		}
+
+		defaulted, err := service.CreateMistakeType(ctx, createdUser.ID, CreateMistakeTypeRequest{Name: "chased entry"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if defaulted.Severity != "medium" {
+			t.Errorf("expected severity to default to medium, got %s", defaulted.Severity)
+		}
+
+		_, err = service.CreateMistakeType(ctx, createdUser.ID, CreateMistakeTypeRequest{Name: "moved stop"})
+		if !errors.Is(err, ErrMistakeTypeExists) {
+			t.Errorf("expected ErrMistakeTypeExists, got %v", err)
+		}
+
+		_, err = service.UpdateMistakeType(ctx, defaulted.ID, createdUser.ID, UpdateMistakeTypeRequest{Name: "moved stop"})
+		if !errors.Is(err, ErrMistakeTypeExists) {
+			t.Errorf("expected ErrMistakeTypeExists when renaming onto another type, got %v", err)
+		}
+
+		updated, err := service.UpdateMistakeType(ctx, created.ID, createdUser.ID, UpdateMistakeTypeRequest{
+			Name:     "moved stop",
+			Severity: "low",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if updated.Severity != "low" {
+			t.Errorf("expected severity low, got %s", updated.Severity)
+		}
+
+		if err := service.DeleteMistakeType(ctx, defaulted.ID, createdUser.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		mistakeTypes, err := service.GetUserMistakeTypes(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(mistakeTypes) != 1 || mistakeTypes[0].ID != created.ID {
+			t.Errorf("expected only the moved stop mistake type to remain, got %d", len(mistakeTypes))
+		}
+	})
+
+	t.Run("rejects invalid severity and other users' mistake types", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		owner, err := userRepo.Create(ctx, user.NewUser("owner@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create owner: %v", err)
+		}
+		otherUser, err := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create other user: %v", err)
+		}
+
+		_, err = service.CreateMistakeType(ctx, owner.ID, CreateMistakeTypeRequest{Name: "fomo", Severity: "critical"})
+		if !errors.Is(err, ErrInvalidSeverity) {
+			t.Errorf("expected ErrInvalidSeverity, got %v", err)
+		}
+
+		created, err := service.CreateMistakeType(ctx, owner.ID, CreateMistakeTypeRequest{Name: "fomo"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if _, err := service.GetMistakeType(ctx, created.ID, otherUser.ID); !errors.Is(err, ErrMistakeTypeNotFound) {
+			t.Errorf("expected ErrMistakeTypeNotFound for another user, got %v", err)
+		}
+		if err := service.DeleteMistakeType(ctx, created.ID, otherUser.ID); !errors.Is(err, ErrMistakeTypeNotFound) {
+			t.Errorf("expected ErrMistakeTypeNotFound deleting another user's type, got %v", err)
+		}
+	})
+}
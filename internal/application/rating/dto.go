@@ -0,0 +1,24 @@
+package rating
+
+import "time"
+
+// CreateDimensionRequest represents a request to add a rating dimension
+type CreateDimensionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// UpdateDimensionRequest represents a request to update a rating dimension
+type UpdateDimensionRequest struct {
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+// DimensionDTO represents a rating dimension data transfer object
+type DimensionDTO struct {
+	ID          int64     `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description"`
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -0,0 +1,145 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/raihanstark/trade-journal/internal/domain/rating"
+)
+
+// maxNameLength is the longest dimension name accepted, in characters
+const maxNameLength = 50
+
+var (
+	ErrDimensionNotFound = errors.New("rating dimension not found")
+	ErrDimensionExists   = errors.New("rating dimension already exists with this name")
+	ErrInvalidDimension  = errors.New("name is required and must be at most 50 characters")
+)
+
+// Service handles the rating dimensions a user scores their trades on
+type Service struct {
+	repo rating.Repository
+}
+
+// NewService creates a new rating service
+func NewService(repo rating.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateDimension adds a rating dimension for the user
+func (s *Service) CreateDimension(ctx context.Context, userID int64, req CreateDimensionRequest) (*DimensionDTO, error) {
+	entity := &rating.Dimension{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	if err := validate(entity); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.GetByName(ctx, userID, entity.Name)
+	if err == nil {
+		return nil, ErrDimensionExists
+	}
+	if !errors.Is(err, rating.ErrNotFound) {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDTO(created), nil
+}
+
+// GetDimension retrieves a rating dimension by ID
+func (s *Service) GetDimension(ctx context.Context, id int64, userID int64) (*DimensionDTO, error) {
+	entity, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrDimensionNotFound
+	}
+
+	return toDTO(entity), nil
+}
+
+// GetUserDimensions retrieves the user's rating dimensions, in name order
+func (s *Service) GetUserDimensions(ctx context.Context, userID int64) ([]*DimensionDTO, error) {
+	dimensions, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*DimensionDTO, len(dimensions))
+	for i, entity := range dimensions {
+		dtos[i] = toDTO(entity)
+	}
+
+	return dtos, nil
+}
+
+// UpdateDimension renames or redescribes a rating dimension; ratings given on it are kept
+func (s *Service) UpdateDimension(ctx context.Context, id int64, userID int64, req UpdateDimensionRequest) (*DimensionDTO, error) {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrDimensionNotFound
+	}
+
+	existing.Name = strings.TrimSpace(req.Name)
+	existing.Description = req.Description
+	if err := validate(existing); err != nil {
+		return nil, err
+	}
+
+	other, err := s.repo.GetByName(ctx, userID, existing.Name)
+	if err == nil && other.ID != existing.ID {
+		return nil, ErrDimensionExists
+	}
+	if err != nil && !errors.Is(err, rating.ErrNotFound) {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		if errors.Is(err, rating.ErrNotFound) {
+			return nil, ErrDimensionNotFound
+		}
+		return nil, err
+	}
+
+	return toDTO(updated), nil
+}
+
+// DeleteDimension deletes a rating dimension and every rating given on it
+func (s *Service) DeleteDimension(ctx context.Context, id int64, userID int64) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, rating.ErrNotFound) {
+			return ErrDimensionNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+func validate(d *rating.Dimension) error {
+	if d.Name == "" || utf8.RuneCountInString(d.Name) > maxNameLength {
+		return ErrInvalidDimension
+	}
+	return nil
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(d *rating.Dimension) *DimensionDTO {
+	return &DimensionDTO{
+		ID:          d.ID,
+		Name:        d.Name,
+		Description: d.Description,
+		CreatedAt:   d.CreatedAt,
+		UpdatedAt:   d.UpdatedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package rating
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
+	"github.com/raihanstark/trade-journal/internal/testutil"
+)
+
+func TestRatingService_Dimensions_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	ratingRepo := persistence.NewRatingRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(ratingRepo)
+
+	ctx := context.Background()
+
+	t.Run("manages a user's rating dimensions", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("ratings@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+		otherUser, err := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create other user: %v", err)
+		}
+
+		for _, name := range []string{"confidence", "fear", "fomo", "fatigue"} {
+			if _, err := service.CreateDimension(ctx, createdUser.ID, CreateDimensionRequest{Name: name}); err != nil {
+				t.Fatalf("failed to create %s: %v", name, err)
+			}
+		}
+
+		_, err = service.CreateDimension(ctx, createdUser.ID, CreateDimensionRequest{Name: " fear "})
+		if !errors.Is(err, ErrDimensionExists) {
+			t.Errorf("expected ErrDimensionExists, got %v", err)
+		}
+
+		// Other users can define a dimension with the same name
+		if _, err := service.CreateDimension(ctx, otherUser.ID, CreateDimensionRequest{Name: "fear"}); err != nil {
+			t.Errorf("expected no error for another user, got %v", err)
+		}
+
+		dimensions, err := service.GetUserDimensions(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(dimensions) != 4 || dimensions[0].Name != "confidence" || dimensions[3].Name != "fomo" {
+			t.Fatalf("expected 4 dimensions in name order, got %v", dimensions)
+		}
+
+		updated, err := service.UpdateDimension(ctx, dimensions[0].ID, createdUser.ID, UpdateDimensionRequest{
+			Name:        "conviction",
+			Description: "How sure I was about the setup",
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if updated.Name != "conviction" {
+			t.Errorf("expected renamed dimension, got %s", updated.Name)
+		}
+
+		if err := service.DeleteDimension(ctx, dimensions[1].ID, otherUser.ID); !errors.Is(err, ErrDimensionNotFound) {
+			t.Errorf("expected ErrDimensionNotFound deleting another user's dimension, got %v", err)
+		}
+		if err := service.DeleteDimension(ctx, dimensions[1].ID, createdUser.ID); err != nil {
+			t.Errorf("expected no error, got %v", err)
+		}
+	})
+}
@@ -4,13 +4,22 @@ import "time"
 
 // CreateStrategyRequest represents a request to create a new strategy
 type CreateStrategyRequest struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Rules       []RuleRequest `json:"rules"`
 }
 
-// UpdateStrategyRequest represents a request to update an existing strategy
+// UpdateStrategyRequest represents a request to update an existing strategy.
+// Rules replace the stored list in the given order; keep a rule's ID to preserve it.
 type UpdateStrategyRequest struct {
-	Name        string `json:"name"`
+	Name        string        `json:"name"`
+	Description string        `json:"description"`
+	Rules       []RuleRequest `json:"rules"`
+}
+
+// RuleRequest is one rule of a strategy's checklist; ID is omitted for new rules
+type RuleRequest struct {
+	ID          int64  `json:"id"`
 	Description string `json:"description"`
 }
 
@@ -19,6 +28,15 @@ type StrategyDTO struct {
 	ID          int64     `json:"id"`
 	Name        string    `json:"name"`
 	Description string    `json:"description"`
+	Rules       []RuleDTO `json:"rules"`
 	CreatedAt   time.Time `json:"created_at"`
 	UpdatedAt   time.Time `json:"updated_at"`
+	Version     int64     `json:"version"`
+}
+
+// RuleDTO represents a strategy rule
+type RuleDTO struct {
+	ID          int64  `json:"id"`
+	Position    int    `json:"position"`
+	Description string `json:"description"`
 }
@@ -3,32 +3,46 @@ package strategy
 import (
 	"context"
 	"errors"
+	"strings"
+	"unicode/utf8"
 
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
 	"github.com/raihanstark/trade-journal/internal/domain/strategy"
 )
 
 var (
 	ErrStrategyNotFound = errors.New("strategy not found")
+	ErrRuleNotFound     = errors.New("strategy rule not found")
+	ErrInvalidRule      = errors.New("strategy rule description is required and must be at most 255 characters")
+	ErrVersionConflict  = errors.New("strategy was modified by another request")
 )
 
 // Service handles strategy business logic
 type Service struct {
-	repo strategy.Repository
+	repo      strategy.Repository
+	auditRepo audit.Repository
 }
 
 // NewService creates a new strategy service
-func NewService(repo strategy.Repository) *Service {
+func NewService(repo strategy.Repository, auditRepo audit.Repository) *Service {
 	return &Service{
-		repo: repo,
+		repo:      repo,
+		auditRepo: auditRepo,
 	}
 }
 
 // CreateStrategy creates a new strategy
 func (s *Service) CreateStrategy(ctx context.Context, userID int64, req CreateStrategyRequest) (*StrategyDTO, error) {
+	rules, err := toRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
 	strategyEntity := &strategy.Strategy{
 		UserID:      userID,
 		Name:        req.Name,
 		Description: req.Description,
+		Rules:       rules,
 	}
 
 	created, err := s.repo.Create(ctx, strategyEntity)
@@ -36,13 +50,10 @@ func (s *Service) CreateStrategy(ctx context.Context, userID int64, req CreateSt
 		return nil, err
 	}
 
-	return &StrategyDTO{
-		ID:          created.ID,
-		Name:        created.Name,
-		Description: created.Description,
-		CreatedAt:   created.CreatedAt,
-		UpdatedAt:   created.UpdatedAt,
-	}, nil
+	dto := toDTO(created)
+	s.record(ctx, userID, created.ID, audit.ActionCreate, nil, dto)
+
+	return dto, nil
 }
 
 // GetStrategy retrieves a strategy by ID
@@ -52,13 +63,7 @@ func (s *Service) GetStrategy(ctx context.Context, id int64, userID int64) (*Str
 		return nil, ErrStrategyNotFound
 	}
 
-	return &StrategyDTO{
-		ID:          strategyEntity.ID,
-		Name:        strategyEntity.Name,
-		Description: strategyEntity.Description,
-		CreatedAt:   strategyEntity.CreatedAt,
-		UpdatedAt:   strategyEntity.UpdatedAt,
-	}, nil
+	return toDTO(strategyEntity), nil
 }
 
 // GetUserStrategies retrieves all strategies for a user
@@ -70,49 +75,123 @@ func (s *Service) GetUserStrategies(ctx context.Context, userID int64) ([]*Strat
 
 	dtos := make([]*StrategyDTO, len(strategies))
 	for i, strategyEntity := range strategies {
-		dtos[i] = &StrategyDTO{
-			ID:          strategyEntity.ID,
-			Name:        strategyEntity.Name,
-			Description: strategyEntity.Description,
-			CreatedAt:   strategyEntity.CreatedAt,
-			UpdatedAt:   strategyEntity.UpdatedAt,
-		}
+		dtos[i] = toDTO(strategyEntity)
 	}
 
 	return dtos, nil
 }
 
-// UpdateStrategy updates an existing strategy
-func (s *Service) UpdateStrategy(ctx context.Context, id int64, userID int64, req UpdateStrategyRequest) (*StrategyDTO, error) {
+// UpdateStrategy updates an existing strategy, provided it is still at the version the caller read
+func (s *Service) UpdateStrategy(ctx context.Context, id int64, userID int64, req UpdateStrategyRequest, version int64) (*StrategyDTO, error) {
+	rules, err := toRules(req.Rules)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrStrategyNotFound
+	}
+	if existing.Version != version {
+		return nil, ErrVersionConflict
+	}
+
 	strategyEntity := &strategy.Strategy{
 		ID:          id,
 		UserID:      userID,
 		Name:        req.Name,
 		Description: req.Description,
+		Rules:       rules,
+		Version:     version,
 	}
 
 	updated, err := s.repo.Update(ctx, strategyEntity)
 	if err != nil {
+		if errors.Is(err, strategy.ErrRuleNotFound) {
+			return nil, ErrRuleNotFound
+		}
+		if errors.Is(err, strategy.ErrVersionConflict) {
+			return nil, ErrVersionConflict
+		}
 		return nil, ErrStrategyNotFound
 	}
 
-	return &StrategyDTO{
-		ID:          updated.ID,
-		Name:        updated.Name,
-		Description: updated.Description,
-		CreatedAt:   updated.CreatedAt,
-		UpdatedAt:   updated.UpdatedAt,
-	}, nil
+	dto := toDTO(updated)
+	s.record(ctx, userID, id, audit.ActionUpdate, toDTO(existing), dto)
+
+	return dto, nil
 }
 
-// DeleteStrategy deletes a strategy
-func (s *Service) DeleteStrategy(ctx context.Context, id int64, userID int64) error {
-	err := s.repo.Delete(ctx, id, userID)
+// DeleteStrategy deletes a strategy, provided it is still at the version the caller read
+func (s *Service) DeleteStrategy(ctx context.Context, id int64, userID int64, version int64) error {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return ErrStrategyNotFound
+	}
+	if existing.Version != version {
+		return ErrVersionConflict
+	}
+
+	err = s.repo.Delete(ctx, id, userID, version)
 	if err != nil {
 		if err == strategy.ErrNotFound {
 			return ErrStrategyNotFound
 		}
+		if err == strategy.ErrVersionConflict {
+			return ErrVersionConflict
+		}
 		return err
 	}
+
+	s.record(ctx, userID, id, audit.ActionDelete, toDTO(existing), nil)
 	return nil
 }
+
+// record adds a change to the strategy's audit log without failing the change itself
+func (s *Service) record(ctx context.Context, userID int64, strategyID int64, action audit.Action, before, after *StrategyDTO) {
+	event, err := audit.NewEvent(userID, audit.EntityStrategy, strategyID, action, before, after)
+	if err != nil {
+		return
+	}
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		// Log error but don't fail the change
+	}
+}
+
+// toRules trims the requested rules and numbers them in request order
+func toRules(reqs []RuleRequest) ([]strategy.Rule, error) {
+	rules := make([]strategy.Rule, len(reqs))
+	for i, req := range reqs {
+		description := strings.TrimSpace(req.Description)
+		if description == "" || utf8.RuneCountInString(description) > strategy.MaxRuleLength {
+			return nil, ErrInvalidRule
+		}
+		rules[i] = strategy.Rule{
+			ID:          req.ID,
+			Position:    i + 1,
+			Description: description,
+		}
+	}
+	return rules, nil
+}
+
+func toDTO(s *strategy.Strategy) *StrategyDTO {
+	rules := make([]RuleDTO, len(s.Rules))
+	for i, rule := range s.Rules {
+		rules[i] = RuleDTO{
+			ID:          rule.ID,
+			Position:    rule.Position,
+			Description: rule.Description,
+		}
+	}
+
+	return &StrategyDTO{
+		ID:          s.ID,
+		Name:        s.Name,
+		Description: s.Description,
+		Rules:       rules,
+		CreatedAt:   s.CreatedAt,
+		UpdatedAt:   s.UpdatedAt,
+		Version:     s.Version,
+	}
+}
@@ -20,7 +20,8 @@ func TestStrategyService_CreateStrategy_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(strategyRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	service := NewService(strategyRepo, auditRepo)
 
 	ctx := context.Background()
 
@@ -81,7 +82,8 @@ func TestStrategyService_GetStrategy_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(strategyRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	service := NewService(strategyRepo, auditRepo)
 
 	ctx := context.Background()
 
@@ -170,7 +172,8 @@ func TestStrategyService_GetUserStrategies_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(strategyRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	service := NewService(strategyRepo, auditRepo)
 
 	ctx := context.Background()
 
@@ -262,7 +265,8 @@ func TestStrategyService_UpdateStrategy_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(strategyRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	service := NewService(strategyRepo, auditRepo)
 
 	ctx := context.Background()
 
@@ -286,7 +290,7 @@ func TestStrategyService_UpdateStrategy_Integration(t *testing.T) {
 			Description: "New Description",
 		}
 
-		updated, err := service.UpdateStrategy(ctx, created.ID, createdUser.ID, updateReq)
+		updated, err := service.UpdateStrategy(ctx, created.ID, createdUser.ID, updateReq, created.Version)
 
 		// Verify no error
 		if err != nil {
@@ -341,7 +345,7 @@ func TestStrategyService_UpdateStrategy_Integration(t *testing.T) {
 			Description: "Hacked",
 		}
 
-		_, err := service.UpdateStrategy(ctx, strategy.ID, createdUser2.ID, updateReq)
+		_, err := service.UpdateStrategy(ctx, strategy.ID, createdUser2.ID, updateReq, strategy.Version)
 
 		// Should get error
 		if err != ErrStrategyNotFound {
@@ -365,7 +369,8 @@ func TestStrategyService_DeleteStrategy_Integration(t *testing.T) {
 	pg := testutil.SetupTestDatabase(t)
 	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
-	service := NewService(strategyRepo)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	service := NewService(strategyRepo, auditRepo)
 
 	ctx := context.Background()
 
@@ -384,7 +389,7 @@ func TestStrategyService_DeleteStrategy_Integration(t *testing.T) {
 		created, _ := service.CreateStrategy(ctx, createdUser.ID, req)
 
 		// Delete strategy
-		err := service.DeleteStrategy(ctx, created.ID, createdUser.ID)
+		err := service.DeleteStrategy(ctx, created.ID, createdUser.ID, created.Version)
 
 		// Verify no error
 		if err != nil {
@@ -421,7 +426,7 @@ func TestStrategyService_DeleteStrategy_Integration(t *testing.T) {
 		strategy, _ := service.CreateStrategy(ctx, createdUser1.ID, req)
 
 		// User2 tries to delete user1's strategy
-		err := service.DeleteStrategy(ctx, strategy.ID, createdUser2.ID)
+		err := service.DeleteStrategy(ctx, strategy.ID, createdUser2.ID, strategy.Version)
 
 		// Should get error
 		if err != ErrStrategyNotFound {
@@ -436,3 +441,102 @@ func TestStrategyService_DeleteStrategy_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestStrategyService_Rules_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	service := NewService(strategyRepo, auditRepo)
+
+	ctx := context.Background()
+
+	t.Run("stores rules in order and syncs them on update", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, _ := userRepo.Create(ctx, user.NewUser("rules@example.com", "hashedpass"))
+
+		created, err := service.CreateStrategy(ctx, createdUser.ID, CreateStrategyRequest{
+			Name: "Breakout",
+			Rules: []RuleRequest{
+				{Description: "  Wait for the candle to close "},
+				{Description: "Risk at most 1%"},
+				{Description: "No trades before news"},
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(created.Rules) != 3 || created.Rules[0].Description != "Wait for the candle to close" || created.Rules[2].Position != 3 {
+			t.Fatalf("expected 3 trimmed rules in order, got %+v", created.Rules)
+		}
+
+		// Swap the first two rules, drop the third and add a new one
+		updated, err := service.UpdateStrategy(ctx, created.ID, createdUser.ID, UpdateStrategyRequest{
+			Name: "Breakout",
+			Rules: []RuleRequest{
+				{ID: created.Rules[1].ID, Description: "Risk at most 0.5%"},
+				{ID: created.Rules[0].ID, Description: created.Rules[0].Description},
+				{Description: "Move stop to break-even at 1R"},
+			},
+		}, created.Version)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(updated.Rules) != 3 {
+			t.Fatalf("expected 3 rules, got %+v", updated.Rules)
+		}
+		if updated.Rules[0].ID != created.Rules[1].ID || updated.Rules[0].Description != "Risk at most 0.5%" {
+			t.Errorf("expected the reworded risk rule first, got %+v", updated.Rules[0])
+		}
+		if updated.Rules[1].ID != created.Rules[0].ID || updated.Rules[2].Position != 3 {
+			t.Errorf("expected rules to be renumbered in request order, got %+v", updated.Rules)
+		}
+
+		var count int
+		pg.DB.QueryRow("SELECT COUNT(*) FROM strategy_rules WHERE id = $1", created.Rules[2].ID).Scan(&count)
+		if count != 0 {
+			t.Error("expected the dropped rule to be deleted")
+		}
+
+		retrieved, err := service.GetStrategy(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(retrieved.Rules) != 3 || retrieved.Rules[2].Description != "Move stop to break-even at 1R" {
+			t.Errorf("expected rules to be loaded with the strategy, got %+v", retrieved.Rules)
+		}
+	})
+
+	t.Run("rejects blank rules and rules of another strategy", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, _ := userRepo.Create(ctx, user.NewUser("badrules@example.com", "hashedpass"))
+
+		_, err := service.CreateStrategy(ctx, createdUser.ID, CreateStrategyRequest{
+			Name:  "Blank",
+			Rules: []RuleRequest{{Description: "   "}},
+		})
+		if err != ErrInvalidRule {
+			t.Errorf("expected ErrInvalidRule, got %v", err)
+		}
+
+		first, _ := service.CreateStrategy(ctx, createdUser.ID, CreateStrategyRequest{
+			Name:  "First",
+			Rules: []RuleRequest{{Description: "Trend is up"}},
+		})
+		second, _ := service.CreateStrategy(ctx, createdUser.ID, CreateStrategyRequest{Name: "Second"})
+
+		_, err = service.UpdateStrategy(ctx, second.ID, createdUser.ID, UpdateStrategyRequest{
+			Name:  "Second",
+			Rules: []RuleRequest{{ID: first.Rules[0].ID, Description: "Trend is up"}},
+		}, second.Version)
+		if err != ErrRuleNotFound {
+			t.Errorf("expected ErrRuleNotFound, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,312 @@
+package trade
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/raihanstark/trade-journal/internal/domain/account"
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+// Bulk actions
+const (
+	BulkDelete         = "delete"
+	BulkMoveAccount    = "move_account"
+	BulkAddStrategy    = "add_strategy"
+	BulkRemoveStrategy = "remove_strategy"
+	BulkAddTag         = "add_tag"
+	BulkRemoveTag      = "remove_tag"
+)
+
+// Bulk item statuses
+const (
+	BulkStatusDeleted   = "deleted"
+	BulkStatusUpdated   = "updated"
+	BulkStatusUnchanged = "unchanged"
+	BulkStatusFailed    = "failed"
+)
+
+// maxBulkTrades caps the number of trades a single bulk request may touch
+const maxBulkTrades = 500
+
+// ErrBulkRejected is returned together with the report when a trade failed and nothing was applied
+var ErrBulkRejected = errors.New("bulk operation rolled back because some trades failed")
+
+// BulkTrades applies one action to a list of trades in a single transaction: either every trade
+// is changed or none is. Balance effects are summed per account and applied once at the end.
+func (s *Service) BulkTrades(ctx context.Context, userID int64, req BulkTradeRequest) (*BulkTradeResultDTO, error) {
+	if err := trade.NewValidationError(validateBulk(req)); err != nil {
+		return nil, err
+	}
+
+	result := &BulkTradeResultDTO{BalanceChanges: []BalanceChangeDTO{}}
+	events := &auditBuffer{Repository: s.auditRepo}
+	err := s.transactor.WithinTx(ctx, func(trades trade.Repository, accounts account.Repository) error {
+		if req.Action == BulkMoveAccount {
+			if _, err := accounts.GetByID(ctx, *req.AccountID, userID); err != nil {
+				if errors.Is(err, sql.ErrNoRows) || errors.Is(err, account.ErrNotFound) {
+					return ErrAccountNotFound
+				}
+				return err
+			}
+		}
+
+		ledger := &balanceLedger{Repository: accounts, amounts: map[int64]float64{}}
+		txService := s.withRepositories(trades, ledger, events)
+
+		failed := false
+		for _, id := range uniqueIDs(req.TradeIDs) {
+			item, err := txService.bulkItem(ctx, userID, id, req)
+			if err != nil {
+				if !isItemError(err) {
+					return err
+				}
+				failed = true
+				item = BulkItemResultDTO{TradeID: id, Status: BulkStatusFailed, Error: err.Error()}
+			}
+			result.Items = append(result.Items, item)
+		}
+
+		result.BalanceChanges = ledger.changes()
+		if failed {
+			return ErrBulkRejected
+		}
+		return ledger.apply(ctx, userID)
+	})
+	if errors.Is(err, ErrBulkRejected) {
+		// The trades in the report were never stored
+		for i := range result.Items {
+			result.Items[i].Trade = nil
+		}
+		return result, err
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result.Applied = true
+	for _, event := range events.events {
+		if err := s.auditRepo.Record(ctx, event); err != nil {
+			// Log error but don't fail the change
+		}
+	}
+	return result, nil
+}
+
+// bulkItem applies the action of a bulk request to one trade
+func (s *Service) bulkItem(ctx context.Context, userID int64, id int64, req BulkTradeRequest) (BulkItemResultDTO, error) {
+	item := BulkItemResultDTO{TradeID: id}
+
+	t, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, trade.ErrNotFound) {
+			return item, ErrTradeNotFound
+		}
+		return item, err
+	}
+
+	if req.Action == BulkDelete {
+		if err := s.DeleteTrade(ctx, id, userID, t.Version); err != nil {
+			return item, err
+		}
+		item.Status = BulkStatusDeleted
+		return item, nil
+	}
+
+	update := toUpdateRequest(t)
+	changed := false
+	switch req.Action {
+	case BulkMoveAccount:
+		changed = t.AccountID == nil || *t.AccountID != *req.AccountID
+		update.AccountID = req.AccountID
+	case BulkAddStrategy:
+		if !slices.Contains(update.StrategyIDs, *req.StrategyID) {
+			update.StrategyIDs = append(update.StrategyIDs, *req.StrategyID)
+			changed = true
+		}
+	case BulkRemoveStrategy:
+		if i := slices.Index(update.StrategyIDs, *req.StrategyID); i >= 0 {
+			update.StrategyIDs = slices.Delete(update.StrategyIDs, i, i+1)
+			update.SatisfiedRuleIDs = withoutRulesOf(t, *req.StrategyID)
+			changed = true
+		}
+	case BulkAddTag:
+		if tag := trade.NormalizeTag(req.Tag); !slices.Contains(update.Tags, tag) {
+			update.Tags = append(update.Tags, tag)
+			changed = true
+		}
+	case BulkRemoveTag:
+		if i := slices.Index(update.Tags, trade.NormalizeTag(req.Tag)); i >= 0 {
+			update.Tags = slices.Delete(update.Tags, i, i+1)
+			changed = true
+		}
+	}
+
+	if !changed {
+		item.Status = BulkStatusUnchanged
+		item.Trade = s.toDTO(t)
+		return item, nil
+	}
+
+	// A new account may hold another currency, so P/L is converted again
+	dto, err := s.applyUpdate(ctx, userID, t, update, req.Action == BulkMoveAccount)
+	if err != nil {
+		return item, err
+	}
+
+	// applyUpdate only moves the P/L of BUY/SELL trades, deposits and withdrawals carry their amount over here
+	if req.Action == BulkMoveAccount && (t.Type == trade.TradeTypeDeposit || t.Type == trade.TradeTypeWithdraw) {
+		if amount, ok := balanceEffect(t); ok {
+			s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, -amount)
+			s.accountRepo.UpdateBalance(ctx, *req.AccountID, userID, amount)
+		}
+	}
+
+	item.Status = BulkStatusUpdated
+	item.Trade = dto
+	return item, nil
+}
+
+// withRepositories returns a copy of the service working against the given repositories
+func (s *Service) withRepositories(repo trade.Repository, accountRepo account.Repository, auditRepo audit.Repository) *Service {
+	scoped := *s
+	scoped.repo = repo
+	scoped.accountRepo = accountRepo
+	scoped.auditRepo = auditRepo
+	return &scoped
+}
+
+// validateBulk checks that the request names a known action along with the value it needs
+func validateBulk(req BulkTradeRequest) []trade.FieldError {
+	var errs []trade.FieldError
+	add := func(field, code, format string, args ...any) {
+		errs = append(errs, trade.FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch {
+	case len(req.TradeIDs) == 0:
+		add("trade_ids", trade.CodeRequired, "trade_ids must list at least one trade")
+	case len(req.TradeIDs) > maxBulkTrades:
+		add("trade_ids", trade.CodeOutOfRange, "trade_ids may list at most %d trades", maxBulkTrades)
+	}
+
+	switch req.Action {
+	case BulkDelete:
+	case BulkMoveAccount:
+		if req.AccountID == nil {
+			add("account_id", trade.CodeRequired, "account_id is required to move trades")
+		}
+	case BulkAddStrategy, BulkRemoveStrategy:
+		if req.StrategyID == nil {
+			add("strategy_id", trade.CodeRequired, "strategy_id is required for %s", req.Action)
+		}
+	case BulkAddTag, BulkRemoveTag:
+		if trade.NormalizeTag(req.Tag) == "" {
+			add("tag", trade.CodeRequired, "tag is required for %s", req.Action)
+		}
+	case "":
+		add("action", trade.CodeRequired, "action is required")
+	default:
+		add("action", trade.CodeInvalid, "action must be one of %s, %s, %s, %s, %s or %s",
+			BulkDelete, BulkMoveAccount, BulkAddStrategy, BulkRemoveStrategy, BulkAddTag, BulkRemoveTag)
+	}
+	return errs
+}
+
+// isItemError reports whether err is about a single trade rather than the operation as a whole
+func isItemError(err error) bool {
+	var validationErr *trade.ValidationError
+	return errors.As(err, &validationErr) ||
+		errors.Is(err, ErrTradeNotFound) || errors.Is(err, ErrFXRateNotFound) ||
+		errors.Is(err, trade.ErrNotFound) || errors.Is(err, trade.ErrVersionConflict) ||
+		errors.Is(err, trade.ErrInvalidStatusTransition) || errors.Is(err, trade.ErrStrategyNotFound) ||
+		errors.Is(err, trade.ErrStrategyRuleNotFound) || errors.Is(err, trade.ErrMistakeTypeNotFound) ||
+		errors.Is(err, trade.ErrRatingDimensionNotFound) || errors.Is(err, trade.ErrGradeNotFound)
+}
+
+// uniqueIDs drops repeated IDs, keeping the first occurrence of each
+func uniqueIDs(ids []int64) []int64 {
+	seen := make(map[int64]bool, len(ids))
+	var unique []int64
+	for _, id := range ids {
+		if !seen[id] {
+			seen[id] = true
+			unique = append(unique, id)
+		}
+	}
+	return unique
+}
+
+// withoutRulesOf returns the trade's satisfied rules minus those of the given strategy
+func withoutRulesOf(t *trade.Trade, strategyID int64) []int64 {
+	var dropped []int64
+	for _, strategy := range t.Strategies {
+		if strategy.ID != strategyID {
+			continue
+		}
+		for _, rule := range strategy.Rules {
+			dropped = append(dropped, rule.ID)
+		}
+	}
+
+	var kept []int64
+	for _, id := range t.SatisfiedRuleIDs {
+		if !slices.Contains(dropped, id) {
+			kept = append(kept, id)
+		}
+	}
+	return kept
+}
+
+// balanceLedger stands in for the account repository during a bulk operation, collecting
+// balance updates so each account is updated once with the net amount
+type balanceLedger struct {
+	account.Repository
+	amounts map[int64]float64
+	order   []int64
+}
+
+func (l *balanceLedger) UpdateBalance(ctx context.Context, id int64, userID int64, amount float64) (*account.Account, error) {
+	if _, ok := l.amounts[id]; !ok {
+		l.order = append(l.order, id)
+	}
+	l.amounts[id] += amount
+	return nil, nil
+}
+
+// changes lists the net amount per account, leaving out accounts that came out even
+func (l *balanceLedger) changes() []BalanceChangeDTO {
+	changes := []BalanceChangeDTO{}
+	for _, id := range l.order {
+		if l.amounts[id] != 0 {
+			changes = append(changes, BalanceChangeDTO{AccountID: id, Amount: l.amounts[id]})
+		}
+	}
+	return changes
+}
+
+// apply writes the collected amounts to the accounts. Unlike single trade changes a failure
+// here fails the operation, since the transaction can still be rolled back.
+func (l *balanceLedger) apply(ctx context.Context, userID int64) error {
+	for _, change := range l.changes() {
+		if _, err := l.Repository.UpdateBalance(ctx, change.AccountID, userID, change.Amount); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// auditBuffer holds the audit events of a bulk operation until its transaction has committed
+type auditBuffer struct {
+	audit.Repository
+	events []*audit.Event
+}
+
+func (b *auditBuffer) Record(ctx context.Context, event *audit.Event) error {
+	b.events = append(b.events, event)
+	return nil
+}
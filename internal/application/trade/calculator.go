@@ -4,12 +4,41 @@ import (
 	"fmt"
 	"math"
 	"strings"
+	"time"
 
+	"github.com/raihanstark/trade-journal/internal/domain/instrument"
 	tradedom "github.com/raihanstark/trade-journal/internal/domain/trade"
 )
 
-// CalculateTradeMetrics calculates pips, P/L, R:R, and status based on trade data
-func CalculateTradeMetrics(t *tradedom.Trade) {
+// CalculateTradeMetrics calculates pips, P/L, R:R, and status based on trade data.
+// Pips and P/L follow the instrument specification; P/L is expressed in the instrument's quote currency.
+// Trades with executions take their entry, exit and lots from the fills, and their P/L is the
+// P/L realized so far, so a partially closed trade stays open with a P/L.
+func CalculateTradeMetrics(t *tradedom.Trade, spec *instrument.Instrument) {
+	var summary executionSummary
+	if len(t.Executions) > 0 {
+		summary = summarizeExecutions(spec, t.Type, t.Executions)
+		t.Entry = summary.AvgEntry
+		t.Lots = summary.EntryLots
+		t.Exit, t.Pips, t.PL, t.ClosedAt = nil, nil, nil, nil
+		if summary.ExitLots > 0 {
+			exit := summary.AvgExit
+			t.Exit = &exit
+		}
+	}
+
+	// Planned R:R and money at risk, both measured from entry to stop loss
+	t.PlannedRR, t.RealizedR, t.RiskAmount = nil, nil, nil
+	if t.StopLoss != nil {
+		if risk := calculateRiskAmount(spec, t.Type, t.Entry, *t.StopLoss, t.Lots); risk > 0 {
+			t.RiskAmount = &risk
+			if t.TakeProfit != nil {
+				planned := calculateRiskReward(t.Type, t.Entry, *t.TakeProfit, *t.StopLoss)
+				t.PlannedRR = &planned
+			}
+		}
+	}
+
 	// Calculate R:R for open trades (using take profit) or closed trades (using exit)
 	if t.StopLoss != nil {
 		var rrFloat float64
@@ -25,47 +54,139 @@ func CalculateTradeMetrics(t *tradedom.Trade) {
 		}
 	}
 
-	// Only calculate pips/P/L if we have exit price
+	// Only calculate pips/P/L if we have exit price; orders that were never filled keep their status
 	if t.Exit == nil {
-		t.Status = tradedom.TradeStatusOpen
+		if !t.Status.IsUnfilled() {
+			t.Status = tradedom.TradeStatusOpen
+		}
 		return
 	}
 
 	// Calculate pips
-	pips := calculatePips(t.Pair, t.Type, t.Entry, *t.Exit)
+	pips := calculatePips(spec, t.Type, t.Entry, *t.Exit)
 	t.Pips = &pips
 
-	// Calculate P/L (simplified - assumes $10/pip per lot)
-	// In real trading, this would depend on account currency, pair, and lot size
-	pl := pips * t.Lots * 10
+	if len(t.Executions) > 0 {
+		pl := math.Round(summary.RealizedPL*100) / 100
+		t.PL = &pl
+		t.RealizedR = calculateRealizedR(pl, t.RiskAmount)
+		t.Status = tradedom.TradeStatusClosed
+		if t.OpenLots() > 0 {
+			t.Status = tradedom.TradeStatusOpen
+		} else {
+			// The last exit fill closed the position
+			closedAt := summary.LastExitAt
+			t.ClosedAt = &closedAt
+		}
+		return
+	}
+
+	// Calculate P/L from the price move, contract size and lot size
+	pl := calculateProfitLoss(spec, t.Type, t.Entry, *t.Exit, t.Lots)
 	t.PL = &pl
+	t.RealizedR = calculateRealizedR(pl, t.RiskAmount)
 
 	// Set status to closed
 	t.Status = tradedom.TradeStatusClosed
 }
 
-// calculatePips calculates the pip difference between entry and exit
-func calculatePips(pair string, tradeType tradedom.TradeType, entry, exit float64) float64 {
-	var pips float64
+// calculateNetPL deducts commission and fees from the gross P/L and adds swap.
+// Costs are recorded in the account currency, so this runs after FX conversion.
+func calculateNetPL(t *tradedom.Trade) {
+	if t.PL == nil {
+		t.NetPL = nil
+		return
+	}
+
+	net := math.Round((*t.PL-t.Commission-t.Fees+t.Swap)*100) / 100
+	t.NetPL = &net
+}
+
+// executionSummary aggregates a trade's fills
+type executionSummary struct {
+	AvgEntry   float64    // Volume-weighted price of the entry fills
+	AvgExit    float64    // Volume-weighted price of the exit fills
+	EntryLots  float64    // Total lots opened
+	ExitLots   float64    // Total lots closed
+	RealizedPL float64    // P/L realized by the exit fills, in the quote currency
+	FillPL     []*float64 // Realized P/L of each fill, nil for entry fills
+	LastExitAt time.Time  // Time of the latest exit fill
+}
+
+// summarizeExecutions walks the fills in time order, keeping a running average cost,
+// and realizes P/L on each exit fill against the average cost at that moment
+func summarizeExecutions(spec *instrument.Instrument, tradeType tradedom.TradeType, executions []tradedom.Execution) executionSummary {
+	summary := executionSummary{FillPL: make([]*float64, len(executions))}
+
+	var entryValue, exitValue, position, cost float64
+	for i, e := range executions {
+		if e.IsEntry(tradeType) {
+			cost = (cost*position + e.Price*e.Lots) / (position + e.Lots)
+			position += e.Lots
+			entryValue += e.Price * e.Lots
+			summary.EntryLots += e.Lots
+			continue
+		}
 
-	// Determine pip multiplier based on pair
-	// JPY pairs: 1 pip = 0.01
-	// Other pairs: 1 pip = 0.0001
-	pipMultiplier := 10000.0
-	if strings.Contains(pair, "JPY") {
-		pipMultiplier = 100.0
+		pl := calculateProfitLoss(spec, tradeType, cost, e.Price, e.Lots)
+		summary.FillPL[i] = &pl
+		summary.RealizedPL += pl
+		position -= e.Lots
+		exitValue += e.Price * e.Lots
+		summary.ExitLots += e.Lots
+		summary.LastExitAt = e.ExecutedAt
 	}
 
-	// Calculate pip difference based on trade type
-	if tradeType == tradedom.TradeTypeBuy {
-		pips = (exit - entry) * pipMultiplier
-	} else if tradeType == tradedom.TradeTypeSell {
-		pips = (entry - exit) * pipMultiplier
+	if summary.EntryLots > 0 {
+		summary.AvgEntry = roundPrice(entryValue / summary.EntryLots)
+	}
+	if summary.ExitLots > 0 {
+		summary.AvgExit = roundPrice(exitValue / summary.ExitLots)
+	}
+	return summary
+}
+
+// roundPrice rounds to the 8 decimal places prices are stored with
+func roundPrice(price float64) float64 {
+	return math.Round(price*1e8) / 1e8
+}
+
+// calculatePips calculates the pip difference between entry and exit
+func calculatePips(spec *instrument.Instrument, tradeType tradedom.TradeType, entry, exit float64) float64 {
+	if spec.PipSize == 0 {
+		return 0
 	}
 
+	pips := tradedom.PriceMove(tradeType, entry, exit) / spec.PipSize
 	return math.Round(pips*100) / 100 // Round to 2 decimal places
 }
 
+// calculateProfitLoss calculates the P/L in the quote currency for the given lot size
+func calculateProfitLoss(spec *instrument.Instrument, tradeType tradedom.TradeType, entry, exit, lots float64) float64 {
+	pl := tradedom.PriceMove(tradeType, entry, exit) * spec.ContractSize * lots
+	return math.Round(pl*100) / 100 // Round to 2 decimal places
+}
+
+// calculateRiskAmount calculates the money lost in the quote currency if the stop loss is hit.
+// A stop loss at or beyond the entry (e.g. moved to break-even) risks nothing and returns 0.
+func calculateRiskAmount(spec *instrument.Instrument, tradeType tradedom.TradeType, entry, stopLoss, lots float64) float64 {
+	risk := -calculateProfitLoss(spec, tradeType, entry, stopLoss, lots)
+	if risk < 0 {
+		return 0
+	}
+	return risk
+}
+
+// calculateRealizedR expresses P/L as a multiple of the amount risked.
+// Both are in the quote currency, so the ratio holds after conversion.
+func calculateRealizedR(pl float64, riskAmount *float64) *float64 {
+	if riskAmount == nil || *riskAmount == 0 {
+		return nil
+	}
+	r := math.Round(pl / *riskAmount * 100) / 100
+	return &r
+}
+
 // calculateRiskReward calculates the risk:reward ratio
 func calculateRiskReward(tradeType tradedom.TradeType, entry, exit, stopLoss float64) float64 {
 	var risk, reward float64
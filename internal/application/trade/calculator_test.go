@@ -2,7 +2,9 @@ package trade
 
 import (
 	"testing"
+	"time"
 
+	"github.com/raihanstark/trade-journal/internal/domain/instrument"
 	tradedom "github.com/raihanstark/trade-journal/internal/domain/trade"
 )
 
@@ -91,7 +93,7 @@ func TestCalculatePips(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			got := calculatePips(tt.pair, tt.tradeType, tt.entry, tt.exit)
+			got := calculatePips(instrument.Fallback(tt.pair), tt.tradeType, tt.entry, tt.exit)
 			if got != tt.want {
 				t.Errorf("calculatePips() = %.2f, want %.2f", got, tt.want)
 			}
@@ -99,6 +101,112 @@ func TestCalculatePips(t *testing.T) {
 	}
 }
 
+func TestCalculatePips_Registry(t *testing.T) {
+	gold := &instrument.Instrument{Symbol: "XAUUSD", PipSize: 0.1, ContractSize: 100, QuoteCurrency: "USD"}
+	dow := &instrument.Instrument{Symbol: "US30", PipSize: 1, ContractSize: 1, QuoteCurrency: "USD"}
+
+	tests := []struct {
+		name      string
+		spec      *instrument.Instrument
+		tradeType tradedom.TradeType
+		entry     float64
+		exit      float64
+		want      float64
+	}{
+		{
+			name:      "BUY trade - XAUUSD - profit",
+			spec:      gold,
+			tradeType: tradedom.TradeTypeBuy,
+			entry:     2000.00,
+			exit:      2005.50,
+			want:      55.0,
+		},
+		{
+			name:      "SELL trade - XAUUSD - loss",
+			spec:      gold,
+			tradeType: tradedom.TradeTypeSell,
+			entry:     2000.00,
+			exit:      2003.00,
+			want:      -30.0,
+		},
+		{
+			name:      "BUY trade - US30 - profit",
+			spec:      dow,
+			tradeType: tradedom.TradeTypeBuy,
+			entry:     38000,
+			exit:      38120,
+			want:      120.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculatePips(tt.spec, tt.tradeType, tt.entry, tt.exit)
+			if got != tt.want {
+				t.Errorf("calculatePips() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCalculateProfitLoss(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      *instrument.Instrument
+		tradeType tradedom.TradeType
+		entry     float64
+		exit      float64
+		lots      float64
+		want      float64
+	}{
+		{
+			name:      "EURUSD - 50 pips on 1 lot",
+			spec:      instrument.Fallback("EURUSD"),
+			tradeType: tradedom.TradeTypeBuy,
+			entry:     1.1000,
+			exit:      1.1050,
+			lots:      1.0,
+			want:      500.0,
+		},
+		{
+			name:      "USDJPY - 50 pips on 1 lot in JPY",
+			spec:      instrument.Fallback("USDJPY"),
+			tradeType: tradedom.TradeTypeBuy,
+			entry:     110.00,
+			exit:      110.50,
+			lots:      1.0,
+			want:      50000.0,
+		},
+		{
+			name:      "XAUUSD - $5 move on 0.5 lots",
+			spec:      &instrument.Instrument{Symbol: "XAUUSD", PipSize: 0.1, ContractSize: 100, QuoteCurrency: "USD"},
+			tradeType: tradedom.TradeTypeSell,
+			entry:     2000.00,
+			exit:      2005.00,
+			lots:      0.5,
+			want:      -250.0,
+		},
+		{
+			name:      "BTCUSD - $1000 move on 0.1 lots",
+			spec:      &instrument.Instrument{Symbol: "BTCUSD", PipSize: 1, ContractSize: 1, QuoteCurrency: "USD"},
+			tradeType: tradedom.TradeTypeBuy,
+			entry:     60000,
+			exit:      61000,
+			lots:      0.1,
+			want:      100.0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := calculateProfitLoss(tt.spec, tt.tradeType, tt.entry, tt.exit, tt.lots)
+			if got != tt.want {
+				t.Errorf("calculateProfitLoss() = %.2f, want %.2f", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestCalculateRiskReward(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -257,7 +365,7 @@ func TestCalculateTradeMetrics(t *testing.T) {
 			TakeProfit: floatPtr(1.1060),
 		}
 
-		CalculateTradeMetrics(trade)
+		CalculateTradeMetrics(trade, instrument.Fallback(trade.Pair))
 
 		// Assert pips
 		if trade.Pips == nil {
@@ -298,7 +406,7 @@ func TestCalculateTradeMetrics(t *testing.T) {
 			TakeProfit: floatPtr(1.1060),
 		}
 
-		CalculateTradeMetrics(trade)
+		CalculateTradeMetrics(trade, instrument.Fallback(trade.Pair))
 
 		// Assert pips and P/L are nil for open trades
 		if trade.Pips != nil {
@@ -331,7 +439,7 @@ func TestCalculateTradeMetrics(t *testing.T) {
 			TakeProfit: floatPtr(1.0950),
 		}
 
-		CalculateTradeMetrics(trade)
+		CalculateTradeMetrics(trade, instrument.Fallback(trade.Pair))
 
 		// Assert pips (negative for loss)
 		if trade.Pips == nil {
@@ -366,7 +474,7 @@ func TestCalculateTradeMetrics(t *testing.T) {
 			Lots:  1.0,
 		}
 
-		CalculateTradeMetrics(trade)
+		CalculateTradeMetrics(trade, instrument.Fallback(trade.Pair))
 
 		// Assert R:R is not set (empty string)
 		if trade.RR != "" {
@@ -387,3 +495,159 @@ func TestCalculateTradeMetrics(t *testing.T) {
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+func TestCalculateTradeMetrics_RMultiples(t *testing.T) {
+	t.Run("closed trade with stop loss and take profit", func(t *testing.T) {
+		stopLoss := 1.0980
+		takeProfit := 1.1060
+		exit := 1.1050
+		trade := &tradedom.Trade{
+			Type:       tradedom.TradeTypeBuy,
+			Entry:      1.1000,
+			Exit:       &exit,
+			Lots:       0.5,
+			StopLoss:   &stopLoss,
+			TakeProfit: &takeProfit,
+		}
+
+		CalculateTradeMetrics(trade, instrument.Fallback("EURUSD"))
+
+		// 20 pips * 0.5 lots * $10
+		if trade.RiskAmount == nil || *trade.RiskAmount != 100 {
+			t.Errorf("expected risk amount 100, got %v", trade.RiskAmount)
+		}
+		if trade.PlannedRR == nil || *trade.PlannedRR != 3 {
+			t.Errorf("expected planned R:R 3, got %v", trade.PlannedRR)
+		}
+		// $250 won on $100 risked
+		if trade.RealizedR == nil || *trade.RealizedR != 2.5 {
+			t.Errorf("expected realized R 2.5, got %v", trade.RealizedR)
+		}
+	})
+
+	t.Run("open trade has no realized R", func(t *testing.T) {
+		stopLoss := 1.1020
+		trade := &tradedom.Trade{
+			Type:     tradedom.TradeTypeSell,
+			Entry:    1.1000,
+			Lots:     1,
+			StopLoss: &stopLoss,
+		}
+
+		CalculateTradeMetrics(trade, instrument.Fallback("EURUSD"))
+
+		if trade.RiskAmount == nil || *trade.RiskAmount != 200 {
+			t.Errorf("expected risk amount 200, got %v", trade.RiskAmount)
+		}
+		if trade.PlannedRR != nil || trade.RealizedR != nil {
+			t.Errorf("expected no planned or realized R, got %v and %v", trade.PlannedRR, trade.RealizedR)
+		}
+	})
+
+	t.Run("stop loss at break-even risks nothing", func(t *testing.T) {
+		stopLoss := 1.1000
+		exit := 1.1050
+		trade := &tradedom.Trade{
+			Type:     tradedom.TradeTypeBuy,
+			Entry:    1.1000,
+			Exit:     &exit,
+			Lots:     1,
+			StopLoss: &stopLoss,
+		}
+
+		CalculateTradeMetrics(trade, instrument.Fallback("EURUSD"))
+
+		if trade.RiskAmount != nil || trade.RealizedR != nil {
+			t.Errorf("expected no risk amount or realized R, got %v and %v", trade.RiskAmount, trade.RealizedR)
+		}
+	})
+}
+
+func TestCalculateTradeMetrics_Executions(t *testing.T) {
+	spec := instrument.Fallback("EURUSD")
+	at := func(hour int) time.Time {
+		return time.Date(2025, 1, 10, hour, 0, 0, 0, time.UTC)
+	}
+
+	t.Run("scale in then scale out", func(t *testing.T) {
+		stopLoss := 1.0950
+		trade := &tradedom.Trade{
+			Type:     tradedom.TradeTypeBuy,
+			StopLoss: &stopLoss,
+			Executions: []tradedom.Execution{
+				{Side: tradedom.TradeTypeBuy, Price: 1.1000, Lots: 1.0, ExecutedAt: at(9)},
+				{Side: tradedom.TradeTypeBuy, Price: 1.1020, Lots: 1.0, ExecutedAt: at(10)},
+				{Side: tradedom.TradeTypeSell, Price: 1.1060, Lots: 1.0, ExecutedAt: at(12)},
+				{Side: tradedom.TradeTypeSell, Price: 1.1080, Lots: 1.0, ExecutedAt: at(14)},
+			},
+		}
+
+		CalculateTradeMetrics(trade, spec)
+
+		if trade.Entry != 1.101 {
+			t.Errorf("expected average entry 1.101, got %v", trade.Entry)
+		}
+		if trade.Exit == nil || *trade.Exit != 1.107 {
+			t.Errorf("expected average exit 1.107, got %v", trade.Exit)
+		}
+		if trade.Lots != 2.0 {
+			t.Errorf("expected 2 lots, got %v", trade.Lots)
+		}
+		if trade.Pips == nil || *trade.Pips != 60 {
+			t.Errorf("expected 60 pips, got %v", trade.Pips)
+		}
+		// (1.1060 - 1.1010) * 100,000 + (1.1080 - 1.1010) * 100,000 = 500 + 700
+		if trade.PL == nil || *trade.PL != 1200 {
+			t.Errorf("expected P/L 1200, got %v", trade.PL)
+		}
+		if trade.Status != tradedom.TradeStatusClosed {
+			t.Errorf("expected closed, got %s", trade.Status)
+		}
+		if trade.ClosedAt == nil || !trade.ClosedAt.Equal(at(14)) {
+			t.Errorf("expected closed at the last exit fill, got %v", trade.ClosedAt)
+		}
+	})
+
+	t.Run("partial close stays open with realized P/L", func(t *testing.T) {
+		trade := &tradedom.Trade{
+			Type: tradedom.TradeTypeSell,
+			Executions: []tradedom.Execution{
+				{Side: tradedom.TradeTypeSell, Price: 1.1000, Lots: 1.0, ExecutedAt: at(9)},
+				{Side: tradedom.TradeTypeBuy, Price: 1.0950, Lots: 0.4, ExecutedAt: at(12)},
+			},
+		}
+
+		CalculateTradeMetrics(trade, spec)
+
+		if trade.Status != tradedom.TradeStatusOpen {
+			t.Errorf("expected open, got %s", trade.Status)
+		}
+		if trade.ClosedAt != nil {
+			t.Errorf("expected no close time, got %v", trade.ClosedAt)
+		}
+		if trade.OpenLots() != 0.6 {
+			t.Errorf("expected 0.6 open lots, got %v", trade.OpenLots())
+		}
+		if trade.PL == nil || *trade.PL != 200 {
+			t.Errorf("expected realized P/L 200, got %v", trade.PL)
+		}
+	})
+
+	t.Run("entry fills only", func(t *testing.T) {
+		trade := &tradedom.Trade{
+			Type: tradedom.TradeTypeBuy,
+			Executions: []tradedom.Execution{
+				{Side: tradedom.TradeTypeBuy, Price: 1.1000, Lots: 0.5, ExecutedAt: at(9)},
+			},
+		}
+
+		CalculateTradeMetrics(trade, spec)
+
+		if trade.Exit != nil || trade.PL != nil || trade.Pips != nil {
+			t.Error("expected no exit, pips or P/L without exit fills")
+		}
+		if trade.Status != tradedom.TradeStatusOpen {
+			t.Errorf("expected open, got %s", trade.Status)
+		}
+	})
+}
@@ -3,66 +3,275 @@ package trade
 import "time"
 
 type TradeDTO struct {
-	ID          int64      `json:"id"`
-	AccountID   *int64     `json:"account_id"`
-	Date        string     `json:"date"`
-	Time        string     `json:"time"`
-	Pair        string     `json:"pair"`
-	Type        string     `json:"type"`
-	Entry       float64    `json:"entry"`
-	Exit        *float64   `json:"exit"`
-	Lots        float64    `json:"lots"`
-	Pips        *float64   `json:"pips"`
-	PL          *float64   `json:"pl"`
-	RR          string     `json:"rr"`
-	Status      string     `json:"status"`
-	StopLoss    *float64   `json:"stop_loss"`
-	TakeProfit  *float64   `json:"take_profit"`
-	Notes       string     `json:"notes"`
-	Mistakes    string     `json:"mistakes"`
-	Amount      *float64   `json:"amount"`
-	ChartBefore *string    `json:"chart_before"`
-	ChartAfter  *string    `json:"chart_after"`
-	Strategies  []Strategy `json:"strategies"`
-	CreatedAt   time.Time  `json:"created_at"`
-	UpdatedAt   time.Time  `json:"updated_at"`
+	ID           int64         `json:"id"`
+	AccountID    *int64        `json:"account_id"`
+	Date         string        `json:"date"`
+	Time         string        `json:"time"`
+	Pair         string        `json:"pair"`
+	Type         string        `json:"type"`
+	Entry        float64       `json:"entry"`
+	Exit         *float64      `json:"exit"`
+	Lots         float64       `json:"lots"`
+	Pips         *float64      `json:"pips"`
+	PL           *float64      `json:"pl"`
+	RR           string        `json:"rr"`
+	PlannedRR    *float64      `json:"planned_rr"`
+	RealizedR    *float64      `json:"realized_r"`
+	RiskAmount   *float64      `json:"risk_amount"`
+	Status       string        `json:"status"`
+	OrderType    string        `json:"order_type"`
+	StopLoss     *float64      `json:"stop_loss"`
+	TakeProfit   *float64      `json:"take_profit"`
+	Notes        string        `json:"notes"`
+	Mistakes     string        `json:"mistakes"`
+	Grade        string        `json:"grade"`
+	GroupID      *int64        `json:"group_id"`
+	Amount       *float64      `json:"amount"`
+	FXRate       *float64      `json:"fx_rate"`
+	Unconverted  bool          `json:"pl_unconverted"`
+	Commission   float64       `json:"commission"`
+	Swap         float64       `json:"swap"`
+	Fees         float64       `json:"fees"`
+	NetPL        *float64      `json:"net_pl"`
+	OpenedAt     time.Time     `json:"opened_at"`
+	ClosedAt     *time.Time    `json:"closed_at"`
+	CloseDate    *string       `json:"close_date"`
+	CloseTime    *string       `json:"close_time"`
+	HoldingSecs  *int64        `json:"holding_seconds"`
+	OpenLots     float64       `json:"open_lots"`
+	ChartBefore  *string       `json:"chart_before"`
+	ChartAfter   *string       `json:"chart_after"`
+	Strategies   []Strategy    `json:"strategies"`
+	Tags         []string      `json:"tags"`
+	MistakeTypes []MistakeType `json:"mistake_types"`
+	Ratings      []Rating      `json:"ratings"`
+	CreatedAt    time.Time     `json:"created_at"`
+	UpdatedAt    time.Time     `json:"updated_at"`
+	DeletedAt    *time.Time    `json:"deleted_at"`
+	Version      int64         `json:"version"`
 }
 
+// Strategy is a strategy linked to the trade; Compliant is true when every rule was satisfied
 type Strategy struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Rules     []StrategyRule `json:"rules"`
+	Compliant bool           `json:"compliant"`
+}
+
+type StrategyRule struct {
+	ID          int64  `json:"id"`
+	Description string `json:"description"`
+	Satisfied   bool   `json:"satisfied"`
+}
+
+type MistakeType struct {
+	ID       int64  `json:"id"`
+	Name     string `json:"name"`
+	Severity string `json:"severity"`
+}
+
+// Rating is a psychology score from 1 to 5 taken before entry or after exit
+type Rating struct {
+	DimensionID int64  `json:"dimension_id"`
+	Dimension   string `json:"dimension"`
+	Phase       string `json:"phase"`
+	Value       int    `json:"value"`
+}
+
+type RatingRequest struct {
+	DimensionID int64  `json:"dimension_id"`
+	Phase       string `json:"phase"`
+	Value       int    `json:"value"`
+}
+
+// HistoryEventDTO is one recorded change to a trade; Changes maps each changed field to its old and new value
+type HistoryEventDTO struct {
+	ID        int64                  `json:"id"`
+	ActorID   int64                  `json:"actor_id"`
+	Action    string                 `json:"action"`
+	Changes   map[string]FieldChange `json:"changes"`
+	CreatedAt time.Time              `json:"created_at"`
+}
+
+type FieldChange struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// SearchResultDTO is a trade matched by a search; the snippets hold the matching text with terms
+// wrapped in <mark> and are left out when that field did not match
+type SearchResultDTO struct {
+	Trade           *TradeDTO `json:"trade"`
+	Rank            float64   `json:"rank"`
+	NotesSnippet    string    `json:"notes_snippet,omitempty"`
+	MistakesSnippet string    `json:"mistakes_snippet,omitempty"`
+}
+
+type TagDTO struct {
 	ID   int64  `json:"id"`
 	Name string `json:"name"`
 }
 
+// ExecutionDTO is a single fill; PL is set on exit fills and expressed in the account currency
+type ExecutionDTO struct {
+	ID         int64     `json:"id"`
+	Side       string    `json:"side"`
+	Price      float64   `json:"price"`
+	Lots       float64   `json:"lots"`
+	ExecutedAt time.Time `json:"executed_at"`
+	PL         *float64  `json:"pl"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+type CreateExecutionRequest struct {
+	Side       string  `json:"side"`
+	Price      float64 `json:"price"`
+	Lots       float64 `json:"lots"`
+	ExecutedAt string  `json:"executed_at"`
+}
+
 type CreateTradeRequest struct {
-	AccountID   *int64   `json:"account_id"`
-	Date        string   `json:"date"`
-	Time        string   `json:"time"`
-	Pair        string   `json:"pair"`
-	Type        string   `json:"type"`
-	Entry       float64  `json:"entry"`
-	Exit        *float64 `json:"exit"`
-	Lots        float64  `json:"lots"`
-	StopLoss    *float64 `json:"stop_loss"`
-	TakeProfit  *float64 `json:"take_profit"`
-	Notes       string   `json:"notes"`
-	Mistakes    string   `json:"mistakes"`
-	Amount      *float64 `json:"amount"`
-	StrategyIDs []int64  `json:"strategy_ids"`
+	AccountID        *int64          `json:"account_id"`
+	Date             string          `json:"date"`
+	Time             string          `json:"time"`
+	Pair             string          `json:"pair"`
+	Type             string          `json:"type"`
+	Status           string          `json:"status"`
+	OrderType        string          `json:"order_type"`
+	Entry            float64         `json:"entry"`
+	Exit             *float64        `json:"exit"`
+	Lots             float64         `json:"lots"`
+	StopLoss         *float64        `json:"stop_loss"`
+	TakeProfit       *float64        `json:"take_profit"`
+	Notes            string          `json:"notes"`
+	Mistakes         string          `json:"mistakes"`
+	Grade            string          `json:"grade"`
+	Amount           *float64        `json:"amount"`
+	Commission       float64         `json:"commission"`
+	Swap             float64         `json:"swap"`
+	Fees             float64         `json:"fees"`
+	CloseDate        string          `json:"close_date"`
+	CloseTime        string          `json:"close_time"`
+	StrategyIDs      []int64         `json:"strategy_ids"`
+	SatisfiedRuleIDs []int64         `json:"satisfied_rule_ids"`
+	Tags             []string        `json:"tags"`
+	MistakeTypeIDs   []int64         `json:"mistake_type_ids"`
+	Ratings          []RatingRequest `json:"ratings"`
+}
+
+// ListTradesRequest holds the filters, sort and page of a trade listing as given in the query
+// string. List fields take repeated or comma-separated values; Sort lists field names, each
+// prefixed with - to sort descending. Cursor is the NextCursor of the previous page.
+type ListTradesRequest struct {
+	AccountID   string
+	StartDate   string
+	EndDate     string
+	Pairs       []string
+	Types       []string
+	Statuses    []string
+	StrategyIDs []string
+	Tags        []string
+	Grade       string
+	MinPL       string
+	MaxPL       string
+	MinR        string
+	MaxR        string
+	MinLots     string
+	MaxLots     string
+	Weekdays    []string
+	Hours       []string
+	Sort        []string
+	Limit       string
+	Cursor      string
+}
+
+// TradePageDTO is one page of a trade listing. NextCursor fetches the following page and is
+// null on the last one.
+type TradePageDTO struct {
+	Trades     []*TradeDTO `json:"trades"`
+	NextCursor *string     `json:"next_cursor"`
 }
 
 type UpdateTradeRequest struct {
-	AccountID   *int64   `json:"account_id"`
-	Date        string   `json:"date"`
-	Time        string   `json:"time"`
+	AccountID        *int64          `json:"account_id"`
+	Date             string          `json:"date"`
+	Time             string          `json:"time"`
+	Pair             string          `json:"pair"`
+	Type             string          `json:"type"`
+	Status           string          `json:"status"`
+	OrderType        string          `json:"order_type"`
+	Entry            float64         `json:"entry"`
+	Exit             *float64        `json:"exit"`
+	Lots             float64         `json:"lots"`
+	StopLoss         *float64        `json:"stop_loss"`
+	TakeProfit       *float64        `json:"take_profit"`
+	Notes            string          `json:"notes"`
+	Mistakes         string          `json:"mistakes"`
+	Grade            string          `json:"grade"`
+	Amount           *float64        `json:"amount"`
+	Commission       float64         `json:"commission"`
+	Swap             float64         `json:"swap"`
+	Fees             float64         `json:"fees"`
+	CloseDate        string          `json:"close_date"`
+	CloseTime        string          `json:"close_time"`
+	StrategyIDs      []int64         `json:"strategy_ids"`
+	SatisfiedRuleIDs []int64         `json:"satisfied_rule_ids"`
+	Tags             []string        `json:"tags"`
+	MistakeTypeIDs   []int64         `json:"mistake_type_ids"`
+	Ratings          []RatingRequest `json:"ratings"`
+}
+
+// PositionSizeRequest takes either RiskPercent of the account balance or a fixed RiskAmount
+type PositionSizeRequest struct {
+	AccountID   int64    `json:"account_id"`
 	Pair        string   `json:"pair"`
-	Type        string   `json:"type"`
 	Entry       float64  `json:"entry"`
-	Exit        *float64 `json:"exit"`
-	Lots        float64  `json:"lots"`
-	StopLoss    *float64 `json:"stop_loss"`
-	TakeProfit  *float64 `json:"take_profit"`
-	Notes       string   `json:"notes"`
-	Mistakes    string   `json:"mistakes"`
-	Amount      *float64 `json:"amount"`
-	StrategyIDs []int64  `json:"strategy_ids"`
+	StopLoss    float64  `json:"stop_loss"`
+	RiskPercent *float64 `json:"risk_percent"`
+	RiskAmount  *float64 `json:"risk_amount"`
+}
+
+// PositionSizeDTO holds the suggested position; money amounts are in the account currency
+type PositionSizeDTO struct {
+	Pair        string  `json:"pair"`
+	Type        string  `json:"type"`
+	Lots        float64 `json:"lots"`
+	RiskAmount  float64 `json:"risk_amount"`
+	RiskPercent float64 `json:"risk_percent"`
+	StopPips    float64 `json:"stop_pips"`
+	PipValue    float64 `json:"pip_value"`
+	Currency    string  `json:"currency"`
+}
+
+// BulkTradeRequest applies one action to many trades. AccountID is the target of move_account,
+// StrategyID the strategy of add_strategy and remove_strategy, Tag the tag of add_tag and remove_tag.
+type BulkTradeRequest struct {
+	Action     string  `json:"action"`
+	TradeIDs   []int64 `json:"trade_ids"`
+	AccountID  *int64  `json:"account_id"`
+	StrategyID *int64  `json:"strategy_id"`
+	Tag        string  `json:"tag"`
+}
+
+// BulkTradeResultDTO reports a bulk operation. When any item failed nothing was applied and
+// Applied is false; the other items then report what would have happened to them.
+type BulkTradeResultDTO struct {
+	Applied        bool                `json:"applied"`
+	Items          []BulkItemResultDTO `json:"items"`
+	BalanceChanges []BalanceChangeDTO  `json:"balance_changes"`
+}
+
+// BulkItemResultDTO is the outcome for one trade: deleted, updated, unchanged or failed
+type BulkItemResultDTO struct {
+	TradeID int64     `json:"trade_id"`
+	Status  string    `json:"status"`
+	Error   string    `json:"error,omitempty"`
+	Trade   *TradeDTO `json:"trade,omitempty"`
+}
+
+// BalanceChangeDTO is the net amount a bulk operation moved on one account
+type BalanceChangeDTO struct {
+	AccountID int64   `json:"account_id"`
+	Amount    float64 `json:"amount"`
 }
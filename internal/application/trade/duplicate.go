@@ -0,0 +1,53 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+var ErrDuplicateNotSupported = errors.New("only BUY or SELL trades can be duplicated")
+
+// DuplicateTrade clones a trade into a new open trade stamped with the current time in the user's
+// timezone. The setup carries over (account, pair, direction, size, levels, notes, strategies and tags)
+// while everything about the outcome is left behind: exit, close, costs, P/L, mistakes, ratings and grade.
+func (s *Service) DuplicateTrade(ctx context.Context, id int64, userID int64) (*TradeDTO, error) {
+	source, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if source.Type != trade.TradeTypeBuy && source.Type != trade.TradeTypeSell {
+		return nil, ErrDuplicateNotSupported
+	}
+
+	loc, err := s.location(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now().In(loc)
+
+	req := CreateTradeRequest{
+		AccountID:  source.AccountID,
+		Date:       now.Format("2006-01-02"),
+		Time:       now.Format("15:04"),
+		Pair:       source.Pair,
+		Type:       string(source.Type),
+		Status:     string(trade.TradeStatusOpen),
+		OrderType:  string(source.OrderType),
+		Entry:      source.Entry,
+		Lots:       source.Lots,
+		StopLoss:   source.StopLoss,
+		TakeProfit: source.TakeProfit,
+		Notes:      source.Notes,
+	}
+	for _, strategy := range source.Strategies {
+		req.StrategyIDs = append(req.StrategyIDs, strategy.ID)
+	}
+	for _, tag := range source.Tags {
+		req.Tags = append(req.Tags, tag.Name)
+	}
+
+	return s.CreateTrade(ctx, userID, req)
+}
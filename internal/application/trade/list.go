@@ -0,0 +1,209 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+const (
+	// DefaultPageSize is the number of trades on a page when the caller does not ask for a limit
+	DefaultPageSize = 50
+	// MaxPageSize caps the number of trades on one page
+	MaxPageSize = 200
+)
+
+// weekdays accepts full and three-letter English day names
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// ListTrades returns a page of the user's trades matching the filters of req in the order it
+// asks for. Dates, weekdays and hours are read in the user's timezone.
+func (s *Service) ListTrades(ctx context.Context, userID int64, req ListTradesRequest) (*TradePageDTO, error) {
+	loc, err := s.location(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter, fieldErrors := req.toFilter(loc)
+	page, pageErrors := req.toPage()
+	if err := trade.NewValidationError(append(fieldErrors, pageErrors...)); err != nil {
+		return nil, err
+	}
+
+	trades, next, err := s.repo.Find(ctx, userID, filter, page)
+	if errors.Is(err, trade.ErrInvalidCursor) {
+		return nil, trade.NewValidationError([]trade.FieldError{
+			{Field: "cursor", Code: trade.CodeInvalid, Message: "cursor is invalid or was issued for another sort"},
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TradePageDTO{Trades: make([]*TradeDTO, len(trades))}
+	for i, t := range trades {
+		result.Trades[i] = s.toDTO(t)
+	}
+	if next != "" {
+		result.NextCursor = &next
+	}
+	return result, nil
+}
+
+// toPage parses the page size and cursor of the request
+func (req ListTradesRequest) toPage() (trade.Page, []trade.FieldError) {
+	page := trade.Page{Limit: DefaultPageSize, Cursor: strings.TrimSpace(req.Cursor)}
+	if req.Limit == "" {
+		return page, nil
+	}
+
+	limit, err := strconv.Atoi(req.Limit)
+	if err != nil || limit < 1 || limit > MaxPageSize {
+		return page, []trade.FieldError{
+			{Field: "limit", Code: trade.CodeOutOfRange, Message: fmt.Sprintf("limit must be between 1 and %d", MaxPageSize)},
+		}
+	}
+	page.Limit = limit
+	return page, nil
+}
+
+// toFilter parses the request into a filter, collecting every value that does not parse
+func (req ListTradesRequest) toFilter(loc *time.Location) (trade.Filter, []trade.FieldError) {
+	var errs []trade.FieldError
+	add := func(field, code, format string, args ...any) {
+		errs = append(errs, trade.FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	filter := trade.Filter{
+		Pairs:    splitValues(req.Pairs),
+		Tags:     req.Tags,
+		Grade:    strings.TrimSpace(req.Grade),
+		Location: loc,
+	}
+
+	if req.AccountID != "" {
+		id, err := strconv.ParseInt(req.AccountID, 10, 64)
+		if err != nil {
+			add("account_id", trade.CodeInvalidFormat, "account_id must be a number")
+		}
+		filter.AccountID = &id
+	}
+
+	if req.StartDate != "" {
+		start, err := time.ParseInLocation("2006-01-02", req.StartDate, loc)
+		if err != nil {
+			add("start_date", trade.CodeInvalidFormat, "start_date must be in YYYY-MM-DD format")
+		}
+		filter.OpenedFrom = &start
+	}
+	if req.EndDate != "" {
+		end, err := time.ParseInLocation("2006-01-02", req.EndDate, loc)
+		if err != nil {
+			add("end_date", trade.CodeInvalidFormat, "end_date must be in YYYY-MM-DD format")
+		}
+		// The end date is inclusive
+		end = end.AddDate(0, 0, 1)
+		filter.OpenedTo = &end
+	}
+
+	for _, value := range splitValues(req.Types) {
+		tradeType := trade.TradeType(strings.ToUpper(value))
+		switch tradeType {
+		case trade.TradeTypeBuy, trade.TradeTypeSell, trade.TradeTypeDeposit, trade.TradeTypeWithdraw:
+			filter.Types = append(filter.Types, tradeType)
+		default:
+			add("type", trade.CodeInvalid, "type must be one of BUY, SELL, DEPOSIT or WITHDRAW, got %q", value)
+		}
+	}
+
+	for _, value := range splitValues(req.Statuses) {
+		status := trade.TradeStatus(strings.ToLower(value))
+		if !status.IsValid() {
+			add("status", trade.CodeInvalid, "status must be one of pending, open, closed, cancelled or expired, got %q", value)
+			continue
+		}
+		filter.Statuses = append(filter.Statuses, status)
+	}
+
+	for _, value := range splitValues(req.StrategyIDs) {
+		id, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			add("strategy_id", trade.CodeInvalidFormat, "strategy_id must be a number, got %q", value)
+			continue
+		}
+		filter.StrategyIDs = append(filter.StrategyIDs, id)
+	}
+
+	bound := func(field, value string) *float64 {
+		if value == "" {
+			return nil
+		}
+		parsed, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			add(field, trade.CodeInvalidFormat, "%s must be a number", field)
+			return nil
+		}
+		return &parsed
+	}
+	filter.MinPL = bound("min_pl", req.MinPL)
+	filter.MaxPL = bound("max_pl", req.MaxPL)
+	filter.MinR = bound("min_r", req.MinR)
+	filter.MaxR = bound("max_r", req.MaxR)
+	filter.MinLots = bound("min_lots", req.MinLots)
+	filter.MaxLots = bound("max_lots", req.MaxLots)
+
+	for _, value := range splitValues(req.Weekdays) {
+		weekday, ok := weekdays[strings.ToLower(value)]
+		if !ok {
+			add("weekday", trade.CodeInvalid, "weekday must be a day name such as mon or monday, got %q", value)
+			continue
+		}
+		filter.Weekdays = append(filter.Weekdays, weekday)
+	}
+
+	for _, value := range splitValues(req.Hours) {
+		hour, err := strconv.Atoi(value)
+		if err != nil || hour < 0 || hour > 23 {
+			add("hour", trade.CodeOutOfRange, "hour must be between 0 and 23, got %q", value)
+			continue
+		}
+		filter.Hours = append(filter.Hours, hour)
+	}
+
+	for _, value := range splitValues(req.Sort) {
+		sort := trade.Sort{Key: trade.SortKey(strings.ToLower(strings.TrimPrefix(value, "-"))), Descending: strings.HasPrefix(value, "-")}
+		if !sort.Key.IsValid() {
+			add("sort", trade.CodeInvalid, "sort must list date, pair, type, status, lots, pips, pl or r, each optionally prefixed with - for descending, got %q", value)
+			continue
+		}
+		filter.Sort = append(filter.Sort, sort)
+	}
+
+	return filter, errs
+}
+
+// splitValues flattens repeated and comma-separated query values, dropping blanks
+func splitValues(values []string) []string {
+	var split []string
+	for _, value := range values {
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				split = append(split, part)
+			}
+		}
+	}
+	return split
+}
@@ -0,0 +1,195 @@
+package trade
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+var ErrInvalidPatch = errors.New("invalid merge patch")
+
+// metricFields are the request fields that feed pips, P/L, R:R or the FX conversion;
+// a patch touching none of them keeps the stored metrics
+var metricFields = map[string]bool{
+	"account_id":  true,
+	"date":        true,
+	"time":        true,
+	"pair":        true,
+	"type":        true,
+	"status":      true,
+	"order_type":  true,
+	"entry":       true,
+	"exit":        true,
+	"lots":        true,
+	"stop_loss":   true,
+	"take_profit": true,
+	"amount":      true,
+	"commission":  true,
+	"swap":        true,
+	"fees":        true,
+	"close_date":  true,
+	"close_time":  true,
+}
+
+// PatchTrade applies a JSON Merge Patch (RFC 7396) to a trade, provided it is still at the version
+// the caller read. Fields left out of the patch keep their value and null clears a field. Metrics
+// are only recalculated when a field they depend on changed.
+func (s *Service) PatchTrade(ctx context.Context, id int64, userID int64, patch []byte, version int64) (*TradeDTO, error) {
+	var changes map[string]any
+	if err := json.Unmarshal(patch, &changes); err != nil || changes == nil {
+		return nil, fmt.Errorf("%w: expected a JSON object", ErrInvalidPatch)
+	}
+
+	existingTrade, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, err
+	}
+	if existingTrade.Version != version {
+		return nil, trade.ErrVersionConflict
+	}
+
+	stored := toUpdateRequest(existingTrade)
+	current, err := toDocument(stored)
+	if err != nil {
+		return nil, err
+	}
+	patched, err := toDocument(stored)
+	if err != nil {
+		return nil, err
+	}
+	mergePatch(patched, changes)
+
+	req, err := fromDocument(patched)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.applyUpdate(ctx, userID, existingTrade, req, metricsChanged(current, patched))
+}
+
+// metricsChanged tells whether updated differs from current in any field the metrics depend on
+func metricsChanged(current map[string]any, updated map[string]any) bool {
+	for field := range metricFields {
+		if !reflect.DeepEqual(current[field], updated[field]) {
+			return true
+		}
+	}
+	return false
+}
+
+// mergePatch merges patch into target following RFC 7396: objects merge key by key,
+// null removes a key and any other value replaces it
+func mergePatch(target map[string]any, patch map[string]any) {
+	for key, value := range patch {
+		if value == nil {
+			delete(target, key)
+			continue
+		}
+		nested, ok := value.(map[string]any)
+		if !ok {
+			target[key] = value
+			continue
+		}
+		existing, ok := target[key].(map[string]any)
+		if !ok {
+			existing = map[string]any{}
+		}
+		mergePatch(existing, nested)
+		target[key] = existing
+	}
+}
+
+// toDocument encodes a request as a generic JSON object so a patch can be merged into it
+func toDocument(req UpdateTradeRequest) (map[string]any, error) {
+	data, err := json.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	var doc map[string]any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	return doc, nil
+}
+
+// fromDocument decodes a patched document, rejecting fields that cannot be written
+func fromDocument(doc map[string]any) (UpdateTradeRequest, error) {
+	var req UpdateTradeRequest
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return req, err
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(&req); err != nil {
+		return req, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return req, nil
+}
+
+// toUpdateRequest expresses a stored trade as the update request that would reproduce it
+func toUpdateRequest(t *trade.Trade) UpdateTradeRequest {
+	req := UpdateTradeRequest{
+		AccountID:        t.AccountID,
+		Date:             t.Date.Format("2006-01-02"),
+		Time:             t.Time.Format("15:04"),
+		Pair:             t.Pair,
+		Type:             string(t.Type),
+		Status:           string(t.Status),
+		OrderType:        string(t.OrderType),
+		Entry:            t.Entry,
+		Exit:             t.Exit,
+		Lots:             t.Lots,
+		StopLoss:         t.StopLoss,
+		TakeProfit:       t.TakeProfit,
+		Notes:            t.Notes,
+		Mistakes:         t.Mistakes,
+		Grade:            t.Grade,
+		Amount:           t.Amount,
+		Commission:       t.Commission,
+		Swap:             t.Swap,
+		Fees:             t.Fees,
+		SatisfiedRuleIDs: t.SatisfiedRuleIDs,
+	}
+	if t.CloseDate != nil {
+		req.CloseDate = t.CloseDate.Format("2006-01-02")
+	}
+	if t.CloseTime != nil {
+		req.CloseTime = t.CloseTime.Format("15:04")
+	}
+	for _, strategy := range t.Strategies {
+		req.StrategyIDs = append(req.StrategyIDs, strategy.ID)
+	}
+	for _, tag := range t.Tags {
+		req.Tags = append(req.Tags, tag.Name)
+	}
+	for _, mistake := range t.MistakeTypes {
+		req.MistakeTypeIDs = append(req.MistakeTypeIDs, mistake.ID)
+	}
+	for _, r := range t.Ratings {
+		req.Ratings = append(req.Ratings, RatingRequest{
+			DimensionID: r.DimensionID,
+			Phase:       string(r.Phase),
+			Value:       r.Value,
+		})
+	}
+	return req
+}
+
+// keepMetrics carries the derived figures of the stored trade over to its update
+func keepMetrics(t *trade.Trade, previous *trade.Trade) {
+	t.Pips = previous.Pips
+	t.PL = previous.PL
+	t.RR = previous.RR
+	t.PlannedRR = previous.PlannedRR
+	t.RealizedR = previous.RealizedR
+	t.RiskAmount = previous.RiskAmount
+	t.FXRate = previous.FXRate
+	t.NetPL = previous.NetPL
+}
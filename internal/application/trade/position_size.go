@@ -0,0 +1,91 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+var (
+	ErrInvalidEntry     = errors.New("entry must be greater than zero")
+	ErrInvalidStopLoss  = errors.New("stop_loss must be greater than zero and differ from entry")
+	ErrInvalidRisk      = errors.New("provide either risk_percent or risk_amount, greater than zero")
+	ErrPositionTooSmall = errors.New("risk is too small for the minimum position size")
+)
+
+// lotStep is the smallest lot increment brokers accept
+const lotStep = 0.01
+
+// CalculatePositionSize returns the largest position, in steps of 0.01 lots, that loses no
+// more than the given risk if the stop loss is hit. Risk is either a percent of the account
+// balance or a fixed amount in the account currency.
+func (s *Service) CalculatePositionSize(ctx context.Context, userID int64, req PositionSizeRequest) (*PositionSizeDTO, error) {
+	if req.Entry <= 0 {
+		return nil, ErrInvalidEntry
+	}
+	if req.StopLoss <= 0 || req.StopLoss == req.Entry {
+		return nil, ErrInvalidStopLoss
+	}
+	if (req.RiskPercent == nil) == (req.RiskAmount == nil) {
+		return nil, ErrInvalidRisk
+	}
+
+	acc, err := s.accountRepo.GetByID(ctx, req.AccountID, userID)
+	if err != nil {
+		return nil, ErrAccountNotFound
+	}
+
+	riskBudget := 0.0
+	if req.RiskPercent != nil {
+		if *req.RiskPercent <= 0 || *req.RiskPercent > 100 {
+			return nil, ErrInvalidRisk
+		}
+		riskBudget = acc.CurrentBalance * *req.RiskPercent / 100
+	} else {
+		if *req.RiskAmount <= 0 {
+			return nil, ErrInvalidRisk
+		}
+		riskBudget = *req.RiskAmount
+	}
+
+	// The stop loss side decides the direction
+	tradeType := trade.TradeTypeBuy
+	if req.StopLoss > req.Entry {
+		tradeType = trade.TradeTypeSell
+	}
+
+	spec := s.resolveInstrument(ctx, userID, req.Pair)
+	rate, err := s.conversionRate(ctx, userID, spec, acc.Currency, time.Now(), req.Entry)
+	if err != nil {
+		return nil, err
+	}
+
+	// Money lost per lot at the stop loss, unrounded so small positions size correctly
+	riskPerLot := trade.PriceMove(tradeType, req.StopLoss, req.Entry) * spec.ContractSize * rate
+	lots := math.Floor(riskBudget/riskPerLot/lotStep+1e-9) * lotStep
+	lots = math.Round(lots*100) / 100
+	if lots < lotStep {
+		return nil, ErrPositionTooSmall
+	}
+
+	riskAmount := math.Round(calculateRiskAmount(spec, tradeType, req.Entry, req.StopLoss, lots)*rate*100) / 100
+
+	riskPercent := 0.0
+	if acc.CurrentBalance > 0 {
+		riskPercent = math.Round(riskAmount/acc.CurrentBalance*100*100) / 100
+	}
+
+	return &PositionSizeDTO{
+		Pair:        spec.Symbol,
+		Type:        string(tradeType),
+		Lots:        lots,
+		RiskAmount:  riskAmount,
+		RiskPercent: riskPercent,
+		StopPips:    calculatePips(spec, tradeType, req.StopLoss, req.Entry),
+		PipValue:    math.Round(spec.PipValue()*lots*rate*100) / 100,
+		Currency:    acc.Currency,
+	}, nil
+}
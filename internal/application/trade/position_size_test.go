@@ -0,0 +1,134 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/account"
+)
+
+func TestService_CalculatePositionSize(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	percent := func(v float64) *float64 { return &v }
+
+	tests := []struct {
+		name            string
+		pair            string
+		entry           float64
+		stopLoss        float64
+		riskPercent     *float64
+		riskAmount      *float64
+		wantType        string
+		wantLots        float64
+		wantRiskAmount  float64
+		wantRiskPercent float64
+		wantStopPips    float64
+		wantPipValue    float64
+	}{
+		{
+			name:            "1% of balance on a 20 pip stop",
+			pair:            "EUR/USD",
+			entry:           1.1000,
+			stopLoss:        1.0980,
+			riskPercent:     percent(1),
+			wantType:        "BUY",
+			wantLots:        0.5,
+			wantRiskAmount:  100,
+			wantRiskPercent: 1,
+			wantStopPips:    20,
+			wantPipValue:    5,
+		},
+		{
+			name:            "fixed risk on a short rounds lots down",
+			pair:            "EUR/USD",
+			entry:           1.1000,
+			stopLoss:        1.1030,
+			riskAmount:      percent(100),
+			wantType:        "SELL",
+			wantLots:        0.33,
+			wantRiskAmount:  99,
+			wantRiskPercent: 0.99,
+			wantStopPips:    30,
+			wantPipValue:    3.3,
+		},
+		{
+			name:            "pair based in the account currency converts at the entry price",
+			pair:            "USD/JPY",
+			entry:           150.00,
+			stopLoss:        149.50,
+			riskPercent:     percent(1),
+			wantType:        "BUY",
+			wantLots:        0.3,
+			wantRiskAmount:  100,
+			wantRiskPercent: 1,
+			wantStopPips:    50,
+			wantPipValue:    2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			accountSpy := &AccountRepositorySpy{
+				GetByIDResult: &account.Account{ID: 1, UserID: userID, Currency: "USD", CurrentBalance: 10000},
+			}
+			service := NewService(&TradeRepositorySpy{}, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+			result, err := service.CalculatePositionSize(ctx, userID, PositionSizeRequest{
+				AccountID:   1,
+				Pair:        tt.pair,
+				Entry:       tt.entry,
+				StopLoss:    tt.stopLoss,
+				RiskPercent: tt.riskPercent,
+				RiskAmount:  tt.riskAmount,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Type != tt.wantType {
+				t.Errorf("type = %s, want %s", result.Type, tt.wantType)
+			}
+			if result.Lots != tt.wantLots {
+				t.Errorf("lots = %v, want %v", result.Lots, tt.wantLots)
+			}
+			if result.RiskAmount != tt.wantRiskAmount {
+				t.Errorf("risk amount = %v, want %v", result.RiskAmount, tt.wantRiskAmount)
+			}
+			if result.RiskPercent != tt.wantRiskPercent {
+				t.Errorf("risk percent = %v, want %v", result.RiskPercent, tt.wantRiskPercent)
+			}
+			if result.StopPips != tt.wantStopPips {
+				t.Errorf("stop pips = %v, want %v", result.StopPips, tt.wantStopPips)
+			}
+			if result.PipValue != tt.wantPipValue {
+				t.Errorf("pip value = %v, want %v", result.PipValue, tt.wantPipValue)
+			}
+		})
+	}
+
+	t.Run("invalid requests", func(t *testing.T) {
+		service := NewService(&TradeRepositorySpy{}, &AccountRepositorySpy{
+			GetByIDResult: &account.Account{ID: 1, UserID: userID, Currency: "USD", CurrentBalance: 100},
+		}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		cases := []struct {
+			name string
+			req  PositionSizeRequest
+			want error
+		}{
+			{"no entry", PositionSizeRequest{AccountID: 1, Pair: "EURUSD", StopLoss: 1.09, RiskPercent: percent(1)}, ErrInvalidEntry},
+			{"stop at entry", PositionSizeRequest{AccountID: 1, Pair: "EURUSD", Entry: 1.1, StopLoss: 1.1, RiskPercent: percent(1)}, ErrInvalidStopLoss},
+			{"no risk", PositionSizeRequest{AccountID: 1, Pair: "EURUSD", Entry: 1.1, StopLoss: 1.09}, ErrInvalidRisk},
+			{"both risks", PositionSizeRequest{AccountID: 1, Pair: "EURUSD", Entry: 1.1, StopLoss: 1.09, RiskPercent: percent(1), RiskAmount: percent(10)}, ErrInvalidRisk},
+			{"below minimum lot", PositionSizeRequest{AccountID: 1, Pair: "EURUSD", Entry: 1.1, StopLoss: 1.09, RiskPercent: percent(1)}, ErrPositionTooSmall},
+		}
+
+		for _, c := range cases {
+			if _, err := service.CalculatePositionSize(ctx, userID, c.req); !errors.Is(err, c.want) {
+				t.Errorf("%s: expected %v, got %v", c.name, c.want, err)
+			}
+		}
+	})
+}
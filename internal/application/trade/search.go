@@ -0,0 +1,51 @@
+package trade
+
+import (
+	"context"
+	"errors"
+	"strings"
+)
+
+const (
+	// DefaultSearchLimit is the number of results returned when the caller does not ask for a limit
+	DefaultSearchLimit = 20
+	// MaxSearchLimit caps the number of results of one search
+	MaxSearchLimit = 100
+)
+
+var (
+	ErrSearchQueryRequired = errors.New("search query is required")
+	ErrInvalidSearchLimit  = errors.New("limit must be between 1 and 100")
+)
+
+// SearchTrades finds the user's trades whose pair, notes, mistakes or strategy names match query,
+// best match first. The query takes web search syntax: quoted phrases, OR and -excluded words.
+// A limit of 0 returns up to DefaultSearchLimit results.
+func (s *Service) SearchTrades(ctx context.Context, userID int64, query string, limit int) ([]*SearchResultDTO, error) {
+	query = strings.TrimSpace(query)
+	if query == "" {
+		return nil, ErrSearchQueryRequired
+	}
+	if limit == 0 {
+		limit = DefaultSearchLimit
+	}
+	if limit < 0 || limit > MaxSearchLimit {
+		return nil, ErrInvalidSearchLimit
+	}
+
+	results, err := s.repo.Search(ctx, userID, query, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*SearchResultDTO, len(results))
+	for i, result := range results {
+		dtos[i] = &SearchResultDTO{
+			Trade:           s.toDTO(result.Trade),
+			Rank:            result.Rank,
+			NotesSnippet:    result.NotesSnippet,
+			MistakesSnippet: result.MistakesSnippet,
+		}
+	}
+	return dtos, nil
+}
@@ -2,67 +2,87 @@ package trade
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/raihanstark/trade-journal/internal/domain/account"
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
+	"github.com/raihanstark/trade-journal/internal/domain/fx"
+	"github.com/raihanstark/trade-journal/internal/domain/instrument"
 	"github.com/raihanstark/trade-journal/internal/domain/trade"
+	"github.com/raihanstark/trade-journal/internal/domain/user"
 )
 
 var (
 	ErrAccountIDRequired = errors.New("account_id is required")
+	ErrAccountNotFound   = errors.New("account not found")
+	ErrFXRateNotFound    = errors.New("no fx rate available to convert P/L into the account currency")
+	ErrTradeNotFound     = errors.New("trade not found")
+	ErrAccountDeleted    = errors.New("the trade's account is in the trash, restore the account first")
+
+	ErrExecutionsNotSupported   = errors.New("executions can only be added to BUY or SELL trades")
+	ErrExecutionExceedsPosition = errors.New("execution closes more lots than are open")
+	ErrExecutionWithoutEntry    = errors.New("a trade needs at least one entry execution")
 )
 
 type Service struct {
-	repo        trade.Repository
-	accountRepo account.Repository
+	repo           trade.Repository
+	accountRepo    account.Repository
+	instrumentRepo instrument.Repository
+	fxRepo         fx.Repository
+	userRepo       user.Repository
+	auditRepo      audit.Repository
+	transactor     trade.Transactor
 }
 
-func NewService(repo trade.Repository, accountRepo account.Repository) *Service {
+func NewService(repo trade.Repository, accountRepo account.Repository, instrumentRepo instrument.Repository, fxRepo fx.Repository, userRepo user.Repository, auditRepo audit.Repository, transactor trade.Transactor) *Service {
 	return &Service{
-		repo:        repo,
-		accountRepo: accountRepo,
-	}
-}
-
-func (s *Service) GetTradesByAccountID(ctx context.Context, accountID int64, userID int64) ([]*TradeDTO, error) {
-	trades, err := s.repo.GetByAccountID(ctx, accountID, userID)
-	if err != nil {
-		return nil, err
+		repo:           repo,
+		accountRepo:    accountRepo,
+		instrumentRepo: instrumentRepo,
+		fxRepo:         fxRepo,
+		userRepo:       userRepo,
+		auditRepo:      auditRepo,
+		transactor:     transactor,
 	}
-
-	dtos := make([]*TradeDTO, len(trades))
-	for i, t := range trades {
-		dtos[i] = s.toDTO(t)
-	}
-	return dtos, nil
-
 }
 
 func (s *Service) CreateTrade(ctx context.Context, userID int64, req CreateTradeRequest) (*TradeDTO, error) {
 	// Validate required fields
+	var fieldErrors []trade.FieldError
 	if req.AccountID == nil {
-		return nil, ErrAccountIDRequired
+		fieldErrors = append(fieldErrors, trade.FieldError{Field: "account_id", Code: trade.CodeRequired, Message: ErrAccountIDRequired.Error()})
 	}
 
-	// Parse date and time
-	date, err := time.Parse("2006-01-02", req.Date)
-	if err != nil {
-		return nil, err
-	}
+	// Parse open and close dates and times
+	date, tradeTime, dateErrors := parseDateTime(req.Date, req.Time)
+	fieldErrors = append(fieldErrors, dateErrors...)
+	closeDate, closeTime, closeErrors := parseCloseDateTime(req.CloseDate, req.CloseTime)
+	fieldErrors = append(fieldErrors, closeErrors...)
 
-	tradeTime, err := time.Parse("15:04", req.Time)
+	// Dates and times are entered in the user's timezone
+	loc, err := s.location(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	status, orderType := orderLifecycle(req.Status, req.OrderType, nil)
+
 	t := &trade.Trade{
 		UserID:     userID,
 		AccountID:  req.AccountID,
 		Date:       date,
 		Time:       tradeTime,
+		OpenedAt:   combineDateTime(date, tradeTime, loc),
 		Pair:       req.Pair,
 		Type:       trade.TradeType(req.Type),
+		Status:     status,
+		OrderType:  orderType,
 		Entry:      req.Entry,
 		Exit:       req.Exit,
 		Lots:       req.Lots,
@@ -70,11 +90,31 @@ func (s *Service) CreateTrade(ctx context.Context, userID int64, req CreateTrade
 		TakeProfit: req.TakeProfit,
 		Notes:      req.Notes,
 		Mistakes:   req.Mistakes,
+		Grade:      strings.TrimSpace(req.Grade),
 		Amount:     req.Amount,
+		Commission: req.Commission,
+		Swap:       req.Swap,
+		Fees:       req.Fees,
+		Tags:       trade.NewTags(req.Tags),
+		Ratings:    toRatings(req.Ratings),
+	}
+	if closeDate != nil {
+		closedAt := combineDateTime(*closeDate, closeTime, loc)
+		t.ClosedAt = &closedAt
+	}
+
+	if err := trade.NewValidationError(append(fieldErrors, t.Validate()...)); err != nil {
+		return nil, err
+	}
+
+	if err := s.checkAccount(ctx, *t.AccountID, userID); err != nil {
+		return nil, err
 	}
 
 	// Calculate metrics (pips, P/L, R:R, status)
-	CalculateTradeMetrics(t)
+	if err := s.calculateMetrics(ctx, userID, t, nil, loc); err != nil {
+		return nil, err
+	}
 
 	// Convert strategy IDs to Strategy objects
 	var strategies []trade.Strategy
@@ -82,6 +122,13 @@ func (s *Service) CreateTrade(ctx context.Context, userID int64, req CreateTrade
 		strategies = append(strategies, trade.Strategy{ID: id})
 	}
 	t.Strategies = strategies
+	t.SatisfiedRuleIDs = req.SatisfiedRuleIDs
+
+	var mistakes []trade.MistakeType
+	for _, id := range req.MistakeTypeIDs {
+		mistakes = append(mistakes, trade.MistakeType{ID: id})
+	}
+	t.MistakeTypes = mistakes
 
 	created, err := s.repo.Create(ctx, t)
 	if err != nil {
@@ -89,142 +136,82 @@ func (s *Service) CreateTrade(ctx context.Context, userID int64, req CreateTrade
 	}
 
 	// Update account balance
-	if t.AccountID != nil {
-		if t.Type == trade.TradeTypeDeposit || t.Type == trade.TradeTypeWithdraw {
-			// Handle DEPOSIT and WITHDRAW trades
-			if t.Amount != nil {
-				amount := *t.Amount
-				if t.Type == trade.TradeTypeWithdraw {
-					amount = -amount // Withdraw reduces balance
-				}
-				_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, amount)
-				if err != nil {
-					// Log error but don't fail the trade creation
-				}
-			}
-		} else if (t.Type == trade.TradeTypeBuy || t.Type == trade.TradeTypeSell) && t.PL != nil {
-			// Handle closed BUY/SELL trades - update balance with P/L
-			_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, *t.PL)
-			if err != nil {
-				// Log error but don't fail the trade creation
-			}
+	if amount, ok := balanceEffect(t); ok {
+		_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, amount)
+		if err != nil {
+			// Log error but don't fail the trade creation
 		}
 	}
 
-	return s.toDTO(created), nil
-}
-
-func (s *Service) GetUserTrades(ctx context.Context, userID int64) ([]*TradeDTO, error) {
-	trades, err := s.repo.GetByUserID(ctx, userID)
-	if err != nil {
-		return nil, err
-	}
-
-	dtos := make([]*TradeDTO, len(trades))
-	for i, t := range trades {
-		dtos[i] = s.toDTO(t)
-	}
+	dto := s.toDTO(created)
+	s.record(ctx, userID, created.ID, audit.ActionCreate, nil, dto)
 
-	return dtos, nil
+	return dto, nil
 }
 
-func (s *Service) GetUserTradesWithDateFilter(ctx context.Context, userID int64, startDate, endDate *string) ([]*TradeDTO, error) {
-	// If no date filter provided, return all trades
-	if startDate == nil || endDate == nil {
-		return s.GetUserTrades(ctx, userID)
-	}
-
-	// Parse dates
-	start, err := time.Parse("2006-01-02", *startDate)
-	if err != nil {
-		return nil, errors.New("invalid start_date format, expected YYYY-MM-DD")
-	}
-
-	end, err := time.Parse("2006-01-02", *endDate)
-	if err != nil {
-		return nil, errors.New("invalid end_date format, expected YYYY-MM-DD")
-	}
-
-	// Get filtered trades
-	trades, err := s.repo.GetByUserIDAndDateRange(ctx, userID, start, end)
+func (s *Service) GetTrade(ctx context.Context, id int64, userID int64) (*TradeDTO, error) {
+	t, err := s.repo.GetByID(ctx, id, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	dtos := make([]*TradeDTO, len(trades))
-	for i, t := range trades {
-		dtos[i] = s.toDTO(t)
-	}
-
-	return dtos, nil
+	return s.toDTO(t), nil
 }
 
-func (s *Service) GetTradesByAccountIDWithDateFilter(ctx context.Context, accountID int64, userID int64, startDate, endDate *string) ([]*TradeDTO, error) {
-	// If no date filter provided, return all trades for account
-	if startDate == nil || endDate == nil {
-		return s.GetTradesByAccountID(ctx, accountID, userID)
-	}
-
-	// Parse dates
-	start, err := time.Parse("2006-01-02", *startDate)
+// UpdateTrade replaces a trade, provided it is still at the version the caller read
+func (s *Service) UpdateTrade(ctx context.Context, id int64, userID int64, req UpdateTradeRequest, version int64) (*TradeDTO, error) {
+	// Get the existing trade first to compare P/L changes
+	existingTrade, err := s.repo.GetByID(ctx, id, userID)
 	if err != nil {
-		return nil, errors.New("invalid start_date format, expected YYYY-MM-DD")
+		return nil, err
 	}
-
-	end, err := time.Parse("2006-01-02", *endDate)
-	if err != nil {
-		return nil, errors.New("invalid end_date format, expected YYYY-MM-DD")
+	if existingTrade.Version != version {
+		return nil, trade.ErrVersionConflict
 	}
 
-	// Get filtered trades
-	trades, err := s.repo.GetByAccountIDAndDateRange(ctx, accountID, userID, start, end)
+	// Metrics are only recalculated when a field they depend on changed, as for a patch
+	current, err := toDocument(toUpdateRequest(existingTrade))
 	if err != nil {
 		return nil, err
 	}
-
-	dtos := make([]*TradeDTO, len(trades))
-	for i, t := range trades {
-		dtos[i] = s.toDTO(t)
-	}
-
-	return dtos, nil
-}
-
-func (s *Service) GetTrade(ctx context.Context, id int64, userID int64) (*TradeDTO, error) {
-	t, err := s.repo.GetByID(ctx, id, userID)
+	updated, err := toDocument(req)
 	if err != nil {
 		return nil, err
 	}
 
-	return s.toDTO(t), nil
+	return s.applyUpdate(ctx, userID, existingTrade, req, metricsChanged(current, updated))
 }
 
-func (s *Service) UpdateTrade(ctx context.Context, id int64, userID int64, req UpdateTradeRequest) (*TradeDTO, error) {
-	// Get the existing trade first to compare P/L changes
-	existingTrade, err := s.repo.GetByID(ctx, id, userID)
-	if err != nil {
-		return nil, err
-	}
+// applyUpdate saves req over existingTrade and moves any change in settled P/L into the account
+// balances. Without recalculate the stored metrics are kept, so edits that leave prices, sizes,
+// costs and dates alone do not re-convert P/L at today's rates.
+func (s *Service) applyUpdate(ctx context.Context, userID int64, existingTrade *trade.Trade, req UpdateTradeRequest, recalculate bool) (*TradeDTO, error) {
+	id := existingTrade.ID
 
-	// Parse date and time
-	date, err := time.Parse("2006-01-02", req.Date)
-	if err != nil {
-		return nil, err
-	}
+	// Parse open and close dates and times
+	date, tradeTime, fieldErrors := parseDateTime(req.Date, req.Time)
+	closeDate, closeTime, closeErrors := parseCloseDateTime(req.CloseDate, req.CloseTime)
+	fieldErrors = append(fieldErrors, closeErrors...)
 
-	tradeTime, err := time.Parse("15:04", req.Time)
+	// Dates and times are entered in the user's timezone
+	loc, err := s.location(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 
+	status, orderType := orderLifecycle(req.Status, req.OrderType, existingTrade)
+
 	t := &trade.Trade{
 		ID:         id,
 		UserID:     userID,
 		AccountID:  req.AccountID,
 		Date:       date,
 		Time:       tradeTime,
+		OpenedAt:   combineDateTime(date, tradeTime, loc),
 		Pair:       req.Pair,
 		Type:       trade.TradeType(req.Type),
+		Status:     status,
+		OrderType:  orderType,
 		Entry:      req.Entry,
 		Exit:       req.Exit,
 		Lots:       req.Lots,
@@ -232,11 +219,52 @@ func (s *Service) UpdateTrade(ctx context.Context, id int64, userID int64, req U
 		TakeProfit: req.TakeProfit,
 		Notes:      req.Notes,
 		Mistakes:   req.Mistakes,
+		Grade:      strings.TrimSpace(req.Grade),
 		Amount:     req.Amount,
+		Commission: req.Commission,
+		Swap:       req.Swap,
+		Fees:       req.Fees,
+		Tags:       trade.NewTags(req.Tags),
+		Ratings:    toRatings(req.Ratings),
+		Executions: existingTrade.Executions,
+		Version:    existingTrade.Version,
+	}
+	if closeDate != nil {
+		closedAt := combineDateTime(*closeDate, closeTime, loc)
+		t.ClosedAt = &closedAt
+	}
+
+	if err := trade.NewValidationError(append(fieldErrors, t.Validate()...)); err != nil {
+		return nil, err
+	}
+
+	if !existingTrade.Status.CanTransitionTo(t.Status) {
+		return nil, fmt.Errorf("%w from %s to %s", trade.ErrInvalidStatusTransition, existingTrade.Status, t.Status)
+	}
+
+	// A trade may stay on its account, but only move to one that is not in the trash
+	if t.AccountID != nil && (existingTrade.AccountID == nil || *existingTrade.AccountID != *t.AccountID) {
+		if err := s.checkAccount(ctx, *t.AccountID, userID); err != nil {
+			return nil, err
+		}
+	}
+
+	// Entry, exit and lots of a trade with executions are derived from its fills
+	if len(t.Executions) > 0 {
+		if err := validateExecutions(t.Type, t.Executions); err != nil {
+			return nil, err
+		}
 	}
 
 	// Calculate metrics (pips, P/L, R:R, status)
-	CalculateTradeMetrics(t)
+	if recalculate {
+		if err := s.calculateMetrics(ctx, userID, t, existingTrade.ClosedAt, loc); err != nil {
+			return nil, err
+		}
+	} else {
+		keepMetrics(t, existingTrade)
+		settleClose(t, existingTrade.ClosedAt, loc)
+	}
 
 	// Convert strategy IDs to Strategy objects
 	var strategies []trade.Strategy
@@ -244,6 +272,13 @@ func (s *Service) UpdateTrade(ctx context.Context, id int64, userID int64, req U
 		strategies = append(strategies, trade.Strategy{ID: id})
 	}
 	t.Strategies = strategies
+	t.SatisfiedRuleIDs = req.SatisfiedRuleIDs
+
+	var mistakes []trade.MistakeType
+	for _, id := range req.MistakeTypeIDs {
+		mistakes = append(mistakes, trade.MistakeType{ID: id})
+	}
+	t.MistakeTypes = mistakes
 
 	updated, err := s.repo.Update(ctx, t)
 	if err != nil {
@@ -262,22 +297,22 @@ func (s *Service) UpdateTrade(ctx context.Context, id int64, userID int64, req U
 
 		if accountChanged {
 			// Revert P/L from old account
-			if existingTrade.AccountID != nil && existingTrade.PL != nil {
-				_, err = s.accountRepo.UpdateBalance(ctx, *existingTrade.AccountID, userID, -*existingTrade.PL)
+			if existingTrade.AccountID != nil && existingTrade.SettledPL() != nil {
+				_, err = s.accountRepo.UpdateBalance(ctx, *existingTrade.AccountID, userID, -*existingTrade.SettledPL())
 			}
 			// Add P/L to new account
-			if t.AccountID != nil && t.PL != nil {
-				_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, *t.PL)
+			if t.AccountID != nil && t.SettledPL() != nil {
+				_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, *t.SettledPL())
 			}
 		} else if t.AccountID != nil {
 			// Same account, calculate P/L difference
 			oldPL := float64(0)
-			if existingTrade.PL != nil {
-				oldPL = *existingTrade.PL
+			if existingTrade.SettledPL() != nil {
+				oldPL = *existingTrade.SettledPL()
 			}
 			newPL := float64(0)
-			if t.PL != nil {
-				newPL = *t.PL
+			if t.SettledPL() != nil {
+				newPL = *t.SettledPL()
 			}
 
 			plDifference := newPL - oldPL
@@ -292,92 +327,782 @@ func (s *Service) UpdateTrade(ctx context.Context, id int64, userID int64, req U
 		}
 	}
 
-	return s.toDTO(updated), nil
+	dto := s.toDTO(updated)
+	s.record(ctx, userID, id, audit.ActionUpdate, s.toDTO(existingTrade), dto)
+
+	return dto, nil
 }
 
-func (s *Service) DeleteTrade(ctx context.Context, id int64, userID int64) error {
+// DeleteTrade moves a trade to the trash, provided it is still at the version the caller read,
+// and reverts its effect on the account balance
+func (s *Service) DeleteTrade(ctx context.Context, id int64, userID int64, version int64) error {
 	// Get the trade first to revert balance changes
 	t, err := s.repo.GetByID(ctx, id, userID)
 	if err != nil {
 		return err
 	}
+	if t.Version != version {
+		return trade.ErrVersionConflict
+	}
+
+	// Delete first so a request that loses the race leaves the balance alone
+	if err := s.repo.Delete(ctx, id, userID, version); err != nil {
+		return err
+	}
+
+	if amount, ok := balanceEffect(t); ok {
+		_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, -amount)
+		if err != nil {
+			// Log error but don't fail the deletion
+		}
+	}
+
+	s.record(ctx, userID, id, audit.ActionDelete, s.toDTO(t), nil)
+	return nil
+}
+
+// GetDeletedTrades lists the trades in the user's trash, most recently deleted first
+func (s *Service) GetDeletedTrades(ctx context.Context, userID int64) ([]*TradeDTO, error) {
+	trades, err := s.repo.GetDeletedByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*TradeDTO, len(trades))
+	for i, t := range trades {
+		dtos[i] = s.toDTO(t)
+	}
+	return dtos, nil
+}
+
+// RestoreTrade takes a trade out of the trash and re-applies its effect on the account balance.
+// Trades whose account is itself in the trash are restored together with the account instead.
+func (s *Service) RestoreTrade(ctx context.Context, id int64, userID int64) (*TradeDTO, error) {
+	t, err := s.repo.GetDeletedByID(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, trade.ErrNotFound) {
+			return nil, ErrTradeNotFound
+		}
+		return nil, err
+	}
 
-	// Revert account balance before deleting
 	if t.AccountID != nil {
-		if t.Type == trade.TradeTypeDeposit || t.Type == trade.TradeTypeWithdraw {
-			// Revert DEPOSIT and WITHDRAW trades
-			if t.Amount != nil {
-				amount := *t.Amount
-				// Reverse the transaction
-				if t.Type == trade.TradeTypeDeposit {
-					amount = -amount // Revert deposit by subtracting
-				}
-				// For withdraw, amount stays positive to add it back
-				_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, amount)
-				if err != nil {
-					// Log error but continue with deletion
-				}
-			}
-		} else if (t.Type == trade.TradeTypeBuy || t.Type == trade.TradeTypeSell) && t.PL != nil {
-			// Revert P/L for closed BUY/SELL trades
-			_, err = s.accountRepo.UpdateBalance(ctx, *t.AccountID, userID, -*t.PL)
-			if err != nil {
-				// Log error but continue with deletion
-			}
+		if _, err := s.accountRepo.GetByID(ctx, *t.AccountID, userID); err != nil {
+			return nil, ErrAccountDeleted
+		}
+	}
+
+	restored, err := s.repo.Restore(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, trade.ErrNotFound) {
+			return nil, ErrTradeNotFound
+		}
+		return nil, err
+	}
+
+	if amount, ok := balanceEffect(restored); ok {
+		_, err = s.accountRepo.UpdateBalance(ctx, *restored.AccountID, userID, amount)
+		if err != nil {
+			// Log error but don't fail the restore
+		}
+	}
+
+	dto := s.toDTO(restored)
+	s.record(ctx, userID, id, audit.ActionRestore, s.toDTO(t), dto)
+
+	return dto, nil
+}
+
+// PurgeDeletedTrades permanently removes trades that have been in the trash since before the given instant
+func (s *Service) PurgeDeletedTrades(ctx context.Context, before time.Time) (int64, error) {
+	return s.repo.PurgeDeleted(ctx, before)
+}
+
+// GetTradeHistory lists the recorded changes to a trade, oldest first. Deleted trades keep their history.
+func (s *Service) GetTradeHistory(ctx context.Context, id int64, userID int64) ([]*HistoryEventDTO, error) {
+	events, err := s.auditRepo.GetByEntity(ctx, userID, audit.EntityTrade, id)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*HistoryEventDTO, len(events))
+	for i, e := range events {
+		changes := make(map[string]FieldChange, len(e.Changes))
+		for field, change := range e.Changes {
+			changes[field] = FieldChange{From: change.From, To: change.To}
+		}
+		dtos[i] = &HistoryEventDTO{
+			ID:        e.ID,
+			ActorID:   e.UserID,
+			Action:    string(e.Action),
+			Changes:   changes,
+			CreatedAt: e.CreatedAt,
+		}
+	}
+	return dtos, nil
+}
+
+// record adds a change to the trade's audit log; like balance updates, a failure here
+// does not undo or fail the change itself
+func (s *Service) record(ctx context.Context, userID int64, tradeID int64, action audit.Action, before, after *TradeDTO) {
+	event, err := audit.NewEvent(userID, audit.EntityTrade, tradeID, action, before, after)
+	if err != nil {
+		return
+	}
+	if err := s.auditRepo.Record(ctx, event); err != nil {
+		// Log error but don't fail the change
+	}
+}
+
+// RecordTrade adds a change made to a trade elsewhere, such as moving it to the trash along
+// with its account, to the trade's audit log. Either snapshot is nil for the side that does not exist.
+func (s *Service) RecordTrade(ctx context.Context, userID int64, action audit.Action, before, after *trade.Trade) {
+	var from, to *TradeDTO
+	tradeID := int64(0)
+	if before != nil {
+		from = s.toDTO(before)
+		tradeID = before.ID
+	}
+	if after != nil {
+		to = s.toDTO(after)
+		tradeID = after.ID
+	}
+	s.record(ctx, userID, tradeID, action, from, to)
+}
+
+// balanceEffect returns the amount a trade adds to its account balance: deposits add and
+// withdrawals subtract their amount, closed BUY/SELL trades add their net P/L
+func balanceEffect(t *trade.Trade) (float64, bool) {
+	if t.AccountID == nil {
+		return 0, false
+	}
+
+	switch t.Type {
+	case trade.TradeTypeDeposit:
+		if t.Amount != nil {
+			return *t.Amount, true
+		}
+	case trade.TradeTypeWithdraw:
+		if t.Amount != nil {
+			return -*t.Amount, true
+		}
+	case trade.TradeTypeBuy, trade.TradeTypeSell:
+		if pl := t.SettledPL(); pl != nil {
+			return *pl, true
+		}
+	}
+	return 0, false
+}
+
+// orderLifecycle returns the status and order type a trade request asks for. Fields left empty
+// keep those of the current trade, or default to a filled market order for a new one.
+// Open and closed both mean the order was filled; the calculator settles which of the two applies.
+func orderLifecycle(status, orderType string, current *trade.Trade) (trade.TradeStatus, trade.OrderType) {
+	resolvedStatus, resolvedOrderType := trade.TradeStatusOpen, trade.OrderTypeMarket
+	if current != nil {
+		if current.Status != "" {
+			resolvedStatus = current.Status
 		}
+		if current.OrderType != "" {
+			resolvedOrderType = current.OrderType
+		}
+	}
+
+	if status != "" {
+		resolvedStatus = trade.TradeStatus(status)
 	}
+	if orderType != "" {
+		resolvedOrderType = trade.OrderType(orderType)
+	}
+	return resolvedStatus, resolvedOrderType
+}
 
-	return s.repo.Delete(ctx, id, userID)
+// combineDateTime joins a wall-clock date and time into an instant in the given timezone
+func combineDateTime(date, tradeTime time.Time, loc *time.Location) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), tradeTime.Hour(), tradeTime.Minute(), 0, 0, loc)
+}
+
+// parseCloseDateTime parses the optional close date and time; a close date without a time closes at midnight
+func parseCloseDateTime(dateStr, timeStr string) (closeDate *time.Time, closeTime time.Time, errs []trade.FieldError) {
+	if dateStr == "" {
+		if timeStr != "" {
+			errs = append(errs, trade.FieldError{Field: "close_date", Code: trade.CodeRequired, Message: "close_date is required when close_time is set"})
+		}
+		return nil, closeTime, errs
+	}
+
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		errs = append(errs, trade.FieldError{Field: "close_date", Code: trade.CodeInvalidFormat, Message: "close_date must be in YYYY-MM-DD format"})
+	} else {
+		closeDate = &date
+	}
+
+	if timeStr != "" {
+		closeTime, err = time.Parse("15:04", timeStr)
+		if err != nil {
+			errs = append(errs, trade.FieldError{Field: "close_time", Code: trade.CodeInvalidFormat, Message: "close_time must be in HH:MM format"})
+		}
+	}
+
+	return closeDate, closeTime, errs
+}
+
+// settleClose keeps the close in step with the status. A trade that is not closed has no close;
+// a closed trade without an explicit close keeps its previous one, or closes now when its exit is first set.
+func settleClose(t *trade.Trade, previous *time.Time, loc *time.Location) {
+	closedAt := t.ClosedAt
+	switch {
+	case t.Status != trade.TradeStatusClosed:
+		closedAt = nil
+	case closedAt == nil && previous != nil:
+		closedAt = previous
+	case closedAt == nil:
+		now := time.Now().Truncate(time.Minute)
+		if now.Before(t.OpenedAt) {
+			now = t.OpenedAt
+		}
+		closedAt = &now
+	}
+	t.SetClosedAt(closedAt, loc)
+}
+
+// checkAccount returns ErrAccountNotFound unless the user has the account and it is not in the trash
+func (s *Service) checkAccount(ctx context.Context, accountID int64, userID int64) error {
+	if _, err := s.accountRepo.GetByID(ctx, accountID, userID); err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, account.ErrNotFound) {
+			return ErrAccountNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// location returns the timezone the user enters and filters dates in
+func (s *Service) location(ctx context.Context, userID int64) (*time.Location, error) {
+	u, err := s.userRepo.GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return u.Location(), nil
+}
+
+// parseDateTime parses the date and time of a trade request
+func parseDateTime(dateStr, timeStr string) (date, tradeTime time.Time, errs []trade.FieldError) {
+	date, err := time.Parse("2006-01-02", dateStr)
+	if err != nil {
+		errs = append(errs, trade.FieldError{Field: "date", Code: trade.CodeInvalidFormat, Message: "date must be in YYYY-MM-DD format"})
+	}
+
+	tradeTime, err = time.Parse("15:04", timeStr)
+	if err != nil {
+		errs = append(errs, trade.FieldError{Field: "time", Code: trade.CodeInvalidFormat, Message: "time must be in HH:MM format"})
+	}
+
+	return date, tradeTime, errs
+}
+
+// calculateMetrics derives pips, gross and net P/L, R:R, status and close for a trade,
+// with P/L expressed in the account currency. previousClose is the close the trade had before.
+func (s *Service) calculateMetrics(ctx context.Context, userID int64, t *trade.Trade, previousClose *time.Time, loc *time.Location) error {
+	spec := s.resolveInstrument(ctx, userID, t.Pair)
+	CalculateTradeMetrics(t, spec)
+	// The close is settled first since P/L converts at the rate of the close date
+	settleClose(t, previousClose, loc)
+	if err := s.convertToAccountCurrency(ctx, userID, t, spec); err != nil {
+		return err
+	}
+	calculateNetPL(t)
+	return nil
+}
+
+// resolveInstrument looks up the instrument specification for a pair,
+// falling back to a forex guess when the symbol is not in the registry
+func (s *Service) resolveInstrument(ctx context.Context, userID int64, pair string) *instrument.Instrument {
+	spec, err := s.instrumentRepo.GetBySymbol(ctx, userID, instrument.NormalizeSymbol(pair))
+	if err != nil {
+		return instrument.Fallback(pair)
+	}
+	return spec
+}
+
+// convertToAccountCurrency converts a trade's P/L and risk amount from the instrument's quote
+// currency into the account currency as of the close date, or the open date while the trade is
+// open, and records the rate used. Without a rate the P/L stays in the quote currency and no
+// rate is recorded, which marks it unconverted; the risk amount is dropped.
+func (s *Service) convertToAccountCurrency(ctx context.Context, userID int64, t *trade.Trade, spec *instrument.Instrument) error {
+	if (t.PL == nil && t.RiskAmount == nil) || t.AccountID == nil {
+		return nil
+	}
+
+	acc, err := s.accountRepo.GetByID(ctx, *t.AccountID, userID)
+	if err != nil {
+		return err
+	}
+
+	// Pairs based in the account currency convert at the exit price, or the entry price while open
+	price := t.Entry
+	if t.Exit != nil {
+		price = *t.Exit
+	}
+
+	date := t.Date
+	if t.CloseDate != nil {
+		date = *t.CloseDate
+	}
+
+	rate, err := s.conversionRate(ctx, userID, spec, acc.Currency, date, price)
+	if errors.Is(err, ErrFXRateNotFound) {
+		t.RiskAmount = nil
+		t.FXRate = nil
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if t.PL != nil {
+		pl := math.Round(*t.PL*rate*100) / 100
+		t.PL = &pl
+	}
+	if t.RiskAmount != nil {
+		risk := math.Round(*t.RiskAmount*rate*100) / 100
+		t.RiskAmount = &risk
+	}
+	t.FXRate = &rate
+	return nil
+}
+
+// conversionRate returns the rate converting the instrument's quote currency into the given
+// currency on a date. A pair based in that currency (e.g. USDJPY into USD) converts at
+// its own price when no rate is stored.
+func (s *Service) conversionRate(ctx context.Context, userID int64, spec *instrument.Instrument, currency string, date time.Time, price float64) (float64, error) {
+	rate, err := fx.Lookup(ctx, s.fxRepo, userID, spec.QuoteCurrency, currency, date)
+	if errors.Is(err, fx.ErrNotFound) {
+		if spec.BaseCurrency() != currency || price <= 0 {
+			return 0, fmt.Errorf("%w: %s/%s on %s", ErrFXRateNotFound, spec.QuoteCurrency, currency, date.Format("2006-01-02"))
+		}
+		return 1 / price, nil
+	}
+	return rate, err
 }
 
 func (s *Service) toDTO(t *trade.Trade) *TradeDTO {
 	strategies := make([]Strategy, len(t.Strategies))
 	for i, s := range t.Strategies {
+		rules := make([]StrategyRule, len(s.Rules))
+		for j, rule := range s.Rules {
+			rules[j] = StrategyRule{
+				ID:          rule.ID,
+				Description: rule.Description,
+				Satisfied:   rule.Satisfied,
+			}
+		}
 		strategies[i] = Strategy{
-			ID:   s.ID,
-			Name: s.Name,
+			ID:        s.ID,
+			Name:      s.Name,
+			Rules:     rules,
+			Compliant: s.Compliant(),
+		}
+	}
+
+	tags := make([]string, len(t.Tags))
+	for i, tag := range t.Tags {
+		tags[i] = tag.Name
+	}
+
+	ratings := make([]Rating, len(t.Ratings))
+	for i, r := range t.Ratings {
+		ratings[i] = Rating{
+			DimensionID: r.DimensionID,
+			Dimension:   r.Dimension,
+			Phase:       string(r.Phase),
+			Value:       r.Value,
+		}
+	}
+
+	mistakes := make([]MistakeType, len(t.MistakeTypes))
+	for i, m := range t.MistakeTypes {
+		mistakes[i] = MistakeType{
+			ID:       m.ID,
+			Name:     m.Name,
+			Severity: m.Severity,
 		}
 	}
 
 	return &TradeDTO{
-		ID:          t.ID,
-		AccountID:   t.AccountID,
-		Date:        t.Date.Format("2006-01-02"),
-		Time:        t.Time.Format("15:04"),
-		Pair:        t.Pair,
-		Type:        string(t.Type),
-		Entry:       t.Entry,
-		Exit:        t.Exit,
-		Lots:        t.Lots,
-		Pips:        t.Pips,
-		PL:          t.PL,
-		RR:          t.RR,
-		Status:      string(t.Status),
-		StopLoss:    t.StopLoss,
-		TakeProfit:  t.TakeProfit,
-		Notes:       t.Notes,
-		Mistakes:    t.Mistakes,
-		Amount:      t.Amount,
-		ChartBefore: t.ChartBefore,
-		ChartAfter:  t.ChartAfter,
-		Strategies:  strategies,
-		CreatedAt:   t.CreatedAt,
-		UpdatedAt:   t.UpdatedAt,
+		ID:           t.ID,
+		AccountID:    t.AccountID,
+		Date:         t.Date.Format("2006-01-02"),
+		Time:         t.Time.Format("15:04"),
+		Pair:         t.Pair,
+		Type:         string(t.Type),
+		Entry:        t.Entry,
+		Exit:         t.Exit,
+		Lots:         t.Lots,
+		Pips:         t.Pips,
+		PL:           t.PL,
+		RR:           t.RR,
+		PlannedRR:    t.PlannedRR,
+		RealizedR:    t.RealizedR,
+		RiskAmount:   t.RiskAmount,
+		Status:       string(t.Status),
+		OrderType:    string(t.OrderType),
+		StopLoss:     t.StopLoss,
+		TakeProfit:   t.TakeProfit,
+		Notes:        t.Notes,
+		Mistakes:     t.Mistakes,
+		Grade:        t.Grade,
+		GroupID:      t.GroupID,
+		Amount:       t.Amount,
+		FXRate:       t.FXRate,
+		Unconverted:  t.Unconverted(),
+		Commission:   t.Commission,
+		Swap:         t.Swap,
+		Fees:         t.Fees,
+		NetPL:        t.NetPL,
+		OpenedAt:     t.OpenedAt,
+		ClosedAt:     t.ClosedAt,
+		CloseDate:    formatOptionalTime(t.CloseDate, "2006-01-02"),
+		CloseTime:    formatOptionalTime(t.CloseTime, "15:04"),
+		HoldingSecs:  holdingSeconds(t.HoldingDuration()),
+		OpenLots:     t.OpenLots(),
+		ChartBefore:  t.ChartBefore,
+		ChartAfter:   t.ChartAfter,
+		Strategies:   strategies,
+		Tags:         tags,
+		MistakeTypes: mistakes,
+		Ratings:      ratings,
+		CreatedAt:    t.CreatedAt,
+		UpdatedAt:    t.UpdatedAt,
+		DeletedAt:    t.DeletedAt,
+		Version:      t.Version,
+	}
+}
+
+// toRatings converts requested ratings to the domain
+func toRatings(reqs []RatingRequest) []trade.Rating {
+	var ratings []trade.Rating
+	for _, r := range reqs {
+		ratings = append(ratings, trade.Rating{
+			DimensionID: r.DimensionID,
+			Phase:       trade.RatingPhase(r.Phase),
+			Value:       r.Value,
+		})
+	}
+	return ratings
+}
+
+// ListTags returns the tags a user has put on trades, in name order
+func (s *Service) ListTags(ctx context.Context, userID int64) ([]*TagDTO, error) {
+	tags, err := s.repo.GetTags(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*TagDTO, len(tags))
+	for i, tag := range tags {
+		dtos[i] = &TagDTO{ID: tag.ID, Name: tag.Name}
+	}
+	return dtos, nil
+}
+
+// formatOptionalTime formats t with layout, or returns nil when t is unset
+func formatOptionalTime(t *time.Time, layout string) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(layout)
+	return &formatted
+}
+
+// holdingSeconds converts a holding duration to whole seconds
+func holdingSeconds(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
 	}
+	seconds := int64(d.Seconds())
+	return &seconds
 }
 
 func (s *Service) UpdateChartBefore(ctx context.Context, tradeID int64, userID int64, chartURL string) (*TradeDTO, error) {
+	existing, err := s.repo.GetByID(ctx, tradeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	trade, err := s.repo.UpdateChartBefore(ctx, tradeID, userID, chartURL)
 	if err != nil {
 		return nil, err
 	}
-	return s.toDTO(trade), nil
+
+	dto := s.toDTO(trade)
+	s.record(ctx, userID, tradeID, audit.ActionUpdate, s.toDTO(existing), dto)
+	return dto, nil
 }
 
 func (s *Service) UpdateChartAfter(ctx context.Context, tradeID int64, userID int64, chartURL string) (*TradeDTO, error) {
+	existing, err := s.repo.GetByID(ctx, tradeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	trade, err := s.repo.UpdateChartAfter(ctx, tradeID, userID, chartURL)
 	if err != nil {
 		return nil, err
 	}
-	return s.toDTO(trade), nil
+
+	dto := s.toDTO(trade)
+	s.record(ctx, userID, tradeID, audit.ActionUpdate, s.toDTO(existing), dto)
+	return dto, nil
+}
+
+// ListExecutions returns the fills of a trade in time order with the P/L each exit fill realized
+func (s *Service) ListExecutions(ctx context.Context, tradeID int64, userID int64) ([]*ExecutionDTO, error) {
+	t, err := s.repo.GetByID(ctx, tradeID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	spec := s.resolveInstrument(ctx, userID, t.Pair)
+	summary := summarizeExecutions(spec, t.Type, t.Executions)
+
+	dtos := make([]*ExecutionDTO, len(t.Executions))
+	for i, e := range t.Executions {
+		dtos[i] = toExecutionDTO(e, summary.FillPL[i], t.FXRate)
+	}
+	return dtos, nil
+}
+
+// AddExecution records a fill on a trade and recalculates the trade from its fills.
+// The first fill added to a trade that was journaled with a single entry and exit
+// converts that entry (and exit) into fills of their own. It fails with ErrVersionConflict
+// when the trade is no longer at version.
+func (s *Service) AddExecution(ctx context.Context, tradeID int64, userID int64, req CreateExecutionRequest, version int64) (*TradeDTO, error) {
+	t, err := s.repo.GetByID(ctx, tradeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Version != version {
+		return nil, trade.ErrVersionConflict
+	}
+	before := s.toDTO(t)
+
+	if t.Type != trade.TradeTypeBuy && t.Type != trade.TradeTypeSell {
+		return nil, ErrExecutionsNotSupported
+	}
+
+	// A fill on a pending order triggers it; cancelled and expired orders cannot be filled
+	if !t.Status.CanTransitionTo(trade.TradeStatusOpen) {
+		return nil, fmt.Errorf("%w from %s to %s", trade.ErrInvalidStatusTransition, t.Status, trade.TradeStatusOpen)
+	}
+
+	execution := trade.Execution{
+		TradeID: t.ID,
+		Side:    trade.TradeType(req.Side),
+		Price:   req.Price,
+		Lots:    req.Lots,
+	}
+	fieldErrors := execution.Validate()
+
+	execution.ExecutedAt, err = time.Parse(time.RFC3339, req.ExecutedAt)
+	if err != nil {
+		fieldErrors = append(fieldErrors, trade.FieldError{Field: "executed_at", Code: trade.CodeInvalidFormat, Message: "executed_at must be an RFC 3339 timestamp"})
+	}
+
+	if err := trade.NewValidationError(fieldErrors); err != nil {
+		return nil, err
+	}
+
+	// The entry of a pending order is only its order price, so it does not become a fill
+	var pending []trade.Execution
+	if len(t.Executions) == 0 && t.Status != trade.TradeStatusPending {
+		pending = openingExecutions(t)
+	}
+	pending = append(pending, execution)
+
+	executions := sortExecutions(append(append([]trade.Execution{}, t.Executions...), pending...))
+	if err := validateExecutions(t.Type, executions); err != nil {
+		return nil, err
+	}
+
+	if t.Status == trade.TradeStatusPending {
+		t.Status = trade.TradeStatusOpen
+	}
+
+	return s.recalculateFromExecutions(ctx, userID, t, executions, before, func(trades trade.Repository) error {
+		for _, e := range pending {
+			if _, err := trades.AddExecution(ctx, &e); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteExecution removes a fill from a trade and recalculates the trade from the remaining fills
+func (s *Service) DeleteExecution(ctx context.Context, tradeID int64, executionID int64, userID int64, version int64) (*TradeDTO, error) {
+	t, err := s.repo.GetByID(ctx, tradeID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if t.Version != version {
+		return nil, trade.ErrVersionConflict
+	}
+	before := s.toDTO(t)
+
+	var remaining []trade.Execution
+	found := false
+	for _, e := range t.Executions {
+		if e.ID == executionID {
+			found = true
+			continue
+		}
+		remaining = append(remaining, e)
+	}
+	if !found {
+		return nil, trade.ErrExecutionNotFound
+	}
+
+	if err := validateExecutions(t.Type, remaining); err != nil {
+		return nil, err
+	}
+
+	return s.recalculateFromExecutions(ctx, userID, t, remaining, before, func(trades trade.Repository) error {
+		return trades.DeleteExecution(ctx, executionID, tradeID)
+	})
+}
+
+// recalculateFromExecutions derives the trade's metrics from the given fills, then stores the
+// fill change made by write, the trade and the change in realized P/L to the account balance in
+// one transaction. A trade that cannot be recalculated stores nothing. before is the trade as it
+// was prior to the change and is kept in the audit log.
+func (s *Service) recalculateFromExecutions(ctx context.Context, userID int64, t *trade.Trade, executions []trade.Execution, before *TradeDTO, write func(trades trade.Repository) error) (*TradeDTO, error) {
+	oldPL := float64(0)
+	if t.SettledPL() != nil {
+		oldPL = *t.SettledPL()
+	}
+
+	loc, err := s.location(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	t.Executions = executions
+	if err := s.calculateMetrics(ctx, userID, t, nil, loc); err != nil {
+		return nil, err
+	}
+
+	newPL := float64(0)
+	if t.SettledPL() != nil {
+		newPL = *t.SettledPL()
+	}
+
+	var updated *trade.Trade
+	err = s.transactor.WithinTx(ctx, func(trades trade.Repository, accounts account.Repository) error {
+		if err := write(trades); err != nil {
+			return err
+		}
+
+		var err error
+		updated, err = trades.Update(ctx, t)
+		if err != nil {
+			return err
+		}
+
+		if t.AccountID != nil && newPL != oldPL {
+			if _, err := accounts.UpdateBalance(ctx, *t.AccountID, userID, newPL-oldPL); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	dto := s.toDTO(updated)
+	s.record(ctx, userID, t.ID, audit.ActionUpdate, before, dto)
+
+	return dto, nil
+}
+
+// openingExecutions converts the entry and exit of a trade without fills into fills
+func openingExecutions(t *trade.Trade) []trade.Execution {
+	executions := []trade.Execution{{
+		TradeID:    t.ID,
+		Side:       t.Type,
+		Price:      t.Entry,
+		Lots:       t.Lots,
+		ExecutedAt: t.OpenedAt,
+	}}
+
+	if t.Exit != nil {
+		closingSide := trade.TradeTypeSell
+		if t.Type == trade.TradeTypeSell {
+			closingSide = trade.TradeTypeBuy
+		}
+		closedAt := t.OpenedAt
+		if t.ClosedAt != nil {
+			closedAt = *t.ClosedAt
+		}
+		executions = append(executions, trade.Execution{
+			TradeID:    t.ID,
+			Side:       closingSide,
+			Price:      *t.Exit,
+			Lots:       t.Lots,
+			ExecutedAt: closedAt,
+		})
+	}
+
+	return executions
+}
+
+// sortExecutions orders fills by execution time, keeping the insertion order of simultaneous fills
+func sortExecutions(executions []trade.Execution) []trade.Execution {
+	sort.SliceStable(executions, func(i, j int) bool {
+		return executions[i].ExecutedAt.Before(executions[j].ExecutedAt)
+	})
+	return executions
+}
+
+// validateExecutions checks that the fills open a position before closing it
+// and never close more lots than are open at that moment
+func validateExecutions(tradeType trade.TradeType, executions []trade.Execution) error {
+	var entryLots, position float64
+	for _, e := range executions {
+		if e.IsEntry(tradeType) {
+			entryLots += e.Lots
+			position += e.Lots
+			continue
+		}
+
+		position -= e.Lots
+		if math.Round(position*100) < 0 {
+			return ErrExecutionExceedsPosition
+		}
+	}
+
+	if entryLots == 0 {
+		return ErrExecutionWithoutEntry
+	}
+	return nil
+}
+
+func toExecutionDTO(e trade.Execution, pl *float64, fxRate *float64) *ExecutionDTO {
+	// Convert the fill's P/L into the account currency at the trade's rate
+	if pl != nil && fxRate != nil {
+		converted := math.Round(*pl**fxRate*100) / 100
+		pl = &converted
+	}
+
+	return &ExecutionDTO{
+		ID:         e.ID,
+		Side:       string(e.Side),
+		Price:      e.Price,
+		Lots:       e.Lots,
+		ExecutedAt: e.ExecutedAt,
+		PL:         pl,
+		CreatedAt:  e.CreatedAt,
+	}
 }
@@ -2,11 +2,19 @@ package trade
 
 import (
 	"context"
+	"errors"
+	"slices"
+	"strconv"
+	"strings"
 	"testing"
 	"time"
 
 	accountApp "github.com/raihanstark/trade-journal/internal/application/account"
+	fxApp "github.com/raihanstark/trade-journal/internal/application/fx"
 	strategyApp "github.com/raihanstark/trade-journal/internal/application/strategy"
+	"github.com/raihanstark/trade-journal/internal/domain/mistake"
+	"github.com/raihanstark/trade-journal/internal/domain/rating"
+	tradedom "github.com/raihanstark/trade-journal/internal/domain/trade"
 	"github.com/raihanstark/trade-journal/internal/domain/user"
 	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
 	"github.com/raihanstark/trade-journal/internal/testutil"
@@ -22,12 +30,15 @@ func TestTradeService_CreateTrade_Deposit_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -91,12 +102,15 @@ func TestTradeService_CreateTrade_Withdraw_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -158,12 +172,15 @@ func TestTradeService_CreateTrade_ClosedTrade_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -305,8 +322,8 @@ func TestTradeService_CreateTrade_ClosedTrade_Integration(t *testing.T) {
 
 		// Create closed BUY trade (50 pips loss * 1 lot = $500 loss)
 		exit := 1.0950
-		stopLoss := 1.1020
-		takeProfit := 1.0980
+		stopLoss := 1.0940
+		takeProfit := 1.1060
 		tradeReq := CreateTradeRequest{
 			AccountID:  &account.ID,
 			Date:       time.Now().Format("2006-01-02"),
@@ -348,12 +365,15 @@ func TestTradeService_UpdateTrade_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -416,7 +436,7 @@ func TestTradeService_UpdateTrade_Integration(t *testing.T) {
 			TakeProfit: &takeProfit,
 		}
 
-		_, err := tradeService.UpdateTrade(ctx, trade.ID, createdUser.ID, updateReq)
+		_, err := tradeService.UpdateTrade(ctx, trade.ID, createdUser.ID, updateReq, trade.Version)
 		if err != nil {
 			t.Fatalf("failed to update trade: %v", err)
 		}
@@ -499,7 +519,7 @@ func TestTradeService_UpdateTrade_Integration(t *testing.T) {
 			TakeProfit: &takeProfit,
 		}
 
-		_, err := tradeService.UpdateTrade(ctx, trade.ID, createdUser.ID, updateReq)
+		_, err := tradeService.UpdateTrade(ctx, trade.ID, createdUser.ID, updateReq, trade.Version)
 		if err != nil {
 			t.Fatalf("failed to update trade: %v", err)
 		}
@@ -526,12 +546,15 @@ func TestTradeService_DeleteTrade_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -580,7 +603,7 @@ func TestTradeService_DeleteTrade_Integration(t *testing.T) {
 		}
 
 		// Delete the trade
-		err := tradeService.DeleteTrade(ctx, trade.ID, createdUser.ID)
+		err := tradeService.DeleteTrade(ctx, trade.ID, createdUser.ID, trade.Version)
 		if err != nil {
 			t.Fatalf("failed to delete trade: %v", err)
 		}
@@ -607,14 +630,17 @@ func TestTradeService_WithStrategies_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
-	strategyService := strategyApp.NewService(strategyRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+	strategyService := strategyApp.NewService(strategyRepo, auditRepo)
 
 	ctx := context.Background()
 
@@ -688,12 +714,15 @@ func TestTradeService_GetTradesByAccountID_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	exit := 1.1050
 	stopLoss := 1.0980
@@ -764,10 +793,11 @@ func TestTradeService_GetTradesByAccountID_Integration(t *testing.T) {
 		}
 
 		// Get trades by account ID
-		trades, err := tradeService.GetTradesByAccountID(ctx, account.ID, createdUser.ID)
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{AccountID: strconv.FormatInt(account.ID, 10)})
 		if err != nil {
 			t.Fatalf("failed to get trades by account ID: %v", err)
 		}
+		trades := page.Trades
 
 		// verify that only get one trade for account1
 		if len(trades) != 1 {
@@ -791,12 +821,15 @@ func TestTradeService_DateFiltering_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -898,10 +931,11 @@ func TestTradeService_DateFiltering_Integration(t *testing.T) {
 		// Test with date filter
 		startDate := "2025-01-15"
 		endDate := "2025-01-16"
-		trades, err := tradeService.GetUserTradesWithDateFilter(ctx, createdUser.ID, &startDate, &endDate)
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{StartDate: startDate, EndDate: endDate})
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
+		trades := page.Trades
 
 		// Should only return 2 trades (dates 2025-01-15 and 2025-01-16)
 		if len(trades) != 2 {
@@ -916,13 +950,70 @@ func TestTradeService_DateFiltering_Integration(t *testing.T) {
 		}
 
 		// Test without date filter (should return all 4 trades)
-		allTrades, err := tradeService.GetUserTradesWithDateFilter(ctx, createdUser.ID, nil, nil)
+		all, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(all.Trades) != 4 {
+			t.Fatalf("expected 4 trades, got %d", len(all.Trades))
+		}
+	})
+
+	t.Run("should interpret the date range in the user's timezone", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("timezone@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := userRepo.UpdateTimezone(ctx, createdUser.ID, "America/New_York"); err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:        "Test Account",
+			AccountType: "demo",
+			Currency:    "USD",
+			IsActive:    true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// 22:00 in New York on 2025-01-15 is already 2025-01-16 in UTC
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "22:00",
+			Pair:      "EURUSD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      0.1,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if want := time.Date(2025, 1, 16, 3, 0, 0, 0, time.UTC); !created.OpenedAt.Equal(want) {
+			t.Errorf("expected opened_at %v, got %v", want, created.OpenedAt.UTC())
+		}
+
+		day := "2025-01-15"
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{StartDate: day, EndDate: day})
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
+		if len(page.Trades) != 1 {
+			t.Fatalf("expected the trade on the user's 2025-01-15, got %d trades", len(page.Trades))
+		}
 
-		if len(allTrades) != 4 {
-			t.Fatalf("expected 4 trades, got %d", len(allTrades))
+		nextDay := "2025-01-16"
+		page, err = tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{StartDate: nextDay, EndDate: nextDay})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(page.Trades) != 0 {
+			t.Fatalf("expected no trades on the user's 2025-01-16, got %d", len(page.Trades))
 		}
 	})
 
@@ -1037,10 +1128,15 @@ func TestTradeService_DateFiltering_Integration(t *testing.T) {
 		// Test with date filter for account 1
 		startDate := "2025-01-15"
 		endDate := "2025-01-16"
-		trades, err := tradeService.GetTradesByAccountIDWithDateFilter(ctx, account1.ID, createdUser.ID, &startDate, &endDate)
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{
+			AccountID: strconv.FormatInt(account1.ID, 10),
+			StartDate: startDate,
+			EndDate:   endDate,
+		})
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
+		trades := page.Trades
 
 		// Should only return 2 trades from account 1 (dates 2025-01-15 and 2025-01-16)
 		if len(trades) != 2 {
@@ -1058,13 +1154,13 @@ func TestTradeService_DateFiltering_Integration(t *testing.T) {
 		}
 
 		// Test without date filter (should return all 3 trades from account 1)
-		allTrades, err := tradeService.GetTradesByAccountIDWithDateFilter(ctx, account1.ID, createdUser.ID, nil, nil)
+		all, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{AccountID: strconv.FormatInt(account1.ID, 10)})
 		if err != nil {
 			t.Fatalf("expected no error, got %v", err)
 		}
 
-		if len(allTrades) != 3 {
-			t.Fatalf("expected 3 trades, got %d", len(allTrades))
+		if len(all.Trades) != 3 {
+			t.Fatalf("expected 3 trades, got %d", len(all.Trades))
 		}
 	})
 }
@@ -1075,12 +1171,15 @@ func TestTradeService_UpdateChart_Integration(t *testing.T) {
 	}
 
 	pg := testutil.SetupTestDatabase(t)
-	tradeRepo := persistence.NewTradeRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
 	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
 	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
 
-	tradeService := NewService(tradeRepo, accountRepo)
-	accountService := accountApp.NewService(accountRepo)
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
 
 	ctx := context.Background()
 
@@ -1286,3 +1385,1598 @@ func TestTradeService_UpdateChart_Integration(t *testing.T) {
 		}
 	})
 }
+
+func TestTradeService_CreateTrade_FXConversion_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+	fxService := fxApp.NewService(fxRepo)
+
+	ctx := context.Background()
+
+	t.Run("converts P/L into the account currency at the rate for the close date", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("fx@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "EUR Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "EUR",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		// The rate on or before the close date applies, not the later one
+		csv := "date,base_currency,quote_currency,rate\n" +
+			"2025-01-10,EUR,USD,1.2500\n" +
+			"2025-01-20,EUR,USD,1.0000\n"
+		if _, err := fxService.ImportCSV(ctx, createdUser.ID, strings.NewReader(csv)); err != nil {
+			t.Fatalf("failed to import rates: %v", err)
+		}
+
+		exit := 1.1050
+		trade, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			CloseDate: "2025-01-16",
+			CloseTime: "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		// 500 USD / 1.25 = 400 EUR
+		if trade.PL == nil || *trade.PL != 400 {
+			t.Errorf("expected P/L 400, got %v", trade.PL)
+		}
+		if trade.FXRate == nil || *trade.FXRate != 0.8 {
+			t.Errorf("expected fx rate 0.8, got %v", trade.FXRate)
+		}
+
+		var balance float64
+		err = pg.DB.QueryRow("SELECT current_balance FROM accounts WHERE id = $1", account.ID).Scan(&balance)
+		if err != nil {
+			t.Fatalf("failed to query balance: %v", err)
+		}
+		if balance != 400 {
+			t.Errorf("expected balance 400, got %.2f", balance)
+		}
+	})
+}
+
+func TestTradeService_Executions_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("scale out in two fills", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("executions@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+		})
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+
+		// Close half at 1R
+		partial, err := tradeService.AddExecution(ctx, created.ID, createdUser.ID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1050,
+			Lots:       0.5,
+			ExecutedAt: "2025-01-15T12:00:00Z",
+		}, created.Version)
+		if err != nil {
+			t.Fatalf("failed to add execution: %v", err)
+		}
+		if partial.Status != "open" || partial.OpenLots != 0.5 {
+			t.Errorf("expected open trade with 0.5 lots left, got %s with %.2f", partial.Status, partial.OpenLots)
+		}
+
+		// Trail the rest
+		closed, err := tradeService.AddExecution(ctx, created.ID, createdUser.ID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1100,
+			Lots:       0.5,
+			ExecutedAt: "2025-01-15T16:00:00Z",
+		}, partial.Version)
+		if err != nil {
+			t.Fatalf("failed to add execution: %v", err)
+		}
+		if closed.Status != "closed" || closed.OpenLots != 0 {
+			t.Errorf("expected closed trade, got %s with %.2f open lots", closed.Status, closed.OpenLots)
+		}
+		if closed.PL == nil || *closed.PL != 750 {
+			t.Errorf("expected P/L 750, got %v", closed.PL)
+		}
+		if closed.CloseDate == nil || *closed.CloseDate != "2025-01-15" || closed.CloseTime == nil || *closed.CloseTime != "16:00" {
+			t.Errorf("expected the trade to close at the last fill, got %v %v", closed.CloseDate, closed.CloseTime)
+		}
+
+		executions, err := tradeService.ListExecutions(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("failed to list executions: %v", err)
+		}
+		if len(executions) != 3 {
+			t.Fatalf("expected 3 executions, got %d", len(executions))
+		}
+		if executions[0].PL != nil {
+			t.Errorf("expected no P/L on the entry fill, got %v", *executions[0].PL)
+		}
+		if executions[1].PL == nil || *executions[1].PL != 250 || executions[2].PL == nil || *executions[2].PL != 500 {
+			t.Errorf("expected fill P/L 250 and 500, got %v and %v", executions[1].PL, executions[2].PL)
+		}
+
+		var balance float64
+		err = pg.DB.QueryRow("SELECT current_balance FROM accounts WHERE id = $1", account.ID).Scan(&balance)
+		if err != nil {
+			t.Fatalf("failed to query balance: %v", err)
+		}
+		if balance != 750 {
+			t.Errorf("expected balance 750, got %.2f", balance)
+		}
+
+		// Removing the last fill reopens the remainder
+		reopened, err := tradeService.DeleteExecution(ctx, created.ID, executions[2].ID, createdUser.ID, closed.Version)
+		if err != nil {
+			t.Fatalf("failed to delete execution: %v", err)
+		}
+		if reopened.Status != "open" || reopened.PL == nil || *reopened.PL != 250 {
+			t.Errorf("expected open trade with P/L 250, got %s with %v", reopened.Status, reopened.PL)
+		}
+		if reopened.ClosedAt != nil {
+			t.Errorf("expected no close on a reopened trade, got %v", reopened.ClosedAt)
+		}
+	})
+}
+
+func TestTradeService_OrderLifecycle_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("pending limit order is triggered and closed", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("orders@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		orderReq := CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Status:    "pending",
+			OrderType: "limit",
+			Entry:     1.1000,
+			Lots:      1.0,
+		}
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, orderReq)
+		if err != nil {
+			t.Fatalf("failed to create order: %v", err)
+		}
+		if created.Status != "pending" || created.OrderType != "limit" || created.PL != nil {
+			t.Fatalf("expected a pending limit order without P/L, got %s %s", created.Status, created.OrderType)
+		}
+
+		exit := 1.1050
+		filled, err := tradeService.UpdateTrade(ctx, created.ID, createdUser.ID, UpdateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Status:    "open",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+			CloseDate: "2025-01-15",
+			CloseTime: "16:00",
+		}, created.Version)
+		if err != nil {
+			t.Fatalf("failed to trigger order: %v", err)
+		}
+		if filled.Status != "closed" || filled.OrderType != "limit" || filled.PL == nil || *filled.PL != 500 {
+			t.Errorf("expected a closed limit order with P/L 500, got %s %s %v", filled.Status, filled.OrderType, filled.PL)
+		}
+
+		// A second order is cancelled and stays out of the balance
+		cancelled, err := tradeService.CreateTrade(ctx, createdUser.ID, orderReq)
+		if err != nil {
+			t.Fatalf("failed to create order: %v", err)
+		}
+		_, err = tradeService.UpdateTrade(ctx, cancelled.ID, createdUser.ID, UpdateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Status:    "cancelled",
+			Entry:     1.1000,
+			Lots:      1.0,
+		}, cancelled.Version)
+		if err != nil {
+			t.Fatalf("failed to cancel order: %v", err)
+		}
+
+		stored, err := tradeService.GetTrade(ctx, cancelled.ID, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != "cancelled" {
+			t.Errorf("expected a cancelled order, got %s", stored.Status)
+		}
+
+		var balance float64
+		err = pg.DB.QueryRow("SELECT current_balance FROM accounts WHERE id = $1", account.ID).Scan(&balance)
+		if err != nil {
+			t.Fatalf("failed to query balance: %v", err)
+		}
+		if balance != 500 {
+			t.Errorf("expected balance 500, got %.2f", balance)
+		}
+	})
+}
+
+func TestTradeService_Tags_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("tags are shared between trades and replaced on update", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("tags@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tradeReq := CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Tags:      []string{"Breakout", "london"},
+		}
+		first, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+		if strings.Join(first.Tags, ",") != "breakout,london" {
+			t.Errorf("expected tags breakout,london, got %v", first.Tags)
+		}
+
+		tradeReq.Tags = []string{"breakout"}
+		second, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+
+		tags, err := tradeService.ListTags(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("failed to list tags: %v", err)
+		}
+		if len(tags) != 2 {
+			t.Errorf("expected the breakout tag to be shared, got %d tags", len(tags))
+		}
+
+		updated, err := tradeService.UpdateTrade(ctx, second.ID, createdUser.ID, UpdateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Tags:      []string{"news"},
+		}, second.Version)
+		if err != nil {
+			t.Fatalf("failed to update trade: %v", err)
+		}
+		if strings.Join(updated.Tags, ",") != "news" {
+			t.Errorf("expected tags to be replaced with news, got %v", updated.Tags)
+		}
+
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{Tags: []string{"breakout"}})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Trades) != 1 || page.Trades[0].ID != first.ID {
+			t.Errorf("expected only the first trade to be tagged breakout, got %d trades", len(page.Trades))
+		}
+	})
+}
+
+func TestTradeService_Mistakes_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	mistakeRepo := persistence.NewMistakeRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("links catalogued mistakes and keeps the free-text note", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("mistakes@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherUser, err := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		movedStop, err := mistakeRepo.Create(ctx, &mistake.MistakeType{UserID: createdUser.ID, Name: "moved stop", Severity: mistake.SeverityHigh})
+		if err != nil {
+			t.Fatal(err)
+		}
+		foreign, err := mistakeRepo.Create(ctx, &mistake.MistakeType{UserID: otherUser.ID, Name: "fomo", Severity: mistake.SeverityLow})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		exit := 1.0950
+		tradeReq := CreateTradeRequest{
+			AccountID:      &account.ID,
+			Date:           "2025-01-15",
+			Time:           "10:00",
+			Pair:           "EUR/USD",
+			Type:           "BUY",
+			Entry:          1.1000,
+			Exit:           &exit,
+			Lots:           1.0,
+			Mistakes:       "Dragged the stop twice",
+			MistakeTypeIDs: []int64{movedStop.ID},
+		}
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+		if len(created.MistakeTypes) != 1 || created.MistakeTypes[0].Name != "moved stop" || created.MistakeTypes[0].Severity != "high" {
+			t.Errorf("expected the moved stop mistake type, got %+v", created.MistakeTypes)
+		}
+		if created.Mistakes != "Dragged the stop twice" {
+			t.Errorf("expected the free-text note to be kept, got %q", created.Mistakes)
+		}
+
+		tradeReq.MistakeTypeIDs = []int64{foreign.ID}
+		_, err = tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if !errors.Is(err, tradedom.ErrMistakeTypeNotFound) {
+			t.Errorf("expected ErrMistakeTypeNotFound for another user's mistake type, got %v", err)
+		}
+
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(page.Trades) != 1 {
+			t.Errorf("expected the rejected trade not to be stored, got %d trades", len(page.Trades))
+		}
+	})
+}
+
+func TestTradeService_Ratings_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	ratingRepo := persistence.NewRatingRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("stores ratings per phase and replaces them on update", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("ratings@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		otherUser, err := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		confidence, err := ratingRepo.Create(ctx, &rating.Dimension{UserID: createdUser.ID, Name: "confidence"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		foreign, err := ratingRepo.Create(ctx, &rating.Dimension{UserID: otherUser.ID, Name: "fear"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tradeReq := CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Ratings: []RatingRequest{
+				{DimensionID: confidence.ID, Phase: "before", Value: 4},
+				{DimensionID: confidence.ID, Phase: "after", Value: 2},
+			},
+		}
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+		if len(created.Ratings) != 2 || created.Ratings[0].Phase != "before" || created.Ratings[0].Dimension != "confidence" {
+			t.Errorf("expected before and after confidence ratings, got %+v", created.Ratings)
+		}
+
+		updated, err := tradeService.UpdateTrade(ctx, created.ID, createdUser.ID, UpdateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Ratings:   []RatingRequest{{DimensionID: confidence.ID, Phase: "before", Value: 5}},
+		}, created.Version)
+		if err != nil {
+			t.Fatalf("failed to update trade: %v", err)
+		}
+		if len(updated.Ratings) != 1 || updated.Ratings[0].Value != 5 {
+			t.Errorf("expected ratings to be replaced, got %+v", updated.Ratings)
+		}
+
+		tradeReq.Ratings = []RatingRequest{{DimensionID: foreign.ID, Phase: "before", Value: 3}}
+		_, err = tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if !errors.Is(err, tradedom.ErrRatingDimensionNotFound) {
+			t.Errorf("expected ErrRatingDimensionNotFound for another user's dimension, got %v", err)
+		}
+	})
+}
+
+func TestTradeService_StrategyRules_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+	strategyService := strategyApp.NewService(strategyRepo, auditRepo)
+
+	ctx := context.Background()
+
+	t.Run("records satisfied rules and reports compliance per strategy", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("rules@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		breakout, err := strategyService.CreateStrategy(ctx, createdUser.ID, strategyApp.CreateStrategyRequest{
+			Name: "Breakout",
+			Rules: []strategyApp.RuleRequest{
+				{Description: "Wait for the candle to close"},
+				{Description: "Risk at most 1%"},
+			},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		other, err := strategyService.CreateStrategy(ctx, createdUser.ID, strategyApp.CreateStrategyRequest{
+			Name:  "Reversal",
+			Rules: []strategyApp.RuleRequest{{Description: "Divergence on RSI"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tradeReq := CreateTradeRequest{
+			AccountID:        &account.ID,
+			Date:             "2025-01-15",
+			Time:             "10:00",
+			Pair:             "EUR/USD",
+			Type:             "BUY",
+			Entry:            1.1000,
+			Lots:             1.0,
+			StrategyIDs:      []int64{breakout.ID},
+			SatisfiedRuleIDs: []int64{breakout.Rules[0].ID},
+		}
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+		if len(created.Strategies) != 1 || len(created.Strategies[0].Rules) != 2 {
+			t.Fatalf("expected the breakout strategy with 2 rules, got %+v", created.Strategies)
+		}
+		if !created.Strategies[0].Rules[0].Satisfied || created.Strategies[0].Rules[1].Satisfied {
+			t.Errorf("expected only the first rule to be satisfied, got %+v", created.Strategies[0].Rules)
+		}
+		if created.Strategies[0].Compliant {
+			t.Error("expected a trade that broke a rule not to be compliant")
+		}
+
+		updated, err := tradeService.UpdateTrade(ctx, created.ID, createdUser.ID, UpdateTradeRequest{
+			AccountID:        &account.ID,
+			Date:             "2025-01-15",
+			Time:             "10:00",
+			Pair:             "EUR/USD",
+			Type:             "BUY",
+			Entry:            1.1000,
+			Lots:             1.0,
+			StrategyIDs:      []int64{breakout.ID},
+			SatisfiedRuleIDs: []int64{breakout.Rules[0].ID, breakout.Rules[1].ID},
+		}, created.Version)
+		if err != nil {
+			t.Fatalf("failed to update trade: %v", err)
+		}
+		if !updated.Strategies[0].Compliant {
+			t.Errorf("expected the trade to be compliant after update, got %+v", updated.Strategies[0].Rules)
+		}
+
+		// A rule of a strategy the trade is not linked to
+		tradeReq.SatisfiedRuleIDs = []int64{other.Rules[0].ID}
+		_, err = tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if !errors.Is(err, tradedom.ErrStrategyRuleNotFound) {
+			t.Errorf("expected ErrStrategyRuleNotFound for a rule of an unlinked strategy, got %v", err)
+		}
+	})
+
+	t.Run("rules added to a strategy later do not count against earlier trades", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("playbook@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		breakout, err := strategyService.CreateStrategy(ctx, createdUser.ID, strategyApp.CreateStrategyRequest{
+			Name:  "Breakout",
+			Rules: []strategyApp.RuleRequest{{Description: "Wait for the candle to close"}},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:        "USD Account",
+			AccountType: "demo",
+			Currency:    "USD",
+			IsActive:    true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID:        &account.ID,
+			Date:             "2025-01-15",
+			Time:             "10:00",
+			Pair:             "EUR/USD",
+			Type:             "BUY",
+			Entry:            1.1000,
+			Lots:             1.0,
+			StrategyIDs:      []int64{breakout.ID},
+			SatisfiedRuleIDs: []int64{breakout.Rules[0].ID},
+		})
+		if err != nil {
+			t.Fatalf("failed to create trade: %v", err)
+		}
+
+		_, err = strategyService.UpdateStrategy(ctx, breakout.ID, createdUser.ID, strategyApp.UpdateStrategyRequest{
+			Name: "Breakout",
+			Rules: []strategyApp.RuleRequest{
+				{ID: breakout.Rules[0].ID, Description: "Wait for the candle to close"},
+				{Description: "Trade with the trend"},
+			},
+		}, breakout.Version)
+		if err != nil {
+			t.Fatalf("failed to update strategy: %v", err)
+		}
+
+		// Editing the trade keeps the rule set it was checked against
+		patched, err := tradeService.PatchTrade(ctx, created.ID, createdUser.ID, []byte(`{"notes": "clean entry"}`), created.Version)
+		if err != nil {
+			t.Fatalf("failed to patch trade: %v", err)
+		}
+		if len(patched.Strategies[0].Rules) != 1 || !patched.Strategies[0].Compliant {
+			t.Errorf("expected the trade to stay compliant with its one rule, got %+v", patched.Strategies[0].Rules)
+		}
+
+		adherence, err := pg.Queries.GetTradeRuleAdherenceByUserID(ctx, int32(createdUser.ID))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(adherence) != 1 || adherence[0].Rules != 1 || adherence[0].SatisfiedRules != 1 {
+			t.Errorf("expected 1 of 1 rules satisfied, got %+v", adherence)
+		}
+	})
+}
+
+func TestTradeService_Grades_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	gradeRepo := persistence.NewGradeRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("accepts grades on the user's scale only", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("grades@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		tradeReq := CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Grade:     "A+",
+		}
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if err != nil {
+			t.Fatalf("expected the default scale to accept A+, got %v", err)
+		}
+		if created.Grade != "A+" {
+			t.Errorf("expected grade 'A+', got %q", created.Grade)
+		}
+
+		if _, err := gradeRepo.ReplaceScale(ctx, createdUser.ID, []string{"1", "2", "3"}); err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tradeService.CreateTrade(ctx, createdUser.ID, tradeReq)
+		if !errors.Is(err, tradedom.ErrGradeNotFound) {
+			t.Errorf("expected ErrGradeNotFound for a grade off the scale, got %v", err)
+		}
+
+		tradeReq.Grade = "1"
+		if _, err := tradeService.CreateTrade(ctx, createdUser.ID, tradeReq); err != nil {
+			t.Errorf("expected a grade on the custom scale to be accepted, got %v", err)
+		}
+	})
+}
+
+func TestTradeService_History_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+
+	t.Run("records create, update and delete with field diffs", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("history@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+			Name:          "USD Account",
+			Broker:        "Test Broker",
+			AccountNumber: "123",
+			AccountType:   "demo",
+			Currency:      "USD",
+			IsActive:      true,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		stopLoss := 1.0950
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			StopLoss:  &stopLoss,
+			Notes:     "clean breakout",
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		movedStop := 1.0980
+		updated, err := tradeService.UpdateTrade(ctx, created.ID, createdUser.ID, UpdateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			StopLoss:  &movedStop,
+			Notes:     "clean breakout",
+		}, created.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if err := tradeService.DeleteTrade(ctx, created.ID, createdUser.ID, updated.Version); err != nil {
+			t.Fatal(err)
+		}
+
+		history, err := tradeService.GetTradeHistory(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if len(history) != 3 {
+			t.Fatalf("expected 3 events, got %d", len(history))
+		}
+		for i, action := range []string{"create", "update", "delete"} {
+			if history[i].Action != action {
+				t.Errorf("expected event %d to be %s, got %s", i, action, history[i].Action)
+			}
+			if history[i].ActorID != createdUser.ID {
+				t.Errorf("expected actor %d, got %d", createdUser.ID, history[i].ActorID)
+			}
+		}
+
+		if change := history[0].Changes["notes"]; change.From != nil || change.To != "clean breakout" {
+			t.Errorf("expected create to record notes, got %v", change)
+		}
+
+		update := history[1].Changes
+		if len(update) != 1 {
+			t.Errorf("expected only stop_loss to change, got %v", update)
+		}
+		if change := update["stop_loss"]; change.From != stopLoss || change.To != movedStop {
+			t.Errorf("expected stop_loss %.4f -> %.4f, got %v", stopLoss, movedStop, change)
+		}
+	})
+
+	t.Run("history is scoped to the user", func(t *testing.T) {
+		other, err := userRepo.Create(ctx, user.NewUser("history-other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		history, err := tradeService.GetTradeHistory(ctx, 1, other.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(history) != 0 {
+			t.Errorf("expected no events for another user, got %d", len(history))
+		}
+	})
+}
+
+func TestTradeService_VersionConflict_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+	testutil.TruncateTables(t, pg.DB)
+
+	createdUser, err := userRepo.Create(ctx, user.NewUser("versions@example.com", "hashedpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+		Name:          "USD Account",
+		Broker:        "Test Broker",
+		AccountNumber: "123",
+		AccountType:   "demo",
+		Currency:      "USD",
+		IsActive:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("stale writes are rejected and leave the balance alone", func(t *testing.T) {
+		exit := 1.1050
+		req := UpdateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+		}
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: req.AccountID,
+			Date:      req.Date,
+			Time:      req.Time,
+			Pair:      req.Pair,
+			Type:      req.Type,
+			Entry:     req.Entry,
+			Exit:      req.Exit,
+			Lots:      req.Lots,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if created.Version != 1 {
+			t.Fatalf("expected new trade at version 1, got %d", created.Version)
+		}
+
+		// Both tabs read version 1; the first save wins and moves the trade to version 2
+		betterExit := 1.1100
+		req.Exit = &betterExit
+		updated, err := tradeService.UpdateTrade(ctx, created.ID, createdUser.ID, req, created.Version)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Version != 2 {
+			t.Errorf("expected version 2 after update, got %d", updated.Version)
+		}
+
+		before, err := accountService.GetAccount(ctx, account.ID, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		worseExit := 1.0900
+		req.Exit = &worseExit
+		_, err = tradeService.UpdateTrade(ctx, created.ID, createdUser.ID, req, created.Version)
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
+		}
+
+		if err := tradeService.DeleteTrade(ctx, created.ID, createdUser.ID, created.Version); !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
+		}
+
+		after, err := accountService.GetAccount(ctx, account.ID, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if after.CurrentBalance != before.CurrentBalance {
+			t.Errorf("expected balance to stay %.2f, got %.2f", before.CurrentBalance, after.CurrentBalance)
+		}
+
+		if err := tradeService.DeleteTrade(ctx, created.ID, createdUser.ID, updated.Version); err != nil {
+			t.Fatal(err)
+		}
+	})
+
+	t.Run("repository rejects a write that lost the race", func(t *testing.T) {
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first, err := tradeRepo.GetByID(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		second, err := tradeRepo.GetByID(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		first.Notes = "first"
+		if _, err := tradeRepo.Update(ctx, first); err != nil {
+			t.Fatal(err)
+		}
+
+		second.Notes = "second"
+		if _, err := tradeRepo.Update(ctx, second); !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Errorf("expected ErrVersionConflict, got %v", err)
+		}
+	})
+}
+
+func TestTradeService_BulkTrades_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+
+	ctx := context.Background()
+	testutil.TruncateTables(t, pg.DB)
+
+	createdUser, err := userRepo.Create(ctx, user.NewUser("bulk@example.com", "hashedpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+		Name:          "USD Account",
+		Broker:        "Test Broker",
+		AccountNumber: "123",
+		AccountType:   "demo",
+		Currency:      "USD",
+		IsActive:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Two winners of $500 each
+	var tradeIDs []int64
+	for range 2 {
+		exit := 1.1050
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, CreateTradeRequest{
+			AccountID: &account.ID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		tradeIDs = append(tradeIDs, created.ID)
+	}
+
+	balance := func() float64 {
+		acc, err := accountService.GetAccount(ctx, account.ID, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return acc.CurrentBalance
+	}
+
+	t.Run("a failing trade leaves every trade and the balance untouched", func(t *testing.T) {
+		before := balance()
+
+		result, err := tradeService.BulkTrades(ctx, createdUser.ID, BulkTradeRequest{
+			Action:   BulkDelete,
+			TradeIDs: append([]int64{tradeIDs[0]}, 999999),
+		})
+		if !errors.Is(err, ErrBulkRejected) {
+			t.Fatalf("expected ErrBulkRejected, got %v", err)
+		}
+		if result.Items[1].Status != BulkStatusFailed {
+			t.Errorf("expected the missing trade to fail, got %s", result.Items[1].Status)
+		}
+
+		if _, err := tradeService.GetTrade(ctx, tradeIDs[0], createdUser.ID); err != nil {
+			t.Errorf("expected trade %d to survive the rollback: %v", tradeIDs[0], err)
+		}
+		if after := balance(); after != before {
+			t.Errorf("expected balance to stay %.2f, got %.2f", before, after)
+		}
+	})
+
+	t.Run("tags and deletes apply to every trade", func(t *testing.T) {
+		before := balance()
+
+		if _, err := tradeService.BulkTrades(ctx, createdUser.ID, BulkTradeRequest{
+			Action:   BulkAddTag,
+			TradeIDs: tradeIDs,
+			Tag:      "cleanup",
+		}); err != nil {
+			t.Fatal(err)
+		}
+		for _, id := range tradeIDs {
+			tagged, err := tradeService.GetTrade(ctx, id, createdUser.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(tagged.Tags) != 1 || tagged.Tags[0] != "cleanup" {
+				t.Errorf("expected trade %d to be tagged cleanup, got %v", id, tagged.Tags)
+			}
+		}
+
+		result, err := tradeService.BulkTrades(ctx, createdUser.ID, BulkTradeRequest{
+			Action:   BulkDelete,
+			TradeIDs: tradeIDs,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(result.BalanceChanges) != 1 || result.BalanceChanges[0].Amount != -1000 {
+			t.Errorf("expected a single balance change of -1000, got %+v", result.BalanceChanges)
+		}
+		if after := balance(); after != before-1000 {
+			t.Errorf("expected balance %.2f, got %.2f", before-1000, after)
+		}
+	})
+}
+
+func TestTradeService_SearchTrades_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+	strategyService := strategyApp.NewService(strategyRepo, auditRepo)
+
+	ctx := context.Background()
+	testutil.TruncateTables(t, pg.DB)
+
+	createdUser, err := userRepo.Create(ctx, user.NewUser("search@example.com", "hashedpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherUser, err := userRepo.Create(ctx, user.NewUser("other-search@example.com", "hashedpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+		Name:          "USD Account",
+		Broker:        "Test Broker",
+		AccountNumber: "123",
+		AccountType:   "demo",
+		Currency:      "USD",
+		IsActive:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherAccount, err := accountService.CreateAccount(ctx, otherUser.ID, accountApp.CreateAccountRequest{
+		Name:          "Other Account",
+		Broker:        "Test Broker",
+		AccountNumber: "456",
+		AccountType:   "demo",
+		Currency:      "USD",
+		IsActive:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategy, err := strategyService.CreateStrategy(ctx, createdUser.ID, strategyApp.CreateStrategyRequest{Name: "Momentum Fade"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(userID int64, accountID int64, pair, notes, mistakes string, strategyIDs []int64) *TradeDTO {
+		created, err := tradeService.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID:   &accountID,
+			Date:        "2025-01-15",
+			Time:        "10:00",
+			Pair:        pair,
+			Type:        "BUY",
+			Entry:       1.1000,
+			Lots:        1.0,
+			Notes:       notes,
+			Mistakes:    mistakes,
+			StrategyIDs: strategyIDs,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return created
+	}
+
+	nfp := create(createdUser.ID, account.ID, "EUR/USD", "Faded the NFP spike after the first candle", "", nil)
+	mention := create(createdUser.ID, account.ID, "GBP/USD", "Quiet session", "Entered before NFP without a stop", nil)
+	fade := create(createdUser.ID, account.ID, "USD/JPY", "Range day", "", []int64{strategy.ID})
+	create(otherUser.ID, otherAccount.ID, "EUR/USD", "NFP spike", "", nil)
+
+	t.Run("ranks matches in notes and mistakes with snippets", func(t *testing.T) {
+		results, err := tradeService.SearchTrades(ctx, createdUser.ID, "nfp spike", 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != 1 || results[0].Trade.ID != nfp.ID {
+			t.Fatalf("expected only the NFP spike trade, got %d results", len(results))
+		}
+		if !strings.Contains(results[0].NotesSnippet, "<mark>NFP</mark>") || !strings.Contains(results[0].NotesSnippet, "<mark>spike</mark>") {
+			t.Errorf("expected highlighted notes snippet, got %q", results[0].NotesSnippet)
+		}
+
+		results, err = tradeService.SearchTrades(ctx, createdUser.ID, "NFP", 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != 2 {
+			t.Fatalf("expected 2 results for NFP, got %d", len(results))
+		}
+		for _, result := range results {
+			if result.Trade.ID == mention.ID && !strings.Contains(result.MistakesSnippet, "<mark>NFP</mark>") {
+				t.Errorf("expected highlighted mistakes snippet, got %q", result.MistakesSnippet)
+			}
+			if result.Trade.ID == mention.ID && result.NotesSnippet != "" {
+				t.Errorf("expected no notes snippet for a trade matching on mistakes, got %q", result.NotesSnippet)
+			}
+		}
+	})
+
+	t.Run("matches pairs and strategy names", func(t *testing.T) {
+		results, err := tradeService.SearchTrades(ctx, createdUser.ID, "jpy", 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != 1 || results[0].Trade.ID != fade.ID {
+			t.Errorf("expected the USD/JPY trade, got %d results", len(results))
+		}
+
+		results, err = tradeService.SearchTrades(ctx, createdUser.ID, "momentum", 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != 1 || results[0].Trade.ID != fade.ID {
+			t.Errorf("expected the trade linked to Momentum Fade, got %d results", len(results))
+		}
+	})
+
+	t.Run("leaves out trades in the trash", func(t *testing.T) {
+		if err := tradeService.DeleteTrade(ctx, nfp.ID, createdUser.ID, nfp.Version); err != nil {
+			t.Fatal(err)
+		}
+
+		results, err := tradeService.SearchTrades(ctx, createdUser.ID, "spike", 0)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(results) != 0 {
+			t.Errorf("expected no results once the trade is in the trash, got %d", len(results))
+		}
+	})
+}
+
+func TestTradeService_ListTrades_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	accountRepo := persistence.NewAccountRepository(pg.Queries)
+	instrumentRepo := persistence.NewInstrumentRepository(pg.Queries)
+	fxRepo := persistence.NewFXRateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	auditRepo := persistence.NewAuditRepository(pg.Queries)
+	strategyRepo := persistence.NewStrategyRepository(pg.Queries)
+
+	tradeService := NewService(tradeRepo, accountRepo, instrumentRepo, fxRepo, userRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+	accountService := accountApp.NewService(accountRepo, auditRepo, persistence.NewTransactor(pg.DB, pg.Queries), tradeService)
+	strategyService := strategyApp.NewService(strategyRepo, auditRepo)
+
+	ctx := context.Background()
+	testutil.TruncateTables(t, pg.DB)
+
+	createdUser, err := userRepo.Create(ctx, user.NewUser("filters@example.com", "hashedpass"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	account, err := accountService.CreateAccount(ctx, createdUser.ID, accountApp.CreateAccountRequest{
+		Name:          "USD Account",
+		Broker:        "Test Broker",
+		AccountNumber: "123",
+		AccountType:   "demo",
+		Currency:      "USD",
+		IsActive:      true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	strategy, err := strategyService.CreateStrategy(ctx, createdUser.ID, strategyApp.CreateStrategyRequest{Name: "Breakout"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(req CreateTradeRequest) int64 {
+		req.AccountID = &account.ID
+		created, err := tradeService.CreateTrade(ctx, createdUser.ID, req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return created.ID
+	}
+	price := func(p float64) *float64 { return &p }
+
+	// Monday morning winner of $500 at 1R
+	mondayWin := create(CreateTradeRequest{Date: "2025-01-13", Time: "08:00", Pair: "EUR/USD", Type: "BUY", Entry: 1.1000, Exit: price(1.1050), Lots: 1.0,
+		StopLoss: price(1.0950), StrategyIDs: []int64{strategy.ID}, Tags: []string{"london"}})
+	// Wednesday afternoon loser of $250 at -1R
+	wednesdayLoss := create(CreateTradeRequest{Date: "2025-01-15", Time: "14:00", Pair: "GBP/USD", Type: "SELL", Entry: 1.2500, Exit: price(1.2550), Lots: 0.5,
+		StopLoss: price(1.2550), Tags: []string{"london"}})
+	// Wednesday morning trade still open
+	wednesdayOpen := create(CreateTradeRequest{Date: "2025-01-15", Time: "09:00", Pair: "EUR/USD", Type: "BUY", Entry: 1.1000, Lots: 1.0})
+	// Friday morning winner of $2000
+	fridayWin := create(CreateTradeRequest{Date: "2025-01-17", Time: "08:30", Pair: "EUR/USD", Type: "SELL", Entry: 1.1000, Exit: price(1.0900), Lots: 2.0,
+		StrategyIDs: []int64{strategy.ID}})
+
+	strategyID := strconv.FormatInt(strategy.ID, 10)
+	tests := []struct {
+		name     string
+		req      ListTradesRequest
+		expected []int64
+	}{
+		{"no filters lists most recent first", ListTradesRequest{}, []int64{fridayWin, wednesdayLoss, wednesdayOpen, mondayWin}},
+		{"pair", ListTradesRequest{Pairs: []string{"GBP/USD"}}, []int64{wednesdayLoss}},
+		{"type sorted by P/L", ListTradesRequest{Types: []string{"sell"}, Sort: []string{"-pl"}}, []int64{fridayWin, wednesdayLoss}},
+		{"status", ListTradesRequest{Statuses: []string{"open"}}, []int64{wednesdayOpen}},
+		{"strategy", ListTradesRequest{StrategyIDs: []string{strategyID}}, []int64{fridayWin, mondayWin}},
+		{"tag", ListTradesRequest{Tags: []string{"London"}}, []int64{wednesdayLoss, mondayWin}},
+		{"P/L range", ListTradesRequest{MinPL: "0", MaxPL: "1000"}, []int64{mondayWin}},
+		{"R range", ListTradesRequest{MaxR: "0"}, []int64{wednesdayLoss}},
+		{"lots range", ListTradesRequest{MinLots: "1", MaxLots: "1"}, []int64{wednesdayOpen, mondayWin}},
+		{"weekday", ListTradesRequest{Weekdays: []string{"wed"}}, []int64{wednesdayLoss, wednesdayOpen}},
+		{"hours", ListTradesRequest{Hours: []string{"8,9"}}, []int64{fridayWin, wednesdayOpen, mondayWin}},
+		{"date range", ListTradesRequest{StartDate: "2025-01-14", EndDate: "2025-01-15"}, []int64{wednesdayLoss, wednesdayOpen}},
+		{"combined with multi-field sort", ListTradesRequest{Pairs: []string{"EUR/USD"}, Hours: []string{"8"}, Sort: []string{"type", "-lots"}}, []int64{mondayWin, fridayWin}},
+		{"P/L ascending puts open trades last", ListTradesRequest{Sort: []string{"pl"}}, []int64{wednesdayLoss, mondayWin, fridayWin, wednesdayOpen}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			page, err := tradeService.ListTrades(ctx, createdUser.ID, tt.req)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			ids := make([]int64, len(page.Trades))
+			for i, trade := range page.Trades {
+				ids[i] = trade.ID
+			}
+			if !slices.Equal(ids, tt.expected) {
+				t.Errorf("expected trades %v, got %v", tt.expected, ids)
+			}
+			if page.NextCursor != nil {
+				t.Errorf("expected a single page, got next cursor %q", *page.NextCursor)
+			}
+		})
+	}
+
+	// Paging through one or two trades at a time must visit every trade once in the unpaged order,
+	// including across ties and the open trade's missing P/L
+	pagingTests := []struct {
+		name     string
+		sort     []string
+		expected []int64
+	}{
+		{"default order", nil, []int64{fridayWin, wednesdayLoss, wednesdayOpen, mondayWin}},
+		{"date ascending", []string{"date"}, []int64{mondayWin, wednesdayOpen, wednesdayLoss, fridayWin}},
+		{"P/L with nulls", []string{"pl"}, []int64{wednesdayLoss, mondayWin, fridayWin, wednesdayOpen}},
+		{"P/L descending with nulls", []string{"-pl"}, []int64{fridayWin, mondayWin, wednesdayLoss, wednesdayOpen}},
+		{"ties on lots", []string{"lots"}, []int64{wednesdayLoss, wednesdayOpen, mondayWin, fridayWin}},
+	}
+
+	for _, tt := range pagingTests {
+		for _, limit := range []string{"1", "2"} {
+			t.Run("pages "+tt.name+" by "+limit, func(t *testing.T) {
+				var ids []int64
+				req := ListTradesRequest{Sort: tt.sort, Limit: limit}
+				for range len(tt.expected) + 1 {
+					page, err := tradeService.ListTrades(ctx, createdUser.ID, req)
+					if err != nil {
+						t.Fatalf("expected no error, got %v", err)
+					}
+					for _, trade := range page.Trades {
+						ids = append(ids, trade.ID)
+					}
+					if page.NextCursor == nil {
+						break
+					}
+					req.Cursor = *page.NextCursor
+				}
+				if !slices.Equal(ids, tt.expected) {
+					t.Errorf("expected trades %v, got %v", tt.expected, ids)
+				}
+			})
+		}
+	}
+
+	t.Run("rejects a cursor issued for another sort", func(t *testing.T) {
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{Limit: "1"})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		_, err = tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{Limit: "1", Sort: []string{"pl"}, Cursor: *page.NextCursor})
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "cursor" {
+			t.Errorf("expected a validation error on cursor, got %v", err)
+		}
+	})
+
+	t.Run("reads weekdays and hours in the user's current timezone", func(t *testing.T) {
+		pg.DB.Exec("UPDATE users SET timezone = 'Asia/Tokyo' WHERE id = $1", createdUser.ID)
+		defer pg.DB.Exec("UPDATE users SET timezone = 'UTC' WHERE id = $1", createdUser.ID)
+
+		// The 14:00 UTC Wednesday loss opened at 23:00 in Tokyo
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{Weekdays: []string{"wed"}, Hours: []string{"23"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(page.Trades) != 1 || page.Trades[0].ID != wednesdayLoss {
+			t.Errorf("expected only the Wednesday loss, got %d trades", len(page.Trades))
+		}
+	})
+
+	t.Run("bounds and sorts P/L net of costs", func(t *testing.T) {
+		// Thursday winner of $100 gross that lost $50 after commission
+		costlyWin := create(CreateTradeRequest{Date: "2025-01-16", Time: "10:00", Pair: "EUR/USD", Type: "BUY", Entry: 1.1000, Exit: price(1.1010), Lots: 1.0,
+			Commission: 150})
+
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{MaxPL: "0", Sort: []string{"pl"}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		ids := make([]int64, len(page.Trades))
+		for i, trade := range page.Trades {
+			ids[i] = trade.ID
+		}
+		if !slices.Equal(ids, []int64{wednesdayLoss, costlyWin}) {
+			t.Errorf("expected the Wednesday loss then the costly win, got %v", ids)
+		}
+	})
+
+	t.Run("leaves out trades in the trash", func(t *testing.T) {
+		trade, err := tradeService.GetTrade(ctx, fridayWin, createdUser.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := tradeService.DeleteTrade(ctx, fridayWin, createdUser.ID, trade.Version); err != nil {
+			t.Fatal(err)
+		}
+
+		page, err := tradeService.ListTrades(ctx, createdUser.ID, ListTradesRequest{StrategyIDs: []string{strategyID}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(page.Trades) != 1 || page.Trades[0].ID != mondayWin {
+			t.Errorf("expected only the Monday trade, got %d trades", len(page.Trades))
+		}
+	})
+}
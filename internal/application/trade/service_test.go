@@ -2,12 +2,19 @@ package trade
 
 import (
 	"context"
+	"database/sql"
 	"errors"
+	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/raihanstark/trade-journal/internal/domain/account"
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
+	"github.com/raihanstark/trade-journal/internal/domain/fx"
+	"github.com/raihanstark/trade-journal/internal/domain/instrument"
 	tradedom "github.com/raihanstark/trade-journal/internal/domain/trade"
+	"github.com/raihanstark/trade-journal/internal/domain/user"
 )
 
 // TradeRepositorySpy records calls to the trade repository
@@ -25,14 +32,37 @@ type TradeRepositorySpy struct {
 	UpdateError   error
 	DeleteError   error
 
-	GetByAccountIDCalls  []GetByAccountIDCall
-	GetByAccountIDResult []*tradedom.Trade
-	GetByAccountIDError  error
+	// GetByIDResults serves trades by ID, taking precedence over GetByIDResult when set
+	GetByIDResults map[int64]*tradedom.Trade
 
 	UpdateChartBeforeResult *tradedom.Trade
 	UpdateChartBeforeError  error
 	UpdateChartAfterResult  *tradedom.Trade
 	UpdateChartAfterError   error
+
+	AddExecutionCalls    []*tradedom.Execution
+	DeleteExecutionCalls []DeleteCall
+	DeleteExecutionError error
+
+	GetTagsResult []tradedom.Tag
+
+	GetDeletedByIDResult *tradedom.Trade
+	GetDeletedByIDError  error
+	RestoreCalls         []DeleteCall
+	RestoreResult        *tradedom.Trade
+
+	SearchCalls  []SearchCall
+	SearchResult []*tradedom.SearchResult
+
+	FindCalls      []FindCall
+	FindResult     []*tradedom.Trade
+	FindNextCursor string
+	FindError      error
+}
+
+type FindCall struct {
+	Filter tradedom.Filter
+	Page   tradedom.Page
 }
 
 type GetByIDCall struct {
@@ -40,16 +70,17 @@ type GetByIDCall struct {
 	UserID int64
 }
 
-type GetByAccountIDCall struct {
-	AccountID int64
-	UserID    int64
-}
-
 type DeleteCall struct {
 	ID     int64
 	UserID int64
 }
 
+type SearchCall struct {
+	UserID int64
+	Query  string
+	Limit  int
+}
+
 func (s *TradeRepositorySpy) Create(ctx context.Context, trade *tradedom.Trade) (*tradedom.Trade, error) {
 	s.CreateCalls = append(s.CreateCalls, trade)
 	return s.CreateResult, s.CreateError
@@ -57,19 +88,26 @@ func (s *TradeRepositorySpy) Create(ctx context.Context, trade *tradedom.Trade)
 
 func (s *TradeRepositorySpy) GetByID(ctx context.Context, id int64, userID int64) (*tradedom.Trade, error) {
 	s.GetByIDCalls = append(s.GetByIDCalls, GetByIDCall{ID: id, UserID: userID})
+	if s.GetByIDResults != nil {
+		if t, ok := s.GetByIDResults[id]; ok {
+			return t, nil
+		}
+		return nil, sql.ErrNoRows
+	}
 	return s.GetByIDResult, s.GetByIDError
 }
 
-func (s *TradeRepositorySpy) GetByUserID(ctx context.Context, userID int64) ([]*tradedom.Trade, error) {
-	return nil, errors.New("not implemented")
-}
-
-func (s *TradeRepositorySpy) GetByUserIDAndDateRange(ctx context.Context, userID int64, startDate, endDate time.Time) ([]*tradedom.Trade, error) {
-	return nil, errors.New("not implemented")
+func (s *TradeRepositorySpy) Find(ctx context.Context, userID int64, filter tradedom.Filter, page tradedom.Page) ([]*tradedom.Trade, string, error) {
+	s.FindCalls = append(s.FindCalls, FindCall{Filter: filter, Page: page})
+	if s.FindError != nil {
+		return nil, "", s.FindError
+	}
+	return s.FindResult, s.FindNextCursor, nil
 }
 
-func (s *TradeRepositorySpy) GetByAccountIDAndDateRange(ctx context.Context, accountID int64, userID int64, startDate, endDate time.Time) ([]*tradedom.Trade, error) {
-	return nil, errors.New("not implemented")
+func (s *TradeRepositorySpy) Search(ctx context.Context, userID int64, query string, limit int) ([]*tradedom.SearchResult, error) {
+	s.SearchCalls = append(s.SearchCalls, SearchCall{UserID: userID, Query: query, Limit: limit})
+	return s.SearchResult, nil
 }
 
 func (s *TradeRepositorySpy) Update(ctx context.Context, trade *tradedom.Trade) (*tradedom.Trade, error) {
@@ -77,7 +115,7 @@ func (s *TradeRepositorySpy) Update(ctx context.Context, trade *tradedom.Trade)
 	return s.UpdateResult, s.UpdateError
 }
 
-func (s *TradeRepositorySpy) Delete(ctx context.Context, id int64, userID int64) error {
+func (s *TradeRepositorySpy) Delete(ctx context.Context, id int64, userID int64, version int64) error {
 	s.DeleteCalls = append(s.DeleteCalls, DeleteCall{ID: id, UserID: userID})
 	return s.DeleteError
 }
@@ -88,6 +126,10 @@ type AccountRepositorySpy struct {
 
 	UpdateBalanceResult *account.Account
 	UpdateBalanceError  error
+
+	// GetByIDResult defaults to a USD account when nil
+	GetByIDResult *account.Account
+	GetByIDError  error
 }
 
 type UpdateBalanceCall struct {
@@ -110,7 +152,13 @@ func (s *AccountRepositorySpy) Create(ctx context.Context, acc *account.Account)
 }
 
 func (s *AccountRepositorySpy) GetByID(ctx context.Context, id int64, userID int64) (*account.Account, error) {
-	return nil, errors.New("not implemented")
+	if s.GetByIDError != nil {
+		return nil, s.GetByIDError
+	}
+	if s.GetByIDResult != nil {
+		return s.GetByIDResult, nil
+	}
+	return &account.Account{ID: id, UserID: userID, Currency: "USD"}, nil
 }
 
 func (s *AccountRepositorySpy) GetByUserID(ctx context.Context, userID int64) ([]*account.Account, error) {
@@ -121,13 +169,151 @@ func (s *AccountRepositorySpy) Update(ctx context.Context, acc *account.Account)
 	return nil, errors.New("not implemented")
 }
 
-func (s *AccountRepositorySpy) Delete(ctx context.Context, id int64, userID int64) error {
+func (s *AccountRepositorySpy) Delete(ctx context.Context, id int64, userID int64, version int64) error {
+	return errors.New("not implemented")
+}
+
+func (s *AccountRepositorySpy) GetDeletedByUserID(ctx context.Context, userID int64) ([]*account.Account, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *AccountRepositorySpy) Restore(ctx context.Context, id int64, userID int64) (*account.Account, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *AccountRepositorySpy) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, errors.New("not implemented")
+}
+
+// AuditRepositorySpy records audit events in memory
+type AuditRepositorySpy struct {
+	Events []*audit.Event
+}
+
+func (s *AuditRepositorySpy) Record(ctx context.Context, event *audit.Event) error {
+	s.Events = append(s.Events, event)
+	return nil
+}
+
+func (s *AuditRepositorySpy) GetByEntity(ctx context.Context, userID int64, entityType audit.EntityType, entityID int64) ([]*audit.Event, error) {
+	var events []*audit.Event
+	for _, e := range s.Events {
+		if e.UserID == userID && e.EntityType == entityType && e.EntityID == entityID {
+			events = append(events, e)
+		}
+	}
+	return events, nil
+}
+
+// TransactorSpy runs the work against the configured spies and records how the transaction ended
+type TransactorSpy struct {
+	Trades   tradedom.Repository
+	Accounts account.Repository
+
+	Committed  bool
+	RolledBack bool
+}
+
+func (s *TransactorSpy) WithinTx(ctx context.Context, fn func(trades tradedom.Repository, accounts account.Repository) error) error {
+	if err := fn(s.Trades, s.Accounts); err != nil {
+		s.RolledBack = true
+		return err
+	}
+	s.Committed = true
+	return nil
+}
+
+// InstrumentRepositorySpy serves instrument specifications from an in-memory registry
+type InstrumentRepositorySpy struct {
+	Instruments map[string]*instrument.Instrument
+}
+
+func (s *InstrumentRepositorySpy) GetBySymbol(ctx context.Context, userID int64, symbol string) (*instrument.Instrument, error) {
+	if spec, ok := s.Instruments[symbol]; ok {
+		return spec, nil
+	}
+	return nil, instrument.ErrNotFound
+}
+
+func (s *InstrumentRepositorySpy) Create(ctx context.Context, i *instrument.Instrument) (*instrument.Instrument, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *InstrumentRepositorySpy) GetByID(ctx context.Context, id int64, userID int64) (*instrument.Instrument, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *InstrumentRepositorySpy) GetByUserID(ctx context.Context, userID int64) ([]*instrument.Instrument, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *InstrumentRepositorySpy) Update(ctx context.Context, i *instrument.Instrument) (*instrument.Instrument, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *InstrumentRepositorySpy) Delete(ctx context.Context, id int64, userID int64) error {
+	return errors.New("not implemented")
+}
+
+// FXRepositorySpy serves rates from an in-memory table keyed by "BASE/QUOTE", or from dated
+// History when it has rates for the pair
+type FXRepositorySpy struct {
+	Rates   map[string]float64
+	History []fx.Rate
+}
+
+func (s *FXRepositorySpy) GetOnOrBefore(ctx context.Context, userID int64, base, quote string, date time.Time) (*fx.Rate, error) {
+	var latest *fx.Rate
+	for i, rate := range s.History {
+		if rate.BaseCurrency == base && rate.QuoteCurrency == quote && !rate.Date.After(date) &&
+			(latest == nil || rate.Date.After(latest.Date)) {
+			latest = &s.History[i]
+		}
+	}
+	if latest != nil {
+		return latest, nil
+	}
+	if rate, ok := s.Rates[base+"/"+quote]; ok {
+		return &fx.Rate{BaseCurrency: base, QuoteCurrency: quote, Date: date, Rate: rate}, nil
+	}
+	return nil, fx.ErrNotFound
+}
+
+func (s *FXRepositorySpy) Upsert(ctx context.Context, rate *fx.Rate) (*fx.Rate, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *FXRepositorySpy) GetByUserID(ctx context.Context, userID int64) ([]*fx.Rate, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *FXRepositorySpy) Delete(ctx context.Context, id int64, userID int64) error {
 	return errors.New("not implemented")
 }
 
-func (s *TradeRepositorySpy) GetByAccountID(ctx context.Context, accountID int64, userID int64) ([]*tradedom.Trade, error) {
-	s.GetByAccountIDCalls = append(s.GetByAccountIDCalls, GetByAccountIDCall{AccountID: accountID, UserID: userID})
-	return s.GetByAccountIDResult, s.GetByAccountIDError
+// UserRepositorySpy serves a user with the configured timezone
+type UserRepositorySpy struct {
+	Timezone string
+}
+
+func (s *UserRepositorySpy) GetByID(ctx context.Context, id int64) (*user.User, error) {
+	return &user.User{ID: id, Timezone: s.Timezone}, nil
+}
+
+func (s *UserRepositorySpy) Create(ctx context.Context, u *user.User) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *UserRepositorySpy) GetByEmail(ctx context.Context, email string) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *UserRepositorySpy) UpdateTimezone(ctx context.Context, id int64, timezone string) (*user.User, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *TradeRepositorySpy) GetByGroupID(ctx context.Context, groupID int64, userID int64) ([]*tradedom.Trade, error) {
+	return nil, errors.New("not implemented")
 }
 
 func (s *TradeRepositorySpy) UpdateChartBefore(ctx context.Context, id int64, userID int64, chartURL string) (*tradedom.Trade, error) {
@@ -138,26 +324,35 @@ func (s *TradeRepositorySpy) UpdateChartAfter(ctx context.Context, id int64, use
 	return s.UpdateChartAfterResult, s.UpdateChartAfterError
 }
 
-func TestService_GetTradesByAccountID(t *testing.T) {
-	ctx := context.Background()
-	accountID := int64(1)
-	userID := int64(1)
-	amount := 1000.0
+func (s *TradeRepositorySpy) AddExecution(ctx context.Context, execution *tradedom.Execution) (*tradedom.Execution, error) {
+	s.AddExecutionCalls = append(s.AddExecutionCalls, execution)
+	return execution, nil
+}
 
-	tradeSpy := &TradeRepositorySpy{
-		GetByAccountIDResult: []*tradedom.Trade{{ID: 1, UserID: userID, AccountID: &accountID, Type: tradedom.TradeTypeDeposit, Amount: &amount, CreatedAt: time.Now(), UpdatedAt: time.Now()}},
-	}
+func (s *TradeRepositorySpy) DeleteExecution(ctx context.Context, id int64, tradeID int64) error {
+	s.DeleteExecutionCalls = append(s.DeleteExecutionCalls, DeleteCall{ID: id, UserID: tradeID})
+	return s.DeleteExecutionError
+}
 
-	service := NewService(tradeSpy, &AccountRepositorySpy{})
+func (s *TradeRepositorySpy) GetTags(ctx context.Context, userID int64) ([]tradedom.Tag, error) {
+	return s.GetTagsResult, nil
+}
 
-	trades, err := service.GetTradesByAccountID(ctx, accountID, userID)
-	if err != nil {
-		t.Fatalf("unexpected error: %v", err)
-	}
+func (s *TradeRepositorySpy) GetDeletedByID(ctx context.Context, id int64, userID int64) (*tradedom.Trade, error) {
+	return s.GetDeletedByIDResult, s.GetDeletedByIDError
+}
 
-	if len(trades) != 1 {
-		t.Fatalf("expected 1 trade, got %d", len(trades))
-	}
+func (s *TradeRepositorySpy) GetDeletedByUserID(ctx context.Context, userID int64) ([]*tradedom.Trade, error) {
+	return nil, errors.New("not implemented")
+}
+
+func (s *TradeRepositorySpy) Restore(ctx context.Context, id int64, userID int64) (*tradedom.Trade, error) {
+	s.RestoreCalls = append(s.RestoreCalls, DeleteCall{ID: id, UserID: userID})
+	return s.RestoreResult, nil
+}
+
+func (s *TradeRepositorySpy) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, errors.New("not implemented")
 }
 
 func TestService_CreateTrade_Validation(t *testing.T) {
@@ -167,7 +362,7 @@ func TestService_CreateTrade_Validation(t *testing.T) {
 	t.Run("account_id is required for creating trade", func(t *testing.T) {
 		tradeSpy := &TradeRepositorySpy{}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		amount := 1000.0
 		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
@@ -193,6 +388,90 @@ func TestService_CreateTrade_Validation(t *testing.T) {
 	})
 }
 
+func TestService_CreateTrade_FieldValidation(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	accountID := int64(1)
+
+	stopAboveEntry := 1.1050
+	negative := -1.0
+
+	tests := []struct {
+		name string
+		req  CreateTradeRequest
+		want []tradedom.FieldError
+	}{
+		{
+			name: "unknown type",
+			req:  CreateTradeRequest{AccountID: &accountID, Date: "2025-01-15", Time: "10:00", Type: "HOLD"},
+			want: []tradedom.FieldError{{Field: "type", Code: tradedom.CodeInvalid}},
+		},
+		{
+			name: "empty pair and negative lots",
+			req:  CreateTradeRequest{AccountID: &accountID, Date: "2025-01-15", Time: "10:00", Type: "BUY", Entry: 1.1, Lots: -1},
+			want: []tradedom.FieldError{
+				{Field: "pair", Code: tradedom.CodeRequired},
+				{Field: "lots", Code: tradedom.CodeMustBePositive},
+			},
+		},
+		{
+			name: "BUY with stop loss above entry",
+			req:  CreateTradeRequest{AccountID: &accountID, Date: "2025-01-15", Time: "10:00", Type: "BUY", Pair: "EURUSD", Entry: 1.1, Lots: 1, StopLoss: &stopAboveEntry},
+			want: []tradedom.FieldError{{Field: "stop_loss", Code: tradedom.CodeWrongSide}},
+		},
+		{
+			name: "SELL with take profit above entry",
+			req:  CreateTradeRequest{AccountID: &accountID, Date: "2025-01-15", Time: "10:00", Type: "SELL", Pair: "EURUSD", Entry: 1.1, Lots: 1, TakeProfit: &stopAboveEntry},
+			want: []tradedom.FieldError{{Field: "take_profit", Code: tradedom.CodeWrongSide}},
+		},
+		{
+			name: "withdrawal without positive amount",
+			req:  CreateTradeRequest{AccountID: &accountID, Date: "2025-01-15", Time: "10:00", Type: "WITHDRAW", Amount: &negative},
+			want: []tradedom.FieldError{{Field: "amount", Code: tradedom.CodeMustBePositive}},
+		},
+		{
+			name: "all request errors are reported together",
+			req:  CreateTradeRequest{Date: "15/01/2025", Time: "10am", Type: "DEPOSIT"},
+			want: []tradedom.FieldError{
+				{Field: "account_id", Code: tradedom.CodeRequired},
+				{Field: "date", Code: tradedom.CodeInvalidFormat},
+				{Field: "time", Code: tradedom.CodeInvalidFormat},
+				{Field: "amount", Code: tradedom.CodeRequired},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tradeSpy := &TradeRepositorySpy{}
+			service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+			_, err := service.CreateTrade(ctx, userID, tt.req)
+
+			var validationErr *tradedom.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Fatalf("expected a validation error, got %v", err)
+			}
+			if len(validationErr.Fields) != len(tt.want) {
+				t.Fatalf("expected %d field errors, got %+v", len(tt.want), validationErr.Fields)
+			}
+			for i, want := range tt.want {
+				got := validationErr.Fields[i]
+				if got.Field != want.Field || got.Code != want.Code {
+					t.Errorf("error %d: expected %s/%s, got %s/%s", i, want.Field, want.Code, got.Field, got.Code)
+				}
+				if got.Message == "" {
+					t.Errorf("error %d: expected a message", i)
+				}
+			}
+
+			if len(tradeSpy.CreateCalls) != 0 {
+				t.Errorf("expected no Create calls, got %d", len(tradeSpy.CreateCalls))
+			}
+		})
+	}
+}
+
 func TestService_CreateTrade_Deposit(t *testing.T) {
 	ctx := context.Background()
 	accountID := int64(1)
@@ -210,7 +489,7 @@ func TestService_CreateTrade_Deposit(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
 			AccountID: &accountID,
@@ -265,7 +544,7 @@ func TestService_CreateTrade_Withdraw(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
 			AccountID: &accountID,
@@ -311,7 +590,7 @@ func TestService_CreateTrade_ClosedTrade(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
 			AccountID: &accountID,
@@ -353,7 +632,7 @@ func TestService_CreateTrade_ClosedTrade(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
 			AccountID: &accountID,
@@ -394,7 +673,7 @@ func TestService_CreateTrade_ClosedTrade(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
 			AccountID: &accountID,
@@ -424,6 +703,351 @@ func TestService_CreateTrade_ClosedTrade(t *testing.T) {
 	})
 }
 
+func TestService_CreateTrade_Costs(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	exit := 1.1050
+
+	t.Run("balance is updated with P/L after costs", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID:  &accountID,
+			Date:       time.Now().Format("2006-01-02"),
+			Time:       time.Now().Format("15:04"),
+			Pair:       "EUR/USD",
+			Type:       "BUY",
+			Entry:      1.1000,
+			Exit:       &exit,
+			Lots:       1.0,
+			Commission: 7,
+			Swap:       -2.5,
+			Fees:       1,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		created := tradeSpy.CreateCalls[0]
+		if created.PL == nil || *created.PL != 500 {
+			t.Errorf("expected gross P/L 500, got %v", created.PL)
+		}
+		// 500 - 7 commission - 1 fee - 2.50 swap
+		if created.NetPL == nil || *created.NetPL != 489.5 {
+			t.Errorf("expected net P/L 489.50, got %v", created.NetPL)
+		}
+
+		if len(accountSpy.UpdateBalanceCalls) != 1 || accountSpy.UpdateBalanceCalls[0].Amount != 489.5 {
+			t.Errorf("expected balance to be credited with 489.50, got %+v", accountSpy.UpdateBalanceCalls)
+		}
+	})
+
+	t.Run("open trade has no net P/L", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID:  &accountID,
+			Date:       time.Now().Format("2006-01-02"),
+			Time:       time.Now().Format("15:04"),
+			Pair:       "EUR/USD",
+			Type:       "BUY",
+			Entry:      1.1000,
+			Lots:       1.0,
+			Commission: 7,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if tradeSpy.CreateCalls[0].NetPL != nil {
+			t.Errorf("expected no net P/L, got %v", *tradeSpy.CreateCalls[0].NetPL)
+		}
+	})
+
+	t.Run("rejects negative commission", func(t *testing.T) {
+		service := NewService(&TradeRepositorySpy{}, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID:  &accountID,
+			Date:       time.Now().Format("2006-01-02"),
+			Time:       time.Now().Format("15:04"),
+			Pair:       "EUR/USD",
+			Type:       "BUY",
+			Entry:      1.1000,
+			Lots:       1.0,
+			Commission: -7,
+		})
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "commission" {
+			t.Fatalf("expected a validation error on commission, got %v", err)
+		}
+	})
+}
+
+func TestService_CreateTrade_OpenedAt(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+
+	cases := []struct {
+		name     string
+		timezone string
+		want     time.Time
+	}{
+		{"default timezone is UTC", "", time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)},
+		{"date and time are wall clock in the user's timezone", "America/New_York", time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC)},
+		{"timezone ahead of UTC moves the instant to the day before", "Australia/Sydney", time.Date(2025, 1, 14, 22, 30, 0, 0, time.UTC)},
+	}
+
+	for _, tt := range cases {
+		t.Run(tt.name, func(t *testing.T) {
+			tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+			service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{Timezone: tt.timezone}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+			_, err := service.CreateTrade(ctx, 1, CreateTradeRequest{
+				AccountID: &accountID,
+				Date:      "2025-01-15",
+				Time:      "09:30",
+				Pair:      "EUR/USD",
+				Type:      "BUY",
+				Entry:     1.1000,
+				Lots:      1.0,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			created := tradeSpy.CreateCalls[0]
+			if !created.OpenedAt.Equal(tt.want) {
+				t.Errorf("expected opened_at %v, got %v", tt.want, created.OpenedAt.UTC())
+			}
+			if created.Date.Format("2006-01-02") != "2025-01-15" || created.Time.Format("15:04") != "09:30" {
+				t.Errorf("expected wall clock date and time to be kept, got %s %s", created.Date.Format("2006-01-02"), created.Time.Format("15:04"))
+			}
+		})
+	}
+}
+
+func TestService_CreateTrade_FXConversion(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+
+	tests := []struct {
+		name            string
+		pair            string
+		entry           float64
+		exit            float64
+		accountCurrency string
+		rates           map[string]float64
+		wantPL          float64
+		wantRate        float64
+	}{
+		{
+			name:            "JPY quoted pair converts through the inverse USD/JPY rate",
+			pair:            "EUR/JPY",
+			entry:           160.00,
+			exit:            160.50,
+			accountCurrency: "USD",
+			rates:           map[string]float64{"USD/JPY": 150},
+			wantPL:          333.33, // 50,000 JPY / 150
+			wantRate:        1.0 / 150,
+		},
+		{
+			name:            "pair based in the account currency converts at its exit price",
+			pair:            "USD/JPY",
+			entry:           150.00,
+			exit:            151.00,
+			accountCurrency: "USD",
+			wantPL:          662.25, // 100,000 JPY / 151
+			wantRate:        1.0 / 151,
+		},
+		{
+			name:            "USD P/L converts into a EUR account",
+			pair:            "EUR/USD",
+			entry:           1.1000,
+			exit:            1.1050,
+			accountCurrency: "EUR",
+			rates:           map[string]float64{"EUR/USD": 1.25},
+			wantPL:          400, // 500 USD / 1.25
+			wantRate:        1.0 / 1.25,
+		},
+		{
+			name:            "same currency uses a rate of 1",
+			pair:            "EUR/USD",
+			entry:           1.1000,
+			exit:            1.1050,
+			accountCurrency: "USD",
+			wantPL:          500,
+			wantRate:        1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+			accountSpy := &AccountRepositorySpy{
+				GetByIDResult: &account.Account{ID: accountID, UserID: userID, Currency: tt.accountCurrency},
+			}
+			service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{Rates: tt.rates}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+			_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+				AccountID: &accountID,
+				Date:      "2025-01-15",
+				Time:      "10:00",
+				Pair:      tt.pair,
+				Type:      "BUY",
+				Entry:     tt.entry,
+				Exit:      &tt.exit,
+				Lots:      1.0,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			created := tradeSpy.CreateCalls[0]
+			if created.PL == nil || *created.PL != tt.wantPL {
+				t.Errorf("expected P/L %.2f, got %v", tt.wantPL, created.PL)
+			}
+			if created.FXRate == nil || *created.FXRate != tt.wantRate {
+				t.Errorf("expected fx rate %v, got %v", tt.wantRate, created.FXRate)
+			}
+
+			if len(accountSpy.UpdateBalanceCalls) != 1 || accountSpy.UpdateBalanceCalls[0].Amount != tt.wantPL {
+				t.Errorf("expected balance updated by %.2f, got %+v", tt.wantPL, accountSpy.UpdateBalanceCalls)
+			}
+		})
+	}
+
+	t.Run("closed trade converts at the rate of the close date", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		accountSpy := &AccountRepositorySpy{
+			GetByIDResult: &account.Account{ID: accountID, UserID: userID, Currency: "EUR"},
+		}
+		fxSpy := &FXRepositorySpy{History: []fx.Rate{
+			{BaseCurrency: "EUR", QuoteCurrency: "USD", Date: time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC), Rate: 1.25},
+			{BaseCurrency: "EUR", QuoteCurrency: "USD", Date: time.Date(2025, 1, 20, 0, 0, 0, 0, time.UTC), Rate: 1.0},
+		}}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, fxSpy, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		exit := 1.1050
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			CloseDate: "2025-01-20",
+			CloseTime: "16:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// 500 USD at the close-date rate of 1.0 rather than the open-date rate of 1.25
+		created := tradeSpy.CreateCalls[0]
+		if created.PL == nil || *created.PL != 500 {
+			t.Errorf("expected P/L 500.00, got %v", created.PL)
+		}
+		if created.FXRate == nil || *created.FXRate != 1 {
+			t.Errorf("expected fx rate 1, got %v", created.FXRate)
+		}
+	})
+
+	t.Run("missing rate stores the P/L unconverted", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		exit := 190.50
+		stopLoss := 189.50
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "GBP/JPY",
+			Type:      "BUY",
+			Entry:     190.00,
+			Exit:      &exit,
+			Lots:      1.0,
+			StopLoss:  &stopLoss,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// 50 pips * 1000 JPY per pip, left in JPY
+		created := tradeSpy.CreateCalls[0]
+		if created.PL == nil || *created.PL != 50000 {
+			t.Errorf("expected P/L 50000 in the quote currency, got %v", created.PL)
+		}
+		if created.FXRate != nil || !created.Unconverted() {
+			t.Errorf("expected no fx rate, got %v", created.FXRate)
+		}
+		if created.RiskAmount != nil {
+			t.Errorf("expected no risk amount, got %v", *created.RiskAmount)
+		}
+	})
+
+	t.Run("risk amount of an open trade converts into the account currency", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		accountSpy := &AccountRepositorySpy{
+			GetByIDResult: &account.Account{ID: accountID, UserID: userID, Currency: "EUR"},
+		}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{Rates: map[string]float64{"EUR/USD": 1.25}}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		stopLoss := 1.0980
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			StopLoss:  &stopLoss,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// 200 USD / 1.25
+		created := tradeSpy.CreateCalls[0]
+		if created.RiskAmount == nil || *created.RiskAmount != 160 {
+			t.Errorf("expected risk amount 160, got %v", created.RiskAmount)
+		}
+	})
+
+	t.Run("open trade without a rate is saved without a risk amount", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		stopLoss := 189.50
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "GBP/JPY",
+			Type:      "BUY",
+			Entry:     190.00,
+			Lots:      1.0,
+			StopLoss:  &stopLoss,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if tradeSpy.CreateCalls[0].RiskAmount != nil {
+			t.Errorf("expected no risk amount, got %v", *tradeSpy.CreateCalls[0].RiskAmount)
+		}
+	})
+}
+
 func TestService_UpdateTrade_PLDifference(t *testing.T) {
 	ctx := context.Background()
 	accountID := int64(1)
@@ -445,6 +1069,7 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 				Type:      tradedom.TradeTypeBuy,
 				Entry:     1.1000,
 				Exit:      &oldExit,
+				Status:    tradedom.TradeStatusClosed,
 				Lots:      1.0,
 			},
 			UpdateResult: &tradedom.Trade{
@@ -462,7 +1087,7 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 		tradeSpy.GetByIDResult.PL = &oldPL
 
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
 			AccountID: &accountID,
@@ -473,7 +1098,7 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 			Entry:     1.1000,
 			Exit:      &newExit,
 			Lots:      1.0,
-		})
+		}, 0)
 
 		// Assert no error
 		if err != nil {
@@ -503,6 +1128,7 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 				Type:      tradedom.TradeTypeBuy,
 				Entry:     1.1000,
 				Exit:      &exit,
+				Status:    tradedom.TradeStatusClosed,
 				Lots:      1.0,
 			},
 			UpdateResult: &tradedom.Trade{
@@ -519,7 +1145,7 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 		pl := 500.0
 		tradeSpy.GetByIDResult.PL = &pl
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
 			AccountID: &accountID,
@@ -530,7 +1156,7 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 			Entry:     1.1000,
 			Exit:      &exit,
 			Lots:      1.0,
-		})
+		}, 0)
 
 		// Assert no error
 		if err != nil {
@@ -544,6 +1170,458 @@ func TestService_UpdateTrade_PLDifference(t *testing.T) {
 	})
 }
 
+func TestService_CloseTime(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+	exit := 1.1050
+	openedAt := time.Date(2025, 1, 15, 14, 30, 0, 0, time.UTC)
+
+	closedTradeRequest := func(closeDate, closeTime string) UpdateTradeRequest {
+		return UpdateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "09:30",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+			CloseDate: closeDate,
+			CloseTime: closeTime,
+		}
+	}
+
+	openTrade := func() *tradedom.Trade {
+		return &tradedom.Trade{ID: tradeID, UserID: userID, AccountID: &accountID, Type: tradedom.TradeTypeBuy, Entry: 1.1000, Lots: 1.0, Status: tradedom.TradeStatusOpen, OpenedAt: openedAt}
+	}
+
+	t.Run("explicit close is read in the user's timezone", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: openTrade(), UpdateResult: &tradedom.Trade{ID: tradeID}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{Timezone: "America/New_York"}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		if _, err := service.UpdateTrade(ctx, tradeID, userID, closedTradeRequest("2025-01-15", "16:00"), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		want := time.Date(2025, 1, 15, 21, 0, 0, 0, time.UTC)
+		if updated.ClosedAt == nil || !updated.ClosedAt.Equal(want) {
+			t.Fatalf("expected closed_at %v, got %v", want, updated.ClosedAt)
+		}
+		if updated.CloseDate.Format("2006-01-02") != "2025-01-15" || updated.CloseTime.Format("15:04") != "16:00" {
+			t.Errorf("expected wall clock close 2025-01-15 16:00, got %s %s", updated.CloseDate.Format("2006-01-02"), updated.CloseTime.Format("15:04"))
+		}
+		if held := updated.HoldingDuration(); held == nil || *held != 390*time.Minute {
+			t.Errorf("expected a holding duration of 6h30m, got %v", held)
+		}
+	})
+
+	t.Run("close defaults to now when the exit is first set", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: openTrade(), UpdateResult: &tradedom.Trade{ID: tradeID}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{Timezone: "America/New_York"}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		before := time.Now().Truncate(time.Minute)
+		if _, err := service.UpdateTrade(ctx, tradeID, userID, closedTradeRequest("", ""), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		closedAt := tradeSpy.UpdateCalls[0].ClosedAt
+		if closedAt == nil || closedAt.Before(before) || closedAt.After(time.Now()) {
+			t.Errorf("expected the trade to close now, got %v", closedAt)
+		}
+	})
+
+	t.Run("previous close is kept when none is given", func(t *testing.T) {
+		previous := time.Date(2025, 1, 16, 8, 0, 0, 0, time.UTC)
+		existing := openTrade()
+		existing.Exit, existing.Status, existing.ClosedAt = &exit, tradedom.TradeStatusClosed, &previous
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: existing, UpdateResult: &tradedom.Trade{ID: tradeID}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{Timezone: "America/New_York"}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		if _, err := service.UpdateTrade(ctx, tradeID, userID, closedTradeRequest("", ""), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if closedAt := tradeSpy.UpdateCalls[0].ClosedAt; closedAt == nil || !closedAt.Equal(previous) {
+			t.Errorf("expected closed_at %v to be kept, got %v", previous, closedAt)
+		}
+	})
+
+	t.Run("reopening a trade clears its close", func(t *testing.T) {
+		previous := time.Date(2025, 1, 16, 8, 0, 0, 0, time.UTC)
+		existing := openTrade()
+		existing.Exit, existing.Status, existing.ClosedAt = &exit, tradedom.TradeStatusClosed, &previous
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: existing, UpdateResult: &tradedom.Trade{ID: tradeID}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		req := closedTradeRequest("2025-01-16", "08:00")
+		req.Exit = nil
+		if _, err := service.UpdateTrade(ctx, tradeID, userID, req, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.ClosedAt != nil || updated.CloseDate != nil || updated.CloseTime != nil {
+			t.Errorf("expected no close on an open trade, got %v", updated.ClosedAt)
+		}
+	})
+
+	t.Run("rejects a close before the open", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: openTrade()}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.UpdateTrade(ctx, tradeID, userID, closedTradeRequest("2025-01-15", "09:00"), 0)
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Code != tradedom.CodeBeforeOpen {
+			t.Fatalf("expected a before_open validation error, got %v", err)
+		}
+		if len(tradeSpy.UpdateCalls) != 0 {
+			t.Error("expected no update")
+		}
+	})
+
+	t.Run("rejects a close time without a close date", func(t *testing.T) {
+		service := NewService(&TradeRepositorySpy{GetByIDResult: openTrade()}, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.UpdateTrade(ctx, tradeID, userID, closedTradeRequest("", "16:00"), 0)
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "close_date" {
+			t.Fatalf("expected a validation error on close_date, got %v", err)
+		}
+	})
+}
+
+func TestService_OrderLifecycle(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+	exit := 1.1050
+	openedAt := time.Date(2025, 1, 15, 9, 30, 0, 0, time.UTC)
+
+	orderRequest := func(status string) UpdateTradeRequest {
+		return UpdateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "09:30",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Status:    status,
+			OrderType: "limit",
+			Entry:     1.1000,
+			Lots:      1.0,
+		}
+	}
+
+	order := func(status tradedom.TradeStatus) *tradedom.Trade {
+		return &tradedom.Trade{ID: tradeID, UserID: userID, AccountID: &accountID, Type: tradedom.TradeTypeBuy, Entry: 1.1000, Lots: 1.0, Status: status, OrderType: tradedom.OrderTypeLimit, OpenedAt: openedAt}
+	}
+
+	t.Run("creates a pending order without P/L", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: tradeID}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest(orderRequest("pending")))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		created := tradeSpy.CreateCalls[0]
+		if created.Status != tradedom.TradeStatusPending || created.OrderType != tradedom.OrderTypeLimit {
+			t.Errorf("expected a pending limit order, got %s %s", created.Status, created.OrderType)
+		}
+		if created.PL != nil || created.ClosedAt != nil || created.OpenLots() != 0 {
+			t.Errorf("expected no P/L, close or open lots on a pending order")
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Error("expected no balance update")
+		}
+	})
+
+	t.Run("trades default to filled market orders", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: tradeID}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		req := CreateTradeRequest(orderRequest(""))
+		req.OrderType = ""
+		if _, err := service.CreateTrade(ctx, userID, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		created := tradeSpy.CreateCalls[0]
+		if created.Status != tradedom.TradeStatusOpen || created.OrderType != tradedom.OrderTypeMarket {
+			t.Errorf("expected an open market order, got %s %s", created.Status, created.OrderType)
+		}
+	})
+
+	t.Run("rejects unfilled market orders and exits on unfilled orders", func(t *testing.T) {
+		service := NewService(&TradeRepositorySpy{}, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		req := CreateTradeRequest(orderRequest("pending"))
+		req.OrderType = "market"
+		req.Exit = &exit
+		_, err := service.CreateTrade(ctx, userID, req)
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+		fields := []string{}
+		for _, f := range validationErr.Fields {
+			fields = append(fields, f.Field)
+		}
+		if strings.Join(fields, ",") != "status,exit" {
+			t.Errorf("expected errors on status and exit, got %v", fields)
+		}
+	})
+
+	t.Run("triggering a pending order fills it", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: order(tradedom.TradeStatusPending), UpdateResult: &tradedom.Trade{ID: tradeID}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		req := orderRequest("open")
+		req.Exit = &exit
+		if _, err := service.UpdateTrade(ctx, tradeID, userID, req, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.Status != tradedom.TradeStatusClosed || updated.PL == nil {
+			t.Errorf("expected a closed trade with P/L, got %s", updated.Status)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 1 {
+			t.Errorf("expected the P/L to be booked, got %d balance updates", len(accountSpy.UpdateBalanceCalls))
+		}
+	})
+
+	t.Run("pending orders keep their status when none is given", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: order(tradedom.TradeStatusPending), UpdateResult: &tradedom.Trade{ID: tradeID}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy})
+
+		if _, err := service.UpdateTrade(ctx, tradeID, userID, orderRequest(""), 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if status := tradeSpy.UpdateCalls[0].Status; status != tradedom.TradeStatusPending {
+			t.Errorf("expected the order to stay pending, got %s", status)
+		}
+	})
+
+	transitions := []struct {
+		from    tradedom.TradeStatus
+		to      string
+		allowed bool
+	}{
+		{tradedom.TradeStatusPending, "cancelled", true},
+		{tradedom.TradeStatusPending, "expired", true},
+		{tradedom.TradeStatusCancelled, "open", false},
+		{tradedom.TradeStatusExpired, "pending", false},
+		{tradedom.TradeStatusOpen, "pending", false},
+		{tradedom.TradeStatusClosed, "cancelled", false},
+	}
+	for _, tt := range transitions {
+		t.Run(string(tt.from)+" to "+tt.to, func(t *testing.T) {
+			tradeSpy := &TradeRepositorySpy{GetByIDResult: order(tt.from), UpdateResult: &tradedom.Trade{ID: tradeID}}
+			service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+			_, err := service.UpdateTrade(ctx, tradeID, userID, orderRequest(tt.to), 0)
+
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if status := tradeSpy.UpdateCalls[0].Status; string(status) != tt.to {
+					t.Errorf("expected status %s, got %s", tt.to, status)
+				}
+				return
+			}
+			if !errors.Is(err, tradedom.ErrInvalidStatusTransition) {
+				t.Fatalf("expected ErrInvalidStatusTransition, got %v", err)
+			}
+			if len(tradeSpy.UpdateCalls) != 0 {
+				t.Error("expected no update")
+			}
+		})
+	}
+
+	t.Run("a fill triggers a pending order", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: order(tradedom.TradeStatusPending), UpdateResult: &tradedom.Trade{ID: tradeID}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy})
+
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{Side: "BUY", Price: 1.0995, Lots: 1, ExecutedAt: "2025-01-15T10:00:00Z"}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// The order price does not become a fill of its own
+		if len(tradeSpy.AddExecutionCalls) != 1 {
+			t.Fatalf("expected 1 stored execution, got %d", len(tradeSpy.AddExecutionCalls))
+		}
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.Status != tradedom.TradeStatusOpen || updated.Entry != 1.0995 {
+			t.Errorf("expected an open trade at the fill price, got %s at %v", updated.Status, updated.Entry)
+		}
+	})
+
+	t.Run("a cancelled order cannot be filled", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: order(tradedom.TradeStatusCancelled)}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{Side: "BUY", Price: 1.0995, Lots: 1, ExecutedAt: "2025-01-15T10:00:00Z"}, 0)
+		if !errors.Is(err, tradedom.ErrInvalidStatusTransition) {
+			t.Fatalf("expected ErrInvalidStatusTransition, got %v", err)
+		}
+		if len(tradeSpy.AddExecutionCalls) != 0 {
+			t.Error("expected nothing to be stored")
+		}
+	})
+}
+
+func TestService_Tags(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+
+	tradeRequest := func(tags ...string) CreateTradeRequest {
+		return CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "09:30",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Tags:      tags,
+		}
+	}
+
+	t.Run("normalizes tags and drops blanks and duplicates", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		if _, err := service.CreateTrade(ctx, userID, tradeRequest(" Breakout", "london", "", "BREAKOUT ")); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		tags := tradeSpy.CreateCalls[0].Tags
+		if len(tags) != 2 || tags[0].Name != "breakout" || tags[1].Name != "london" {
+			t.Errorf("expected tags breakout and london, got %v", tags)
+		}
+	})
+
+	t.Run("rejects tags that are too long", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, tradeRequest(strings.Repeat("x", tradedom.MaxTagLength+1)))
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "tags" || validationErr.Fields[0].Code != tradedom.CodeTooLong {
+			t.Fatalf("expected a too_long validation error on tags, got %v", err)
+		}
+		if len(tradeSpy.CreateCalls) != 0 {
+			t.Error("expected nothing to be stored")
+		}
+	})
+
+	t.Run("lists the user's tags", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetTagsResult: []tradedom.Tag{{ID: 1, Name: "breakout"}, {ID: 2, Name: "london"}}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		tags, err := service.ListTags(ctx, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tags) != 2 || tags[1].Name != "london" {
+			t.Errorf("expected 2 tags, got %v", tags)
+		}
+	})
+}
+
+func TestService_Ratings(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+
+	tradeRequest := func(ratings ...RatingRequest) CreateTradeRequest {
+		return CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "09:30",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Ratings:   ratings,
+		}
+	}
+
+	t.Run("passes ratings before entry and after exit to the repository", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, tradeRequest(
+			RatingRequest{DimensionID: 1, Phase: "before", Value: 4},
+			RatingRequest{DimensionID: 1, Phase: "after", Value: 2},
+		))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		ratings := tradeSpy.CreateCalls[0].Ratings
+		if len(ratings) != 2 || ratings[0].Phase != tradedom.RatingPhaseBefore || ratings[1].Value != 2 {
+			t.Errorf("expected ratings to reach the repository, got %v", ratings)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		rating RatingRequest
+		code   string
+	}{
+		{"score above the scale", RatingRequest{DimensionID: 1, Phase: "before", Value: 6}, tradedom.CodeOutOfRange},
+		{"score below the scale", RatingRequest{DimensionID: 1, Phase: "after", Value: 0}, tradedom.CodeOutOfRange},
+		{"unknown phase", RatingRequest{DimensionID: 1, Phase: "during", Value: 3}, tradedom.CodeInvalid},
+	}
+	for _, tt := range tests {
+		t.Run("rejects "+tt.name, func(t *testing.T) {
+			tradeSpy := &TradeRepositorySpy{}
+			service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+			_, err := service.CreateTrade(ctx, userID, tradeRequest(tt.rating))
+
+			var validationErr *tradedom.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "ratings" || validationErr.Fields[0].Code != tt.code {
+				t.Fatalf("expected a %s validation error on ratings, got %v", tt.code, err)
+			}
+			if len(tradeSpy.CreateCalls) != 0 {
+				t.Error("expected nothing to be stored")
+			}
+		})
+	}
+
+	t.Run("rejects rating a dimension twice in the same phase", func(t *testing.T) {
+		service := NewService(&TradeRepositorySpy{}, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.CreateTrade(ctx, userID, tradeRequest(
+			RatingRequest{DimensionID: 1, Phase: "before", Value: 4},
+			RatingRequest{DimensionID: 1, Phase: "before", Value: 3},
+		))
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Code != tradedom.CodeDuplicate {
+			t.Fatalf("expected a duplicate validation error, got %v", err)
+		}
+	})
+}
+
 func TestService_UpdateTrade_AccountChange(t *testing.T) {
 	ctx := context.Background()
 	oldAccountID := int64(1)
@@ -562,6 +1640,7 @@ func TestService_UpdateTrade_AccountChange(t *testing.T) {
 				Type:      tradedom.TradeTypeBuy,
 				Entry:     1.1000,
 				Exit:      &exit,
+				Status:    tradedom.TradeStatusClosed,
 				PL:        &pl,
 			},
 			UpdateResult: &tradedom.Trade{
@@ -575,7 +1654,7 @@ func TestService_UpdateTrade_AccountChange(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
 			AccountID: &newAccountID,
@@ -586,7 +1665,7 @@ func TestService_UpdateTrade_AccountChange(t *testing.T) {
 			Entry:     1.1000,
 			Exit:      &exit,
 			Lots:      1.0,
-		})
+		}, 0)
 
 		// Assert no error
 		if err != nil {
@@ -636,9 +1715,9 @@ func TestService_DeleteTrade_RevertsBalance(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
-		err := service.DeleteTrade(ctx, tradeID, userID)
+		err := service.DeleteTrade(ctx, tradeID, userID, 0)
 
 		// Assert no error
 		if err != nil {
@@ -673,9 +1752,9 @@ func TestService_DeleteTrade_RevertsBalance(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
-		err := service.DeleteTrade(ctx, tradeID, userID)
+		err := service.DeleteTrade(ctx, tradeID, userID, 0)
 
 		// Assert no error
 		if err != nil {
@@ -705,9 +1784,9 @@ func TestService_DeleteTrade_RevertsBalance(t *testing.T) {
 			},
 		}
 		accountSpy := &AccountRepositorySpy{}
-		service := NewService(tradeSpy, accountSpy)
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
-		err := service.DeleteTrade(ctx, tradeID, userID)
+		err := service.DeleteTrade(ctx, tradeID, userID, 0)
 
 		// Assert no error
 		if err != nil {
@@ -726,91 +1805,1140 @@ func TestService_DeleteTrade_RevertsBalance(t *testing.T) {
 	})
 }
 
-func TestService_GetUserTradesWithDateFilter(t *testing.T) {
-	t.Run("returns error when start_date format is invalid", func(t *testing.T) {
-		tradeRepo := &TradeRepositorySpy{}
-		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
-
-		invalidDate := "invalid-date"
-		endDate := "2025-01-16"
-
-		_, err := service.GetUserTradesWithDateFilter(context.Background(), 1, &invalidDate, &endDate)
-
-		if err == nil {
-			t.Fatal("expected error for invalid start_date")
-		}
+func TestService_VersionConflict(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
 
-		if err.Error() != "invalid start_date format, expected YYYY-MM-DD" {
-			t.Errorf("unexpected error message: %v", err)
+	closedTrade := func() *tradedom.Trade {
+		pl := 50.0
+		return &tradedom.Trade{
+			ID:        tradeID,
+			UserID:    userID,
+			AccountID: &accountID,
+			Type:      tradedom.TradeTypeBuy,
+			Status:    tradedom.TradeStatusClosed,
+			PL:        &pl,
+			Version:   3,
 		}
-	})
-
-	t.Run("returns error when end_date format is invalid", func(t *testing.T) {
-		tradeRepo := &TradeRepositorySpy{}
-		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
+	}
 
-		startDate := "2025-01-15"
-		invalidDate := "not-a-date"
+	t.Run("stale update is rejected before touching the balance", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: closedTrade()}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
-		_, err := service.GetUserTradesWithDateFilter(context.Background(), 1, &startDate, &invalidDate)
+		exit := 1.1100
+		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+		}, 2)
 
-		if err == nil {
-			t.Fatal("expected error for invalid end_date")
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
 		}
-
-		if err.Error() != "invalid end_date format, expected YYYY-MM-DD" {
-			t.Errorf("unexpected error message: %v", err)
+		if len(tradeSpy.UpdateCalls) != 0 {
+			t.Errorf("expected no call to Update, got %d", len(tradeSpy.UpdateCalls))
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
 		}
 	})
-}
-
-func TestService_GetTradesByAccountIDWithDateFilter(t *testing.T) {
-	t.Run("returns error when start_date format is invalid", func(t *testing.T) {
-		tradeRepo := &TradeRepositorySpy{}
-		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
 
-		invalidDate := "bad-format"
-		endDate := "2025-01-16"
+	t.Run("stale delete is rejected before touching the balance", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: closedTrade()}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
-		_, err := service.GetTradesByAccountIDWithDateFilter(context.Background(), 1, 1, &invalidDate, &endDate)
+		err := service.DeleteTrade(ctx, tradeID, userID, 2)
 
-		if err == nil {
-			t.Fatal("expected error for invalid start_date")
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
 		}
-
-		if err.Error() != "invalid start_date format, expected YYYY-MM-DD" {
-			t.Errorf("unexpected error message: %v", err)
+		if len(tradeSpy.DeleteCalls) != 0 {
+			t.Errorf("expected no call to Delete, got %d", len(tradeSpy.DeleteCalls))
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
 		}
 	})
 
-	t.Run("returns error when end_date format is invalid", func(t *testing.T) {
-		tradeRepo := &TradeRepositorySpy{}
-		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
-
-		startDate := "2025-01-15"
-		invalidDate := "2025/01/16"
+	t.Run("delete that loses the race leaves the balance alone", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: closedTrade(),
+			DeleteError:   tradedom.ErrVersionConflict,
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
-		_, err := service.GetTradesByAccountIDWithDateFilter(context.Background(), 1, 1, &startDate, &invalidDate)
+		err := service.DeleteTrade(ctx, tradeID, userID, 3)
 
-		if err == nil {
-			t.Fatal("expected error for invalid end_date")
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
 		}
-
-		if err.Error() != "invalid end_date format, expected YYYY-MM-DD" {
-			t.Errorf("unexpected error message: %v", err)
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
 		}
 	})
-}
-
-func TestService_UpdateChartBefore(t *testing.T) {
-	ctx := context.Background()
-	tradeID := int64(1)
-	userID := int64(1)
-	chartURL := "http://localhost:9000/trade-journal/chart-before.png"
+
+	t.Run("update carries the expected version to the repository", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: closedTrade(),
+			UpdateResult:  closedTrade(),
+		}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		exit := 1.1100
+		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+		}, 3)
+
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(tradeSpy.UpdateCalls) != 1 || tradeSpy.UpdateCalls[0].Version != 3 {
+			t.Errorf("expected Update to be called with version 3, got %+v", tradeSpy.UpdateCalls)
+		}
+	})
+}
+
+func TestService_PatchTrade(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+
+	storedTrade := func() *tradedom.Trade {
+		exit := 1.1050
+		pips := 50.0
+		pl := 500.0
+		takeProfit := 1.1200
+		return &tradedom.Trade{
+			ID:         tradeID,
+			UserID:     userID,
+			AccountID:  &accountID,
+			Date:       time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			Time:       time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+			Pair:       "EUR/USD",
+			Type:       tradedom.TradeTypeBuy,
+			Status:     tradedom.TradeStatusClosed,
+			Entry:      1.1000,
+			Exit:       &exit,
+			Lots:       1.0,
+			Pips:       &pips,
+			PL:         &pl,
+			TakeProfit: &takeProfit,
+			Notes:      "first take",
+			Version:    3,
+		}
+	}
+
+	newService := func(tradeSpy *TradeRepositorySpy, accountSpy *AccountRepositorySpy) *Service {
+		return NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+	}
+
+	t.Run("patching notes keeps metrics and balance", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: storedTrade(), UpdateResult: storedTrade()}
+		accountSpy := &AccountRepositorySpy{}
+
+		_, err := newService(tradeSpy, accountSpy).PatchTrade(ctx, tradeID, userID, []byte(`{"notes": "second take"}`), 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tradeSpy.UpdateCalls) != 1 {
+			t.Fatalf("expected 1 call to Update, got %d", len(tradeSpy.UpdateCalls))
+		}
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.Notes != "second take" {
+			t.Errorf("expected notes to be patched, got %q", updated.Notes)
+		}
+		if updated.Pair != "EUR/USD" || updated.Exit == nil || *updated.Exit != 1.1050 {
+			t.Errorf("expected untouched fields to be kept, got pair %s exit %v", updated.Pair, updated.Exit)
+		}
+		if updated.PL == nil || *updated.PL != 500.0 {
+			t.Errorf("expected stored P/L to be kept, got %v", updated.PL)
+		}
+		if updated.Version != 3 {
+			t.Errorf("expected update to carry version 3, got %d", updated.Version)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+	})
+
+	t.Run("a full update leaving prices alone keeps metrics", func(t *testing.T) {
+		// Converted at a rate that is no longer on file, so a recalculation would change it
+		stored := storedTrade()
+		fxRate := 0.9
+		pl := 450.0
+		stored.FXRate = &fxRate
+		stored.PL = &pl
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: stored, UpdateResult: storedTrade()}
+		accountSpy := &AccountRepositorySpy{}
+
+		req := toUpdateRequest(stored)
+		req.Notes = "second take"
+		_, err := newService(tradeSpy, accountSpy).UpdateTrade(ctx, tradeID, userID, req, 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.PL == nil || *updated.PL != 450 || updated.FXRate == nil || *updated.FXRate != 0.9 {
+			t.Errorf("expected stored P/L and rate to be kept, got %v at %v", updated.PL, updated.FXRate)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+	})
+
+	t.Run("patching exit recalculates P/L", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: storedTrade(), UpdateResult: storedTrade()}
+		accountSpy := &AccountRepositorySpy{}
+
+		_, err := newService(tradeSpy, accountSpy).PatchTrade(ctx, tradeID, userID, []byte(`{"exit": 1.1100}`), 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.PL == nil || *updated.PL < 999.99 || *updated.PL > 1000.01 {
+			t.Errorf("expected P/L to be recalculated to 1000, got %v", updated.PL)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 1 {
+			t.Fatalf("expected 1 call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+		if amount := accountSpy.UpdateBalanceCalls[0].Amount; amount < 499.99 || amount > 500.01 {
+			t.Errorf("expected balance difference 500, got %.2f", amount)
+		}
+	})
+
+	t.Run("null clears a field", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: storedTrade(), UpdateResult: storedTrade()}
+
+		_, err := newService(tradeSpy, &AccountRepositorySpy{}).PatchTrade(ctx, tradeID, userID, []byte(`{"take_profit": null}`), 3)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if takeProfit := tradeSpy.UpdateCalls[0].TakeProfit; takeProfit != nil {
+			t.Errorf("expected take profit to be cleared, got %v", *takeProfit)
+		}
+	})
+
+	t.Run("rejects invalid patches", func(t *testing.T) {
+		patches := map[string]string{
+			"unknown field": `{"colour": "red"}`,
+			"wrong type":    `{"lots": "many"}`,
+			"not an object": `[1, 2]`,
+		}
+		for name, patch := range patches {
+			tradeSpy := &TradeRepositorySpy{GetByIDResult: storedTrade()}
+
+			_, err := newService(tradeSpy, &AccountRepositorySpy{}).PatchTrade(ctx, tradeID, userID, []byte(patch), 3)
+			if !errors.Is(err, ErrInvalidPatch) {
+				t.Errorf("%s: expected ErrInvalidPatch, got %v", name, err)
+			}
+			if len(tradeSpy.UpdateCalls) != 0 {
+				t.Errorf("%s: expected no call to Update, got %d", name, len(tradeSpy.UpdateCalls))
+			}
+		}
+	})
+
+	t.Run("stale version is rejected", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: storedTrade()}
+
+		_, err := newService(tradeSpy, &AccountRepositorySpy{}).PatchTrade(ctx, tradeID, userID, []byte(`{"notes": "late"}`), 2)
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
+		}
+		if len(tradeSpy.UpdateCalls) != 0 {
+			t.Errorf("expected no call to Update, got %d", len(tradeSpy.UpdateCalls))
+		}
+	})
+}
+
+func TestService_DuplicateTrade(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+
+	exit := 1.1050
+	pl := 500.0
+	stopLoss := 1.0950
+	grade := "A"
+	closedTrade := &tradedom.Trade{
+		ID:         tradeID,
+		UserID:     userID,
+		AccountID:  &accountID,
+		Date:       time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+		Time:       time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+		Pair:       "EUR/USD",
+		Type:       tradedom.TradeTypeBuy,
+		Status:     tradedom.TradeStatusClosed,
+		OrderType:  tradedom.OrderTypeMarket,
+		Entry:      1.1000,
+		Exit:       &exit,
+		Lots:       1.0,
+		PL:         &pl,
+		StopLoss:   &stopLoss,
+		Notes:      "london open breakout",
+		Mistakes:   "moved stop",
+		Grade:      grade,
+		Commission: 7,
+		Strategies: []tradedom.Strategy{{ID: 4, Name: "Breakout"}},
+		Tags:       []tradedom.Tag{{ID: 2, Name: "london"}},
+		Version:    5,
+	}
+
+	t.Run("clones the setup into a new open trade", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: closedTrade, CreateResult: &tradedom.Trade{ID: 2, UserID: userID, AccountID: &accountID}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		before := time.Now().UTC().Truncate(time.Minute)
+		_, err := service.DuplicateTrade(ctx, tradeID, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tradeSpy.CreateCalls) != 1 {
+			t.Fatalf("expected 1 call to Create, got %d", len(tradeSpy.CreateCalls))
+		}
+		created := tradeSpy.CreateCalls[0]
+		if created.Pair != "EUR/USD" || created.Type != tradedom.TradeTypeBuy || created.Lots != 1.0 || created.Entry != 1.1000 {
+			t.Errorf("expected setup to be copied, got %s %s %v lots at %v", created.Type, created.Pair, created.Lots, created.Entry)
+		}
+		if created.StopLoss == nil || *created.StopLoss != stopLoss || created.Notes != "london open breakout" {
+			t.Errorf("expected stop loss and notes to be copied, got %v %q", created.StopLoss, created.Notes)
+		}
+		if len(created.Strategies) != 1 || created.Strategies[0].ID != 4 {
+			t.Errorf("expected strategy 4 to be copied, got %v", created.Strategies)
+		}
+		if len(created.Tags) != 1 || created.Tags[0].Name != "london" {
+			t.Errorf("expected tag london to be copied, got %v", created.Tags)
+		}
+		if created.Status != tradedom.TradeStatusOpen || created.Exit != nil || created.PL != nil || created.ClosedAt != nil {
+			t.Errorf("expected an open trade without exit or P/L, got status %s exit %v pl %v", created.Status, created.Exit, created.PL)
+		}
+		if created.Mistakes != "" || created.Grade != "" || created.Commission != 0 {
+			t.Errorf("expected outcome fields to be left behind, got mistakes %q grade %q commission %v", created.Mistakes, created.Grade, created.Commission)
+		}
+		if created.OpenedAt.Before(before) {
+			t.Errorf("expected a fresh timestamp, got %v", created.OpenedAt)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+	})
+
+	t.Run("deposits cannot be duplicated", func(t *testing.T) {
+		amount := 1000.0
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID, AccountID: &accountID, Type: tradedom.TradeTypeDeposit, Amount: &amount}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.DuplicateTrade(ctx, tradeID, userID)
+		if !errors.Is(err, ErrDuplicateNotSupported) {
+			t.Fatalf("expected ErrDuplicateNotSupported, got %v", err)
+		}
+		if len(tradeSpy.CreateCalls) != 0 {
+			t.Errorf("expected no call to Create, got %d", len(tradeSpy.CreateCalls))
+		}
+	})
+}
+
+func TestService_ListTrades(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+
+	newService := func(tradeSpy *TradeRepositorySpy) *Service {
+		return NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+	}
+
+	t.Run("parses the query into a filter", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{}
+
+		_, err := newService(tradeSpy).ListTrades(ctx, userID, ListTradesRequest{
+			AccountID:   "3",
+			StartDate:   "2025-01-13",
+			EndDate:     "2025-01-17",
+			Pairs:       []string{"EUR/USD,GBP/USD"},
+			Types:       []string{"buy"},
+			Statuses:    []string{"closed"},
+			StrategyIDs: []string{"4", "5"},
+			MinPL:       "-100",
+			MaxR:        "2.5",
+			Weekdays:    []string{"mon", "Friday"},
+			Hours:       []string{"8", "9"},
+			Sort:        []string{"-pl,pair"},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tradeSpy.FindCalls) != 1 {
+			t.Fatalf("expected 1 call to Find, got %d", len(tradeSpy.FindCalls))
+		}
+		filter := tradeSpy.FindCalls[0].Filter
+		if filter.AccountID == nil || *filter.AccountID != 3 {
+			t.Errorf("expected account 3, got %v", filter.AccountID)
+		}
+		if filter.OpenedFrom == nil || !filter.OpenedFrom.Equal(time.Date(2025, 1, 13, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected trades opened from 2025-01-13, got %v", filter.OpenedFrom)
+		}
+		if filter.OpenedTo == nil || !filter.OpenedTo.Equal(time.Date(2025, 1, 18, 0, 0, 0, 0, time.UTC)) {
+			t.Errorf("expected the end date to be inclusive, got %v", filter.OpenedTo)
+		}
+		if !slices.Equal(filter.Pairs, []string{"EUR/USD", "GBP/USD"}) {
+			t.Errorf("expected comma-separated pairs to be split, got %v", filter.Pairs)
+		}
+		if !slices.Equal(filter.Types, []tradedom.TradeType{tradedom.TradeTypeBuy}) || !slices.Equal(filter.Statuses, []tradedom.TradeStatus{tradedom.TradeStatusClosed}) {
+			t.Errorf("expected BUY closed trades, got %v %v", filter.Types, filter.Statuses)
+		}
+		if !slices.Equal(filter.StrategyIDs, []int64{4, 5}) {
+			t.Errorf("expected strategies 4 and 5, got %v", filter.StrategyIDs)
+		}
+		if filter.MinPL == nil || *filter.MinPL != -100 || filter.MaxPL != nil || filter.MaxR == nil || *filter.MaxR != 2.5 {
+			t.Errorf("expected P/L of at least -100 and at most 2.5R, got %v %v %v", filter.MinPL, filter.MaxPL, filter.MaxR)
+		}
+		if !slices.Equal(filter.Weekdays, []time.Weekday{time.Monday, time.Friday}) || !slices.Equal(filter.Hours, []int{8, 9}) {
+			t.Errorf("expected Monday and Friday at 8 and 9, got %v %v", filter.Weekdays, filter.Hours)
+		}
+		if filter.Location == nil || filter.Location.String() != "UTC" {
+			t.Errorf("expected weekdays and hours to be read in the user's timezone, got %v", filter.Location)
+		}
+		expectedSort := []tradedom.Sort{{Key: tradedom.SortByPL, Descending: true}, {Key: tradedom.SortByPair}}
+		if !slices.Equal(filter.Sort, expectedSort) {
+			t.Errorf("expected sort %v, got %v", expectedSort, filter.Sort)
+		}
+	})
+
+	t.Run("reports every invalid value", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{}
+
+		_, err := newService(tradeSpy).ListTrades(ctx, userID, ListTradesRequest{
+			Types:    []string{"LONG"},
+			MaxLots:  "many",
+			Weekdays: []string{"someday"},
+			Hours:    []string{"24"},
+			Sort:     []string{"-profit"},
+		})
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected ValidationError, got %v", err)
+		}
+		var fields []string
+		for _, f := range validationErr.Fields {
+			fields = append(fields, f.Field)
+		}
+		if !slices.Equal(fields, []string{"type", "max_lots", "weekday", "hour", "sort"}) {
+			t.Errorf("expected errors on type, max_lots, weekday, hour and sort, got %v", fields)
+		}
+		if len(tradeSpy.FindCalls) != 0 {
+			t.Errorf("expected no call to Find, got %d", len(tradeSpy.FindCalls))
+		}
+	})
+
+	t.Run("pages with the default size and returns the next cursor", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			FindResult:     []*tradedom.Trade{{ID: 7, UserID: userID, Type: tradedom.TradeTypeBuy, Status: tradedom.TradeStatusOpen}},
+			FindNextCursor: "next",
+		}
+
+		page, err := newService(tradeSpy).ListTrades(ctx, userID, ListTradesRequest{Cursor: "previous"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := tradeSpy.FindCalls[0].Page; got.Limit != DefaultPageSize || got.Cursor != "previous" {
+			t.Errorf("expected a page of %d after the given cursor, got %+v", DefaultPageSize, got)
+		}
+		if len(page.Trades) != 1 || page.Trades[0].ID != 7 {
+			t.Errorf("expected trade 7, got %v", page.Trades)
+		}
+		if page.NextCursor == nil || *page.NextCursor != "next" {
+			t.Errorf("expected next cursor, got %v", page.NextCursor)
+		}
+	})
+
+	t.Run("has no next cursor on the last page", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{}
+
+		page, err := newService(tradeSpy).ListTrades(ctx, userID, ListTradesRequest{Limit: "10"})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if got := tradeSpy.FindCalls[0].Page.Limit; got != 10 {
+			t.Errorf("expected a page of 10, got %d", got)
+		}
+		if page.NextCursor != nil {
+			t.Errorf("expected no next cursor, got %q", *page.NextCursor)
+		}
+		if page.Trades == nil {
+			t.Error("expected an empty list rather than nil so it encodes as []")
+		}
+	})
+
+	t.Run("rejects a limit out of range", func(t *testing.T) {
+		for _, limit := range []string{"0", "201", "ten"} {
+			tradeSpy := &TradeRepositorySpy{}
+
+			_, err := newService(tradeSpy).ListTrades(ctx, userID, ListTradesRequest{Limit: limit})
+
+			var validationErr *tradedom.ValidationError
+			if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "limit" {
+				t.Errorf("limit %q: expected a validation error on limit, got %v", limit, err)
+			}
+			if len(tradeSpy.FindCalls) != 0 {
+				t.Errorf("limit %q: expected no call to Find, got %d", limit, len(tradeSpy.FindCalls))
+			}
+		}
+	})
+
+	t.Run("reports an invalid cursor", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{FindError: tradedom.ErrInvalidCursor}
+
+		_, err := newService(tradeSpy).ListTrades(ctx, userID, ListTradesRequest{Cursor: "garbage"})
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) || validationErr.Fields[0].Field != "cursor" {
+			t.Errorf("expected a validation error on cursor, got %v", err)
+		}
+	})
+}
+
+func TestService_SearchTrades(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+
+	newService := func(tradeSpy *TradeRepositorySpy) *Service {
+		return NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+	}
+
+	t.Run("passes the trimmed query with the default limit", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{SearchResult: []*tradedom.SearchResult{
+			{
+				Trade:        &tradedom.Trade{ID: 7, UserID: userID, Pair: "EUR/USD", Type: tradedom.TradeTypeBuy},
+				Rank:         0.6,
+				NotesSnippet: "faded the <mark>NFP</mark> spike",
+			},
+		}}
+
+		results, err := newService(tradeSpy).SearchTrades(ctx, userID, "  nfp spike ", 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tradeSpy.SearchCalls) != 1 {
+			t.Fatalf("expected 1 call to Search, got %d", len(tradeSpy.SearchCalls))
+		}
+		call := tradeSpy.SearchCalls[0]
+		if call.Query != "nfp spike" || call.Limit != DefaultSearchLimit {
+			t.Errorf("expected query %q with limit %d, got %q with %d", "nfp spike", DefaultSearchLimit, call.Query, call.Limit)
+		}
+		if len(results) != 1 || results[0].Trade.ID != 7 {
+			t.Fatalf("expected trade 7 in the results, got %v", results)
+		}
+		if results[0].NotesSnippet != "faded the <mark>NFP</mark> spike" || results[0].MistakesSnippet != "" {
+			t.Errorf("expected only the notes snippet, got %q and %q", results[0].NotesSnippet, results[0].MistakesSnippet)
+		}
+	})
+
+	t.Run("rejects an empty query", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{}
+
+		_, err := newService(tradeSpy).SearchTrades(ctx, userID, "   ", 0)
+		if !errors.Is(err, ErrSearchQueryRequired) {
+			t.Fatalf("expected ErrSearchQueryRequired, got %v", err)
+		}
+		if len(tradeSpy.SearchCalls) != 0 {
+			t.Errorf("expected no call to Search, got %d", len(tradeSpy.SearchCalls))
+		}
+	})
+
+	t.Run("rejects a limit above the maximum", func(t *testing.T) {
+		_, err := newService(&TradeRepositorySpy{}).SearchTrades(ctx, userID, "nfp", MaxSearchLimit+1)
+		if !errors.Is(err, ErrInvalidSearchLimit) {
+			t.Fatalf("expected ErrInvalidSearchLimit, got %v", err)
+		}
+	})
+}
+
+func TestService_BulkTrades(t *testing.T) {
+	ctx := context.Background()
+	userID := int64(1)
+	liveAccount := int64(1)
+	demoAccount := int64(2)
+
+	closedTrade := func(id int64, pl float64, tags ...string) *tradedom.Trade {
+		exit := 1.1050
+		return &tradedom.Trade{
+			ID:        id,
+			UserID:    userID,
+			AccountID: &liveAccount,
+			Date:      time.Date(2025, 1, 15, 0, 0, 0, 0, time.UTC),
+			Time:      time.Date(0, 1, 1, 10, 0, 0, 0, time.UTC),
+			Pair:      "EUR/USD",
+			Type:      tradedom.TradeTypeBuy,
+			Status:    tradedom.TradeStatusClosed,
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+			PL:        &pl,
+			Tags:      tradedom.NewTags(tags),
+			Version:   1,
+		}
+	}
+	deposit := func(id int64, amount float64) *tradedom.Trade {
+		return &tradedom.Trade{
+			ID:        id,
+			UserID:    userID,
+			AccountID: &liveAccount,
+			Date:      time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC),
+			Time:      time.Date(0, 1, 1, 9, 0, 0, 0, time.UTC),
+			Type:      tradedom.TradeTypeDeposit,
+			Status:    tradedom.TradeStatusClosed,
+			Amount:    &amount,
+			Version:   1,
+		}
+	}
+
+	newService := func(tradeSpy *TradeRepositorySpy, accountSpy *AccountRepositorySpy, auditSpy *AuditRepositorySpy) (*Service, *TransactorSpy) {
+		transactor := &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy}
+		return NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditSpy, transactor), transactor
+	}
+
+	t.Run("delete sums balance effects per account", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResults: map[int64]*tradedom.Trade{
+			1: closedTrade(1, 500),
+			2: closedTrade(2, -200),
+			3: deposit(3, 1000),
+		}}
+		accountSpy := &AccountRepositorySpy{}
+		auditSpy := &AuditRepositorySpy{}
+		service, transactor := newService(tradeSpy, accountSpy, auditSpy)
+
+		result, err := service.BulkTrades(ctx, userID, BulkTradeRequest{
+			Action:   BulkDelete,
+			TradeIDs: []int64{1, 2, 3, 2},
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if !result.Applied || !transactor.Committed {
+			t.Errorf("expected the operation to commit")
+		}
+		if len(result.Items) != 3 {
+			t.Fatalf("expected 3 items for 3 distinct trades, got %d", len(result.Items))
+		}
+		for _, item := range result.Items {
+			if item.Status != BulkStatusDeleted {
+				t.Errorf("expected trade %d to be deleted, got %s", item.TradeID, item.Status)
+			}
+		}
+		if len(tradeSpy.DeleteCalls) != 3 {
+			t.Errorf("expected 3 calls to Delete, got %d", len(tradeSpy.DeleteCalls))
+		}
+
+		// Both trades and the deposit sit on one account, which is updated once with the net amount
+		if len(accountSpy.UpdateBalanceCalls) != 1 {
+			t.Fatalf("expected 1 call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+		if call := accountSpy.UpdateBalanceCalls[0]; call.ID != liveAccount || call.Amount != -1300 {
+			t.Errorf("expected -1300 on account %d, got %.2f on account %d", liveAccount, call.Amount, call.ID)
+		}
+		if len(result.BalanceChanges) != 1 || result.BalanceChanges[0].Amount != -1300 {
+			t.Errorf("expected a single balance change of -1300, got %+v", result.BalanceChanges)
+		}
+		if len(auditSpy.Events) != 3 {
+			t.Errorf("expected 3 audit events, got %d", len(auditSpy.Events))
+		}
+	})
+
+	t.Run("a failing trade rolls back the whole operation", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResults: map[int64]*tradedom.Trade{
+			1: closedTrade(1, 500),
+		}}
+		accountSpy := &AccountRepositorySpy{}
+		auditSpy := &AuditRepositorySpy{}
+		service, transactor := newService(tradeSpy, accountSpy, auditSpy)
+
+		result, err := service.BulkTrades(ctx, userID, BulkTradeRequest{
+			Action:   BulkDelete,
+			TradeIDs: []int64{1, 99},
+		})
+		if !errors.Is(err, ErrBulkRejected) {
+			t.Fatalf("expected ErrBulkRejected, got %v", err)
+		}
+
+		if result.Applied || !transactor.RolledBack {
+			t.Errorf("expected the operation to roll back")
+		}
+		if result.Items[0].Status != BulkStatusDeleted {
+			t.Errorf("expected trade 1 to report deleted, got %s", result.Items[0].Status)
+		}
+		if failed := result.Items[1]; failed.Status != BulkStatusFailed || failed.Error != ErrTradeNotFound.Error() {
+			t.Errorf("expected trade 99 to fail with %q, got %s %q", ErrTradeNotFound, failed.Status, failed.Error)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+		if len(auditSpy.Events) != 0 {
+			t.Errorf("expected no audit events, got %d", len(auditSpy.Events))
+		}
+	})
+
+	t.Run("add tag skips trades that already have it", func(t *testing.T) {
+		tagged := closedTrade(2, 300, "news")
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResults: map[int64]*tradedom.Trade{
+				1: closedTrade(1, 500),
+				2: tagged,
+			},
+			UpdateResult: closedTrade(1, 500, "news"),
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service, _ := newService(tradeSpy, accountSpy, &AuditRepositorySpy{})
+
+		result, err := service.BulkTrades(ctx, userID, BulkTradeRequest{
+			Action:   BulkAddTag,
+			TradeIDs: []int64{1, 2},
+			Tag:      "News",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if result.Items[0].Status != BulkStatusUpdated || result.Items[1].Status != BulkStatusUnchanged {
+			t.Errorf("expected updated and unchanged, got %s and %s", result.Items[0].Status, result.Items[1].Status)
+		}
+		if len(tradeSpy.UpdateCalls) != 1 {
+			t.Fatalf("expected 1 call to Update, got %d", len(tradeSpy.UpdateCalls))
+		}
+		if tags := tradeSpy.UpdateCalls[0].Tags; len(tags) != 1 || tags[0].Name != "news" {
+			t.Errorf("expected the news tag to be added, got %v", tags)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+	})
+
+	t.Run("move account carries P/L and deposits to the new account", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResults: map[int64]*tradedom.Trade{
+				1: closedTrade(1, 500),
+				2: deposit(2, 1000),
+			},
+			UpdateResult: closedTrade(1, 500),
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service, _ := newService(tradeSpy, accountSpy, &AuditRepositorySpy{})
+
+		result, err := service.BulkTrades(ctx, userID, BulkTradeRequest{
+			Action:    BulkMoveAccount,
+			TradeIDs:  []int64{1, 2},
+			AccountID: &demoAccount,
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		want := map[int64]float64{liveAccount: -1500, demoAccount: 1500}
+		if len(accountSpy.UpdateBalanceCalls) != len(want) {
+			t.Fatalf("expected %d calls to UpdateBalance, got %d", len(want), len(accountSpy.UpdateBalanceCalls))
+		}
+		for _, call := range accountSpy.UpdateBalanceCalls {
+			if call.Amount != want[call.ID] {
+				t.Errorf("expected %.2f on account %d, got %.2f", want[call.ID], call.ID, call.Amount)
+			}
+		}
+		for _, updated := range tradeSpy.UpdateCalls {
+			if *updated.AccountID != demoAccount {
+				t.Errorf("expected trade %d to move to account %d, got %d", updated.ID, demoAccount, *updated.AccountID)
+			}
+		}
+		if len(result.BalanceChanges) != 2 {
+			t.Errorf("expected 2 balance changes, got %+v", result.BalanceChanges)
+		}
+	})
+
+	t.Run("rejects incomplete requests", func(t *testing.T) {
+		requests := map[string]BulkTradeRequest{
+			"missing action":     {TradeIDs: []int64{1}},
+			"unknown action":     {Action: "archive", TradeIDs: []int64{1}},
+			"missing trade ids":  {Action: BulkDelete},
+			"missing account id": {Action: BulkMoveAccount, TradeIDs: []int64{1}},
+			"missing strategy":   {Action: BulkAddStrategy, TradeIDs: []int64{1}},
+			"blank tag":          {Action: BulkRemoveTag, TradeIDs: []int64{1}, Tag: "  "},
+		}
+		for name, req := range requests {
+			service, transactor := newService(&TradeRepositorySpy{}, &AccountRepositorySpy{}, &AuditRepositorySpy{})
+
+			_, err := service.BulkTrades(ctx, userID, req)
+			var validationErr *tradedom.ValidationError
+			if !errors.As(err, &validationErr) {
+				t.Errorf("%s: expected a validation error, got %v", name, err)
+			}
+			if transactor.Committed || transactor.RolledBack {
+				t.Errorf("%s: expected no transaction", name)
+			}
+		}
+	})
+
+	t.Run("unknown target account fails the whole request", func(t *testing.T) {
+		accountSpy := &AccountRepositorySpy{GetByIDError: sql.ErrNoRows}
+		service, _ := newService(&TradeRepositorySpy{}, accountSpy, &AuditRepositorySpy{})
+
+		_, err := service.BulkTrades(ctx, userID, BulkTradeRequest{
+			Action:    BulkMoveAccount,
+			TradeIDs:  []int64{1},
+			AccountID: &demoAccount,
+		})
+		if !errors.Is(err, ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got %v", err)
+		}
+	})
+}
+
+func TestService_RestoreTrade(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+
+	t.Run("restoring closed trade re-applies P/L", func(t *testing.T) {
+		pl := 50.0
+		trashed := &tradedom.Trade{
+			ID:        tradeID,
+			UserID:    userID,
+			AccountID: &accountID,
+			Type:      tradedom.TradeTypeBuy,
+			PL:        &pl,
+		}
+		tradeSpy := &TradeRepositorySpy{
+			GetDeletedByIDResult: trashed,
+			RestoreResult:        trashed,
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.RestoreTrade(ctx, tradeID, userID)
+
+		// Assert no error
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		// Assert trade was restored
+		if len(tradeSpy.RestoreCalls) != 1 {
+			t.Fatalf("expected 1 call to Restore, got %d", len(tradeSpy.RestoreCalls))
+		}
+
+		// Assert balance was re-applied
+		if len(accountSpy.UpdateBalanceCalls) != 1 {
+			t.Fatalf("expected 1 call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+
+		balanceCall := accountSpy.UpdateBalanceCalls[0]
+		if balanceCall.Amount != 50.0 {
+			t.Errorf("expected balance change 50.0, got %.2f", balanceCall.Amount)
+		}
+	})
+
+	t.Run("restoring withdraw subtracts amount again", func(t *testing.T) {
+		amount := 500.0
+		trashed := &tradedom.Trade{
+			ID:        tradeID,
+			UserID:    userID,
+			AccountID: &accountID,
+			Type:      tradedom.TradeTypeWithdraw,
+			Amount:    &amount,
+		}
+		tradeSpy := &TradeRepositorySpy{
+			GetDeletedByIDResult: trashed,
+			RestoreResult:        trashed,
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		if _, err := service.RestoreTrade(ctx, tradeID, userID); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(accountSpy.UpdateBalanceCalls) != 1 {
+			t.Fatalf("expected 1 call to UpdateBalance, got %d", len(accountSpy.UpdateBalanceCalls))
+		}
+
+		balanceCall := accountSpy.UpdateBalanceCalls[0]
+		if balanceCall.Amount != -500.0 {
+			t.Errorf("expected balance change -500.0, got %.2f", balanceCall.Amount)
+		}
+	})
+
+	t.Run("trade not in trash", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetDeletedByIDError: tradedom.ErrNotFound,
+		}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.RestoreTrade(ctx, tradeID, userID)
+		if !errors.Is(err, ErrTradeNotFound) {
+			t.Errorf("expected ErrTradeNotFound, got %v", err)
+		}
+	})
+
+	t.Run("account in trash blocks restore", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetDeletedByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Type:      tradedom.TradeTypeBuy,
+			},
+		}
+		accountSpy := &AccountRepositorySpy{GetByIDError: account.ErrNotFound}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.RestoreTrade(ctx, tradeID, userID)
+		if !errors.Is(err, ErrAccountDeleted) {
+			t.Errorf("expected ErrAccountDeleted, got %v", err)
+		}
+
+		if len(tradeSpy.RestoreCalls) != 0 {
+			t.Errorf("expected no call to Restore, got %d", len(tradeSpy.RestoreCalls))
+		}
+	})
+}
+
+func TestService_TrashedAccount(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	trashedAccountID := int64(2)
+	userID := int64(1)
+	tradeID := int64(1)
+
+	t.Run("a trade cannot be created on an account in the trash", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: tradeID}}
+		accountSpy := &AccountRepositorySpy{GetByIDError: sql.ErrNoRows}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		amount := 1000.0
+		_, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID: &trashedAccountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Type:      "DEPOSIT",
+			Amount:    &amount,
+		})
+		if !errors.Is(err, ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got %v", err)
+		}
+		if len(tradeSpy.CreateCalls) != 0 || len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Error("expected no trade and no balance change")
+		}
+	})
+
+	t.Run("a trade cannot be moved to an account in the trash", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Type:      tradedom.TradeTypeBuy,
+				Entry:     1.1000,
+				Lots:      1.0,
+				Status:    tradedom.TradeStatusOpen,
+			},
+			UpdateResult: &tradedom.Trade{ID: tradeID},
+		}
+		accountSpy := &AccountRepositorySpy{GetByIDError: account.ErrNotFound}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
+			AccountID: &trashedAccountID,
+			Date:      "2025-01-15",
+			Time:      "10:00",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+		}, 0)
+		if !errors.Is(err, ErrAccountNotFound) {
+			t.Fatalf("expected ErrAccountNotFound, got %v", err)
+		}
+		if len(tradeSpy.UpdateCalls) != 0 || len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Error("expected no update and no balance change")
+		}
+	})
+}
+
+func TestService_AuditLog(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+
+	t.Run("update records changed fields only", func(t *testing.T) {
+		oldStop := 1.0950
+		newStop := 1.0980
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Pair:      "EUR/USD",
+				Type:      tradedom.TradeTypeBuy,
+				Status:    tradedom.TradeStatusOpen,
+				OrderType: tradedom.OrderTypeMarket,
+				Entry:     1.1000,
+				Lots:      1.0,
+				StopLoss:  &oldStop,
+				Notes:     "clean breakout",
+			},
+			UpdateResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Pair:      "EUR/USD",
+				Type:      tradedom.TradeTypeBuy,
+				Status:    tradedom.TradeStatusOpen,
+				OrderType: tradedom.OrderTypeMarket,
+				Entry:     1.1000,
+				Lots:      1.0,
+				StopLoss:  &newStop,
+				Notes:     "late entry after the breakout",
+				UpdatedAt: time.Now(),
+			},
+		}
+		auditSpy := &AuditRepositorySpy{}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditSpy, &TransactorSpy{})
+
+		_, err := service.UpdateTrade(ctx, tradeID, userID, UpdateTradeRequest{
+			AccountID: &accountID,
+			Date:      time.Now().Format("2006-01-02"),
+			Time:      time.Now().Format("15:04"),
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			StopLoss:  &newStop,
+			Notes:     "late entry after the breakout",
+		}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(auditSpy.Events) != 1 {
+			t.Fatalf("expected 1 audit event, got %d", len(auditSpy.Events))
+		}
+		event := auditSpy.Events[0]
+		if event.Action != audit.ActionUpdate || event.EntityType != audit.EntityTrade || event.EntityID != tradeID || event.UserID != userID {
+			t.Errorf("unexpected event %+v", event)
+		}
+
+		if len(event.Changes) != 2 {
+			t.Errorf("expected 2 changed fields, got %v", event.Changes)
+		}
+		if change := event.Changes["stop_loss"]; change.From != oldStop || change.To != newStop {
+			t.Errorf("expected stop_loss change %.4f -> %.4f, got %v", oldStop, newStop, change)
+		}
+		if change := event.Changes["notes"]; change.From != "clean breakout" || change.To != "late entry after the breakout" {
+			t.Errorf("unexpected notes change %v", change)
+		}
+	})
+
+	t.Run("delete records the trade as it was", func(t *testing.T) {
+		amount := 1000.0
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Type:      tradedom.TradeTypeDeposit,
+				Amount:    &amount,
+			},
+		}
+		auditSpy := &AuditRepositorySpy{}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditSpy, &TransactorSpy{})
+
+		if err := service.DeleteTrade(ctx, tradeID, userID, 0); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(auditSpy.Events) != 1 || auditSpy.Events[0].Action != audit.ActionDelete {
+			t.Fatalf("expected 1 delete event, got %v", auditSpy.Events)
+		}
+		if change := auditSpy.Events[0].Changes["amount"]; change.From != amount || change.To != nil {
+			t.Errorf("expected amount change %.2f -> nil, got %v", amount, change)
+		}
+	})
+
+	t.Run("failed delete records nothing", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID, Type: tradedom.TradeTypeBuy},
+			DeleteError:   tradedom.ErrNotFound,
+		}
+		auditSpy := &AuditRepositorySpy{}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditSpy, &TransactorSpy{})
+
+		if err := service.DeleteTrade(ctx, tradeID, userID, 0); err == nil {
+			t.Fatal("expected error")
+		}
+		if len(auditSpy.Events) != 0 {
+			t.Errorf("expected no audit events, got %d", len(auditSpy.Events))
+		}
+	})
+
+	t.Run("history lists the trade's events", func(t *testing.T) {
+		auditSpy := &AuditRepositorySpy{Events: []*audit.Event{
+			{ID: 1, UserID: userID, EntityType: audit.EntityTrade, EntityID: tradeID, Action: audit.ActionCreate},
+			{ID: 2, UserID: userID, EntityType: audit.EntityAccount, EntityID: tradeID, Action: audit.ActionCreate},
+			{ID: 3, UserID: userID, EntityType: audit.EntityTrade, EntityID: tradeID, Action: audit.ActionUpdate,
+				Changes: map[string]audit.Change{"entry": {From: 1.1, To: 1.2}}},
+		}}
+		service := NewService(&TradeRepositorySpy{}, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditSpy, &TransactorSpy{})
+
+		history, err := service.GetTradeHistory(ctx, tradeID, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(history) != 2 {
+			t.Fatalf("expected 2 events, got %d", len(history))
+		}
+		if history[1].Action != "update" || history[1].ActorID != userID {
+			t.Errorf("unexpected event %+v", history[1])
+		}
+		if change := history[1].Changes["entry"]; change.From != 1.1 || change.To != 1.2 {
+			t.Errorf("unexpected entry change %v", change)
+		}
+	})
+}
+
+func TestService_UpdateChartBefore(t *testing.T) {
+	ctx := context.Background()
+	tradeID := int64(1)
+	userID := int64(1)
+	chartURL := "http://localhost:9000/trade-journal/chart-before.png"
 
 	t.Run("updates chart before successfully", func(t *testing.T) {
 		updatedTrade := &tradedom.Trade{
@@ -819,10 +2947,11 @@ func TestService_UpdateChartBefore(t *testing.T) {
 			ChartBefore: &chartURL,
 		}
 
-		tradeRepo := &TradeRepositorySpy{}
+		tradeRepo := &TradeRepositorySpy{GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID}}
 		tradeRepo.UpdateChartBeforeResult = updatedTrade
 		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
+		auditRepo := &AuditRepositorySpy{}
+		service := NewService(tradeRepo, accountRepo, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditRepo, &TransactorSpy{})
 
 		result, err := service.UpdateChartBefore(ctx, tradeID, userID, chartURL)
 
@@ -830,6 +2959,10 @@ func TestService_UpdateChartBefore(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
+		if len(auditRepo.Events) != 1 || auditRepo.Events[0].Action != audit.ActionUpdate {
+			t.Errorf("expected the chart change to be audited as an update, got %v", auditRepo.Events)
+		}
+
 		if result.ChartBefore == nil {
 			t.Fatal("expected chart_before to be set")
 		}
@@ -841,10 +2974,10 @@ func TestService_UpdateChartBefore(t *testing.T) {
 
 	t.Run("propagates error from repository", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		tradeRepo := &TradeRepositorySpy{}
+		tradeRepo := &TradeRepositorySpy{GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID}}
 		tradeRepo.UpdateChartBeforeError = expectedErr
 		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
+		service := NewService(tradeRepo, accountRepo, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.UpdateChartBefore(ctx, tradeID, userID, chartURL)
 
@@ -867,10 +3000,11 @@ func TestService_UpdateChartAfter(t *testing.T) {
 			ChartAfter: &chartURL,
 		}
 
-		tradeRepo := &TradeRepositorySpy{}
+		tradeRepo := &TradeRepositorySpy{GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID}}
 		tradeRepo.UpdateChartAfterResult = updatedTrade
 		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
+		auditRepo := &AuditRepositorySpy{}
+		service := NewService(tradeRepo, accountRepo, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, auditRepo, &TransactorSpy{})
 
 		result, err := service.UpdateChartAfter(ctx, tradeID, userID, chartURL)
 
@@ -878,6 +3012,10 @@ func TestService_UpdateChartAfter(t *testing.T) {
 			t.Fatalf("unexpected error: %v", err)
 		}
 
+		if len(auditRepo.Events) != 1 || auditRepo.Events[0].Action != audit.ActionUpdate {
+			t.Errorf("expected the chart change to be audited as an update, got %v", auditRepo.Events)
+		}
+
 		if result.ChartAfter == nil {
 			t.Fatal("expected chart_after to be set")
 		}
@@ -889,10 +3027,10 @@ func TestService_UpdateChartAfter(t *testing.T) {
 
 	t.Run("propagates error from repository", func(t *testing.T) {
 		expectedErr := errors.New("database error")
-		tradeRepo := &TradeRepositorySpy{}
+		tradeRepo := &TradeRepositorySpy{GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID}}
 		tradeRepo.UpdateChartAfterError = expectedErr
 		accountRepo := &AccountRepositorySpy{}
-		service := NewService(tradeRepo, accountRepo)
+		service := NewService(tradeRepo, accountRepo, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
 
 		_, err := service.UpdateChartAfter(ctx, tradeID, userID, chartURL)
 
@@ -901,3 +3039,367 @@ func TestService_UpdateChartAfter(t *testing.T) {
 		}
 	})
 }
+
+func TestService_AddExecution(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+	date := time.Date(2025, 1, 10, 0, 0, 0, 0, time.UTC)
+	tradeTime := time.Date(0, 1, 1, 9, 30, 0, 0, time.UTC)
+	openedAt := time.Date(2025, 1, 10, 9, 30, 0, 0, time.UTC)
+
+	t.Run("first scale-out converts the entry into a fill and realizes partial P/L", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Date:      date,
+				Time:      tradeTime,
+				OpenedAt:  openedAt,
+				Pair:      "EURUSD",
+				Type:      tradedom.TradeTypeBuy,
+				Entry:     1.1000,
+				Lots:      1.0,
+				Status:    tradedom.TradeStatusOpen,
+			},
+			UpdateResult: &tradedom.Trade{ID: tradeID},
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy})
+
+		// Close half at +50 pips: 0.0050 * 100,000 * 0.5 = $250
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1050,
+			Lots:       0.5,
+			ExecutedAt: "2025-01-10T12:00:00Z",
+		}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(tradeSpy.AddExecutionCalls) != 2 {
+			t.Fatalf("expected the opening fill and the new fill to be stored, got %d", len(tradeSpy.AddExecutionCalls))
+		}
+		opening := tradeSpy.AddExecutionCalls[0]
+		if opening.Side != tradedom.TradeTypeBuy || opening.Price != 1.1000 || opening.Lots != 1.0 {
+			t.Errorf("unexpected opening fill: %+v", opening)
+		}
+		if !opening.ExecutedAt.Equal(openedAt) {
+			t.Errorf("expected opening fill at the time the trade was opened, got %v", opening.ExecutedAt)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.Status != tradedom.TradeStatusOpen {
+			t.Errorf("expected partially closed trade to stay open, got %s", updated.Status)
+		}
+		if updated.OpenLots() != 0.5 {
+			t.Errorf("expected 0.5 open lots, got %.2f", updated.OpenLots())
+		}
+		if updated.PL == nil || *updated.PL != 250 {
+			t.Errorf("expected realized P/L 250, got %v", updated.PL)
+		}
+
+		if len(accountSpy.UpdateBalanceCalls) != 1 || accountSpy.UpdateBalanceCalls[0].Amount != 250 {
+			t.Errorf("expected balance to be credited with 250, got %+v", accountSpy.UpdateBalanceCalls)
+		}
+	})
+
+	t.Run("closing the remainder closes the trade and credits only the new P/L", func(t *testing.T) {
+		exit := 1.1050
+		pl := 250.0
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Pair:      "EURUSD",
+				Type:      tradedom.TradeTypeBuy,
+				Entry:     1.1000,
+				Exit:      &exit,
+				Lots:      1.0,
+				PL:        &pl,
+				Status:    tradedom.TradeStatusOpen,
+				Executions: []tradedom.Execution{
+					{ID: 1, Side: tradedom.TradeTypeBuy, Price: 1.1000, Lots: 1.0, ExecutedAt: time.Date(2025, 1, 10, 9, 30, 0, 0, time.UTC)},
+					{ID: 2, Side: tradedom.TradeTypeSell, Price: 1.1050, Lots: 0.5, ExecutedAt: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)},
+				},
+			},
+			UpdateResult: &tradedom.Trade{ID: tradeID},
+		}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy})
+
+		// Trail the rest out at +100 pips: 0.0100 * 100,000 * 0.5 = $500
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1100,
+			Lots:       0.5,
+			ExecutedAt: "2025-01-10T16:00:00Z",
+		}, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.Status != tradedom.TradeStatusClosed {
+			t.Errorf("expected trade to be closed, got %s", updated.Status)
+		}
+		if updated.Exit == nil || *updated.Exit != 1.1075 {
+			t.Errorf("expected weighted average exit 1.1075, got %v", updated.Exit)
+		}
+		if updated.PL == nil || *updated.PL != 750 {
+			t.Errorf("expected total P/L 750, got %v", updated.PL)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 1 || accountSpy.UpdateBalanceCalls[0].Amount != 500 {
+			t.Errorf("expected balance to be credited with 500, got %+v", accountSpy.UpdateBalanceCalls)
+		}
+	})
+
+	t.Run("stores no fill when the trade cannot be recalculated", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Date:      date,
+				Time:      tradeTime,
+				OpenedAt:  openedAt,
+				Pair:      "EURUSD",
+				Type:      tradedom.TradeTypeBuy,
+				Entry:     1.1000,
+				Lots:      1.0,
+				Status:    tradedom.TradeStatusOpen,
+			},
+			UpdateResult: &tradedom.Trade{ID: tradeID},
+		}
+		// The account currency the realized P/L converts into cannot be looked up
+		lookupErr := errors.New("connection reset")
+		accountSpy := &AccountRepositorySpy{GetByIDError: lookupErr}
+		transactor := &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, transactor)
+
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1050,
+			Lots:       0.5,
+			ExecutedAt: "2025-01-10T12:00:00Z",
+		}, 0)
+		if !errors.Is(err, lookupErr) {
+			t.Fatalf("expected the lookup error, got %v", err)
+		}
+		if len(tradeSpy.AddExecutionCalls) != 0 || len(tradeSpy.UpdateCalls) != 0 || len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected nothing to be stored, got %d fills, %d updates and %d balance changes",
+				len(tradeSpy.AddExecutionCalls), len(tradeSpy.UpdateCalls), len(accountSpy.UpdateBalanceCalls))
+		}
+	})
+
+	t.Run("rolls the fill back when the trade fails to save", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:        tradeID,
+				UserID:    userID,
+				AccountID: &accountID,
+				Date:      date,
+				Time:      tradeTime,
+				OpenedAt:  openedAt,
+				Pair:      "EURUSD",
+				Type:      tradedom.TradeTypeBuy,
+				Entry:     1.1000,
+				Lots:      1.0,
+				Status:    tradedom.TradeStatusOpen,
+			},
+			UpdateError: tradedom.ErrVersionConflict,
+		}
+		accountSpy := &AccountRepositorySpy{}
+		transactor := &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, transactor)
+
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1050,
+			Lots:       0.5,
+			ExecutedAt: "2025-01-10T12:00:00Z",
+		}, 0)
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
+		}
+		if len(tradeSpy.AddExecutionCalls) == 0 || !transactor.RolledBack {
+			t.Error("expected the fills to be written in a transaction that rolled back")
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 0 {
+			t.Errorf("expected no balance change, got %+v", accountSpy.UpdateBalanceCalls)
+		}
+	})
+
+	t.Run("rejects closing more lots than are open", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{
+				ID:     tradeID,
+				UserID: userID,
+				Date:   date,
+				Time:   tradeTime,
+				Pair:   "EURUSD",
+				Type:   tradedom.TradeTypeBuy,
+				Entry:  1.1000,
+				Lots:   1.0,
+				Status: tradedom.TradeStatusOpen,
+			},
+		}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{
+			Side:       "SELL",
+			Price:      1.1050,
+			Lots:       1.5,
+			ExecutedAt: "2025-01-10T12:00:00Z",
+		}, 0)
+		if !errors.Is(err, ErrExecutionExceedsPosition) {
+			t.Fatalf("expected ErrExecutionExceedsPosition, got %v", err)
+		}
+		if len(tradeSpy.AddExecutionCalls) != 0 || len(tradeSpy.UpdateCalls) != 0 {
+			t.Error("expected nothing to be stored")
+		}
+	})
+
+	t.Run("rejects invalid fills", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{
+			GetByIDResult: &tradedom.Trade{ID: tradeID, UserID: userID, Type: tradedom.TradeTypeBuy, Entry: 1.1, Lots: 1, Status: tradedom.TradeStatusOpen},
+		}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.AddExecution(ctx, tradeID, userID, CreateExecutionRequest{Side: "HOLD", Price: 0, Lots: 1, ExecutedAt: "2025-01-10"}, 0)
+
+		var validationErr *tradedom.ValidationError
+		if !errors.As(err, &validationErr) {
+			t.Fatalf("expected a validation error, got %v", err)
+		}
+		fields := []string{}
+		for _, f := range validationErr.Fields {
+			fields = append(fields, f.Field)
+		}
+		if strings.Join(fields, ",") != "side,price,executed_at" {
+			t.Errorf("expected errors on side, price and executed_at, got %v", fields)
+		}
+	})
+}
+
+func TestService_DeleteExecution(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+	tradeID := int64(1)
+	exit := 1.1075
+	pl := 750.0
+
+	newTrade := func() *tradedom.Trade {
+		return &tradedom.Trade{
+			ID:        tradeID,
+			UserID:    userID,
+			AccountID: &accountID,
+			Pair:      "EURUSD",
+			Type:      tradedom.TradeTypeBuy,
+			Entry:     1.1000,
+			Exit:      &exit,
+			Lots:      1.0,
+			PL:        &pl,
+			Status:    tradedom.TradeStatusClosed,
+			Executions: []tradedom.Execution{
+				{ID: 1, Side: tradedom.TradeTypeBuy, Price: 1.1000, Lots: 1.0, ExecutedAt: time.Date(2025, 1, 10, 9, 30, 0, 0, time.UTC)},
+				{ID: 2, Side: tradedom.TradeTypeSell, Price: 1.1050, Lots: 0.5, ExecutedAt: time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)},
+				{ID: 3, Side: tradedom.TradeTypeSell, Price: 1.1100, Lots: 0.5, ExecutedAt: time.Date(2025, 1, 10, 16, 0, 0, 0, time.UTC)},
+			},
+		}
+	}
+
+	t.Run("removing an exit fill reopens the trade and reverts its P/L", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: newTrade(), UpdateResult: &tradedom.Trade{ID: tradeID}}
+		accountSpy := &AccountRepositorySpy{}
+		service := NewService(tradeSpy, accountSpy, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{Trades: tradeSpy, Accounts: accountSpy})
+
+		_, err := service.DeleteExecution(ctx, tradeID, 3, userID, 0)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		updated := tradeSpy.UpdateCalls[0]
+		if updated.Status != tradedom.TradeStatusOpen {
+			t.Errorf("expected trade to reopen, got %s", updated.Status)
+		}
+		if updated.PL == nil || *updated.PL != 250 {
+			t.Errorf("expected realized P/L 250, got %v", updated.PL)
+		}
+		if len(accountSpy.UpdateBalanceCalls) != 1 || accountSpy.UpdateBalanceCalls[0].Amount != -500 {
+			t.Errorf("expected balance to be debited by 500, got %+v", accountSpy.UpdateBalanceCalls)
+		}
+	})
+
+	t.Run("rejects removing the entry fill while exits remain", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: newTrade()}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.DeleteExecution(ctx, tradeID, 1, userID, 0)
+		if !errors.Is(err, ErrExecutionExceedsPosition) {
+			t.Fatalf("expected ErrExecutionExceedsPosition, got %v", err)
+		}
+		if len(tradeSpy.DeleteExecutionCalls) != 0 {
+			t.Error("expected execution not to be deleted")
+		}
+	})
+
+	t.Run("returns not found for an unknown fill", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: newTrade()}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.DeleteExecution(ctx, tradeID, 99, userID, 0)
+		if !errors.Is(err, tradedom.ErrExecutionNotFound) {
+			t.Fatalf("expected ErrExecutionNotFound, got %v", err)
+		}
+	})
+
+	t.Run("rejects a stale version", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{GetByIDResult: newTrade()}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		_, err := service.DeleteExecution(ctx, tradeID, 3, userID, 1)
+		if !errors.Is(err, tradedom.ErrVersionConflict) {
+			t.Fatalf("expected ErrVersionConflict, got %v", err)
+		}
+		if len(tradeSpy.DeleteExecutionCalls) != 0 || len(tradeSpy.UpdateCalls) != 0 {
+			t.Error("expected the trade to be left untouched")
+		}
+	})
+}
+
+func TestService_Grades(t *testing.T) {
+	ctx := context.Background()
+	accountID := int64(1)
+	userID := int64(1)
+
+	t.Run("passes the trimmed grade to the repository", func(t *testing.T) {
+		tradeSpy := &TradeRepositorySpy{CreateResult: &tradedom.Trade{ID: 1, Grade: "A+"}}
+		service := NewService(tradeSpy, &AccountRepositorySpy{}, &InstrumentRepositorySpy{}, &FXRepositorySpy{}, &UserRepositorySpy{}, &AuditRepositorySpy{}, &TransactorSpy{})
+
+		result, err := service.CreateTrade(ctx, userID, CreateTradeRequest{
+			AccountID: &accountID,
+			Date:      "2025-01-15",
+			Time:      "09:30",
+			Pair:      "EUR/USD",
+			Type:      "BUY",
+			Entry:     1.1000,
+			Lots:      1.0,
+			Grade:     " A+ ",
+		})
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := tradeSpy.CreateCalls[0].Grade; got != "A+" {
+			t.Errorf("expected grade 'A+' to reach the repository, got %q", got)
+		}
+		if result.Grade != "A+" {
+			t.Errorf("expected grade 'A+', got %q", result.Grade)
+		}
+	})
+}
@@ -0,0 +1,35 @@
+package tradegroup
+
+import "time"
+
+// CreateGroupRequest represents a request to create a trade group from the user's trades
+type CreateGroupRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	TradeIDs    []int64 `json:"trade_ids"`
+}
+
+// UpdateGroupRequest represents a request to update a trade group; TradeIDs replaces the members
+type UpdateGroupRequest struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description"`
+	TradeIDs    []int64 `json:"trade_ids"`
+}
+
+// GroupDTO represents a trade group with the combined figures of its filled trades;
+// PL is nil until every trade has settled and the close fields stay nil until every trade is closed
+type GroupDTO struct {
+	ID              int64      `json:"id"`
+	Name            string     `json:"name"`
+	Description     string     `json:"description"`
+	TradeIDs        []int64    `json:"trade_ids"`
+	Trades          int        `json:"trades"`
+	PL              *float64   `json:"pl"`
+	Risk            *float64   `json:"risk"`
+	RealizedR       *float64   `json:"realized_r"`
+	OpenedAt        *time.Time `json:"opened_at"`
+	ClosedAt        *time.Time `json:"closed_at"`
+	DurationSeconds *int64     `json:"duration_seconds"`
+	CreatedAt       time.Time  `json:"created_at"`
+	UpdatedAt       time.Time  `json:"updated_at"`
+}
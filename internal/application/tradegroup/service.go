@@ -0,0 +1,203 @@
+package tradegroup
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+	"github.com/raihanstark/trade-journal/internal/domain/tradegroup"
+)
+
+var (
+	ErrGroupNotFound = errors.New("trade group not found")
+	ErrInvalidGroup  = errors.New("name is required and must be at most 100 characters")
+	ErrTradeNotFound = errors.New("trade not found")
+)
+
+// Service handles trade groups and their combined figures
+type Service struct {
+	repo       tradegroup.Repository
+	tradeRepo  trade.Repository
+	transactor tradegroup.Transactor
+}
+
+// NewService creates a new trade group service
+func NewService(repo tradegroup.Repository, tradeRepo trade.Repository, transactor tradegroup.Transactor) *Service {
+	return &Service{
+		repo:       repo,
+		tradeRepo:  tradeRepo,
+		transactor: transactor,
+	}
+}
+
+// CreateGroup creates a group and moves the given trades into it
+func (s *Service) CreateGroup(ctx context.Context, userID int64, req CreateGroupRequest) (*GroupDTO, error) {
+	entity := &tradegroup.Group{
+		UserID:      userID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: req.Description,
+	}
+	if err := validate(entity); err != nil {
+		return nil, err
+	}
+	if err := s.checkTrades(ctx, userID, req.TradeIDs); err != nil {
+		return nil, err
+	}
+
+	var created *tradegroup.Group
+	err := s.transactor.WithinGroupTx(ctx, func(groups tradegroup.Repository) error {
+		var err error
+		created, err = groups.Create(ctx, entity)
+		if err != nil {
+			return err
+		}
+		return groups.SetTrades(ctx, created.ID, userID, req.TradeIDs)
+	})
+	if err != nil {
+		return nil, membershipError(err)
+	}
+
+	return s.toDTO(ctx, created)
+}
+
+// GetGroup retrieves a trade group with its combined figures
+func (s *Service) GetGroup(ctx context.Context, id int64, userID int64) (*GroupDTO, error) {
+	entity, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrGroupNotFound
+	}
+
+	return s.toDTO(ctx, entity)
+}
+
+// GetUserGroups retrieves the user's trade groups, newest first
+func (s *Service) GetUserGroups(ctx context.Context, userID int64) ([]*GroupDTO, error) {
+	groups, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*GroupDTO, len(groups))
+	for i, entity := range groups {
+		dto, err := s.toDTO(ctx, entity)
+		if err != nil {
+			return nil, err
+		}
+		dtos[i] = dto
+	}
+
+	return dtos, nil
+}
+
+// UpdateGroup renames a group and replaces its trades; trades left out become ungrouped
+func (s *Service) UpdateGroup(ctx context.Context, id int64, userID int64, req UpdateGroupRequest) (*GroupDTO, error) {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrGroupNotFound
+	}
+
+	existing.Name = strings.TrimSpace(req.Name)
+	existing.Description = req.Description
+	if err := validate(existing); err != nil {
+		return nil, err
+	}
+	if err := s.checkTrades(ctx, userID, req.TradeIDs); err != nil {
+		return nil, err
+	}
+
+	var updated *tradegroup.Group
+	err = s.transactor.WithinGroupTx(ctx, func(groups tradegroup.Repository) error {
+		var err error
+		updated, err = groups.Update(ctx, existing)
+		if err != nil {
+			return err
+		}
+		return groups.SetTrades(ctx, updated.ID, userID, req.TradeIDs)
+	})
+	if err != nil {
+		if errors.Is(err, tradegroup.ErrNotFound) {
+			return nil, ErrGroupNotFound
+		}
+		return nil, membershipError(err)
+	}
+
+	return s.toDTO(ctx, updated)
+}
+
+// DeleteGroup removes a group; its trades are kept and become ungrouped
+func (s *Service) DeleteGroup(ctx context.Context, id int64, userID int64) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, tradegroup.ErrNotFound) {
+			return ErrGroupNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// checkTrades makes sure every trade belongs to the user before membership changes
+func (s *Service) checkTrades(ctx context.Context, userID int64, tradeIDs []int64) error {
+	for _, tradeID := range tradeIDs {
+		if _, err := s.tradeRepo.GetByID(ctx, tradeID, userID); err != nil {
+			return ErrTradeNotFound
+		}
+	}
+	return nil
+}
+
+// membershipError maps a trade that vanished while the members were set to ErrTradeNotFound
+func membershipError(err error) error {
+	if errors.Is(err, tradegroup.ErrTradeNotFound) {
+		return ErrTradeNotFound
+	}
+	return err
+}
+
+func validate(g *tradegroup.Group) error {
+	if g.Name == "" || utf8.RuneCountInString(g.Name) > tradegroup.MaxNameLength {
+		return ErrInvalidGroup
+	}
+	return nil
+}
+
+// toDTO converts domain entity to DTO, combining the group's trades
+func (s *Service) toDTO(ctx context.Context, g *tradegroup.Group) (*GroupDTO, error) {
+	trades, err := s.tradeRepo.GetByGroupID(ctx, g.ID, g.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	tradeIDs := make([]int64, len(trades))
+	for i, t := range trades {
+		tradeIDs[i] = t.ID
+	}
+
+	summary := tradegroup.Summarize(trades)
+	return &GroupDTO{
+		ID:              g.ID,
+		Name:            g.Name,
+		Description:     g.Description,
+		TradeIDs:        tradeIDs,
+		Trades:          summary.Trades,
+		PL:              summary.PL,
+		Risk:            summary.Risk,
+		RealizedR:       summary.RealizedR,
+		OpenedAt:        summary.OpenedAt,
+		ClosedAt:        summary.ClosedAt,
+		DurationSeconds: durationSeconds(summary.Duration),
+		CreatedAt:       g.CreatedAt,
+		UpdatedAt:       g.UpdatedAt,
+	}, nil
+}
+
+func durationSeconds(d *time.Duration) *int64 {
+	if d == nil {
+		return nil
+	}
+	seconds := int64(d.Seconds())
+	return &seconds
+}
@@ -0,0 +1,165 @@
+package tradegroup
+
+import (
+	"context"
+	"slices"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
+	"github.com/raihanstark/trade-journal/internal/testutil"
+)
+
+func TestTradeGroupService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	groupRepo := persistence.NewTradeGroupRepository(pg.Queries)
+	tradeRepo := persistence.NewTradeRepository(pg.DB, pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(groupRepo, tradeRepo, persistence.NewTransactor(pg.DB, pg.Queries))
+
+	ctx := context.Background()
+	insert := `INSERT INTO trades (user_id, date, time, type, status, pl, risk_amount, opened_at, closed_at)
+		VALUES ($1, '2025-01-15', '10:00', 'BUY', $2, $3, 100, $4, $5) RETURNING id`
+
+	t.Run("combines the P/L, risk and duration of its trades", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("groups@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		var firstID, secondID int64
+		if err := pg.DB.QueryRow(insert, createdUser.ID, "closed", 300, "2025-01-15T10:00:00Z", "2025-01-15T12:00:00Z").Scan(&firstID); err != nil {
+			t.Fatalf("failed to insert trade: %v", err)
+		}
+		if err := pg.DB.QueryRow(insert, createdUser.ID, "open", nil, "2025-01-15T11:00:00Z", nil).Scan(&secondID); err != nil {
+			t.Fatalf("failed to insert trade: %v", err)
+		}
+
+		created, err := service.CreateGroup(ctx, createdUser.ID, CreateGroupRequest{Name: " Pyramid ", TradeIDs: []int64{firstID, secondID}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if created.Name != "Pyramid" || !slices.Equal(created.TradeIDs, []int64{firstID, secondID}) {
+			t.Errorf("unexpected group: %+v", created)
+		}
+		if created.Trades != 2 || created.Risk == nil || *created.Risk != 200 {
+			t.Errorf("expected 2 trades risking 200, got %+v", created)
+		}
+		if created.PL != nil || created.ClosedAt != nil || created.DurationSeconds != nil {
+			t.Errorf("expected no combined P/L or close while a trade is open, got %+v", created)
+		}
+
+		pg.DB.Exec("UPDATE trades SET status = 'closed', pl = -100, closed_at = '2025-01-15T13:00:00Z' WHERE id = $1", secondID)
+
+		group, err := service.GetGroup(ctx, created.ID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if group.PL == nil || *group.PL != 200 || group.RealizedR == nil || *group.RealizedR != 1 {
+			t.Errorf("expected 200 at 1R, got pl=%v r=%v", group.PL, group.RealizedR)
+		}
+		if group.DurationSeconds == nil || *group.DurationSeconds != 3*3600 {
+			t.Errorf("expected a 3 hour duration, got %v", group.DurationSeconds)
+		}
+
+		trade, _ := tradeRepo.GetByID(ctx, firstID, createdUser.ID)
+		if trade.GroupID == nil || *trade.GroupID != created.ID {
+			t.Errorf("expected the trade to belong to the group, got %v", trade.GroupID)
+		}
+	})
+
+	t.Run("replaces members on update and ungroups them on delete", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, _ := userRepo.Create(ctx, user.NewUser("regroup@example.com", "hashedpass"))
+
+		var firstID, secondID int64
+		pg.DB.QueryRow(insert, createdUser.ID, "closed", 50, "2025-01-15T10:00:00Z", "2025-01-15T11:00:00Z").Scan(&firstID)
+		pg.DB.QueryRow(insert, createdUser.ID, "closed", 20, "2025-01-15T10:30:00Z", "2025-01-15T11:00:00Z").Scan(&secondID)
+
+		created, _ := service.CreateGroup(ctx, createdUser.ID, CreateGroupRequest{Name: "Hedge", TradeIDs: []int64{firstID, secondID}})
+
+		updated, err := service.UpdateGroup(ctx, created.ID, createdUser.ID, UpdateGroupRequest{Name: "Hedge", TradeIDs: []int64{secondID}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if !slices.Equal(updated.TradeIDs, []int64{secondID}) || updated.PL == nil || *updated.PL != 20 {
+			t.Errorf("expected only the second trade, got %+v", updated)
+		}
+
+		if err := service.DeleteGroup(ctx, created.ID, createdUser.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if _, err := service.GetGroup(ctx, created.ID, createdUser.ID); err != ErrGroupNotFound {
+			t.Errorf("expected ErrGroupNotFound, got %v", err)
+		}
+
+		trade, err := tradeRepo.GetByID(ctx, secondID, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected the trade to survive its group, got %v", err)
+		}
+		if trade.GroupID != nil {
+			t.Errorf("expected the trade to be ungrouped, got %d", *trade.GroupID)
+		}
+	})
+
+	t.Run("bumps member versions and leaves trashed members in the group", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, _ := userRepo.Create(ctx, user.NewUser("trashed@example.com", "hashedpass"))
+
+		var keptID, trashedID int64
+		pg.DB.QueryRow(insert, createdUser.ID, "closed", 50, "2025-01-15T10:00:00Z", "2025-01-15T11:00:00Z").Scan(&keptID)
+		pg.DB.QueryRow(insert, createdUser.ID, "closed", 20, "2025-01-15T10:30:00Z", "2025-01-15T11:00:00Z").Scan(&trashedID)
+
+		created, err := service.CreateGroup(ctx, createdUser.ID, CreateGroupRequest{Name: "Scale in", TradeIDs: []int64{keptID, trashedID}})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		pg.DB.Exec("UPDATE trades SET deleted_at = CURRENT_TIMESTAMP WHERE id = $1", trashedID)
+
+		if _, err := service.UpdateGroup(ctx, created.ID, createdUser.ID, UpdateGroupRequest{Name: "Scale in", TradeIDs: []int64{keptID}}); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		var version int64
+		pg.DB.QueryRow("SELECT version FROM trades WHERE id = $1", keptID).Scan(&version)
+		if version != 4 {
+			t.Errorf("expected the kept trade at version 4 after joining, leaving and rejoining, got %d", version)
+		}
+
+		var groupID *int64
+		pg.DB.QueryRow("SELECT group_id FROM trades WHERE id = $1", trashedID).Scan(&groupID)
+		if groupID == nil || *groupID != created.ID {
+			t.Errorf("expected the trashed trade to keep its group, got %v", groupID)
+		}
+	})
+
+	t.Run("rejects other users' trades and invalid names", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		owner, _ := userRepo.Create(ctx, user.NewUser("owner@example.com", "hashedpass"))
+		other, _ := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+
+		var tradeID int64
+		pg.DB.QueryRow(insert, owner.ID, "closed", 50, "2025-01-15T10:00:00Z", "2025-01-15T11:00:00Z").Scan(&tradeID)
+
+		if _, err := service.CreateGroup(ctx, other.ID, CreateGroupRequest{Name: "Stolen", TradeIDs: []int64{tradeID}}); err != ErrTradeNotFound {
+			t.Errorf("expected ErrTradeNotFound, got %v", err)
+		}
+		if _, err := service.CreateGroup(ctx, owner.ID, CreateGroupRequest{Name: "  "}); err != ErrInvalidGroup {
+			t.Errorf("expected ErrInvalidGroup, got %v", err)
+		}
+
+		groups, _ := service.GetUserGroups(ctx, other.ID)
+		if len(groups) != 0 {
+			t.Errorf("expected no groups to be created, got %d", len(groups))
+		}
+	})
+}
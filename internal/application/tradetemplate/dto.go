@@ -0,0 +1,25 @@
+package tradetemplate
+
+import "time"
+
+// CreateTemplateRequest represents a request to save a trade template. Defaults takes any of the
+// fields of a create trade request under their JSON names.
+type CreateTemplateRequest struct {
+	Name     string         `json:"name"`
+	Defaults map[string]any `json:"defaults"`
+}
+
+// UpdateTemplateRequest represents a request to update a trade template; Defaults replaces the stored ones
+type UpdateTemplateRequest struct {
+	Name     string         `json:"name"`
+	Defaults map[string]any `json:"defaults"`
+}
+
+// TemplateDTO represents a trade template data transfer object
+type TemplateDTO struct {
+	ID        int64          `json:"id"`
+	Name      string         `json:"name"`
+	Defaults  map[string]any `json:"defaults"`
+	CreatedAt time.Time      `json:"created_at"`
+	UpdatedAt time.Time      `json:"updated_at"`
+}
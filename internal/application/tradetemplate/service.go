@@ -0,0 +1,162 @@
+package tradetemplate
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+
+	tradeapp "github.com/raihanstark/trade-journal/internal/application/trade"
+	"github.com/raihanstark/trade-journal/internal/domain/tradetemplate"
+)
+
+var (
+	ErrTemplateNotFound = errors.New("trade template not found")
+	ErrTemplateExists   = errors.New("trade template already exists with this name")
+	ErrInvalidTemplate  = errors.New("name is required and must be at most 100 characters")
+	ErrInvalidDefaults  = errors.New("defaults must only hold fields of a trade")
+)
+
+// Service handles the user's trade templates
+type Service struct {
+	repo tradetemplate.Repository
+}
+
+// NewService creates a new trade template service
+func NewService(repo tradetemplate.Repository) *Service {
+	return &Service{
+		repo: repo,
+	}
+}
+
+// CreateTemplate saves a named set of defaults for new trades
+func (s *Service) CreateTemplate(ctx context.Context, userID int64, req CreateTemplateRequest) (*TemplateDTO, error) {
+	entity := &tradetemplate.Template{
+		UserID:   userID,
+		Name:     strings.TrimSpace(req.Name),
+		Defaults: req.Defaults,
+	}
+	if err := validate(entity); err != nil {
+		return nil, err
+	}
+
+	_, err := s.repo.GetByName(ctx, userID, entity.Name)
+	if err == nil {
+		return nil, ErrTemplateExists
+	}
+	if !errors.Is(err, tradetemplate.ErrNotFound) {
+		return nil, err
+	}
+
+	created, err := s.repo.Create(ctx, entity)
+	if err != nil {
+		return nil, err
+	}
+
+	return toDTO(created), nil
+}
+
+// GetTemplate retrieves a trade template by ID
+func (s *Service) GetTemplate(ctx context.Context, id int64, userID int64) (*TemplateDTO, error) {
+	entity, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	return toDTO(entity), nil
+}
+
+// GetUserTemplates retrieves the user's trade templates, in name order
+func (s *Service) GetUserTemplates(ctx context.Context, userID int64) ([]*TemplateDTO, error) {
+	templates, err := s.repo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	dtos := make([]*TemplateDTO, len(templates))
+	for i, entity := range templates {
+		dtos[i] = toDTO(entity)
+	}
+
+	return dtos, nil
+}
+
+// UpdateTemplate renames a trade template and replaces its defaults
+func (s *Service) UpdateTemplate(ctx context.Context, id int64, userID int64, req UpdateTemplateRequest) (*TemplateDTO, error) {
+	existing, err := s.repo.GetByID(ctx, id, userID)
+	if err != nil {
+		return nil, ErrTemplateNotFound
+	}
+
+	existing.Name = strings.TrimSpace(req.Name)
+	existing.Defaults = req.Defaults
+	if err := validate(existing); err != nil {
+		return nil, err
+	}
+
+	other, err := s.repo.GetByName(ctx, userID, existing.Name)
+	if err == nil && other.ID != existing.ID {
+		return nil, ErrTemplateExists
+	}
+	if err != nil && !errors.Is(err, tradetemplate.ErrNotFound) {
+		return nil, err
+	}
+
+	updated, err := s.repo.Update(ctx, existing)
+	if err != nil {
+		if errors.Is(err, tradetemplate.ErrNotFound) {
+			return nil, ErrTemplateNotFound
+		}
+		return nil, err
+	}
+
+	return toDTO(updated), nil
+}
+
+// DeleteTemplate removes a trade template; trades started from it are not affected
+func (s *Service) DeleteTemplate(ctx context.Context, id int64, userID int64) error {
+	err := s.repo.Delete(ctx, id, userID)
+	if err != nil {
+		if errors.Is(err, tradetemplate.ErrNotFound) {
+			return ErrTemplateNotFound
+		}
+		return err
+	}
+	return nil
+}
+
+// validate checks the name and that the defaults decode into a create trade request
+func validate(t *tradetemplate.Template) error {
+	if t.Name == "" || utf8.RuneCountInString(t.Name) > tradetemplate.MaxNameLength {
+		return ErrInvalidTemplate
+	}
+	if t.Defaults == nil {
+		t.Defaults = map[string]any{}
+	}
+
+	data, err := json.Marshal(t.Defaults)
+	if err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDefaults, err)
+	}
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	var req tradeapp.CreateTradeRequest
+	if err := decoder.Decode(&req); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidDefaults, err)
+	}
+	return nil
+}
+
+// toDTO converts domain entity to DTO
+func toDTO(t *tradetemplate.Template) *TemplateDTO {
+	return &TemplateDTO{
+		ID:        t.ID,
+		Name:      t.Name,
+		Defaults:  t.Defaults,
+		CreatedAt: t.CreatedAt,
+		UpdatedAt: t.UpdatedAt,
+	}
+}
@@ -0,0 +1,137 @@
+package tradetemplate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/raihanstark/trade-journal/internal/domain/user"
+	"github.com/raihanstark/trade-journal/internal/infrastructure/persistence"
+	"github.com/raihanstark/trade-journal/internal/testutil"
+)
+
+func TestTradeTemplateService_Integration(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	pg := testutil.SetupTestDatabase(t)
+	templateRepo := persistence.NewTradeTemplateRepository(pg.Queries)
+	userRepo := persistence.NewUserRepository(pg.Queries)
+	service := NewService(templateRepo)
+
+	ctx := context.Background()
+
+	t.Run("creates, updates and deletes templates", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		createdUser, err := userRepo.Create(ctx, user.NewUser("templates@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create test user: %v", err)
+		}
+
+		created, err := service.CreateTemplate(ctx, createdUser.ID, CreateTemplateRequest{
+			Name: "  London breakout ",
+			Defaults: map[string]any{
+				"pair":         "EUR/USD",
+				"lots":         0.5,
+				"strategy_ids": []any{1, 2},
+				"notes":        "Setup:\nEntry:\nExit:",
+			},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if created.Name != "London breakout" {
+			t.Errorf("expected trimmed name, got %q", created.Name)
+		}
+		if created.Defaults["pair"] != "EUR/USD" || created.Defaults["lots"] != 0.5 {
+			t.Errorf("expected defaults to round-trip, got %v", created.Defaults)
+		}
+		if ids, ok := created.Defaults["strategy_ids"].([]any); !ok || len(ids) != 2 {
+			t.Errorf("expected two strategy IDs, got %v", created.Defaults["strategy_ids"])
+		}
+
+		empty, err := service.CreateTemplate(ctx, createdUser.ID, CreateTemplateRequest{Name: "Blank"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if empty.Defaults == nil || len(empty.Defaults) != 0 {
+			t.Errorf("expected empty defaults, got %v", empty.Defaults)
+		}
+
+		_, err = service.CreateTemplate(ctx, createdUser.ID, CreateTemplateRequest{Name: "London breakout"})
+		if !errors.Is(err, ErrTemplateExists) {
+			t.Errorf("expected ErrTemplateExists, got %v", err)
+		}
+
+		_, err = service.UpdateTemplate(ctx, empty.ID, createdUser.ID, UpdateTemplateRequest{Name: "London breakout"})
+		if !errors.Is(err, ErrTemplateExists) {
+			t.Errorf("expected ErrTemplateExists when renaming onto another template, got %v", err)
+		}
+
+		updated, err := service.UpdateTemplate(ctx, created.ID, createdUser.ID, UpdateTemplateRequest{
+			Name:     "London breakout",
+			Defaults: map[string]any{"pair": "GBP/USD"},
+		})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if updated.Defaults["pair"] != "GBP/USD" || updated.Defaults["lots"] != nil {
+			t.Errorf("expected defaults to be replaced, got %v", updated.Defaults)
+		}
+
+		if err := service.DeleteTemplate(ctx, empty.ID, createdUser.ID); err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		templates, err := service.GetUserTemplates(ctx, createdUser.ID)
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+		if len(templates) != 1 || templates[0].ID != created.ID {
+			t.Errorf("expected only the London breakout template to remain, got %d", len(templates))
+		}
+	})
+
+	t.Run("rejects unknown fields and other users' templates", func(t *testing.T) {
+		testutil.TruncateTables(t, pg.DB)
+
+		owner, err := userRepo.Create(ctx, user.NewUser("owner@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create owner: %v", err)
+		}
+		otherUser, err := userRepo.Create(ctx, user.NewUser("other@example.com", "hashedpass"))
+		if err != nil {
+			t.Fatalf("failed to create other user: %v", err)
+		}
+
+		_, err = service.CreateTemplate(ctx, owner.ID, CreateTemplateRequest{
+			Name:     "Scalp",
+			Defaults: map[string]any{"leverage": 100},
+		})
+		if !errors.Is(err, ErrInvalidDefaults) {
+			t.Errorf("expected ErrInvalidDefaults for an unknown field, got %v", err)
+		}
+
+		_, err = service.CreateTemplate(ctx, owner.ID, CreateTemplateRequest{
+			Name:     "Scalp",
+			Defaults: map[string]any{"lots": "one"},
+		})
+		if !errors.Is(err, ErrInvalidDefaults) {
+			t.Errorf("expected ErrInvalidDefaults for a mistyped field, got %v", err)
+		}
+
+		created, err := service.CreateTemplate(ctx, owner.ID, CreateTemplateRequest{Name: "Scalp"})
+		if err != nil {
+			t.Fatalf("expected no error, got %v", err)
+		}
+
+		if _, err := service.GetTemplate(ctx, created.ID, otherUser.ID); !errors.Is(err, ErrTemplateNotFound) {
+			t.Errorf("expected ErrTemplateNotFound for another user, got %v", err)
+		}
+		if err := service.DeleteTemplate(ctx, created.ID, otherUser.ID); !errors.Is(err, ErrTemplateNotFound) {
+			t.Errorf("expected ErrTemplateNotFound deleting another user's template, got %v", err)
+		}
+	})
+}
@@ -13,7 +13,7 @@ import (
 const createAccount = `-- name: CreateAccount :one
 INSERT INTO accounts (user_id, name, broker, account_number, account_type, currency, is_active)
 VALUES ($1, $2, $3, $4, $5, $6, $7)
-RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 `
 
 type CreateAccountParams struct {
@@ -38,6 +38,7 @@ type CreateAccountRow struct {
 	IsActive       bool           `json:"is_active"`
 	CreatedAt      sql.NullTime   `json:"created_at"`
 	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	Version        int32          `json:"version"`
 }
 
 func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (CreateAccountRow, error) {
@@ -63,29 +64,15 @@ func (q *Queries) CreateAccount(ctx context.Context, arg CreateAccountParams) (C
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
-const deleteAccount = `-- name: DeleteAccount :exec
-DELETE FROM accounts
-WHERE id = $1 AND user_id = $2
-`
-
-type DeleteAccountParams struct {
-	ID     int32 `json:"id"`
-	UserID int32 `json:"user_id"`
-}
-
-func (q *Queries) DeleteAccount(ctx context.Context, arg DeleteAccountParams) error {
-	_, err := q.db.ExecContext(ctx, deleteAccount, arg.ID, arg.UserID)
-	return err
-}
-
 const getAccountByID = `-- name: GetAccountByID :one
-SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 FROM accounts
-WHERE id = $1 AND user_id = $2
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 `
 
 type GetAccountByIDParams struct {
@@ -105,6 +92,7 @@ type GetAccountByIDRow struct {
 	IsActive       bool           `json:"is_active"`
 	CreatedAt      sql.NullTime   `json:"created_at"`
 	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	Version        int32          `json:"version"`
 }
 
 func (q *Queries) GetAccountByID(ctx context.Context, arg GetAccountByIDParams) (GetAccountByIDRow, error) {
@@ -122,14 +110,15 @@ func (q *Queries) GetAccountByID(ctx context.Context, arg GetAccountByIDParams)
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const getAccountsByUserID = `-- name: GetAccountsByUserID :many
-SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 FROM accounts
-WHERE user_id = $1
+WHERE user_id = $1 AND deleted_at IS NULL
 ORDER BY created_at DESC
 `
 
@@ -145,6 +134,7 @@ type GetAccountsByUserIDRow struct {
 	IsActive       bool           `json:"is_active"`
 	CreatedAt      sql.NullTime   `json:"created_at"`
 	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	Version        int32          `json:"version"`
 }
 
 func (q *Queries) GetAccountsByUserID(ctx context.Context, userID int32) ([]GetAccountsByUserIDRow, error) {
@@ -168,6 +158,67 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID int32) ([]GetA
 			&i.IsActive,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getDeletedAccountsByUserID = `-- name: GetDeletedAccountsByUserID :many
+SELECT id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, deleted_at, version
+FROM accounts
+WHERE user_id = $1 AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
+`
+
+type GetDeletedAccountsByUserIDRow struct {
+	ID             int32          `json:"id"`
+	UserID         int32          `json:"user_id"`
+	Name           string         `json:"name"`
+	Broker         string         `json:"broker"`
+	AccountNumber  string         `json:"account_number"`
+	AccountType    string         `json:"account_type"`
+	Currency       string         `json:"currency"`
+	CurrentBalance sql.NullString `json:"current_balance"`
+	IsActive       bool           `json:"is_active"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+	Version        int32          `json:"version"`
+}
+
+func (q *Queries) GetDeletedAccountsByUserID(ctx context.Context, userID int32) ([]GetDeletedAccountsByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedAccountsByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetDeletedAccountsByUserIDRow
+	for rows.Next() {
+		var i GetDeletedAccountsByUserIDRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.Name,
+			&i.Broker,
+			&i.AccountNumber,
+			&i.AccountType,
+			&i.Currency,
+			&i.CurrentBalance,
+			&i.IsActive,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -182,6 +233,118 @@ func (q *Queries) GetAccountsByUserID(ctx context.Context, userID int32) ([]GetA
 	return items, nil
 }
 
+const purgeDeletedAccounts = `-- name: PurgeDeletedAccounts :execrows
+DELETE FROM accounts WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedAccounts(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedAccounts, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreAccount = `-- name: RestoreAccount :one
+UPDATE accounts
+SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
+`
+
+type RestoreAccountParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+type RestoreAccountRow struct {
+	ID             int32          `json:"id"`
+	UserID         int32          `json:"user_id"`
+	Name           string         `json:"name"`
+	Broker         string         `json:"broker"`
+	AccountNumber  string         `json:"account_number"`
+	AccountType    string         `json:"account_type"`
+	Currency       string         `json:"currency"`
+	CurrentBalance sql.NullString `json:"current_balance"`
+	IsActive       bool           `json:"is_active"`
+	CreatedAt      sql.NullTime   `json:"created_at"`
+	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	Version        int32          `json:"version"`
+}
+
+func (q *Queries) RestoreAccount(ctx context.Context, arg RestoreAccountParams) (RestoreAccountRow, error) {
+	row := q.db.QueryRowContext(ctx, restoreAccount, arg.ID, arg.UserID)
+	var i RestoreAccountRow
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Name,
+		&i.Broker,
+		&i.AccountNumber,
+		&i.AccountType,
+		&i.Currency,
+		&i.CurrentBalance,
+		&i.IsActive,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const restoreAccountTrades = `-- name: RestoreAccountTrades :exec
+UPDATE trades
+SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE account_id = $1 AND user_id = $2
+    AND deleted_at = (SELECT a.deleted_at FROM accounts a WHERE a.id = $1 AND a.user_id = $2)
+`
+
+type RestoreAccountTradesParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) RestoreAccountTrades(ctx context.Context, arg RestoreAccountTradesParams) error {
+	_, err := q.db.ExecContext(ctx, restoreAccountTrades, arg.ID, arg.UserID)
+	return err
+}
+
+const softDeleteAccount = `-- name: SoftDeleteAccount :execrows
+UPDATE accounts
+SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+WHERE id = $1 AND user_id = $2 AND version = $3 AND deleted_at IS NULL
+`
+
+type SoftDeleteAccountParams struct {
+	ID      int32 `json:"id"`
+	UserID  int32 `json:"user_id"`
+	Version int32 `json:"version"`
+}
+
+func (q *Queries) SoftDeleteAccount(ctx context.Context, arg SoftDeleteAccountParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteAccount, arg.ID, arg.UserID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const softDeleteAccountTrades = `-- name: SoftDeleteAccountTrades :exec
+UPDATE trades
+SET deleted_at = (SELECT a.deleted_at FROM accounts a WHERE a.id = $1 AND a.user_id = $2), version = version + 1
+WHERE account_id = $1 AND user_id = $2 AND deleted_at IS NULL
+`
+
+type SoftDeleteAccountTradesParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) SoftDeleteAccountTrades(ctx context.Context, arg SoftDeleteAccountTradesParams) error {
+	_, err := q.db.ExecContext(ctx, softDeleteAccountTrades, arg.ID, arg.UserID)
+	return err
+}
+
 const updateAccount = `-- name: UpdateAccount :one
 UPDATE accounts
 SET name = $3,
@@ -190,9 +353,10 @@ SET name = $3,
     account_type = $6,
     currency = $7,
     is_active = $8,
+    version = version + 1,
     updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+WHERE id = $1 AND user_id = $2 AND version = $9 AND deleted_at IS NULL
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 `
 
 type UpdateAccountParams struct {
@@ -204,6 +368,7 @@ type UpdateAccountParams struct {
 	AccountType   string `json:"account_type"`
 	Currency      string `json:"currency"`
 	IsActive      bool   `json:"is_active"`
+	Version       int32  `json:"version"`
 }
 
 type UpdateAccountRow struct {
@@ -218,6 +383,7 @@ type UpdateAccountRow struct {
 	IsActive       bool           `json:"is_active"`
 	CreatedAt      sql.NullTime   `json:"created_at"`
 	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	Version        int32          `json:"version"`
 }
 
 func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (UpdateAccountRow, error) {
@@ -230,6 +396,7 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (U
 		arg.AccountType,
 		arg.Currency,
 		arg.IsActive,
+		arg.Version,
 	)
 	var i UpdateAccountRow
 	err := row.Scan(
@@ -244,6 +411,7 @@ func (q *Queries) UpdateAccount(ctx context.Context, arg UpdateAccountParams) (U
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -253,7 +421,7 @@ UPDATE accounts
 SET current_balance = COALESCE(current_balance, 0) + $3::decimal,
     updated_at = CURRENT_TIMESTAMP
 WHERE id = $1 AND user_id = $2
-RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at
+RETURNING id, user_id, name, broker, account_number, account_type, currency, current_balance, is_active, created_at, updated_at, version
 `
 
 type UpdateAccountBalanceParams struct {
@@ -274,6 +442,7 @@ type UpdateAccountBalanceRow struct {
 	IsActive       bool           `json:"is_active"`
 	CreatedAt      sql.NullTime   `json:"created_at"`
 	UpdatedAt      sql.NullTime   `json:"updated_at"`
+	Version        int32          `json:"version"`
 }
 
 func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (UpdateAccountBalanceRow, error) {
@@ -291,6 +460,7 @@ func (q *Queries) UpdateAccountBalance(ctx context.Context, arg UpdateAccountBal
 		&i.IsActive,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
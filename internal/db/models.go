@@ -7,15 +7,234 @@ package db
 import (
 	"database/sql"
 	"database/sql/driver"
+	"encoding/json"
 	"fmt"
 	"time"
 )
 
+type AuditAction string
+
+const (
+	AuditActionCreate  AuditAction = "create"
+	AuditActionUpdate  AuditAction = "update"
+	AuditActionDelete  AuditAction = "delete"
+	AuditActionRestore AuditAction = "restore"
+)
+
+func (e *AuditAction) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AuditAction(s)
+	case string:
+		*e = AuditAction(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AuditAction: %T", src)
+	}
+	return nil
+}
+
+type NullAuditAction struct {
+	AuditAction AuditAction `json:"audit_action"`
+	Valid       bool        `json:"valid"` // Valid is true if AuditAction is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAuditAction) Scan(value interface{}) error {
+	if value == nil {
+		ns.AuditAction, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AuditAction.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAuditAction) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AuditAction), nil
+}
+
+type AuditEntityType string
+
+const (
+	AuditEntityTypeTrade    AuditEntityType = "trade"
+	AuditEntityTypeAccount  AuditEntityType = "account"
+	AuditEntityTypeStrategy AuditEntityType = "strategy"
+)
+
+func (e *AuditEntityType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = AuditEntityType(s)
+	case string:
+		*e = AuditEntityType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for AuditEntityType: %T", src)
+	}
+	return nil
+}
+
+type NullAuditEntityType struct {
+	AuditEntityType AuditEntityType `json:"audit_entity_type"`
+	Valid           bool            `json:"valid"` // Valid is true if AuditEntityType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullAuditEntityType) Scan(value interface{}) error {
+	if value == nil {
+		ns.AuditEntityType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.AuditEntityType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullAuditEntityType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.AuditEntityType), nil
+}
+
+type MistakeSeverity string
+
+const (
+	MistakeSeverityLow    MistakeSeverity = "low"
+	MistakeSeverityMedium MistakeSeverity = "medium"
+	MistakeSeverityHigh   MistakeSeverity = "high"
+)
+
+func (e *MistakeSeverity) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = MistakeSeverity(s)
+	case string:
+		*e = MistakeSeverity(s)
+	default:
+		return fmt.Errorf("unsupported scan type for MistakeSeverity: %T", src)
+	}
+	return nil
+}
+
+type NullMistakeSeverity struct {
+	MistakeSeverity MistakeSeverity `json:"mistake_severity"`
+	Valid           bool            `json:"valid"` // Valid is true if MistakeSeverity is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullMistakeSeverity) Scan(value interface{}) error {
+	if value == nil {
+		ns.MistakeSeverity, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.MistakeSeverity.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullMistakeSeverity) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.MistakeSeverity), nil
+}
+
+type OrderType string
+
+const (
+	OrderTypeMarket OrderType = "market"
+	OrderTypeLimit  OrderType = "limit"
+	OrderTypeStop   OrderType = "stop"
+)
+
+func (e *OrderType) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = OrderType(s)
+	case string:
+		*e = OrderType(s)
+	default:
+		return fmt.Errorf("unsupported scan type for OrderType: %T", src)
+	}
+	return nil
+}
+
+type NullOrderType struct {
+	OrderType OrderType `json:"order_type"`
+	Valid     bool      `json:"valid"` // Valid is true if OrderType is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullOrderType) Scan(value interface{}) error {
+	if value == nil {
+		ns.OrderType, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.OrderType.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullOrderType) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.OrderType), nil
+}
+
+type RatingPhase string
+
+const (
+	RatingPhaseBefore RatingPhase = "before"
+	RatingPhaseAfter  RatingPhase = "after"
+)
+
+func (e *RatingPhase) Scan(src interface{}) error {
+	switch s := src.(type) {
+	case []byte:
+		*e = RatingPhase(s)
+	case string:
+		*e = RatingPhase(s)
+	default:
+		return fmt.Errorf("unsupported scan type for RatingPhase: %T", src)
+	}
+	return nil
+}
+
+type NullRatingPhase struct {
+	RatingPhase RatingPhase `json:"rating_phase"`
+	Valid       bool        `json:"valid"` // Valid is true if RatingPhase is not NULL
+}
+
+// Scan implements the Scanner interface.
+func (ns *NullRatingPhase) Scan(value interface{}) error {
+	if value == nil {
+		ns.RatingPhase, ns.Valid = "", false
+		return nil
+	}
+	ns.Valid = true
+	return ns.RatingPhase.Scan(value)
+}
+
+// Value implements the driver Valuer interface.
+func (ns NullRatingPhase) Value() (driver.Value, error) {
+	if !ns.Valid {
+		return nil, nil
+	}
+	return string(ns.RatingPhase), nil
+}
+
 type TradeStatus string
 
 const (
-	TradeStatusOpen   TradeStatus = "open"
-	TradeStatusClosed TradeStatus = "closed"
+	TradeStatusOpen      TradeStatus = "open"
+	TradeStatusClosed    TradeStatus = "closed"
+	TradeStatusPending   TradeStatus = "pending"
+	TradeStatusCancelled TradeStatus = "cancelled"
+	TradeStatusExpired   TradeStatus = "expired"
 )
 
 func (e *TradeStatus) Scan(src interface{}) error {
@@ -109,6 +328,81 @@ type Account struct {
 	CreatedAt      sql.NullTime   `json:"created_at"`
 	UpdatedAt      sql.NullTime   `json:"updated_at"`
 	CurrentBalance sql.NullString `json:"current_balance"`
+	DeletedAt      sql.NullTime   `json:"deleted_at"`
+	Version        int32          `json:"version"`
+}
+
+type AuditEvent struct {
+	ID         int32           `json:"id"`
+	UserID     int32           `json:"user_id"`
+	EntityType AuditEntityType `json:"entity_type"`
+	EntityID   int32           `json:"entity_id"`
+	Action     AuditAction     `json:"action"`
+	Changes    json.RawMessage `json:"changes"`
+	CreatedAt  sql.NullTime    `json:"created_at"`
+}
+
+type Execution struct {
+	ID         int32        `json:"id"`
+	TradeID    int32        `json:"trade_id"`
+	Side       TradeType    `json:"side"`
+	Price      string       `json:"price"`
+	Lots       string       `json:"lots"`
+	ExecutedAt time.Time    `json:"executed_at"`
+	CreatedAt  sql.NullTime `json:"created_at"`
+}
+
+type FxRate struct {
+	ID            int32        `json:"id"`
+	UserID        int32        `json:"user_id"`
+	BaseCurrency  string       `json:"base_currency"`
+	QuoteCurrency string       `json:"quote_currency"`
+	RateDate      time.Time    `json:"rate_date"`
+	Rate          string       `json:"rate"`
+	CreatedAt     sql.NullTime `json:"created_at"`
+	UpdatedAt     sql.NullTime `json:"updated_at"`
+}
+
+type Grade struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Name      string       `json:"name"`
+	Position  int32        `json:"position"`
+	CreatedAt sql.NullTime `json:"created_at"`
+}
+
+type Instrument struct {
+	ID            int32          `json:"id"`
+	UserID        sql.NullInt32  `json:"user_id"`
+	Symbol        string         `json:"symbol"`
+	Description   sql.NullString `json:"description"`
+	AssetClass    string         `json:"asset_class"`
+	PipSize       string         `json:"pip_size"`
+	TickSize      string         `json:"tick_size"`
+	ContractSize  string         `json:"contract_size"`
+	QuoteCurrency string         `json:"quote_currency"`
+	Digits        int32          `json:"digits"`
+	CreatedAt     sql.NullTime   `json:"created_at"`
+	UpdatedAt     sql.NullTime   `json:"updated_at"`
+}
+
+type MistakeType struct {
+	ID          int32           `json:"id"`
+	UserID      int32           `json:"user_id"`
+	Name        string          `json:"name"`
+	Description sql.NullString  `json:"description"`
+	Severity    MistakeSeverity `json:"severity"`
+	CreatedAt   sql.NullTime    `json:"created_at"`
+	UpdatedAt   sql.NullTime    `json:"updated_at"`
+}
+
+type RatingDimension struct {
+	ID          int32          `json:"id"`
+	UserID      int32          `json:"user_id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	UpdatedAt   sql.NullTime   `json:"updated_at"`
 }
 
 type Strategy struct {
@@ -118,6 +412,23 @@ type Strategy struct {
 	Description sql.NullString `json:"description"`
 	CreatedAt   sql.NullTime   `json:"created_at"`
 	UpdatedAt   sql.NullTime   `json:"updated_at"`
+	Version     int32          `json:"version"`
+}
+
+type StrategyRule struct {
+	ID          int32        `json:"id"`
+	StrategyID  int32        `json:"strategy_id"`
+	Position    int32        `json:"position"`
+	Description string       `json:"description"`
+	CreatedAt   sql.NullTime `json:"created_at"`
+	UpdatedAt   sql.NullTime `json:"updated_at"`
+}
+
+type Tag struct {
+	ID        int32        `json:"id"`
+	UserID    int32        `json:"user_id"`
+	Name      string       `json:"name"`
+	CreatedAt sql.NullTime `json:"created_at"`
 }
 
 type Trade struct {
@@ -144,6 +455,44 @@ type Trade struct {
 	UpdatedAt   sql.NullTime   `json:"updated_at"`
 	ChartBefore sql.NullString `json:"chart_before"`
 	ChartAfter  sql.NullString `json:"chart_after"`
+	FxRate      sql.NullString `json:"fx_rate"`
+	Commission  string         `json:"commission"`
+	Swap        string         `json:"swap"`
+	Fees        string         `json:"fees"`
+	NetPl       sql.NullString `json:"net_pl"`
+	PlannedRr   sql.NullString `json:"planned_rr"`
+	RealizedR   sql.NullString `json:"realized_r"`
+	RiskAmount  sql.NullString `json:"risk_amount"`
+	OpenedAt    time.Time      `json:"opened_at"`
+	ClosedAt    sql.NullTime   `json:"closed_at"`
+	CloseDate   sql.NullTime   `json:"close_date"`
+	CloseTime   sql.NullTime   `json:"close_time"`
+	OrderType   OrderType      `json:"order_type"`
+	Grade       sql.NullString `json:"grade"`
+	GroupID     sql.NullInt32  `json:"group_id"`
+	DeletedAt   sql.NullTime   `json:"deleted_at"`
+	Version     int32          `json:"version"`
+}
+
+type TradeGroup struct {
+	ID          int32          `json:"id"`
+	UserID      int32          `json:"user_id"`
+	Name        string         `json:"name"`
+	Description sql.NullString `json:"description"`
+	CreatedAt   sql.NullTime   `json:"created_at"`
+	UpdatedAt   sql.NullTime   `json:"updated_at"`
+}
+
+type TradeMistake struct {
+	TradeID       int32 `json:"trade_id"`
+	MistakeTypeID int32 `json:"mistake_type_id"`
+}
+
+type TradeRating struct {
+	TradeID     int32       `json:"trade_id"`
+	DimensionID int32       `json:"dimension_id"`
+	Phase       RatingPhase `json:"phase"`
+	Value       int16       `json:"value"`
 }
 
 type TradeStrategy struct {
@@ -151,10 +500,32 @@ type TradeStrategy struct {
 	StrategyID int32 `json:"strategy_id"`
 }
 
+type TradeStrategyRule struct {
+	TradeID    int32 `json:"trade_id"`
+	StrategyID int32 `json:"strategy_id"`
+	RuleID     int32 `json:"rule_id"`
+	Satisfied  bool  `json:"satisfied"`
+}
+
+type TradeTag struct {
+	TradeID int32 `json:"trade_id"`
+	TagID   int32 `json:"tag_id"`
+}
+
+type TradeTemplate struct {
+	ID        int32           `json:"id"`
+	UserID    int32           `json:"user_id"`
+	Name      string          `json:"name"`
+	Defaults  json.RawMessage `json:"defaults"`
+	CreatedAt sql.NullTime    `json:"created_at"`
+	UpdatedAt sql.NullTime    `json:"updated_at"`
+}
+
 type User struct {
 	ID           int32        `json:"id"`
 	Email        string       `json:"email"`
 	PasswordHash string       `json:"password_hash"`
 	CreatedAt    sql.NullTime `json:"created_at"`
 	UpdatedAt    sql.NullTime `json:"updated_at"`
+	Timezone     string       `json:"timezone"`
 }
@@ -10,33 +10,108 @@ import (
 )
 
 type Querier interface {
+	AddTradeMistake(ctx context.Context, arg AddTradeMistakeParams) error
+	AddTradeRating(ctx context.Context, arg AddTradeRatingParams) error
 	AddTradeStrategy(ctx context.Context, arg AddTradeStrategyParams) error
+	AddTradeStrategyRule(ctx context.Context, arg AddTradeStrategyRuleParams) error
+	AddTradeTag(ctx context.Context, arg AddTradeTagParams) error
+	AssignTradeGroup(ctx context.Context, arg AssignTradeGroupParams) (int64, error)
+	ClearTradeGrade(ctx context.Context, arg ClearTradeGradeParams) error
+	ClearTradeGroup(ctx context.Context, groupID sql.NullInt32) error
 	CreateAccount(ctx context.Context, arg CreateAccountParams) (CreateAccountRow, error)
+	CreateAuditEvent(ctx context.Context, arg CreateAuditEventParams) error
+	CreateExecution(ctx context.Context, arg CreateExecutionParams) (Execution, error)
+	CreateGrade(ctx context.Context, arg CreateGradeParams) (Grade, error)
+	CreateInstrument(ctx context.Context, arg CreateInstrumentParams) (Instrument, error)
+	CreateMistakeType(ctx context.Context, arg CreateMistakeTypeParams) (MistakeType, error)
+	CreateRatingDimension(ctx context.Context, arg CreateRatingDimensionParams) (RatingDimension, error)
 	CreateStrategy(ctx context.Context, arg CreateStrategyParams) (Strategy, error)
+	CreateStrategyRule(ctx context.Context, arg CreateStrategyRuleParams) (StrategyRule, error)
 	CreateTrade(ctx context.Context, arg CreateTradeParams) (Trade, error)
+	CreateTradeGroup(ctx context.Context, arg CreateTradeGroupParams) (TradeGroup, error)
+	CreateTradeTemplate(ctx context.Context, arg CreateTradeTemplateParams) (TradeTemplate, error)
 	CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error)
-	DeleteAccount(ctx context.Context, arg DeleteAccountParams) error
+	DeleteExecution(ctx context.Context, arg DeleteExecutionParams) (sql.Result, error)
+	DeleteFXRate(ctx context.Context, arg DeleteFXRateParams) (sql.Result, error)
+	DeleteGradesByUserID(ctx context.Context, userID int32) error
+	DeleteInstrument(ctx context.Context, arg DeleteInstrumentParams) (sql.Result, error)
+	DeleteMistakeType(ctx context.Context, arg DeleteMistakeTypeParams) (sql.Result, error)
+	DeleteRatingDimension(ctx context.Context, arg DeleteRatingDimensionParams) (sql.Result, error)
 	DeleteStrategy(ctx context.Context, arg DeleteStrategyParams) (sql.Result, error)
-	DeleteTrade(ctx context.Context, arg DeleteTradeParams) error
+	DeleteStrategyRule(ctx context.Context, arg DeleteStrategyRuleParams) error
+	DeleteTradeGroup(ctx context.Context, arg DeleteTradeGroupParams) (sql.Result, error)
+	DeleteTradeMistakes(ctx context.Context, tradeID int32) error
+	DeleteTradeRatings(ctx context.Context, tradeID int32) error
 	DeleteTradeStrategies(ctx context.Context, tradeID int32) error
+	DeleteTradeTags(ctx context.Context, tradeID int32) error
+	DeleteTradeTemplate(ctx context.Context, arg DeleteTradeTemplateParams) (sql.Result, error)
 	GetAccountByID(ctx context.Context, arg GetAccountByIDParams) (GetAccountByIDRow, error)
 	GetAccountsByUserID(ctx context.Context, userID int32) ([]GetAccountsByUserIDRow, error)
+	GetAuditEventsByEntity(ctx context.Context, arg GetAuditEventsByEntityParams) ([]AuditEvent, error)
+	GetDeletedAccountsByUserID(ctx context.Context, userID int32) ([]GetDeletedAccountsByUserIDRow, error)
+	GetDeletedTradeByID(ctx context.Context, arg GetDeletedTradeByIDParams) (Trade, error)
+	GetDeletedTradesByUserID(ctx context.Context, userID int32) ([]Trade, error)
+	GetFXRateOnOrBefore(ctx context.Context, arg GetFXRateOnOrBeforeParams) (FxRate, error)
+	GetFXRatesByUserID(ctx context.Context, userID int32) ([]FxRate, error)
+	GetGradesByUserID(ctx context.Context, userID int32) ([]Grade, error)
+	GetInstrumentByID(ctx context.Context, arg GetInstrumentByIDParams) (Instrument, error)
+	GetInstrumentBySymbol(ctx context.Context, arg GetInstrumentBySymbolParams) (Instrument, error)
+	GetInstrumentsByUserID(ctx context.Context, userID sql.NullInt32) ([]Instrument, error)
+	GetMistakeTypeByID(ctx context.Context, arg GetMistakeTypeByIDParams) (MistakeType, error)
+	GetMistakeTypeByName(ctx context.Context, arg GetMistakeTypeByNameParams) (MistakeType, error)
+	GetMistakeTypesByUserID(ctx context.Context, userID int32) ([]MistakeType, error)
+	GetRatingDimensionByID(ctx context.Context, arg GetRatingDimensionByIDParams) (RatingDimension, error)
+	GetRatingDimensionByName(ctx context.Context, arg GetRatingDimensionByNameParams) (RatingDimension, error)
+	GetRatingDimensionsByUserID(ctx context.Context, userID int32) ([]RatingDimension, error)
 	GetStrategiesByUserID(ctx context.Context, userID int32) ([]Strategy, error)
 	GetStrategyByID(ctx context.Context, arg GetStrategyByIDParams) (Strategy, error)
+	GetStrategyRules(ctx context.Context, strategyID int32) ([]StrategyRule, error)
+	GetTagsByUserID(ctx context.Context, userID int32) ([]Tag, error)
 	GetTradeByID(ctx context.Context, arg GetTradeByIDParams) (Trade, error)
+	GetTradeExecutions(ctx context.Context, tradeID int32) ([]Execution, error)
+	GetTradeGroupByID(ctx context.Context, arg GetTradeGroupByIDParams) (TradeGroup, error)
+	GetTradeGroupsByUserID(ctx context.Context, userID int32) ([]TradeGroup, error)
+	GetTradeMistakes(ctx context.Context, tradeID int32) ([]MistakeType, error)
+	GetTradeMistakesByUserID(ctx context.Context, userID int32) ([]GetTradeMistakesByUserIDRow, error)
+	GetTradeRatings(ctx context.Context, tradeID int32) ([]GetTradeRatingsRow, error)
+	GetTradeRatingsByUserID(ctx context.Context, userID int32) ([]GetTradeRatingsByUserIDRow, error)
+	GetTradeRuleAdherenceByUserID(ctx context.Context, userID int32) ([]GetTradeRuleAdherenceByUserIDRow, error)
 	GetTradeStrategies(ctx context.Context, tradeID int32) ([]Strategy, error)
-	GetTradesByAccountID(ctx context.Context, arg GetTradesByAccountIDParams) ([]Trade, error)
-	GetTradesByAccountIDAndDateRange(ctx context.Context, arg GetTradesByAccountIDAndDateRangeParams) ([]Trade, error)
+	GetTradeStrategyRules(ctx context.Context, tradeID int32) ([]GetTradeStrategyRulesRow, error)
+	GetTradeTags(ctx context.Context, tradeID int32) ([]Tag, error)
+	GetTradeTagsByUserID(ctx context.Context, userID int32) ([]GetTradeTagsByUserIDRow, error)
+	GetTradeTemplateByID(ctx context.Context, arg GetTradeTemplateByIDParams) (TradeTemplate, error)
+	GetTradeTemplateByName(ctx context.Context, arg GetTradeTemplateByNameParams) (TradeTemplate, error)
+	GetTradeTemplatesByUserID(ctx context.Context, userID int32) ([]TradeTemplate, error)
+	GetTradesByGroupID(ctx context.Context, arg GetTradesByGroupIDParams) ([]Trade, error)
 	GetTradesByUserID(ctx context.Context, userID int32) ([]Trade, error)
 	GetTradesByUserIDAndDateRange(ctx context.Context, arg GetTradesByUserIDAndDateRangeParams) ([]Trade, error)
 	GetUserByEmail(ctx context.Context, email string) (User, error)
 	GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, error)
+	PurgeDeletedAccounts(ctx context.Context, deletedAt sql.NullTime) (int64, error)
+	PurgeDeletedTrades(ctx context.Context, deletedAt sql.NullTime) (int64, error)
+	RestoreAccount(ctx context.Context, arg RestoreAccountParams) (RestoreAccountRow, error)
+	RestoreAccountTrades(ctx context.Context, arg RestoreAccountTradesParams) error
+	RestoreTrade(ctx context.Context, arg RestoreTradeParams) (Trade, error)
+	SearchTrades(ctx context.Context, arg SearchTradesParams) ([]SearchTradesRow, error)
+	SoftDeleteAccount(ctx context.Context, arg SoftDeleteAccountParams) (int64, error)
+	SoftDeleteAccountTrades(ctx context.Context, arg SoftDeleteAccountTradesParams) error
+	SoftDeleteTrade(ctx context.Context, arg SoftDeleteTradeParams) (int64, error)
 	UpdateAccount(ctx context.Context, arg UpdateAccountParams) (UpdateAccountRow, error)
 	UpdateAccountBalance(ctx context.Context, arg UpdateAccountBalanceParams) (UpdateAccountBalanceRow, error)
+	UpdateInstrument(ctx context.Context, arg UpdateInstrumentParams) (Instrument, error)
+	UpdateMistakeType(ctx context.Context, arg UpdateMistakeTypeParams) (MistakeType, error)
+	UpdateRatingDimension(ctx context.Context, arg UpdateRatingDimensionParams) (RatingDimension, error)
 	UpdateStrategy(ctx context.Context, arg UpdateStrategyParams) (Strategy, error)
+	UpdateStrategyRule(ctx context.Context, arg UpdateStrategyRuleParams) (StrategyRule, error)
 	UpdateTrade(ctx context.Context, arg UpdateTradeParams) (Trade, error)
 	UpdateTradeChartAfter(ctx context.Context, arg UpdateTradeChartAfterParams) (Trade, error)
 	UpdateTradeChartBefore(ctx context.Context, arg UpdateTradeChartBeforeParams) (Trade, error)
+	UpdateTradeGroup(ctx context.Context, arg UpdateTradeGroupParams) (TradeGroup, error)
+	UpdateTradeTemplate(ctx context.Context, arg UpdateTradeTemplateParams) (TradeTemplate, error)
+	UpdateUserTimezone(ctx context.Context, arg UpdateUserTimezoneParams) (UpdateUserTimezoneRow, error)
+	UpsertFXRate(ctx context.Context, arg UpsertFXRateParams) (FxRate, error)
+	UpsertTag(ctx context.Context, arg UpsertTagParams) (Tag, error)
 }
 
 var _ Querier = (*Queries)(nil)
@@ -13,7 +13,7 @@ import (
 const createStrategy = `-- name: CreateStrategy :one
 INSERT INTO strategies (user_id, name, description)
 VALUES ($1, $2, $3)
-RETURNING id, user_id, name, description, created_at, updated_at
+RETURNING id, user_id, name, description, created_at, updated_at, version
 `
 
 type CreateStrategyParams struct {
@@ -32,26 +32,73 @@ func (q *Queries) CreateStrategy(ctx context.Context, arg CreateStrategyParams)
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const createStrategyRule = `-- name: CreateStrategyRule :one
+INSERT INTO strategy_rules (strategy_id, position, description)
+VALUES ($1, $2, $3)
+RETURNING id, strategy_id, position, description, created_at, updated_at
+`
+
+type CreateStrategyRuleParams struct {
+	StrategyID  int32  `json:"strategy_id"`
+	Position    int32  `json:"position"`
+	Description string `json:"description"`
+}
+
+func (q *Queries) CreateStrategyRule(ctx context.Context, arg CreateStrategyRuleParams) (StrategyRule, error) {
+	row := q.db.QueryRowContext(ctx, createStrategyRule,
+		arg.StrategyID,
+		arg.Position,
+		arg.Description,
+	)
+	var i StrategyRule
+	err := row.Scan(
+		&i.ID,
+		&i.StrategyID,
+		&i.Position,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
 
 const deleteStrategy = `-- name: DeleteStrategy :execresult
 DELETE FROM strategies
-WHERE id = $1 AND user_id = $2
+WHERE id = $1 AND user_id = $2 AND version = $3
 `
 
 type DeleteStrategyParams struct {
-	ID     int32 `json:"id"`
-	UserID int32 `json:"user_id"`
+	ID      int32 `json:"id"`
+	UserID  int32 `json:"user_id"`
+	Version int32 `json:"version"`
 }
 
 func (q *Queries) DeleteStrategy(ctx context.Context, arg DeleteStrategyParams) (sql.Result, error) {
-	return q.db.ExecContext(ctx, deleteStrategy, arg.ID, arg.UserID)
+	return q.db.ExecContext(ctx, deleteStrategy, arg.ID, arg.UserID, arg.Version)
+}
+
+const deleteStrategyRule = `-- name: DeleteStrategyRule :exec
+DELETE FROM strategy_rules
+WHERE id = $1 AND strategy_id = $2
+`
+
+type DeleteStrategyRuleParams struct {
+	ID         int32 `json:"id"`
+	StrategyID int32 `json:"strategy_id"`
+}
+
+func (q *Queries) DeleteStrategyRule(ctx context.Context, arg DeleteStrategyRuleParams) error {
+	_, err := q.db.ExecContext(ctx, deleteStrategyRule, arg.ID, arg.StrategyID)
+	return err
 }
 
 const getStrategiesByUserID = `-- name: GetStrategiesByUserID :many
-SELECT id, user_id, name, description, created_at, updated_at FROM strategies
+SELECT id, user_id, name, description, created_at, updated_at, version FROM strategies
 WHERE user_id = $1
 ORDER BY name ASC
 `
@@ -72,6 +119,7 @@ func (q *Queries) GetStrategiesByUserID(ctx context.Context, userID int32) ([]St
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -87,7 +135,7 @@ func (q *Queries) GetStrategiesByUserID(ctx context.Context, userID int32) ([]St
 }
 
 const getStrategyByID = `-- name: GetStrategyByID :one
-SELECT id, user_id, name, description, created_at, updated_at FROM strategies
+SELECT id, user_id, name, description, created_at, updated_at, version FROM strategies
 WHERE id = $1 AND user_id = $2
 `
 
@@ -106,15 +154,52 @@ func (q *Queries) GetStrategyByID(ctx context.Context, arg GetStrategyByIDParams
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const getStrategyRules = `-- name: GetStrategyRules :many
+SELECT id, strategy_id, position, description, created_at, updated_at FROM strategy_rules
+WHERE strategy_id = $1
+ORDER BY position ASC
+`
+
+func (q *Queries) GetStrategyRules(ctx context.Context, strategyID int32) ([]StrategyRule, error) {
+	rows, err := q.db.QueryContext(ctx, getStrategyRules, strategyID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []StrategyRule
+	for rows.Next() {
+		var i StrategyRule
+		if err := rows.Scan(
+			&i.ID,
+			&i.StrategyID,
+			&i.Position,
+			&i.Description,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const updateStrategy = `-- name: UpdateStrategy :one
 UPDATE strategies
-SET name = $2, description = $3, updated_at = CURRENT_TIMESTAMP
-WHERE id = $1 AND user_id = $4
-RETURNING id, user_id, name, description, created_at, updated_at
+SET name = $2, description = $3, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $4 AND version = $5
+RETURNING id, user_id, name, description, created_at, updated_at, version
 `
 
 type UpdateStrategyParams struct {
@@ -122,6 +207,7 @@ type UpdateStrategyParams struct {
 	Name        string         `json:"name"`
 	Description sql.NullString `json:"description"`
 	UserID      int32          `json:"user_id"`
+	Version     int32          `json:"version"`
 }
 
 func (q *Queries) UpdateStrategy(ctx context.Context, arg UpdateStrategyParams) (Strategy, error) {
@@ -130,6 +216,7 @@ func (q *Queries) UpdateStrategy(ctx context.Context, arg UpdateStrategyParams)
 		arg.Name,
 		arg.Description,
 		arg.UserID,
+		arg.Version,
 	)
 	var i Strategy
 	err := row.Scan(
@@ -139,6 +226,40 @@ func (q *Queries) UpdateStrategy(ctx context.Context, arg UpdateStrategyParams)
 		&i.Description,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const updateStrategyRule = `-- name: UpdateStrategyRule :one
+UPDATE strategy_rules
+SET position = $2, description = $3, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND strategy_id = $4
+RETURNING id, strategy_id, position, description, created_at, updated_at
+`
+
+type UpdateStrategyRuleParams struct {
+	ID          int32  `json:"id"`
+	Position    int32  `json:"position"`
+	Description string `json:"description"`
+	StrategyID  int32  `json:"strategy_id"`
+}
+
+func (q *Queries) UpdateStrategyRule(ctx context.Context, arg UpdateStrategyRuleParams) (StrategyRule, error) {
+	row := q.db.QueryRowContext(ctx, updateStrategyRule,
+		arg.ID,
+		arg.Position,
+		arg.Description,
+		arg.StrategyID,
+	)
+	var i StrategyRule
+	err := row.Scan(
+		&i.ID,
+		&i.StrategyID,
+		&i.Position,
+		&i.Description,
+		&i.CreatedAt,
+		&i.UpdatedAt,
 	)
 	return i, err
 }
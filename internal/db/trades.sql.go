@@ -25,6 +25,28 @@ func (q *Queries) AddTradeStrategy(ctx context.Context, arg AddTradeStrategyPara
 	return err
 }
 
+const addTradeStrategyRule = `-- name: AddTradeStrategyRule :exec
+INSERT INTO trade_strategy_rules (trade_id, strategy_id, rule_id, satisfied) VALUES ($1, $2, $3, $4)
+ON CONFLICT DO NOTHING
+`
+
+type AddTradeStrategyRuleParams struct {
+	TradeID    int32 `json:"trade_id"`
+	StrategyID int32 `json:"strategy_id"`
+	RuleID     int32 `json:"rule_id"`
+	Satisfied  bool  `json:"satisfied"`
+}
+
+func (q *Queries) AddTradeStrategyRule(ctx context.Context, arg AddTradeStrategyRuleParams) error {
+	_, err := q.db.ExecContext(ctx, addTradeStrategyRule,
+		arg.TradeID,
+		arg.StrategyID,
+		arg.RuleID,
+		arg.Satisfied,
+	)
+	return err
+}
+
 const createTrade = `-- name: CreateTrade :one
 INSERT INTO
     trades (
@@ -45,7 +67,21 @@ INSERT INTO
         take_profit,
         notes,
         mistakes,
-        amount
+        amount,
+        fx_rate,
+        commission,
+        swap,
+        fees,
+        net_pl,
+        planned_rr,
+        realized_r,
+        risk_amount,
+        opened_at,
+        closed_at,
+        close_date,
+        close_time,
+        order_type,
+        grade
     )
 VALUES (
         $1,
@@ -65,10 +101,24 @@ VALUES (
         $15,
         $16,
         $17,
-        $18
+        $18,
+        $19,
+        $20,
+        $21,
+        $22,
+        $23,
+        $24,
+        $25,
+        $26,
+        $27,
+        $28,
+        $29,
+        $30,
+        $31,
+        $32
     )
 RETURNING
-    id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+    id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 `
 
 type CreateTradeParams struct {
@@ -90,6 +140,20 @@ type CreateTradeParams struct {
 	Notes      sql.NullString `json:"notes"`
 	Mistakes   sql.NullString `json:"mistakes"`
 	Amount     sql.NullString `json:"amount"`
+	FxRate     sql.NullString `json:"fx_rate"`
+	Commission string         `json:"commission"`
+	Swap       string         `json:"swap"`
+	Fees       string         `json:"fees"`
+	NetPl      sql.NullString `json:"net_pl"`
+	PlannedRr  sql.NullString `json:"planned_rr"`
+	RealizedR  sql.NullString `json:"realized_r"`
+	RiskAmount sql.NullString `json:"risk_amount"`
+	OpenedAt   time.Time      `json:"opened_at"`
+	ClosedAt   sql.NullTime   `json:"closed_at"`
+	CloseDate  sql.NullTime   `json:"close_date"`
+	CloseTime  sql.NullTime   `json:"close_time"`
+	OrderType  OrderType      `json:"order_type"`
+	Grade      sql.NullString `json:"grade"`
 }
 
 func (q *Queries) CreateTrade(ctx context.Context, arg CreateTradeParams) (Trade, error) {
@@ -112,6 +176,20 @@ func (q *Queries) CreateTrade(ctx context.Context, arg CreateTradeParams) (Trade
 		arg.Notes,
 		arg.Mistakes,
 		arg.Amount,
+		arg.FxRate,
+		arg.Commission,
+		arg.Swap,
+		arg.Fees,
+		arg.NetPl,
+		arg.PlannedRr,
+		arg.RealizedR,
+		arg.RiskAmount,
+		arg.OpenedAt,
+		arg.ClosedAt,
+		arg.CloseDate,
+		arg.CloseTime,
+		arg.OrderType,
+		arg.Grade,
 	)
 	var i Trade
 	err := row.Scan(
@@ -138,35 +216,168 @@ func (q *Queries) CreateTrade(ctx context.Context, arg CreateTradeParams) (Trade
 		&i.UpdatedAt,
 		&i.ChartBefore,
 		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
-const deleteTrade = `-- name: DeleteTrade :exec
-DELETE FROM trades WHERE id = $1 AND user_id = $2
+const deleteTradeStrategies = `-- name: DeleteTradeStrategies :exec
+DELETE FROM trade_strategies WHERE trade_id = $1
+`
+
+func (q *Queries) DeleteTradeStrategies(ctx context.Context, tradeID int32) error {
+	_, err := q.db.ExecContext(ctx, deleteTradeStrategies, tradeID)
+	return err
+}
+
+const getDeletedTradeByID = `-- name: GetDeletedTradeByID :one
+SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version FROM trades WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
 `
 
-type DeleteTradeParams struct {
+type GetDeletedTradeByIDParams struct {
 	ID     int32 `json:"id"`
 	UserID int32 `json:"user_id"`
 }
 
-func (q *Queries) DeleteTrade(ctx context.Context, arg DeleteTradeParams) error {
-	_, err := q.db.ExecContext(ctx, deleteTrade, arg.ID, arg.UserID)
-	return err
+func (q *Queries) GetDeletedTradeByID(ctx context.Context, arg GetDeletedTradeByIDParams) (Trade, error) {
+	row := q.db.QueryRowContext(ctx, getDeletedTradeByID, arg.ID, arg.UserID)
+	var i Trade
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AccountID,
+		&i.Date,
+		&i.Time,
+		&i.Pair,
+		&i.Type,
+		&i.Entry,
+		&i.Exit,
+		&i.Lots,
+		&i.Pips,
+		&i.Pl,
+		&i.Rr,
+		&i.Status,
+		&i.StopLoss,
+		&i.TakeProfit,
+		&i.Notes,
+		&i.Mistakes,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ChartBefore,
+		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
 }
 
-const deleteTradeStrategies = `-- name: DeleteTradeStrategies :exec
-DELETE FROM trade_strategies WHERE trade_id = $1
+const getDeletedTradesByUserID = `-- name: GetDeletedTradesByUserID :many
+SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
+FROM trades
+WHERE
+    user_id = $1
+    AND deleted_at IS NOT NULL
+ORDER BY deleted_at DESC
 `
 
-func (q *Queries) DeleteTradeStrategies(ctx context.Context, tradeID int32) error {
-	_, err := q.db.ExecContext(ctx, deleteTradeStrategies, tradeID)
-	return err
+func (q *Queries) GetDeletedTradesByUserID(ctx context.Context, userID int32) ([]Trade, error) {
+	rows, err := q.db.QueryContext(ctx, getDeletedTradesByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Trade
+	for rows.Next() {
+		var i Trade
+		if err := rows.Scan(
+			&i.ID,
+			&i.UserID,
+			&i.AccountID,
+			&i.Date,
+			&i.Time,
+			&i.Pair,
+			&i.Type,
+			&i.Entry,
+			&i.Exit,
+			&i.Lots,
+			&i.Pips,
+			&i.Pl,
+			&i.Rr,
+			&i.Status,
+			&i.StopLoss,
+			&i.TakeProfit,
+			&i.Notes,
+			&i.Mistakes,
+			&i.Amount,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.ChartBefore,
+			&i.ChartAfter,
+			&i.FxRate,
+			&i.Commission,
+			&i.Swap,
+			&i.Fees,
+			&i.NetPl,
+			&i.PlannedRr,
+			&i.RealizedR,
+			&i.RiskAmount,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.CloseDate,
+			&i.CloseTime,
+			&i.OrderType,
+			&i.Grade,
+			&i.GroupID,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
 }
 
 const getTradeByID = `-- name: GetTradeByID :one
-SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after FROM trades WHERE id = $1 AND user_id = $2
+SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version FROM trades WHERE id = $1 AND user_id = $2 AND deleted_at IS NULL
 `
 
 type GetTradeByIDParams struct {
@@ -201,12 +412,73 @@ func (q *Queries) GetTradeByID(ctx context.Context, arg GetTradeByIDParams) (Tra
 		&i.UpdatedAt,
 		&i.ChartBefore,
 		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
+const getTradeRuleAdherenceByUserID = `-- name: GetTradeRuleAdherenceByUserID :many
+SELECT tsr.trade_id, COUNT(*) AS rules, COUNT(*) FILTER (WHERE tsr.satisfied) AS satisfied_rules
+FROM
+    trade_strategy_rules tsr
+    INNER JOIN trades t ON t.id = tsr.trade_id
+WHERE
+    t.user_id = $1
+    AND t.deleted_at IS NULL
+GROUP BY tsr.trade_id
+`
+
+type GetTradeRuleAdherenceByUserIDRow struct {
+	TradeID        int32 `json:"trade_id"`
+	Rules          int64 `json:"rules"`
+	SatisfiedRules int64 `json:"satisfied_rules"`
+}
+
+func (q *Queries) GetTradeRuleAdherenceByUserID(ctx context.Context, userID int32) ([]GetTradeRuleAdherenceByUserIDRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTradeRuleAdherenceByUserID, userID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetTradeRuleAdherenceByUserIDRow
+	for rows.Next() {
+		var i GetTradeRuleAdherenceByUserIDRow
+		if err := rows.Scan(
+			&i.TradeID,
+			&i.Rules,
+			&i.SatisfiedRules,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getTradeStrategies = `-- name: GetTradeStrategies :many
-SELECT s.id, s.user_id, s.name, s.description, s.created_at, s.updated_at
+SELECT s.id, s.user_id, s.name, s.description, s.created_at, s.updated_at, s.version
 FROM
     strategies s
     INNER JOIN trade_strategies ts ON s.id = ts.strategy_id
@@ -230,6 +502,7 @@ func (q *Queries) GetTradeStrategies(ctx context.Context, tradeID int32) ([]Stra
 			&i.Description,
 			&i.CreatedAt,
 			&i.UpdatedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -244,53 +517,37 @@ func (q *Queries) GetTradeStrategies(ctx context.Context, tradeID int32) ([]Stra
 	return items, nil
 }
 
-const getTradesByAccountID = `-- name: GetTradesByAccountID :many
-SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
-FROM trades
+const getTradeStrategyRules = `-- name: GetTradeStrategyRules :many
+SELECT sr.id, sr.strategy_id, sr.description, tsr.satisfied
+FROM
+    trade_strategy_rules tsr
+    INNER JOIN strategy_rules sr ON sr.id = tsr.rule_id
 WHERE
-    account_id = $1
-    AND user_id = $2
-ORDER BY date DESC, time DESC
+    tsr.trade_id = $1
+ORDER BY sr.strategy_id ASC, sr.position ASC
 `
 
-type GetTradesByAccountIDParams struct {
-	AccountID sql.NullInt32 `json:"account_id"`
-	UserID    int32         `json:"user_id"`
+type GetTradeStrategyRulesRow struct {
+	ID          int32  `json:"id"`
+	StrategyID  int32  `json:"strategy_id"`
+	Description string `json:"description"`
+	Satisfied   bool   `json:"satisfied"`
 }
 
-func (q *Queries) GetTradesByAccountID(ctx context.Context, arg GetTradesByAccountIDParams) ([]Trade, error) {
-	rows, err := q.db.QueryContext(ctx, getTradesByAccountID, arg.AccountID, arg.UserID)
+func (q *Queries) GetTradeStrategyRules(ctx context.Context, tradeID int32) ([]GetTradeStrategyRulesRow, error) {
+	rows, err := q.db.QueryContext(ctx, getTradeStrategyRules, tradeID)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []Trade
+	var items []GetTradeStrategyRulesRow
 	for rows.Next() {
-		var i Trade
+		var i GetTradeStrategyRulesRow
 		if err := rows.Scan(
 			&i.ID,
-			&i.UserID,
-			&i.AccountID,
-			&i.Date,
-			&i.Time,
-			&i.Pair,
-			&i.Type,
-			&i.Entry,
-			&i.Exit,
-			&i.Lots,
-			&i.Pips,
-			&i.Pl,
-			&i.Rr,
-			&i.Status,
-			&i.StopLoss,
-			&i.TakeProfit,
-			&i.Notes,
-			&i.Mistakes,
-			&i.Amount,
-			&i.CreatedAt,
-			&i.UpdatedAt,
-			&i.ChartBefore,
-			&i.ChartAfter,
+			&i.StrategyID,
+			&i.Description,
+			&i.Satisfied,
 		); err != nil {
 			return nil, err
 		}
@@ -305,31 +562,23 @@ func (q *Queries) GetTradesByAccountID(ctx context.Context, arg GetTradesByAccou
 	return items, nil
 }
 
-const getTradesByAccountIDAndDateRange = `-- name: GetTradesByAccountIDAndDateRange :many
-SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+const getTradesByGroupID = `-- name: GetTradesByGroupID :many
+SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 FROM trades
 WHERE
-    account_id = $1
+    group_id = $1
     AND user_id = $2
-    AND date >= $3
-    AND date <= $4
-ORDER BY date DESC, time DESC
+    AND deleted_at IS NULL
+ORDER BY opened_at ASC
 `
 
-type GetTradesByAccountIDAndDateRangeParams struct {
-	AccountID sql.NullInt32 `json:"account_id"`
-	UserID    int32         `json:"user_id"`
-	Date      time.Time     `json:"date"`
-	Date_2    time.Time     `json:"date_2"`
+type GetTradesByGroupIDParams struct {
+	GroupID sql.NullInt32 `json:"group_id"`
+	UserID  int32         `json:"user_id"`
 }
 
-func (q *Queries) GetTradesByAccountIDAndDateRange(ctx context.Context, arg GetTradesByAccountIDAndDateRangeParams) ([]Trade, error) {
-	rows, err := q.db.QueryContext(ctx, getTradesByAccountIDAndDateRange,
-		arg.AccountID,
-		arg.UserID,
-		arg.Date,
-		arg.Date_2,
-	)
+func (q *Queries) GetTradesByGroupID(ctx context.Context, arg GetTradesByGroupIDParams) ([]Trade, error) {
+	rows, err := q.db.QueryContext(ctx, getTradesByGroupID, arg.GroupID, arg.UserID)
 	if err != nil {
 		return nil, err
 	}
@@ -361,6 +610,23 @@ func (q *Queries) GetTradesByAccountIDAndDateRange(ctx context.Context, arg GetT
 			&i.UpdatedAt,
 			&i.ChartBefore,
 			&i.ChartAfter,
+			&i.FxRate,
+			&i.Commission,
+			&i.Swap,
+			&i.Fees,
+			&i.NetPl,
+			&i.PlannedRr,
+			&i.RealizedR,
+			&i.RiskAmount,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.CloseDate,
+			&i.CloseTime,
+			&i.OrderType,
+			&i.Grade,
+			&i.GroupID,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -376,11 +642,12 @@ func (q *Queries) GetTradesByAccountIDAndDateRange(ctx context.Context, arg GetT
 }
 
 const getTradesByUserID = `-- name: GetTradesByUserID :many
-SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 FROM trades
 WHERE
     user_id = $1
-ORDER BY date DESC, time DESC
+    AND deleted_at IS NULL
+ORDER BY opened_at DESC
 `
 
 func (q *Queries) GetTradesByUserID(ctx context.Context, userID int32) ([]Trade, error) {
@@ -416,6 +683,23 @@ func (q *Queries) GetTradesByUserID(ctx context.Context, userID int32) ([]Trade,
 			&i.UpdatedAt,
 			&i.ChartBefore,
 			&i.ChartAfter,
+			&i.FxRate,
+			&i.Commission,
+			&i.Swap,
+			&i.Fees,
+			&i.NetPl,
+			&i.PlannedRr,
+			&i.RealizedR,
+			&i.RiskAmount,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.CloseDate,
+			&i.CloseTime,
+			&i.OrderType,
+			&i.Grade,
+			&i.GroupID,
+			&i.DeletedAt,
+			&i.Version,
 		); err != nil {
 			return nil, err
 		}
@@ -431,23 +715,24 @@ func (q *Queries) GetTradesByUserID(ctx context.Context, userID int32) ([]Trade,
 }
 
 const getTradesByUserIDAndDateRange = `-- name: GetTradesByUserIDAndDateRange :many
-SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+SELECT id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 FROM trades
 WHERE
     user_id = $1
-    AND date >= $2
-    AND date <= $3
-ORDER BY date DESC, time DESC
+    AND opened_at >= $2
+    AND opened_at < $3
+    AND deleted_at IS NULL
+ORDER BY opened_at DESC
 `
 
 type GetTradesByUserIDAndDateRangeParams struct {
-	UserID int32     `json:"user_id"`
-	Date   time.Time `json:"date"`
-	Date_2 time.Time `json:"date_2"`
+	UserID     int32     `json:"user_id"`
+	OpenedAt   time.Time `json:"opened_at"`
+	OpenedAt_2 time.Time `json:"opened_at_2"`
 }
 
 func (q *Queries) GetTradesByUserIDAndDateRange(ctx context.Context, arg GetTradesByUserIDAndDateRangeParams) ([]Trade, error) {
-	rows, err := q.db.QueryContext(ctx, getTradesByUserIDAndDateRange, arg.UserID, arg.Date, arg.Date_2)
+	rows, err := q.db.QueryContext(ctx, getTradesByUserIDAndDateRange, arg.UserID, arg.OpenedAt, arg.OpenedAt_2)
 	if err != nil {
 		return nil, err
 	}
@@ -479,6 +764,217 @@ func (q *Queries) GetTradesByUserIDAndDateRange(ctx context.Context, arg GetTrad
 			&i.UpdatedAt,
 			&i.ChartBefore,
 			&i.ChartAfter,
+			&i.FxRate,
+			&i.Commission,
+			&i.Swap,
+			&i.Fees,
+			&i.NetPl,
+			&i.PlannedRr,
+			&i.RealizedR,
+			&i.RiskAmount,
+			&i.OpenedAt,
+			&i.ClosedAt,
+			&i.CloseDate,
+			&i.CloseTime,
+			&i.OrderType,
+			&i.Grade,
+			&i.GroupID,
+			&i.DeletedAt,
+			&i.Version,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Close(); err != nil {
+		return nil, err
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const purgeDeletedTrades = `-- name: PurgeDeletedTrades :execrows
+DELETE FROM trades WHERE deleted_at < $1
+`
+
+func (q *Queries) PurgeDeletedTrades(ctx context.Context, deletedAt sql.NullTime) (int64, error) {
+	result, err := q.db.ExecContext(ctx, purgeDeletedTrades, deletedAt)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
+const restoreTrade = `-- name: RestoreTrade :one
+UPDATE trades
+SET deleted_at = NULL, version = version + 1, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1 AND user_id = $2 AND deleted_at IS NOT NULL
+RETURNING id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
+`
+
+type RestoreTradeParams struct {
+	ID     int32 `json:"id"`
+	UserID int32 `json:"user_id"`
+}
+
+func (q *Queries) RestoreTrade(ctx context.Context, arg RestoreTradeParams) (Trade, error) {
+	row := q.db.QueryRowContext(ctx, restoreTrade, arg.ID, arg.UserID)
+	var i Trade
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.AccountID,
+		&i.Date,
+		&i.Time,
+		&i.Pair,
+		&i.Type,
+		&i.Entry,
+		&i.Exit,
+		&i.Lots,
+		&i.Pips,
+		&i.Pl,
+		&i.Rr,
+		&i.Status,
+		&i.StopLoss,
+		&i.TakeProfit,
+		&i.Notes,
+		&i.Mistakes,
+		&i.Amount,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.ChartBefore,
+		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
+	)
+	return i, err
+}
+
+const searchTrades = `-- name: SearchTrades :many
+WITH
+    search AS (
+        SELECT websearch_to_tsquery('english', $1::text) AS query
+    ),
+    strategy_documents AS (
+        SELECT ts.trade_id, setweight(to_tsvector('english', string_agg(s.name, ' ')), 'A') AS document
+        FROM trade_strategies ts
+            INNER JOIN strategies s ON s.id = ts.strategy_id
+        WHERE s.user_id = $2
+        GROUP BY ts.trade_id
+    )
+SELECT
+    trades.id, trades.user_id, trades.account_id, trades.date, trades.time, trades.pair, trades.type, trades.entry, trades.exit, trades.lots, trades.pips, trades.pl, trades.rr, trades.status, trades.stop_loss, trades.take_profit, trades.notes, trades.mistakes, trades.amount, trades.created_at, trades.updated_at, trades.chart_before, trades.chart_after, trades.fx_rate, trades.commission, trades.swap, trades.fees, trades.net_pl, trades.planned_rr, trades.realized_r, trades.risk_amount, trades.opened_at, trades.closed_at, trades.close_date, trades.close_time, trades.order_type, trades.grade, trades.group_id, trades.deleted_at, trades.version,
+    ts_rank(
+        trade_search_document(trades.pair, trades.notes, trades.mistakes) || coalesce(sd.document, ''::tsvector),
+        search.query
+    )::real AS rank,
+    CASE
+        WHEN to_tsvector('english', coalesce(trades.notes, '')) @@ search.query
+        THEN ts_headline('english', trades.notes, search.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, FragmentDelimiter=" ... "')
+        ELSE ''
+    END::text AS notes_snippet,
+    CASE
+        WHEN to_tsvector('english', coalesce(trades.mistakes, '')) @@ search.query
+        THEN ts_headline('english', trades.mistakes, search.query, 'StartSel=<mark>, StopSel=</mark>, MaxFragments=2, FragmentDelimiter=" ... "')
+        ELSE ''
+    END::text AS mistakes_snippet
+FROM trades
+    CROSS JOIN search
+    LEFT JOIN strategy_documents sd ON sd.trade_id = trades.id
+WHERE
+    trades.user_id = $2
+    AND trades.deleted_at IS NULL
+    AND (
+        trade_search_document(trades.pair, trades.notes, trades.mistakes) @@ search.query
+        OR sd.document @@ search.query
+    )
+ORDER BY rank DESC, trades.opened_at DESC, trades.id DESC
+LIMIT $3;
+`
+
+type SearchTradesParams struct {
+	Query       string `json:"query"`
+	UserID      int32  `json:"user_id"`
+	ResultLimit int32  `json:"result_limit"`
+}
+
+type SearchTradesRow struct {
+	Trade           Trade   `json:"trade"`
+	Rank            float32 `json:"rank"`
+	NotesSnippet    string  `json:"notes_snippet"`
+	MistakesSnippet string  `json:"mistakes_snippet"`
+}
+
+func (q *Queries) SearchTrades(ctx context.Context, arg SearchTradesParams) ([]SearchTradesRow, error) {
+	rows, err := q.db.QueryContext(ctx, searchTrades, arg.Query, arg.UserID, arg.ResultLimit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []SearchTradesRow
+	for rows.Next() {
+		var i SearchTradesRow
+		if err := rows.Scan(
+			&i.Trade.ID,
+			&i.Trade.UserID,
+			&i.Trade.AccountID,
+			&i.Trade.Date,
+			&i.Trade.Time,
+			&i.Trade.Pair,
+			&i.Trade.Type,
+			&i.Trade.Entry,
+			&i.Trade.Exit,
+			&i.Trade.Lots,
+			&i.Trade.Pips,
+			&i.Trade.Pl,
+			&i.Trade.Rr,
+			&i.Trade.Status,
+			&i.Trade.StopLoss,
+			&i.Trade.TakeProfit,
+			&i.Trade.Notes,
+			&i.Trade.Mistakes,
+			&i.Trade.Amount,
+			&i.Trade.CreatedAt,
+			&i.Trade.UpdatedAt,
+			&i.Trade.ChartBefore,
+			&i.Trade.ChartAfter,
+			&i.Trade.FxRate,
+			&i.Trade.Commission,
+			&i.Trade.Swap,
+			&i.Trade.Fees,
+			&i.Trade.NetPl,
+			&i.Trade.PlannedRr,
+			&i.Trade.RealizedR,
+			&i.Trade.RiskAmount,
+			&i.Trade.OpenedAt,
+			&i.Trade.ClosedAt,
+			&i.Trade.CloseDate,
+			&i.Trade.CloseTime,
+			&i.Trade.OrderType,
+			&i.Trade.Grade,
+			&i.Trade.GroupID,
+			&i.Trade.DeletedAt,
+			&i.Trade.Version,
+			&i.Rank,
+			&i.NotesSnippet,
+			&i.MistakesSnippet,
 		); err != nil {
 			return nil, err
 		}
@@ -493,6 +989,26 @@ func (q *Queries) GetTradesByUserIDAndDateRange(ctx context.Context, arg GetTrad
 	return items, nil
 }
 
+const softDeleteTrade = `-- name: SoftDeleteTrade :execrows
+UPDATE trades
+SET deleted_at = CURRENT_TIMESTAMP, version = version + 1
+WHERE id = $1 AND user_id = $2 AND version = $3 AND deleted_at IS NULL
+`
+
+type SoftDeleteTradeParams struct {
+	ID      int32 `json:"id"`
+	UserID  int32 `json:"user_id"`
+	Version int32 `json:"version"`
+}
+
+func (q *Queries) SoftDeleteTrade(ctx context.Context, arg SoftDeleteTradeParams) (int64, error) {
+	result, err := q.db.ExecContext(ctx, softDeleteTrade, arg.ID, arg.UserID, arg.Version)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected()
+}
+
 const updateTrade = `-- name: UpdateTrade :one
 UPDATE trades
 SET
@@ -513,12 +1029,29 @@ SET
     notes = $16,
     mistakes = $17,
     amount = $18,
+    fx_rate = $19,
+    commission = $20,
+    swap = $21,
+    fees = $22,
+    net_pl = $23,
+    planned_rr = $24,
+    realized_r = $25,
+    risk_amount = $26,
+    opened_at = $27,
+    closed_at = $28,
+    close_date = $29,
+    close_time = $30,
+    order_type = $31,
+    grade = $32,
+    version = version + 1,
     updated_at = CURRENT_TIMESTAMP
 WHERE
     id = $1
-    AND user_id = $19
+    AND user_id = $33
+    AND version = $34
+    AND deleted_at IS NULL
 RETURNING
-    id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+    id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 `
 
 type UpdateTradeParams struct {
@@ -540,7 +1073,22 @@ type UpdateTradeParams struct {
 	Notes      sql.NullString `json:"notes"`
 	Mistakes   sql.NullString `json:"mistakes"`
 	Amount     sql.NullString `json:"amount"`
+	FxRate     sql.NullString `json:"fx_rate"`
+	Commission string         `json:"commission"`
+	Swap       string         `json:"swap"`
+	Fees       string         `json:"fees"`
+	NetPl      sql.NullString `json:"net_pl"`
+	PlannedRr  sql.NullString `json:"planned_rr"`
+	RealizedR  sql.NullString `json:"realized_r"`
+	RiskAmount sql.NullString `json:"risk_amount"`
+	OpenedAt   time.Time      `json:"opened_at"`
+	ClosedAt   sql.NullTime   `json:"closed_at"`
+	CloseDate  sql.NullTime   `json:"close_date"`
+	CloseTime  sql.NullTime   `json:"close_time"`
+	OrderType  OrderType      `json:"order_type"`
+	Grade      sql.NullString `json:"grade"`
 	UserID     int32          `json:"user_id"`
+	Version    int32          `json:"version"`
 }
 
 func (q *Queries) UpdateTrade(ctx context.Context, arg UpdateTradeParams) (Trade, error) {
@@ -563,7 +1111,22 @@ func (q *Queries) UpdateTrade(ctx context.Context, arg UpdateTradeParams) (Trade
 		arg.Notes,
 		arg.Mistakes,
 		arg.Amount,
+		arg.FxRate,
+		arg.Commission,
+		arg.Swap,
+		arg.Fees,
+		arg.NetPl,
+		arg.PlannedRr,
+		arg.RealizedR,
+		arg.RiskAmount,
+		arg.OpenedAt,
+		arg.ClosedAt,
+		arg.CloseDate,
+		arg.CloseTime,
+		arg.OrderType,
+		arg.Grade,
 		arg.UserID,
+		arg.Version,
 	)
 	var i Trade
 	err := row.Scan(
@@ -590,15 +1153,32 @@ func (q *Queries) UpdateTrade(ctx context.Context, arg UpdateTradeParams) (Trade
 		&i.UpdatedAt,
 		&i.ChartBefore,
 		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const updateTradeChartAfter = `-- name: UpdateTradeChartAfter :one
 UPDATE trades
-SET chart_after = $1, updated_at = NOW()
-WHERE id = $2 AND user_id = $3
-RETURNING id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+SET chart_after = $1, version = version + 1, updated_at = NOW()
+WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+RETURNING id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 `
 
 type UpdateTradeChartAfterParams struct {
@@ -634,15 +1214,32 @@ func (q *Queries) UpdateTradeChartAfter(ctx context.Context, arg UpdateTradeChar
 		&i.UpdatedAt,
 		&i.ChartBefore,
 		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
 
 const updateTradeChartBefore = `-- name: UpdateTradeChartBefore :one
 UPDATE trades
-SET chart_before = $1, updated_at = NOW()
-WHERE id = $2 AND user_id = $3
-RETURNING id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after
+SET chart_before = $1, version = version + 1, updated_at = NOW()
+WHERE id = $2 AND user_id = $3 AND deleted_at IS NULL
+RETURNING id, user_id, account_id, date, time, pair, type, entry, exit, lots, pips, pl, rr, status, stop_loss, take_profit, notes, mistakes, amount, created_at, updated_at, chart_before, chart_after, fx_rate, commission, swap, fees, net_pl, planned_rr, realized_r, risk_amount, opened_at, closed_at, close_date, close_time, order_type, grade, group_id, deleted_at, version
 `
 
 type UpdateTradeChartBeforeParams struct {
@@ -678,6 +1275,23 @@ func (q *Queries) UpdateTradeChartBefore(ctx context.Context, arg UpdateTradeCha
 		&i.UpdatedAt,
 		&i.ChartBefore,
 		&i.ChartAfter,
+		&i.FxRate,
+		&i.Commission,
+		&i.Swap,
+		&i.Fees,
+		&i.NetPl,
+		&i.PlannedRr,
+		&i.RealizedR,
+		&i.RiskAmount,
+		&i.OpenedAt,
+		&i.ClosedAt,
+		&i.CloseDate,
+		&i.CloseTime,
+		&i.OrderType,
+		&i.Grade,
+		&i.GroupID,
+		&i.DeletedAt,
+		&i.Version,
 	)
 	return i, err
 }
@@ -13,7 +13,7 @@ import (
 const createUser = `-- name: CreateUser :one
 INSERT INTO users (email, password_hash)
 VALUES ($1, $2)
-RETURNING id, email, created_at, updated_at
+RETURNING id, email, created_at, updated_at, timezone
 `
 
 type CreateUserParams struct {
@@ -26,6 +26,7 @@ type CreateUserRow struct {
 	Email     string       `json:"email"`
 	CreatedAt sql.NullTime `json:"created_at"`
 	UpdatedAt sql.NullTime `json:"updated_at"`
+	Timezone  string       `json:"timezone"`
 }
 
 func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateUserRow, error) {
@@ -36,12 +37,13 @@ func (q *Queries) CreateUser(ctx context.Context, arg CreateUserParams) (CreateU
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByEmail = `-- name: GetUserByEmail :one
-SELECT id, email, password_hash, created_at, updated_at
+SELECT id, email, password_hash, created_at, updated_at, timezone
 FROM users
 WHERE email = $1
 `
@@ -55,12 +57,13 @@ func (q *Queries) GetUserByEmail(ctx context.Context, email string) (User, error
 		&i.PasswordHash,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
 
 const getUserByID = `-- name: GetUserByID :one
-SELECT id, email, created_at, updated_at
+SELECT id, email, created_at, updated_at, timezone
 FROM users
 WHERE id = $1
 `
@@ -70,6 +73,7 @@ type GetUserByIDRow struct {
 	Email     string       `json:"email"`
 	CreatedAt sql.NullTime `json:"created_at"`
 	UpdatedAt sql.NullTime `json:"updated_at"`
+	Timezone  string       `json:"timezone"`
 }
 
 func (q *Queries) GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, error) {
@@ -80,6 +84,40 @@ func (q *Queries) GetUserByID(ctx context.Context, id int32) (GetUserByIDRow, er
 		&i.Email,
 		&i.CreatedAt,
 		&i.UpdatedAt,
+		&i.Timezone,
+	)
+	return i, err
+}
+
+const updateUserTimezone = `-- name: UpdateUserTimezone :one
+UPDATE users
+SET timezone = $2, updated_at = CURRENT_TIMESTAMP
+WHERE id = $1
+RETURNING id, email, created_at, updated_at, timezone
+`
+
+type UpdateUserTimezoneParams struct {
+	ID       int32  `json:"id"`
+	Timezone string `json:"timezone"`
+}
+
+type UpdateUserTimezoneRow struct {
+	ID        int32        `json:"id"`
+	Email     string       `json:"email"`
+	CreatedAt sql.NullTime `json:"created_at"`
+	UpdatedAt sql.NullTime `json:"updated_at"`
+	Timezone  string       `json:"timezone"`
+}
+
+func (q *Queries) UpdateUserTimezone(ctx context.Context, arg UpdateUserTimezoneParams) (UpdateUserTimezoneRow, error) {
+	row := q.db.QueryRowContext(ctx, updateUserTimezone, arg.ID, arg.Timezone)
+	var i UpdateUserTimezoneRow
+	err := row.Scan(
+		&i.ID,
+		&i.Email,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.Timezone,
 	)
 	return i, err
 }
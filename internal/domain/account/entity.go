@@ -23,6 +23,8 @@ type Account struct {
 	IsActive       bool
 	CreatedAt      time.Time
 	UpdatedAt      time.Time
+	DeletedAt      *time.Time // Instant the account was moved to the trash
+	Version        int64      // Incremented on every write; updates must carry the version they read
 }
 
 // NewAccount creates a new account instance
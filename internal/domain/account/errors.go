@@ -0,0 +1,11 @@
+package account
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when an account is not found or access is denied
+	ErrNotFound = errors.New("account not found")
+
+	// ErrVersionConflict is returned when an account was changed since the version the caller last read
+	ErrVersionConflict = errors.New("account was modified by another request")
+)
@@ -1,13 +1,24 @@
 package account
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // Repository defines the interface for account data access
 type Repository interface {
 	Create(ctx context.Context, account *Account) (*Account, error)
 	GetByID(ctx context.Context, id int64, userID int64) (*Account, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*Account, error)
+	// Update stores the account if its Version is still current and returns ErrVersionConflict otherwise
 	Update(ctx context.Context, account *Account) (*Account, error)
 	UpdateBalance(ctx context.Context, id int64, userID int64, amount float64) (*Account, error)
-	Delete(ctx context.Context, id int64, userID int64) error
+	// Delete moves an account to the trash along with the trades recorded on it, provided it is still at version
+	Delete(ctx context.Context, id int64, userID int64, version int64) error
+	// GetDeletedByUserID returns the accounts in the user's trash, most recently deleted first
+	GetDeletedByUserID(ctx context.Context, userID int64) ([]*Account, error)
+	// Restore takes an account out of the trash along with the trades deleted with it
+	Restore(ctx context.Context, id int64, userID int64) (*Account, error)
+	// PurgeDeleted permanently removes accounts moved to the trash before the given instant
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
 }
@@ -3,7 +3,8 @@ package analytics
 // Analytics represents the trading analytics/metrics for a user
 type Analytics struct {
 	// Performance Metrics
-	TotalPL        float64 // Total Profit/Loss
+	TotalPL        float64 // Total Profit/Loss after costs
+	GrossPL        float64 // Total Profit/Loss before costs
 	WinRate        float64 // Win rate percentage
 	TotalTrades    int64   // Total number of trades
 	WinningTrades  int64   // Number of winning trades
@@ -17,9 +18,75 @@ type Analytics struct {
 	LargestLoss    float64 // Largest losing trade
 
 	// Additional Metrics
-	AvgRR          float64 // Average Risk:Reward ratio
+	AvgRR          float64 // Average realized R-multiple
+	TotalR         float64 // Sum of realized R-multiples
+	RDistribution  []RBucket // Number of trades per 1R bucket
 	ConsecutiveWins int64   // Current consecutive wins
 	ConsecutiveLosses int64 // Current consecutive losses
 	BestStreak     int64   // Best winning streak
 	WorstStreak    int64   // Worst losing streak
+
+	// Holding time, in seconds, of closed trades with a known close
+	AvgHoldWinners    float64 // Average holding time of winning trades
+	MedianHoldWinners float64 // Median holding time of winning trades
+	AvgHoldLosers     float64 // Average holding time of losing trades
+	MedianHoldLosers  float64 // Median holding time of losing trades
+
+	// Limit and stop orders
+	PendingOrders  int64   // Orders still waiting to fill
+	FilledOrders   int64   // Orders that were triggered
+	UnfilledOrders int64   // Orders that were cancelled or expired
+	FillRate       float64 // Percentage of filled orders among those no longer pending
+
+	// Breakdowns
+	Tags          []GroupStats  // Performance per tag
+	Mistakes      []GroupStats  // Count and P/L of the trades carrying each mistake type
+	Ratings       []RatingStats // Performance per rating dimension, phase and score
+	RuleAdherence []GroupStats  // Trades that followed every strategy rule versus trades that broke one
+	Grades        []GradeStats  // Performance per setup grade, best grade first
+
+	// Costs
+	TotalCommission float64 // Commission paid
+	TotalSwap       float64 // Net swap (negative when paid)
+	TotalFees       float64 // Other fees paid
+	TotalCosts      float64 // Commission and fees paid, less swap earned
+}
+
+// GroupStats summarizes the closed trades that share a label such as a tag
+type GroupStats struct {
+	Name          string
+	Trades        int64   // Number of closed trades
+	WinningTrades int64   // Number of winning trades
+	WinRate       float64 // Win rate percentage
+	TotalPL       float64 // Total P/L after costs
+	Expectancy    float64 // Average P/L per trade
+}
+
+// RatingStats summarizes the closed trades given the same score on a rating dimension
+// in the same phase, such as a confidence of 4 before entry
+type RatingStats struct {
+	Dimension     string
+	Phase         string  // before or after
+	Rating        int     // Score from 1 to 5
+	Trades        int64   // Number of closed trades
+	WinningTrades int64   // Number of winning trades
+	WinRate       float64 // Win rate percentage
+	AvgPL         float64 // Average P/L per trade after costs
+}
+
+// GradeStats summarizes the closed trades given the same setup grade
+type GradeStats struct {
+	Grade         string
+	Trades        int64   // Number of closed trades
+	WinningTrades int64   // Number of winning trades
+	WinRate       float64 // Win rate percentage
+	AvgR          float64 // Average realized R-multiple of the trades with a stop loss
+	TotalPL       float64 // Total P/L after costs
+}
+
+// RBucket counts trades whose realized R-multiple falls in [From, To)
+type RBucket struct {
+	From  float64
+	To    float64
+	Count int64
 }
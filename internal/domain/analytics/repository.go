@@ -2,6 +2,7 @@ package analytics
 
 import (
 	"context"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 )
@@ -9,4 +10,16 @@ import (
 type Repository interface {
 	// GetUserTrades returns raw trade data for a specific user
 	GetUserTrades(ctx context.Context, userID int64) ([]db.Trade, error)
+	// GetUserTradesByDateRange returns raw trade data for trades opened in [start, end)
+	GetUserTradesByDateRange(ctx context.Context, userID int64, start, end time.Time) ([]db.Trade, error)
+	// GetUserTradeTags returns the tag names on each of a user's trades
+	GetUserTradeTags(ctx context.Context, userID int64) ([]db.GetTradeTagsByUserIDRow, error)
+	// GetUserTradeMistakes returns the mistake type names on each of a user's trades
+	GetUserTradeMistakes(ctx context.Context, userID int64) ([]db.GetTradeMistakesByUserIDRow, error)
+	// GetUserTradeRatings returns the psychology ratings on each of a user's trades
+	GetUserTradeRatings(ctx context.Context, userID int64) ([]db.GetTradeRatingsByUserIDRow, error)
+	// GetUserTradeRuleAdherence returns how many strategy rules each of a user's trades had and satisfied
+	GetUserTradeRuleAdherence(ctx context.Context, userID int64) ([]db.GetTradeRuleAdherenceByUserIDRow, error)
+	// GetUserGrades returns the user's grade scale, best grade first
+	GetUserGrades(ctx context.Context, userID int64) ([]db.Grade, error)
 }
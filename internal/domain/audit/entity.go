@@ -0,0 +1,109 @@
+package audit
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+)
+
+// EntityType names the kind of record an event describes
+type EntityType string
+
+const (
+	EntityTrade    EntityType = "trade"
+	EntityAccount  EntityType = "account"
+	EntityStrategy EntityType = "strategy"
+)
+
+// Action is the kind of change an event records
+type Action string
+
+const (
+	ActionCreate  Action = "create"
+	ActionUpdate  Action = "update"
+	ActionDelete  Action = "delete"
+	ActionRestore Action = "restore"
+)
+
+// ignoredFields change on every write and would only add noise to the history
+var ignoredFields = map[string]bool{
+	"created_at": true,
+	"updated_at": true,
+	"version":    true,
+}
+
+// Change holds the values of one field before and after an event; nil when the field was absent
+type Change struct {
+	From any `json:"from"`
+	To   any `json:"to"`
+}
+
+// Event records one change made by a user to a trade, account or strategy
+type Event struct {
+	ID         int64
+	UserID     int64 // The user who made the change
+	EntityType EntityType
+	EntityID   int64
+	Action     Action
+	Changes    map[string]Change
+	CreatedAt  time.Time
+}
+
+// NewEvent builds an event from snapshots of the entity taken before and after the change.
+// The snapshots are compared by their JSON fields; pass nil for the side that does not exist,
+// such as before on create or after on delete.
+func NewEvent(userID int64, entityType EntityType, entityID int64, action Action, before, after any) (*Event, error) {
+	from, err := fields(before)
+	if err != nil {
+		return nil, err
+	}
+	to, err := fields(after)
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		UserID:     userID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		Changes:    Diff(from, to),
+	}, nil
+}
+
+// Diff returns the fields whose values differ between before and after
+func Diff(before, after map[string]any) map[string]Change {
+	changes := make(map[string]Change)
+	for field, from := range before {
+		if ignoredFields[field] {
+			continue
+		}
+		if to := after[field]; !reflect.DeepEqual(from, to) {
+			changes[field] = Change{From: from, To: to}
+		}
+	}
+	for field, to := range after {
+		if _, seen := before[field]; seen || ignoredFields[field] || to == nil {
+			continue
+		}
+		changes[field] = Change{From: nil, To: to}
+	}
+	return changes
+}
+
+// fields flattens a snapshot into its JSON fields; a nil snapshot has none
+func fields(snapshot any) (map[string]any, error) {
+	if v := reflect.ValueOf(snapshot); !v.IsValid() || (v.Kind() == reflect.Pointer && v.IsNil()) {
+		return nil, nil
+	}
+
+	data, err := json.Marshal(snapshot)
+	if err != nil {
+		return nil, err
+	}
+	var values map[string]any
+	if err := json.Unmarshal(data, &values); err != nil {
+		return nil, err
+	}
+	return values, nil
+}
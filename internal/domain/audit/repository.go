@@ -0,0 +1,10 @@
+package audit
+
+import "context"
+
+// Repository defines the interface for audit log data operations
+type Repository interface {
+	Record(ctx context.Context, event *Event) error
+	// GetByEntity returns the events of one entity, oldest first
+	GetByEntity(ctx context.Context, userID int64, entityType EntityType, entityID int64) ([]*Event, error)
+}
@@ -0,0 +1,50 @@
+package fx
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// crossCurrency bridges two currencies when neither a direct nor an inverse rate exists
+const crossCurrency = "USD"
+
+// Lookup finds the rate that converts an amount in from into to as of the given date.
+// It tries the direct pair, then the inverse pair, then a cross through USD.
+func Lookup(ctx context.Context, repo Repository, userID int64, from, to string, date time.Time) (float64, error) {
+	if from == to {
+		return 1, nil
+	}
+
+	rate, err := pairRate(ctx, repo, userID, from, to, date)
+	if !errors.Is(err, ErrNotFound) || from == crossCurrency || to == crossCurrency {
+		return rate, err
+	}
+
+	fromCross, err := pairRate(ctx, repo, userID, from, crossCurrency, date)
+	if err != nil {
+		return 0, err
+	}
+	crossTo, err := pairRate(ctx, repo, userID, crossCurrency, to, date)
+	if err != nil {
+		return 0, err
+	}
+	return fromCross * crossTo, nil
+}
+
+// pairRate resolves a single pair from either its direct or inverse quote
+func pairRate(ctx context.Context, repo Repository, userID int64, from, to string, date time.Time) (float64, error) {
+	direct, err := repo.GetOnOrBefore(ctx, userID, from, to, date)
+	if err == nil {
+		return direct.Rate, nil
+	}
+	if !errors.Is(err, ErrNotFound) {
+		return 0, err
+	}
+
+	inverse, err := repo.GetOnOrBefore(ctx, userID, to, from, date)
+	if err != nil {
+		return 0, err
+	}
+	return 1 / inverse.Rate, nil
+}
@@ -0,0 +1,15 @@
+package fx
+
+import "time"
+
+// Rate is the daily exchange rate for one unit of Base expressed in Quote
+type Rate struct {
+	ID            int64
+	UserID        int64
+	BaseCurrency  string
+	QuoteCurrency string
+	Date          time.Time
+	Rate          float64
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
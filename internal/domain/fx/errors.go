@@ -0,0 +1,8 @@
+package fx
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when no rate exists for a pair or access is denied
+	ErrNotFound = errors.New("fx rate not found")
+)
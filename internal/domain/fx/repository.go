@@ -0,0 +1,16 @@
+package fx
+
+import (
+	"context"
+	"time"
+)
+
+// Repository defines the interface for FX rate data access
+type Repository interface {
+	// Upsert stores the rate for its pair and date, replacing an existing one
+	Upsert(ctx context.Context, rate *Rate) (*Rate, error)
+	// GetOnOrBefore returns the most recent rate for the pair on or before the given date
+	GetOnOrBefore(ctx context.Context, userID int64, base, quote string, date time.Time) (*Rate, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Rate, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+}
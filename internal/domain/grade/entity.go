@@ -0,0 +1,35 @@
+package grade
+
+import "time"
+
+// MaxNameLength is the longest grade name accepted, in characters
+const MaxNameLength = 10
+
+// DefaultScale is the grade scale of users who have not configured their own, best grade first
+var DefaultScale = []string{"A+", "A", "B", "C"}
+
+// Grade is one step of a user's setup quality scale. Position 1 is the best grade.
+type Grade struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Position  int
+	CreatedAt time.Time
+}
+
+// Scale returns the grade names best first, or DefaultScale when the user has no grades stored
+func Scale(grades []*Grade) []string {
+	names := make([]string, len(grades))
+	for i, g := range grades {
+		names[i] = g.Name
+	}
+	return ScaleOrDefault(names)
+}
+
+// ScaleOrDefault returns names, or DefaultScale when names is empty
+func ScaleOrDefault(names []string) []string {
+	if len(names) == 0 {
+		return DefaultScale
+	}
+	return names
+}
@@ -0,0 +1,11 @@
+package grade
+
+import "context"
+
+// Repository defines the interface for grade scale data operations
+type Repository interface {
+	GetByUserID(ctx context.Context, userID int64) ([]*Grade, error)
+	// ReplaceScale stores names as the user's scale in order. Trades graded with a
+	// grade that is no longer on the scale become ungraded.
+	ReplaceScale(ctx context.Context, userID int64, names []string) ([]*Grade, error)
+}
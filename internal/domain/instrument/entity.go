@@ -0,0 +1,85 @@
+package instrument
+
+import (
+	"strings"
+	"time"
+)
+
+// AssetClass represents the market an instrument is traded in
+type AssetClass string
+
+const (
+	AssetClassForex     AssetClass = "forex"
+	AssetClassMetal     AssetClass = "metal"
+	AssetClassIndex     AssetClass = "index"
+	AssetClassCrypto    AssetClass = "crypto"
+	AssetClassCommodity AssetClass = "commodity"
+)
+
+// Instrument describes how price movements of a symbol translate into pips and money.
+// Built-in instruments have no UserID; a user-owned instrument with the same symbol overrides them.
+type Instrument struct {
+	ID            int64
+	UserID        *int64
+	Symbol        string
+	Description   string
+	AssetClass    AssetClass
+	PipSize       float64 // Price change that counts as one pip
+	TickSize      float64 // Smallest price increment quoted by the broker
+	ContractSize  float64 // Units of the base asset in one lot
+	QuoteCurrency string  // Currency P/L is realized in
+	Digits        int     // Decimal places in quoted prices
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsBuiltIn reports whether the instrument is part of the shared registry
+func (i *Instrument) IsBuiltIn() bool {
+	return i.UserID == nil
+}
+
+// PipValue returns the value of one pip for one lot in the quote currency
+func (i *Instrument) PipValue() float64 {
+	return i.PipSize * i.ContractSize
+}
+
+// BaseCurrency returns the base currency of a currency or metal pair such as EURUSD or XAUUSD,
+// or an empty string for instruments that are not quoted as a pair
+func (i *Instrument) BaseCurrency() string {
+	if (i.AssetClass != AssetClassForex && i.AssetClass != AssetClassMetal) || len(i.Symbol) != 6 {
+		return ""
+	}
+	return i.Symbol[:3]
+}
+
+// NormalizeSymbol converts a pair as typed by the user ("eur/usd", "EUR-USD") to the registry symbol ("EURUSD")
+func NormalizeSymbol(symbol string) string {
+	replacer := strings.NewReplacer("/", "", "-", "", "_", "", " ", "", ".", "")
+	return strings.ToUpper(replacer.Replace(symbol))
+}
+
+// Fallback returns a forex specification guessed from the symbol for pairs missing from the registry
+// JPY pairs: 1 pip = 0.01, other pairs: 1 pip = 0.0001, 1 lot = 100,000 units
+func Fallback(symbol string) *Instrument {
+	normalized := NormalizeSymbol(symbol)
+
+	pipSize, tickSize, digits := 0.0001, 0.00001, 5
+	if strings.Contains(normalized, "JPY") {
+		pipSize, tickSize, digits = 0.01, 0.001, 3
+	}
+
+	quoteCurrency := "USD"
+	if len(normalized) == 6 {
+		quoteCurrency = normalized[3:]
+	}
+
+	return &Instrument{
+		Symbol:        normalized,
+		AssetClass:    AssetClassForex,
+		PipSize:       pipSize,
+		TickSize:      tickSize,
+		ContractSize:  100000,
+		QuoteCurrency: quoteCurrency,
+		Digits:        digits,
+	}
+}
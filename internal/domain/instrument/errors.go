@@ -0,0 +1,8 @@
+package instrument
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when an instrument is not found or access is denied
+	ErrNotFound = errors.New("instrument not found")
+)
@@ -0,0 +1,15 @@
+package instrument
+
+import "context"
+
+// Repository defines the interface for instrument data access
+type Repository interface {
+	Create(ctx context.Context, instrument *Instrument) (*Instrument, error)
+	// GetByID returns a built-in instrument or one owned by the user
+	GetByID(ctx context.Context, id int64, userID int64) (*Instrument, error)
+	// GetBySymbol returns the user's instrument for the symbol, falling back to the built-in one
+	GetBySymbol(ctx context.Context, userID int64, symbol string) (*Instrument, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Instrument, error)
+	Update(ctx context.Context, instrument *Instrument) (*Instrument, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+}
@@ -0,0 +1,32 @@
+package mistake
+
+import "time"
+
+// Severity ranks how costly a kind of mistake is considered to be
+type Severity string
+
+const (
+	SeverityLow    Severity = "low"
+	SeverityMedium Severity = "medium"
+	SeverityHigh   Severity = "high"
+)
+
+// IsValid reports whether the severity is one of the known levels
+func (s Severity) IsValid() bool {
+	switch s {
+	case SeverityLow, SeverityMedium, SeverityHigh:
+		return true
+	}
+	return false
+}
+
+// MistakeType is an entry in a user's catalogue of mistakes that trades can be tagged with
+type MistakeType struct {
+	ID          int64
+	UserID      int64
+	Name        string
+	Description string
+	Severity    Severity
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,8 @@
+package mistake
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a mistake type is not found or access is denied
+	ErrNotFound = errors.New("mistake type not found")
+)
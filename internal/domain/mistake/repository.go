@@ -0,0 +1,13 @@
+package mistake
+
+import "context"
+
+// Repository defines the interface for mistake type data operations
+type Repository interface {
+	Create(ctx context.Context, mistakeType *MistakeType) (*MistakeType, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*MistakeType, error)
+	GetByName(ctx context.Context, userID int64, name string) (*MistakeType, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*MistakeType, error)
+	Update(ctx context.Context, mistakeType *MistakeType) (*MistakeType, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+}
@@ -0,0 +1,14 @@
+package rating
+
+import "time"
+
+// Dimension is an aspect of the trader's state that a user rates trades on, such as
+// confidence, fear, FOMO or fatigue
+type Dimension struct {
+	ID          int64
+	UserID      int64
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
@@ -0,0 +1,8 @@
+package rating
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a rating dimension is not found or access is denied
+	ErrNotFound = errors.New("rating dimension not found")
+)
@@ -0,0 +1,13 @@
+package rating
+
+import "context"
+
+// Repository defines the interface for rating dimension data operations
+type Repository interface {
+	Create(ctx context.Context, dimension *Dimension) (*Dimension, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Dimension, error)
+	GetByName(ctx context.Context, userID int64, name string) (*Dimension, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Dimension, error)
+	Update(ctx context.Context, dimension *Dimension) (*Dimension, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+}
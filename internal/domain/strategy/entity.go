@@ -8,6 +8,19 @@ type Strategy struct {
 	UserID      int64
 	Name        string
 	Description string
+	Rules       []Rule // Checklist the strategy's trades are expected to follow, in order
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
+	Version     int64 // Incremented on every write; updates must carry the version they read
+}
+
+// MaxRuleLength is the longest rule description accepted, in characters
+const MaxRuleLength = 255
+
+// Rule is one checklist item of a strategy. Rules are numbered from 1 by Position;
+// a rule without an ID has not been stored yet.
+type Rule struct {
+	ID          int64
+	Position    int
+	Description string
 }
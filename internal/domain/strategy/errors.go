@@ -5,4 +5,10 @@ import "errors"
 var (
 	// ErrNotFound is returned when a strategy is not found or access is denied
 	ErrNotFound = errors.New("strategy not found")
+
+	// ErrRuleNotFound is returned when an update references a rule that does not belong to the strategy
+	ErrRuleNotFound = errors.New("strategy rule not found")
+
+	// ErrVersionConflict is returned when a strategy was changed since the version the caller last read
+	ErrVersionConflict = errors.New("strategy was modified by another request")
 )
@@ -7,6 +7,7 @@ type Repository interface {
 	Create(ctx context.Context, strategy *Strategy) (*Strategy, error)
 	GetByID(ctx context.Context, id int64, userID int64) (*Strategy, error)
 	GetByUserID(ctx context.Context, userID int64) ([]*Strategy, error)
+	// Update stores the strategy if its Version is still current and returns ErrVersionConflict otherwise
 	Update(ctx context.Context, strategy *Strategy) (*Strategy, error)
-	Delete(ctx context.Context, id int64, userID int64) error
+	Delete(ctx context.Context, id int64, userID int64, version int64) error
 }
@@ -1,6 +1,10 @@
 package trade
 
-import "time"
+import (
+	"math"
+	"strings"
+	"time"
+)
 
 type TradeType string
 
@@ -11,42 +15,295 @@ const (
 	TradeTypeWithdraw TradeType = "WITHDRAW"
 )
 
+// PriceMove returns how far price has moved in favour of a BUY or SELL position opened at
+// entry; it is zero for other trade types
+func PriceMove(tradeType TradeType, entry, price float64) float64 {
+	switch tradeType {
+	case TradeTypeBuy:
+		return price - entry
+	case TradeTypeSell:
+		return entry - price
+	}
+	return 0
+}
+
+// TradeStatus is the lifecycle stage of a trade. Market orders fill straight away and are
+// open or closed; limit and stop orders start out pending until they are triggered (filled),
+// cancelled or expire.
 type TradeStatus string
 
 const (
-	TradeStatusOpen   TradeStatus = "open"
-	TradeStatusClosed TradeStatus = "closed"
+	TradeStatusPending   TradeStatus = "pending"
+	TradeStatusOpen      TradeStatus = "open"
+	TradeStatusClosed    TradeStatus = "closed"
+	TradeStatusCancelled TradeStatus = "cancelled"
+	TradeStatusExpired   TradeStatus = "expired"
+)
+
+// statusTransitions lists the statuses a trade may move to from each status.
+// Cancelled and expired orders are final, and a filled trade never goes back to pending.
+var statusTransitions = map[TradeStatus][]TradeStatus{
+	TradeStatusPending: {TradeStatusOpen, TradeStatusClosed, TradeStatusCancelled, TradeStatusExpired},
+	TradeStatusOpen:    {TradeStatusClosed},
+	TradeStatusClosed:  {TradeStatusOpen},
+}
+
+// IsValid reports whether s is a known status
+func (s TradeStatus) IsValid() bool {
+	switch s {
+	case TradeStatusPending, TradeStatusOpen, TradeStatusClosed, TradeStatusCancelled, TradeStatusExpired:
+		return true
+	}
+	return false
+}
+
+// IsUnfilled reports whether the trade is an order that has not been, or never was, filled
+func (s TradeStatus) IsUnfilled() bool {
+	return s == TradeStatusPending || s == TradeStatusCancelled || s == TradeStatusExpired
+}
+
+// CanTransitionTo reports whether a trade may move from s to next; keeping the same status is always allowed
+func (s TradeStatus) CanTransitionTo(next TradeStatus) bool {
+	if s == next {
+		return true
+	}
+	for _, allowed := range statusTransitions[s] {
+		if allowed == next {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderType is how the trade was entered: at market, or with a limit or stop order
+type OrderType string
+
+const (
+	OrderTypeMarket OrderType = "market"
+	OrderTypeLimit  OrderType = "limit"
+	OrderTypeStop   OrderType = "stop"
 )
 
+// IsValid reports whether o is a known order type
+func (o OrderType) IsValid() bool {
+	return o == OrderTypeMarket || o == OrderTypeLimit || o == OrderTypeStop
+}
+
 type Trade struct {
-	ID          int64
-	UserID      int64
-	AccountID   *int64
-	Date        time.Time
-	Time        time.Time
-	Pair        string
-	Type        TradeType
-	Entry       float64
-	Exit        *float64
-	Lots        float64
-	Pips        *float64
-	PL          *float64
-	RR          string
-	Status      TradeStatus
-	StopLoss    *float64
-	TakeProfit  *float64
-	Notes       string
-	Mistakes    string
-	Amount      *float64
-	ChartBefore *string
-	ChartAfter  *string
-	Strategies  []Strategy
-	CreatedAt   time.Time
-	UpdatedAt   time.Time
+	ID               int64
+	UserID           int64
+	AccountID        *int64
+	Date             time.Time // Wall-clock open date in the user's timezone
+	Time             time.Time // Wall-clock open time in the user's timezone
+	Pair             string
+	Type             TradeType
+	Entry            float64
+	Exit             *float64
+	Lots             float64
+	Pips             *float64
+	PL               *float64 // Gross P/L in the account currency
+	RR               string
+	PlannedRR        *float64 // Reward-to-risk from entry to take profit
+	RealizedR        *float64 // P/L as a multiple of the risk amount
+	RiskAmount       *float64 // Money lost if the stop loss is hit, in the account currency
+	Status           TradeStatus
+	OrderType        OrderType
+	StopLoss         *float64
+	TakeProfit       *float64
+	Notes            string
+	Mistakes         string // Free-text note on what went wrong
+	Grade            string // Setup quality on the user's grade scale, empty when ungraded
+	GroupID          *int64 // Trade group the trade belongs to; membership is managed from the group
+	Amount           *float64
+	FXRate           *float64
+	Commission       float64    // Commission paid, in the account currency
+	Swap             float64    // Overnight swap, negative when charged
+	Fees             float64    // Other fees paid, in the account currency
+	NetPL            *float64   // P/L after commission, swap and fees
+	OpenedAt         time.Time  // Instant the trade was opened
+	ClosedAt         *time.Time // Instant the trade was closed, when known
+	CloseDate        *time.Time // Wall-clock close date in the user's timezone
+	CloseTime        *time.Time // Wall-clock close time in the user's timezone
+	ChartBefore      *string
+	ChartAfter       *string
+	Strategies       []Strategy
+	SatisfiedRuleIDs []int64 // Rules of the linked strategies the trade followed; the rest were broken
+	Tags             []Tag
+	MistakeTypes     []MistakeType // Catalogued mistakes made on the trade
+	Ratings          []Rating      // Psychology ratings taken before entry and after exit
+	Executions       []Execution
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+	DeletedAt        *time.Time // Instant the trade was moved to the trash
+	Version          int64      // Incremented on every write; updates must carry the version they read
+}
+
+// SearchResult is a trade matched by a full-text search
+type SearchResult struct {
+	Trade           *Trade
+	Rank            float64 // Relevance to the query; higher is better
+	NotesSnippet    string  // Matching part of the notes with terms wrapped in <mark>, empty when the notes did not match
+	MistakesSnippet string  // Matching part of the mistakes with terms wrapped in <mark>, empty when the mistakes did not match
 }
 
 type Strategy struct {
 	ID          int64
 	Name        string
 	Description string
+	Rules       []StrategyRule
+}
+
+// StrategyRule is a rule of a linked strategy and whether the trade satisfied it
+type StrategyRule struct {
+	ID          int64
+	Description string
+	Satisfied   bool
+}
+
+// Compliant reports whether the trade satisfied every rule of the strategy
+func (s Strategy) Compliant() bool {
+	for _, rule := range s.Rules {
+		if !rule.Satisfied {
+			return false
+		}
+	}
+	return true
+}
+
+// MistakeType is an entry from the user's mistake catalogue that a trade is tagged with
+type MistakeType struct {
+	ID       int64
+	Name     string
+	Severity string
+}
+
+// RatingPhase says whether a psychology rating was taken before entry or after exit
+type RatingPhase string
+
+const (
+	RatingPhaseBefore RatingPhase = "before"
+	RatingPhaseAfter  RatingPhase = "after"
+)
+
+// IsValid reports whether the phase is before or after
+func (p RatingPhase) IsValid() bool {
+	return p == RatingPhaseBefore || p == RatingPhaseAfter
+}
+
+// Psychology ratings are scored on a 1-5 scale
+const (
+	MinRating = 1
+	MaxRating = 5
+)
+
+// Rating scores the trader's state on one of their rating dimensions, such as confidence or fatigue
+type Rating struct {
+	DimensionID int64
+	Dimension   string
+	Phase       RatingPhase
+	Value       int
+}
+
+// MaxTagLength is the longest tag name accepted, in characters
+const MaxTagLength = 50
+
+// Tag is a free-form label a user attaches to trades. Tags are scoped to the user and
+// matched by their normalized name.
+type Tag struct {
+	ID   int64
+	Name string
+}
+
+// NormalizeTag trims and lowercases a tag name so that tags differing only in case or spacing match
+func NormalizeTag(name string) string {
+	return strings.ToLower(strings.TrimSpace(name))
+}
+
+// NewTags builds tags from names, dropping blank and duplicate names
+func NewTags(names []string) []Tag {
+	var tags []Tag
+	seen := make(map[string]bool)
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name == "" || seen[name] {
+			continue
+		}
+		seen[name] = true
+		tags = append(tags, Tag{Name: name})
+	}
+	return tags
+}
+
+// Execution is a single fill on a trade. Fills on the trade's side open or add to
+// the position; fills on the opposite side close part or all of it.
+type Execution struct {
+	ID         int64
+	TradeID    int64
+	Side       TradeType
+	Price      float64
+	Lots       float64
+	ExecutedAt time.Time
+	CreatedAt  time.Time
+}
+
+// IsEntry reports whether the fill adds to the position of a trade of the given type
+func (e Execution) IsEntry(tradeType TradeType) bool {
+	return e.Side == tradeType
+}
+
+// OpenLots returns the lots still open on the trade
+func (t *Trade) OpenLots() float64 {
+	if len(t.Executions) == 0 {
+		if t.Status == TradeStatusOpen {
+			return t.Lots
+		}
+		return 0
+	}
+
+	var open float64
+	for _, e := range t.Executions {
+		if e.IsEntry(t.Type) {
+			open += e.Lots
+		} else {
+			open -= e.Lots
+		}
+	}
+	return math.Round(open*100) / 100
+}
+
+// SetClosedAt records the close instant along with its wall-clock date and time in loc
+func (t *Trade) SetClosedAt(closedAt *time.Time, loc *time.Location) {
+	t.ClosedAt, t.CloseDate, t.CloseTime = closedAt, nil, nil
+	if closedAt == nil {
+		return
+	}
+
+	local := closedAt.In(loc)
+	closeDate := time.Date(local.Year(), local.Month(), local.Day(), 0, 0, 0, 0, time.UTC)
+	closeTime := time.Date(0, 1, 1, local.Hour(), local.Minute(), 0, 0, time.UTC)
+	t.CloseDate, t.CloseTime = &closeDate, &closeTime
+}
+
+// HoldingDuration returns how long the trade was held, or nil while the close is unknown
+func (t *Trade) HoldingDuration() *time.Duration {
+	if t.ClosedAt == nil {
+		return nil
+	}
+	d := t.ClosedAt.Sub(t.OpenedAt)
+	return &d
+}
+
+// Unconverted reports a BUY/SELL trade whose P/L is still in the instrument's quote currency
+// because no FX rate was available to convert it into the account currency
+func (t *Trade) Unconverted() bool {
+	return (t.Type == TradeTypeBuy || t.Type == TradeTypeSell) && t.PL != nil && t.FXRate == nil
+}
+
+// SettledPL returns the P/L booked to the account balance: the net P/L,
+// or the gross P/L for trades recorded before costs were tracked
+func (t *Trade) SettledPL() *float64 {
+	if t.NetPL != nil {
+		return t.NetPL
+	}
+	return t.PL
 }
@@ -0,0 +1,35 @@
+package trade
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a trade is not found or access is denied
+	ErrNotFound = errors.New("trade not found")
+
+	// ErrExecutionNotFound is returned when an execution does not exist on the trade
+	ErrExecutionNotFound = errors.New("execution not found")
+
+	// ErrInvalidStatusTransition is returned when a trade cannot move from its status to the requested one
+	ErrInvalidStatusTransition = errors.New("invalid status transition")
+
+	// ErrMistakeTypeNotFound is returned when a trade references a mistake type missing from the user's catalogue
+	ErrMistakeTypeNotFound = errors.New("mistake type not found")
+
+	// ErrRatingDimensionNotFound is returned when a trade is rated on a dimension the user has not defined
+	ErrRatingDimensionNotFound = errors.New("rating dimension not found")
+
+	// ErrStrategyNotFound is returned when a trade is linked to a strategy the user does not have
+	ErrStrategyNotFound = errors.New("strategy not found")
+
+	// ErrStrategyRuleNotFound is returned when a satisfied rule does not belong to any of the trade's strategies
+	ErrStrategyRuleNotFound = errors.New("strategy rule not found")
+
+	// ErrGradeNotFound is returned when a trade is graded with a grade missing from the user's scale
+	ErrGradeNotFound = errors.New("grade is not on the user's grade scale")
+
+	// ErrVersionConflict is returned when a trade was changed since the version the caller last read
+	ErrVersionConflict = errors.New("trade was modified by another request")
+
+	// ErrInvalidCursor is returned when a page cursor is malformed or was issued for another sort order
+	ErrInvalidCursor = errors.New("invalid cursor")
+)
@@ -0,0 +1,65 @@
+package trade
+
+import "time"
+
+// SortKey names a field trade listings can be ordered by
+type SortKey string
+
+const (
+	SortByDate   SortKey = "date" // Open date and time
+	SortByPair   SortKey = "pair"
+	SortByType   SortKey = "type"
+	SortByStatus SortKey = "status"
+	SortByLots   SortKey = "lots"
+	SortByPips   SortKey = "pips"
+	SortByPL     SortKey = "pl"
+	SortByR      SortKey = "r" // Realized R multiple
+)
+
+// IsValid reports whether k is a known sort key
+func (k SortKey) IsValid() bool {
+	switch k {
+	case SortByDate, SortByPair, SortByType, SortByStatus, SortByLots, SortByPips, SortByPL, SortByR:
+		return true
+	}
+	return false
+}
+
+// Sort orders a listing by one field
+type Sort struct {
+	Key        SortKey
+	Descending bool
+}
+
+// Filter selects and orders the trades of a listing. Unset criteria match every trade. A list
+// criterion matches a trade having any of its values, except Tags which a trade must all carry.
+type Filter struct {
+	AccountID   *int64
+	OpenedFrom  *time.Time // Opened at or after
+	OpenedTo    *time.Time // Opened before
+	Pairs       []string
+	Types       []TradeType
+	Statuses    []TradeStatus
+	StrategyIDs []int64
+	Tags        []string
+	Grade       string
+	MinPL       *float64 // Bounds on the settled P/L
+	MaxPL       *float64
+	MinR        *float64 // Bounds on the realized R multiple
+	MaxR        *float64
+	MinLots     *float64
+	MaxLots     *float64
+	Weekdays    []time.Weekday // Weekday the trade opened on in Location
+	Hours       []int          // Hour the trade opened at in Location
+	Location    *time.Location // Timezone weekdays and hours are read in; nil means UTC
+	// Sort lists the fields to order by, most significant first. Trades tied on all of them,
+	// or every trade when Sort is empty, come by open date, time and ID, most recent first.
+	Sort []Sort
+}
+
+// Page asks for one page of a listing
+type Page struct {
+	Limit int // Most trades to return
+	// Cursor is the opaque position returned with the previous page; empty for the first page
+	Cursor string
+}
@@ -3,17 +3,43 @@ package trade
 import (
 	"context"
 	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/account"
 )
 
 type Repository interface {
 	Create(ctx context.Context, trade *Trade) (*Trade, error)
 	GetByID(ctx context.Context, id int64, userID int64) (*Trade, error)
-	GetByUserID(ctx context.Context, userID int64) ([]*Trade, error)
-	GetByUserIDAndDateRange(ctx context.Context, userID int64, startDate, endDate time.Time) ([]*Trade, error)
+	// Find returns a page of the user's trades matching filter, in the order it asks for, along
+	// with the cursor of the next page; the cursor is empty on the last page
+	Find(ctx context.Context, userID int64, filter Filter, page Page) ([]*Trade, string, error)
+	// Search returns up to limit of the user's trades whose pair, notes, mistakes or strategy names
+	// match the web-style query, best match first
+	Search(ctx context.Context, userID int64, query string, limit int) ([]*SearchResult, error)
+	// Update stores the trade if its Version is still current and returns ErrVersionConflict otherwise
 	Update(ctx context.Context, trade *Trade) (*Trade, error)
-	Delete(ctx context.Context, id int64, userID int64) error
-	GetByAccountID(ctx context.Context, accountID int64, userID int64) ([]*Trade, error)
-	GetByAccountIDAndDateRange(ctx context.Context, accountID int64, userID int64, startDate, endDate time.Time) ([]*Trade, error)
+	// Delete moves a trade to the trash, provided it is still at version
+	Delete(ctx context.Context, id int64, userID int64, version int64) error
+	// GetDeletedByID returns a trade from the trash
+	GetDeletedByID(ctx context.Context, id int64, userID int64) (*Trade, error)
+	// GetDeletedByUserID returns the trades in the user's trash, most recently deleted first
+	GetDeletedByUserID(ctx context.Context, userID int64) ([]*Trade, error)
+	// Restore takes a trade out of the trash
+	Restore(ctx context.Context, id int64, userID int64) (*Trade, error)
+	// PurgeDeleted permanently removes trades moved to the trash before the given instant
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+	// GetByGroupID returns the trades of a trade group in open order
+	GetByGroupID(ctx context.Context, groupID int64, userID int64) ([]*Trade, error)
 	UpdateChartBefore(ctx context.Context, id int64, userID int64, chartURL string) (*Trade, error)
 	UpdateChartAfter(ctx context.Context, id int64, userID int64, chartURL string) (*Trade, error)
+	AddExecution(ctx context.Context, execution *Execution) (*Execution, error)
+	DeleteExecution(ctx context.Context, id int64, tradeID int64) error
+	// GetTags returns the tags of a user in name order
+	GetTags(ctx context.Context, userID int64) ([]Tag, error)
+}
+
+// Transactor runs work against trade and account repositories that share one database
+// transaction. The transaction commits when fn returns nil and rolls back otherwise.
+type Transactor interface {
+	WithinTx(ctx context.Context, fn func(trades Repository, accounts account.Repository) error) error
 }
@@ -0,0 +1,177 @@
+package trade
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// Validation error codes
+const (
+	CodeRequired          = "required"
+	CodeInvalid           = "invalid"
+	CodeInvalidFormat     = "invalid_format"
+	CodeMustBePositive    = "must_be_positive"
+	CodeMustNotBeNegative = "must_not_be_negative"
+	CodeWrongSide         = "wrong_side"
+	CodeBeforeOpen        = "before_open"
+	CodeTooLong           = "too_long"
+	CodeOutOfRange        = "out_of_range"
+	CodeDuplicate         = "duplicate"
+)
+
+// FieldError describes why the value of a single field is invalid.
+// Field uses the name of the field in API requests.
+type FieldError struct {
+	Field   string
+	Code    string
+	Message string
+}
+
+// ValidationError is returned when one or more fields are invalid
+type ValidationError struct {
+	Fields []FieldError
+}
+
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Fields))
+	for i, f := range e.Fields {
+		messages[i] = f.Message
+	}
+	return strings.Join(messages, "; ")
+}
+
+// NewValidationError returns a ValidationError for the given field errors, or nil when there are none
+func NewValidationError(fields []FieldError) error {
+	if len(fields) == 0 {
+		return nil
+	}
+	return &ValidationError{Fields: fields}
+}
+
+// Validate checks the invariants of a trade and returns the fields that break them
+func (t *Trade) Validate() []FieldError {
+	var errs []FieldError
+	add := func(field, code, format string, args ...any) {
+		errs = append(errs, FieldError{Field: field, Code: code, Message: fmt.Sprintf(format, args...)})
+	}
+
+	switch t.Type {
+	case TradeTypeBuy, TradeTypeSell:
+		if strings.TrimSpace(t.Pair) == "" {
+			add("pair", CodeRequired, "pair is required")
+		}
+		if t.Entry <= 0 {
+			add("entry", CodeMustBePositive, "entry must be greater than zero")
+		}
+		if t.Lots <= 0 {
+			add("lots", CodeMustBePositive, "lots must be greater than zero")
+		}
+		if t.Exit != nil && *t.Exit <= 0 {
+			add("exit", CodeMustBePositive, "exit must be greater than zero")
+		}
+
+		// A stop loss must not sit in profit, nor a take profit in loss
+		below, above := "below", "above"
+		if t.Type == TradeTypeSell {
+			below, above = above, below
+		}
+		if t.StopLoss != nil {
+			if *t.StopLoss <= 0 {
+				add("stop_loss", CodeMustBePositive, "stop_loss must be greater than zero")
+			} else if t.Entry > 0 && PriceMove(t.Type, t.Entry, *t.StopLoss) > 0 {
+				add("stop_loss", CodeWrongSide, "stop_loss must not be %s entry for a %s", above, t.Type)
+			}
+		}
+		if t.TakeProfit != nil {
+			if *t.TakeProfit <= 0 {
+				add("take_profit", CodeMustBePositive, "take_profit must be greater than zero")
+			} else if t.Entry > 0 && PriceMove(t.Type, t.Entry, *t.TakeProfit) < 0 {
+				add("take_profit", CodeWrongSide, "take_profit must not be %s entry for a %s", below, t.Type)
+			}
+		}
+		if t.ClosedAt != nil && t.ClosedAt.Before(t.OpenedAt) {
+			add("close_date", CodeBeforeOpen, "close_date and close_time must not be before date and time")
+		}
+
+		// An order that was never filled has no exit or close, and only limit and stop orders can wait to fill
+		if !t.OrderType.IsValid() {
+			add("order_type", CodeInvalid, "order_type must be one of market, limit or stop")
+		}
+		if t.Status.IsUnfilled() {
+			if t.OrderType == OrderTypeMarket {
+				add("status", CodeInvalid, "a market order cannot be %s", t.Status)
+			}
+			if t.Exit != nil {
+				add("exit", CodeInvalid, "exit must be empty for a %s order", t.Status)
+			}
+			if t.ClosedAt != nil {
+				add("close_date", CodeInvalid, "close_date must be empty for a %s order", t.Status)
+			}
+		}
+
+	case TradeTypeDeposit, TradeTypeWithdraw:
+		if t.Amount == nil {
+			add("amount", CodeRequired, "amount is required")
+		} else if *t.Amount <= 0 {
+			add("amount", CodeMustBePositive, "amount must be greater than zero")
+		}
+		if t.OrderType != OrderTypeMarket {
+			add("order_type", CodeInvalid, "order_type must be market for a %s", t.Type)
+		}
+		if t.Status.IsUnfilled() {
+			add("status", CodeInvalid, "a %s cannot be %s", t.Type, t.Status)
+		}
+
+	default:
+		add("type", CodeInvalid, "type must be one of BUY, SELL, DEPOSIT or WITHDRAW")
+	}
+
+	for _, tag := range t.Tags {
+		if utf8.RuneCountInString(tag.Name) > MaxTagLength {
+			add("tags", CodeTooLong, "tag %q is longer than %d characters", tag.Name, MaxTagLength)
+		}
+	}
+
+	rated := make(map[Rating]bool)
+	for _, r := range t.Ratings {
+		if !r.Phase.IsValid() {
+			add("ratings", CodeInvalid, "rating phase must be before or after")
+		}
+		if r.Value < MinRating || r.Value > MaxRating {
+			add("ratings", CodeOutOfRange, "rating must be between %d and %d", MinRating, MaxRating)
+		}
+		key := Rating{DimensionID: r.DimensionID, Phase: r.Phase}
+		if rated[key] {
+			add("ratings", CodeDuplicate, "dimension %d is rated more than once %s the trade", r.DimensionID, r.Phase)
+		}
+		rated[key] = true
+	}
+
+	if !t.Status.IsValid() {
+		add("status", CodeInvalid, "status must be one of pending, open, closed, cancelled or expired")
+	}
+	if t.Commission < 0 {
+		add("commission", CodeMustNotBeNegative, "commission cannot be negative")
+	}
+	if t.Fees < 0 {
+		add("fees", CodeMustNotBeNegative, "fees cannot be negative")
+	}
+
+	return errs
+}
+
+// Validate checks a fill and returns the fields that are invalid
+func (e Execution) Validate() []FieldError {
+	var errs []FieldError
+	if e.Side != TradeTypeBuy && e.Side != TradeTypeSell {
+		errs = append(errs, FieldError{Field: "side", Code: CodeInvalid, Message: "side must be BUY or SELL"})
+	}
+	if e.Price <= 0 {
+		errs = append(errs, FieldError{Field: "price", Code: CodeMustBePositive, Message: "price must be greater than zero"})
+	}
+	if e.Lots <= 0 {
+		errs = append(errs, FieldError{Field: "lots", Code: CodeMustBePositive, Message: "lots must be greater than zero"})
+	}
+	return errs
+}
@@ -0,0 +1,92 @@
+package tradegroup
+
+import (
+	"math"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+// MaxNameLength is the longest group name accepted, in characters
+const MaxNameLength = 100
+
+// Group links trades that the user manages as one position, such as a hedge or a scaled entry
+type Group struct {
+	ID          int64
+	UserID      int64
+	Name        string
+	Description string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// Summary combines the filled trades of a group into a single position
+type Summary struct {
+	Trades    int
+	PL        *float64       // Sum of the settled P/L, nil until every trade has one
+	Risk      *float64       // Sum of the risk amounts, nil when no trade has one
+	RealizedR *float64       // Combined P/L as a multiple of the combined risk
+	OpenedAt  *time.Time     // Earliest open instant
+	ClosedAt  *time.Time     // Latest close instant, nil until every trade is closed
+	Duration  *time.Duration // From the first open to the last close
+}
+
+// Summarize combines the trades of a group; unfilled orders are left out
+func Summarize(trades []*trade.Trade) Summary {
+	var summary Summary
+	var pl, risk float64
+	hasRisk, settled, closed := false, true, true
+
+	for _, t := range trades {
+		if t.Status.IsUnfilled() {
+			continue
+		}
+		summary.Trades++
+
+		if p := t.SettledPL(); p != nil {
+			pl += *p
+		} else {
+			settled = false
+		}
+		if t.RiskAmount != nil {
+			risk += *t.RiskAmount
+			hasRisk = true
+		}
+
+		if summary.OpenedAt == nil || t.OpenedAt.Before(*summary.OpenedAt) {
+			openedAt := t.OpenedAt
+			summary.OpenedAt = &openedAt
+		}
+		if t.ClosedAt == nil {
+			closed = false
+		} else if summary.ClosedAt == nil || t.ClosedAt.After(*summary.ClosedAt) {
+			closedAt := *t.ClosedAt
+			summary.ClosedAt = &closedAt
+		}
+	}
+
+	if summary.Trades == 0 {
+		return summary
+	}
+	if settled {
+		pl = math.Round(pl*100) / 100
+		summary.PL = &pl
+	}
+	if hasRisk {
+		risk = math.Round(risk*100) / 100
+		summary.Risk = &risk
+	}
+	if summary.PL != nil && summary.Risk != nil && risk != 0 {
+		r := math.Round(pl/risk*100) / 100
+		summary.RealizedR = &r
+	}
+	if !closed {
+		summary.ClosedAt = nil
+	}
+	if summary.ClosedAt != nil {
+		d := summary.ClosedAt.Sub(*summary.OpenedAt)
+		summary.Duration = &d
+	}
+
+	return summary
+}
@@ -0,0 +1,10 @@
+package tradegroup
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a trade group is not found or access is denied
+	ErrNotFound = errors.New("trade group not found")
+	// ErrTradeNotFound is returned when a trade to link is not found or access is denied
+	ErrTradeNotFound = errors.New("trade not found")
+)
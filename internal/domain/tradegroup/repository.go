@@ -0,0 +1,21 @@
+package tradegroup
+
+import "context"
+
+// Repository defines the interface for trade group data operations
+type Repository interface {
+	Create(ctx context.Context, group *Group) (*Group, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Group, error)
+	GetByUserID(ctx context.Context, userID int64) ([]*Group, error)
+	Update(ctx context.Context, group *Group) (*Group, error)
+	// Delete removes the group; its trades are kept and become ungrouped
+	Delete(ctx context.Context, id int64, userID int64) error
+	// SetTrades makes tradeIDs the only members of the group, moving them out of any other group
+	SetTrades(ctx context.Context, groupID int64, userID int64, tradeIDs []int64) error
+}
+
+// Transactor runs work against a group repository bound to one database transaction.
+// The transaction commits when fn returns nil and rolls back otherwise.
+type Transactor interface {
+	WithinGroupTx(ctx context.Context, fn func(groups Repository) error) error
+}
@@ -0,0 +1,17 @@
+package tradetemplate
+
+import "time"
+
+// MaxNameLength is the longest template name accepted, in characters
+const MaxNameLength = 100
+
+// Template is a named set of defaults the user starts new trades from. Defaults maps the JSON
+// names of create trade request fields, such as "pair", "lots" or "strategy_ids", to their values.
+type Template struct {
+	ID        int64
+	UserID    int64
+	Name      string
+	Defaults  map[string]any
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
@@ -0,0 +1,8 @@
+package tradetemplate
+
+import "errors"
+
+var (
+	// ErrNotFound is returned when a trade template is not found or access is denied
+	ErrNotFound = errors.New("trade template not found")
+)
@@ -0,0 +1,14 @@
+package tradetemplate
+
+import "context"
+
+// Repository defines the interface for trade template data operations
+type Repository interface {
+	Create(ctx context.Context, template *Template) (*Template, error)
+	GetByID(ctx context.Context, id int64, userID int64) (*Template, error)
+	GetByName(ctx context.Context, userID int64, name string) (*Template, error)
+	// GetByUserID returns the user's templates in name order
+	GetByUserID(ctx context.Context, userID int64) ([]*Template, error)
+	Update(ctx context.Context, template *Template) (*Template, error)
+	Delete(ctx context.Context, id int64, userID int64) error
+}
@@ -2,11 +2,15 @@ package user
 
 import "time"
 
+// DefaultTimezone is used until a user picks their own timezone
+const DefaultTimezone = "UTC"
+
 // User represents a user in the system
 type User struct {
 	ID           int64
 	Email        string
 	PasswordHash string
+	Timezone     string // IANA timezone name, e.g. "Europe/London"
 	CreatedAt    time.Time
 	UpdatedAt    time.Time
 }
@@ -17,7 +21,33 @@ func NewUser(email, passwordHash string) *User {
 	return &User{
 		Email:        email,
 		PasswordHash: passwordHash,
+		Timezone:     DefaultTimezone,
 		CreatedAt:    now,
 		UpdatedAt:    now,
 	}
 }
+
+// Location returns the user's timezone, falling back to UTC when it is unset or unknown
+func (u *User) Location() *time.Location {
+	loc, err := LoadLocation(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// LoadLocation resolves an IANA timezone name; an empty name means UTC
+func LoadLocation(name string) (*time.Location, error) {
+	if name == "" {
+		return time.UTC, nil
+	}
+	// time.LoadLocation also accepts "Local", which depends on the server
+	if name == "Local" {
+		return nil, ErrInvalidTimezone
+	}
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return nil, ErrInvalidTimezone
+	}
+	return loc, nil
+}
@@ -0,0 +1,8 @@
+package user
+
+import "errors"
+
+var (
+	// ErrInvalidTimezone is returned when a timezone is not a known IANA name
+	ErrInvalidTimezone = errors.New("invalid timezone, expected an IANA name such as Europe/London")
+)
@@ -7,4 +7,5 @@ type Repository interface {
 	Create(ctx context.Context, user *User) (*User, error)
 	GetByEmail(ctx context.Context, email string) (*User, error)
 	GetByID(ctx context.Context, id int64) (*User, error)
+	UpdateTimezone(ctx context.Context, id int64, timezone string) (*User, error)
 }
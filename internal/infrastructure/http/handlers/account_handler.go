@@ -34,6 +34,7 @@ func (h *AccountHandler) CreateAccount(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create account"})
 	}
 
+	setETag(c, acc.Version)
 	return c.JSON(http.StatusCreated, acc)
 }
 
@@ -66,6 +67,7 @@ func (h *AccountHandler) GetAccount(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch account"})
 	}
 
+	setETag(c, acc.Version)
 	return c.JSON(http.StatusOK, acc)
 }
 
@@ -78,19 +80,28 @@ func (h *AccountHandler) UpdateAccount(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
 	}
 
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
 	var req account.UpdateAccountRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
-	acc, err := h.accountService.UpdateAccount(c.Request().Context(), id, userID, req)
+	acc, err := h.accountService.UpdateAccount(c.Request().Context(), id, userID, req, version)
 	if err != nil {
 		if err == account.ErrAccountNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
 		}
+		if err == account.ErrVersionConflict {
+			return preconditionError(c, err)
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update account"})
 	}
 
+	setETag(c, acc.Version)
 	return c.JSON(http.StatusOK, acc)
 }
 
@@ -103,9 +114,52 @@ func (h *AccountHandler) DeleteAccount(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
 	}
 
-	if err := h.accountService.DeleteAccount(c.Request().Context(), id, userID); err != nil {
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
+	if err := h.accountService.DeleteAccount(c.Request().Context(), id, userID, version); err != nil {
+		if err == account.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found"})
+		}
+		if err == account.ErrVersionConflict {
+			return preconditionError(c, err)
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete account"})
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Account deleted successfully"})
 }
+
+// RestoreAccount handles requests to take an account and its trades out of the trash
+func (h *AccountHandler) RestoreAccount(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid account ID"})
+	}
+
+	acc, err := h.accountService.RestoreAccount(c.Request().Context(), id, userID)
+	if err != nil {
+		if err == account.ErrAccountNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Account not found in trash"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to restore account"})
+	}
+
+	setETag(c, acc.Version)
+	return c.JSON(http.StatusOK, acc)
+}
+
+// currentVersion looks up the version of an account for an If-Match wildcard
+func (h *AccountHandler) currentVersion(c echo.Context, id int64, userID int64) func() (int64, error) {
+	return func() (int64, error) {
+		current, err := h.accountService.GetAccount(c.Request().Context(), id, userID)
+		if err != nil {
+			return 0, err
+		}
+		return current.Version, nil
+	}
+}
@@ -18,8 +18,29 @@ func NewAnalyticsHandler(service *analytics.Service) *AnalyticsHandler {
 func (h *AnalyticsHandler) GetUserAnalytics(c echo.Context) error {
 	userID := c.Get("user_id").(int64)
 
-	result, err := h.service.GetUserAnalytics(c.Request().Context(), userID)
+	// Get date filter parameters
+	var startDate, endDate *string
+	if sd := c.QueryParam("start_date"); sd != "" {
+		startDate = &sd
+	}
+	if ed := c.QueryParam("end_date"); ed != "" {
+		endDate = &ed
+	}
+
+	// grouped=true counts the trades of each trade group as one position
+	var result *analytics.AnalyticsDTO
+	var err error
+	if c.QueryParam("grouped") == "true" {
+		result, err = h.service.GetGroupedUserAnalytics(c.Request().Context(), userID, startDate, endDate)
+	} else {
+		result, err = h.service.GetUserAnalyticsWithDateFilter(c.Request().Context(), userID, startDate, endDate)
+	}
 	if err != nil {
+		if err == analytics.ErrInvalidStartDate || err == analytics.ErrInvalidEndDate {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -5,6 +5,7 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/raihanstark/trade-journal/internal/application/auth"
+	"github.com/raihanstark/trade-journal/internal/domain/user"
 )
 
 // AuthHandler handles authentication HTTP requests
@@ -60,3 +61,44 @@ func (h *AuthHandler) Login(c echo.Context) error {
 
 	return c.JSON(http.StatusOK, response)
 }
+
+// GetSettings returns the authenticated user's preferences
+func (h *AuthHandler) GetSettings(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	response, err := h.authService.GetSettings(c.Request().Context(), userID)
+	if err != nil {
+		switch err {
+		case auth.ErrUserNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// UpdateSettings changes the authenticated user's preferences
+func (h *AuthHandler) UpdateSettings(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req auth.UpdateSettingsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	response, err := h.authService.UpdateSettings(c.Request().Context(), userID, req)
+	if err != nil {
+		switch err {
+		case user.ErrInvalidTimezone:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case auth.ErrUserNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "User not found"})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Internal server error"})
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,61 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+var (
+	errIfMatchRequired = errors.New("If-Match header with the resource's ETag is required")
+	errIfMatchInvalid  = errors.New("If-Match header must be the ETag returned for the resource")
+	errIfMatchNoMatch  = errors.New("If-Match header does not match a current version of the resource")
+)
+
+// setETag exposes a resource's version as its ETag so clients can send it back in If-Match
+func setETag(c echo.Context, version int64) {
+	c.Response().Header().Set("ETag", `"`+strconv.FormatInt(version, 10)+`"`)
+}
+
+// ifMatchVersion reads the version a write expects from the If-Match header. Weak tags are accepted
+// since the version is all that is compared. The wildcard * matches whatever version is current,
+// which current looks up; it fails when the resource cannot be found.
+func ifMatchVersion(c echo.Context, current func() (int64, error)) (int64, error) {
+	header := strings.TrimSpace(c.Request().Header.Get("If-Match"))
+	if header == "" {
+		return 0, errIfMatchRequired
+	}
+
+	if header == "*" {
+		version, err := current()
+		if err != nil {
+			return 0, errIfMatchNoMatch
+		}
+		return version, nil
+	}
+
+	tag := strings.TrimPrefix(header, "W/")
+	if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+		return 0, errIfMatchInvalid
+	}
+	version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+	if err != nil {
+		return 0, errIfMatchInvalid
+	}
+	return version, nil
+}
+
+// preconditionError responds with 428 when If-Match is missing and 412 when it does not match
+func preconditionError(c echo.Context, err error) error {
+	if errors.Is(err, errIfMatchRequired) {
+		return c.JSON(http.StatusPreconditionRequired, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	return c.JSON(http.StatusPreconditionFailed, map[string]string{
+		"error": err.Error(),
+	})
+}
@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/fx"
+)
+
+// FXRateHandler handles FX rate HTTP requests
+type FXRateHandler struct {
+	fxService *fx.Service
+}
+
+// NewFXRateHandler creates a new FX rate handler
+func NewFXRateHandler(fxService *fx.Service) *FXRateHandler {
+	return &FXRateHandler{
+		fxService: fxService,
+	}
+}
+
+// CreateRate handles recording a single daily rate
+func (h *FXRateHandler) CreateRate(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req fx.CreateRateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.fxService.CreateRate(c.Request().Context(), userID, req)
+	if err != nil {
+		switch err {
+		case fx.ErrInvalidRate, fx.ErrInvalidRateDate:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to save FX rate"})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// GetRates handles fetching all rates for a user
+func (h *FXRateHandler) GetRates(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	rates, err := h.fxService.GetUserRates(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch FX rates"})
+	}
+
+	return c.JSON(http.StatusOK, rates)
+}
+
+// DeleteRate handles rate deletion requests
+func (h *FXRateHandler) DeleteRate(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid FX rate ID"})
+	}
+
+	if err := h.fxService.DeleteRate(c.Request().Context(), id, userID); err != nil {
+		if err == fx.ErrRateNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "FX rate not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete FX rate"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "FX rate deleted successfully"})
+}
+
+// ImportRates handles CSV imports, either as a multipart "file" upload or a raw text/csv body
+func (h *FXRateHandler) ImportRates(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var src io.Reader = c.Request().Body
+	if strings.HasPrefix(c.Request().Header.Get(echo.HeaderContentType), echo.MIMEMultipartForm) {
+		file, err := c.FormFile("file")
+		if err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "No file uploaded"})
+		}
+
+		// Limit file size (max 5MB)
+		if file.Size > 5*1024*1024 {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "File size must be less than 5MB"})
+		}
+
+		f, err := file.Open()
+		if err != nil {
+			return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to read file"})
+		}
+		defer f.Close()
+		src = f
+	}
+
+	result, err := h.fxService.ImportCSV(c.Request().Context(), userID, src)
+	if err != nil {
+		if err == fx.ErrInvalidCSV {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to import FX rates"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
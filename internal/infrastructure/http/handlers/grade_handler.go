@@ -0,0 +1,52 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/grade"
+)
+
+// GradeHandler handles grade scale HTTP requests
+type GradeHandler struct {
+	gradeService *grade.Service
+}
+
+// NewGradeHandler creates a new grade handler
+func NewGradeHandler(gradeService *grade.Service) *GradeHandler {
+	return &GradeHandler{
+		gradeService: gradeService,
+	}
+}
+
+// GetScale handles fetching the user's grade scale
+func (h *GradeHandler) GetScale(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	scale, err := h.gradeService.GetScale(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch grade scale"})
+	}
+
+	return c.JSON(http.StatusOK, scale)
+}
+
+// UpdateScale handles replacing the user's grade scale
+func (h *GradeHandler) UpdateScale(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req grade.UpdateScaleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	scale, err := h.gradeService.UpdateScale(c.Request().Context(), userID, req)
+	if err != nil {
+		if err == grade.ErrInvalidScale {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update grade scale"})
+	}
+
+	return c.JSON(http.StatusOK, scale)
+}
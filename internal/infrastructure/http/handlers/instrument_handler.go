@@ -0,0 +1,128 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/instrument"
+)
+
+// InstrumentHandler handles instrument registry HTTP requests
+type InstrumentHandler struct {
+	instrumentService *instrument.Service
+}
+
+// NewInstrumentHandler creates a new instrument handler
+func NewInstrumentHandler(instrumentService *instrument.Service) *InstrumentHandler {
+	return &InstrumentHandler{
+		instrumentService: instrumentService,
+	}
+}
+
+// CreateInstrument handles instrument creation requests
+func (h *InstrumentHandler) CreateInstrument(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req instrument.CreateInstrumentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.instrumentService.CreateInstrument(c.Request().Context(), userID, req)
+	if err != nil {
+		switch err {
+		case instrument.ErrInvalidInstrument, instrument.ErrInvalidAssetClass:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case instrument.ErrInstrumentExists:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create instrument"})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// GetInstruments handles fetching the instrument registry for a user
+func (h *InstrumentHandler) GetInstruments(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	instruments, err := h.instrumentService.GetUserInstruments(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch instruments"})
+	}
+
+	return c.JSON(http.StatusOK, instruments)
+}
+
+// GetInstrument handles fetching a single instrument
+func (h *InstrumentHandler) GetInstrument(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid instrument ID"})
+	}
+
+	result, err := h.instrumentService.GetInstrument(c.Request().Context(), id, userID)
+	if err != nil {
+		if err == instrument.ErrInstrumentNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Instrument not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch instrument"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// UpdateInstrument handles instrument update requests
+func (h *InstrumentHandler) UpdateInstrument(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid instrument ID"})
+	}
+
+	var req instrument.UpdateInstrumentRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.instrumentService.UpdateInstrument(c.Request().Context(), id, userID, req)
+	if err != nil {
+		switch err {
+		case instrument.ErrInstrumentNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Instrument not found"})
+		case instrument.ErrInvalidInstrument, instrument.ErrInvalidAssetClass:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case instrument.ErrInstrumentExists:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update instrument"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteInstrument handles instrument deletion requests
+func (h *InstrumentHandler) DeleteInstrument(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid instrument ID"})
+	}
+
+	if err := h.instrumentService.DeleteInstrument(c.Request().Context(), id, userID); err != nil {
+		switch err {
+		case instrument.ErrInstrumentNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Instrument not found"})
+		case instrument.ErrBuiltInInstrument:
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete instrument"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Instrument deleted successfully"})
+}
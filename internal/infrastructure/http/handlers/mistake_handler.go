@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/mistake"
+)
+
+// MistakeHandler handles mistake catalogue HTTP requests
+type MistakeHandler struct {
+	mistakeService *mistake.Service
+}
+
+// NewMistakeHandler creates a new mistake handler
+func NewMistakeHandler(mistakeService *mistake.Service) *MistakeHandler {
+	return &MistakeHandler{
+		mistakeService: mistakeService,
+	}
+}
+
+// CreateMistakeType handles mistake type creation requests
+func (h *MistakeHandler) CreateMistakeType(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req mistake.CreateMistakeTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.mistakeService.CreateMistakeType(c.Request().Context(), userID, req)
+	if err != nil {
+		switch err {
+		case mistake.ErrInvalidMistakeType, mistake.ErrInvalidSeverity:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case mistake.ErrMistakeTypeExists:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create mistake type"})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// GetMistakeTypes handles fetching the mistake catalogue for a user
+func (h *MistakeHandler) GetMistakeTypes(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	mistakeTypes, err := h.mistakeService.GetUserMistakeTypes(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch mistake types"})
+	}
+
+	return c.JSON(http.StatusOK, mistakeTypes)
+}
+
+// GetMistakeType handles fetching a single mistake type
+func (h *MistakeHandler) GetMistakeType(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid mistake type ID"})
+	}
+
+	result, err := h.mistakeService.GetMistakeType(c.Request().Context(), id, userID)
+	if err != nil {
+		if err == mistake.ErrMistakeTypeNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Mistake type not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch mistake type"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// UpdateMistakeType handles mistake type update requests
+func (h *MistakeHandler) UpdateMistakeType(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid mistake type ID"})
+	}
+
+	var req mistake.UpdateMistakeTypeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.mistakeService.UpdateMistakeType(c.Request().Context(), id, userID, req)
+	if err != nil {
+		switch err {
+		case mistake.ErrMistakeTypeNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Mistake type not found"})
+		case mistake.ErrInvalidMistakeType, mistake.ErrInvalidSeverity:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case mistake.ErrMistakeTypeExists:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update mistake type"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteMistakeType handles mistake type deletion requests
+func (h *MistakeHandler) DeleteMistakeType(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid mistake type ID"})
+	}
+
+	if err := h.mistakeService.DeleteMistakeType(c.Request().Context(), id, userID); err != nil {
+		if err == mistake.ErrMistakeTypeNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Mistake type not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete mistake type"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Mistake type deleted successfully"})
+}
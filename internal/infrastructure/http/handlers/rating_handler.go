@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/rating"
+)
+
+// RatingHandler handles rating dimension HTTP requests
+type RatingHandler struct {
+	ratingService *rating.Service
+}
+
+// NewRatingHandler creates a new rating handler
+func NewRatingHandler(ratingService *rating.Service) *RatingHandler {
+	return &RatingHandler{
+		ratingService: ratingService,
+	}
+}
+
+// CreateDimension handles rating dimension creation requests
+func (h *RatingHandler) CreateDimension(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req rating.CreateDimensionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.ratingService.CreateDimension(c.Request().Context(), userID, req)
+	if err != nil {
+		switch err {
+		case rating.ErrInvalidDimension:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case rating.ErrDimensionExists:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create rating dimension"})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// GetDimensions handles fetching the rating dimensions for a user
+func (h *RatingHandler) GetDimensions(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	dimensions, err := h.ratingService.GetUserDimensions(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch rating dimensions"})
+	}
+
+	return c.JSON(http.StatusOK, dimensions)
+}
+
+// GetDimension handles fetching a single rating dimension
+func (h *RatingHandler) GetDimension(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rating dimension ID"})
+	}
+
+	result, err := h.ratingService.GetDimension(c.Request().Context(), id, userID)
+	if err != nil {
+		if err == rating.ErrDimensionNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Rating dimension not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch rating dimension"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// UpdateDimension handles rating dimension update requests
+func (h *RatingHandler) UpdateDimension(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rating dimension ID"})
+	}
+
+	var req rating.UpdateDimensionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.ratingService.UpdateDimension(c.Request().Context(), id, userID, req)
+	if err != nil {
+		switch err {
+		case rating.ErrDimensionNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Rating dimension not found"})
+		case rating.ErrInvalidDimension:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case rating.ErrDimensionExists:
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update rating dimension"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteDimension handles rating dimension deletion requests
+func (h *RatingHandler) DeleteDimension(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid rating dimension ID"})
+	}
+
+	if err := h.ratingService.DeleteDimension(c.Request().Context(), id, userID); err != nil {
+		if err == rating.ErrDimensionNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Rating dimension not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete rating dimension"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Rating dimension deleted successfully"})
+}
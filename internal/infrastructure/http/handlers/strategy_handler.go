@@ -31,9 +31,13 @@ func (h *StrategyHandler) CreateStrategy(c echo.Context) error {
 
 	strat, err := h.strategyService.CreateStrategy(c.Request().Context(), userID, req)
 	if err != nil {
+		if err == strategy.ErrInvalidRule {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create strategy"})
 	}
 
+	setETag(c, strat.Version)
 	return c.JSON(http.StatusCreated, strat)
 }
 
@@ -66,6 +70,7 @@ func (h *StrategyHandler) GetStrategy(c echo.Context) error {
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch strategy"})
 	}
 
+	setETag(c, strat.Version)
 	return c.JSON(http.StatusOK, strat)
 }
 
@@ -78,19 +83,31 @@ func (h *StrategyHandler) UpdateStrategy(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid strategy ID"})
 	}
 
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
 	var req strategy.UpdateStrategyRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
 	}
 
-	strat, err := h.strategyService.UpdateStrategy(c.Request().Context(), id, userID, req)
+	strat, err := h.strategyService.UpdateStrategy(c.Request().Context(), id, userID, req, version)
 	if err != nil {
 		if err == strategy.ErrStrategyNotFound {
 			return c.JSON(http.StatusNotFound, map[string]string{"error": "Strategy not found"})
 		}
+		if err == strategy.ErrVersionConflict {
+			return preconditionError(c, err)
+		}
+		if err == strategy.ErrInvalidRule || err == strategy.ErrRuleNotFound {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update strategy"})
 	}
 
+	setETag(c, strat.Version)
 	return c.JSON(http.StatusOK, strat)
 }
 
@@ -103,9 +120,31 @@ func (h *StrategyHandler) DeleteStrategy(c echo.Context) error {
 		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid strategy ID"})
 	}
 
-	if err := h.strategyService.DeleteStrategy(c.Request().Context(), id, userID); err != nil {
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
+	if err := h.strategyService.DeleteStrategy(c.Request().Context(), id, userID, version); err != nil {
+		if err == strategy.ErrStrategyNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Strategy not found"})
+		}
+		if err == strategy.ErrVersionConflict {
+			return preconditionError(c, err)
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete strategy"})
 	}
 
 	return c.JSON(http.StatusOK, map[string]string{"message": "Strategy deleted successfully"})
 }
+
+// currentVersion looks up the version of a strategy for an If-Match wildcard
+func (h *StrategyHandler) currentVersion(c echo.Context, id int64, userID int64) func() (int64, error) {
+	return func() (int64, error) {
+		current, err := h.strategyService.GetStrategy(c.Request().Context(), id, userID)
+		if err != nil {
+			return 0, err
+		}
+		return current.Version, nil
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/tradegroup"
+)
+
+// TradeGroupHandler handles trade group HTTP requests
+type TradeGroupHandler struct {
+	tradeGroupService *tradegroup.Service
+}
+
+// NewTradeGroupHandler creates a new trade group handler
+func NewTradeGroupHandler(tradeGroupService *tradegroup.Service) *TradeGroupHandler {
+	return &TradeGroupHandler{
+		tradeGroupService: tradeGroupService,
+	}
+}
+
+// CreateTradeGroup handles trade group creation requests
+func (h *TradeGroupHandler) CreateTradeGroup(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req tradegroup.CreateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.tradeGroupService.CreateGroup(c.Request().Context(), userID, req)
+	if err != nil {
+		switch err {
+		case tradegroup.ErrInvalidGroup:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case tradegroup.ErrTradeNotFound:
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create trade group"})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// GetTradeGroups handles fetching the trade groups of a user
+func (h *TradeGroupHandler) GetTradeGroups(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	groups, err := h.tradeGroupService.GetUserGroups(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch trade groups"})
+	}
+
+	return c.JSON(http.StatusOK, groups)
+}
+
+// GetTradeGroup handles fetching a single trade group
+func (h *TradeGroupHandler) GetTradeGroup(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid trade group ID"})
+	}
+
+	result, err := h.tradeGroupService.GetGroup(c.Request().Context(), id, userID)
+	if err != nil {
+		if err == tradegroup.ErrGroupNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Trade group not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch trade group"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// UpdateTradeGroup handles trade group update requests
+func (h *TradeGroupHandler) UpdateTradeGroup(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid trade group ID"})
+	}
+
+	var req tradegroup.UpdateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.tradeGroupService.UpdateGroup(c.Request().Context(), id, userID, req)
+	if err != nil {
+		switch err {
+		case tradegroup.ErrGroupNotFound:
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Trade group not found"})
+		case tradegroup.ErrInvalidGroup:
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		case tradegroup.ErrTradeNotFound:
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update trade group"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteTradeGroup handles trade group deletion requests
+func (h *TradeGroupHandler) DeleteTradeGroup(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid trade group ID"})
+	}
+
+	if err := h.tradeGroupService.DeleteGroup(c.Request().Context(), id, userID); err != nil {
+		if err == tradegroup.ErrGroupNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Trade group not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete trade group"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Trade group deleted successfully"})
+}
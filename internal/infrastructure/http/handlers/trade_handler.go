@@ -1,13 +1,17 @@
 package handlers
 
 import (
+	"database/sql"
+	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
 	"github.com/labstack/echo/v4"
 	"github.com/raihanstark/trade-journal/internal/application/trade"
+	tradedom "github.com/raihanstark/trade-journal/internal/domain/trade"
 	"github.com/raihanstark/trade-journal/internal/infrastructure/storage"
 )
 
@@ -35,51 +39,110 @@ func (h *TradeHandler) CreateTrade(c echo.Context) error {
 
 	result, err := h.service.CreateTrade(c.Request().Context(), userID, req)
 	if err != nil {
+		var validationErr *tradedom.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationError(c, validationErr)
+		}
+		if errors.Is(err, trade.ErrAccountNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, trade.ErrFXRateNotFound) || errors.Is(err, tradedom.ErrMistakeTypeNotFound) ||
+			errors.Is(err, tradedom.ErrRatingDimensionNotFound) || errors.Is(err, tradedom.ErrStrategyRuleNotFound) ||
+			errors.Is(err, tradedom.ErrGradeNotFound) || errors.Is(err, tradedom.ErrStrategyNotFound) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		c.Logger().Error(err)
 		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
+			"error": "Failed to create trade",
 		})
 	}
 
+	setETag(c, result.Version)
 	return c.JSON(http.StatusCreated, result)
 }
 
 func (h *TradeHandler) GetTrades(c echo.Context) error {
 	userID := c.Get("user_id").(int64)
 
-	// Get date filter parameters
-	var startDate, endDate *string
-	if sd := c.QueryParam("start_date"); sd != "" {
-		startDate = &sd
+	params := c.QueryParams()
+	req := trade.ListTradesRequest{
+		AccountID:   c.QueryParam("account_id"),
+		StartDate:   c.QueryParam("start_date"),
+		EndDate:     c.QueryParam("end_date"),
+		Pairs:       params["pair"],
+		Types:       params["type"],
+		Statuses:    params["status"],
+		StrategyIDs: params["strategy_id"],
+		Tags:        params["tag"],
+		Grade:       c.QueryParam("grade"),
+		MinPL:       c.QueryParam("min_pl"),
+		MaxPL:       c.QueryParam("max_pl"),
+		MinR:        c.QueryParam("min_r"),
+		MaxR:        c.QueryParam("max_r"),
+		MinLots:     c.QueryParam("min_lots"),
+		MaxLots:     c.QueryParam("max_lots"),
+		Weekdays:    params["weekday"],
+		Hours:       params["hour"],
+		Sort:        params["sort"],
+		Limit:       c.QueryParam("limit"),
+		Cursor:      c.QueryParam("cursor"),
+	}
+
+	page, err := h.service.ListTrades(c.Request().Context(), userID, req)
+	if err != nil {
+		var validationErr *tradedom.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationError(c, validationErr)
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
 	}
-	if ed := c.QueryParam("end_date"); ed != "" {
-		endDate = &ed
+	return c.JSON(http.StatusOK, page)
+}
+
+func (h *TradeHandler) GetTags(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	tags, err := h.service.ListTags(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
 	}
+	return c.JSON(http.StatusOK, tags)
+}
+
+func (h *TradeHandler) SearchTrades(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
 
-	// If account_id is provided, get trades by account ID
-	if accountID := c.QueryParam("account_id"); accountID != "" {
-		accountID, err := strconv.ParseInt(accountID, 10, 64)
+	limit := 0
+	if l := c.QueryParam("limit"); l != "" {
+		parsed, err := strconv.Atoi(l)
 		if err != nil {
 			return c.JSON(http.StatusBadRequest, map[string]string{
-				"error": "Invalid account ID",
-			})
-		}
-		trades, err := h.service.GetTradesByAccountIDWithDateFilter(c.Request().Context(), accountID, userID, startDate, endDate)
-		if err != nil {
-			return c.JSON(http.StatusInternalServerError, map[string]string{
-				"error": err.Error(),
+				"error": trade.ErrInvalidSearchLimit.Error(),
 			})
 		}
-		return c.JSON(http.StatusOK, trades)
+		limit = parsed
 	}
 
-	// Otherwise, get all trades for the user
-	trades, err := h.service.GetUserTradesWithDateFilter(c.Request().Context(), userID, startDate, endDate)
+	results, err := h.service.SearchTrades(c.Request().Context(), userID, c.QueryParam("q"), limit)
 	if err != nil {
+		if errors.Is(err, trade.ErrSearchQueryRequired) || errors.Is(err, trade.ErrInvalidSearchLimit) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
 	}
-	return c.JSON(http.StatusOK, trades)
+	return c.JSON(http.StatusOK, results)
 }
 
 func (h *TradeHandler) GetTrade(c echo.Context) error {
@@ -98,9 +161,51 @@ func (h *TradeHandler) GetTrade(c echo.Context) error {
 		})
 	}
 
+	setETag(c, result.Version)
 	return c.JSON(http.StatusOK, result)
 }
 
+func (h *TradeHandler) DuplicateTrade(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	result, err := h.service.DuplicateTrade(c.Request().Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, tradedom.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Trade not found",
+			})
+		}
+		var validationErr *tradedom.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationError(c, validationErr)
+		}
+		if errors.Is(err, trade.ErrAccountNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		if errors.Is(err, trade.ErrDuplicateNotSupported) || errors.Is(err, trade.ErrFXRateNotFound) ||
+			errors.Is(err, tradedom.ErrStrategyNotFound) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		c.Logger().Error(err)
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": "Failed to duplicate trade",
+		})
+	}
+
+	setETag(c, result.Version)
+	return c.JSON(http.StatusCreated, result)
+}
+
 func (h *TradeHandler) UpdateTrade(c echo.Context) error {
 	userID := c.Get("user_id").(int64)
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -110,6 +215,11 @@ func (h *TradeHandler) UpdateTrade(c echo.Context) error {
 		})
 	}
 
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
 	var req trade.UpdateTradeRequest
 	if err := c.Bind(&req); err != nil {
 		return c.JSON(http.StatusBadRequest, map[string]string{
@@ -117,16 +227,91 @@ func (h *TradeHandler) UpdateTrade(c echo.Context) error {
 		})
 	}
 
-	result, err := h.service.UpdateTrade(c.Request().Context(), id, userID, req)
+	result, err := h.service.UpdateTrade(c.Request().Context(), id, userID, req, version)
 	if err != nil {
-		return c.JSON(http.StatusInternalServerError, map[string]string{
-			"error": err.Error(),
+		return updateError(c, err)
+	}
+
+	setETag(c, result.Version)
+	return c.JSON(http.StatusOK, result)
+}
+
+// PatchTrade applies a JSON Merge Patch to a trade; only the fields present in the body change
+func (h *TradeHandler) PatchTrade(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
+	contentType := strings.TrimSpace(strings.Split(c.Request().Header.Get(echo.HeaderContentType), ";")[0])
+	if contentType != "application/merge-patch+json" && contentType != echo.MIMEApplicationJSON {
+		return c.JSON(http.StatusUnsupportedMediaType, map[string]string{
+			"error": "Content-Type must be application/merge-patch+json",
 		})
 	}
 
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.service.PatchTrade(c.Request().Context(), id, userID, body, version)
+	if err != nil {
+		if errors.Is(err, trade.ErrInvalidPatch) {
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return updateError(c, err)
+	}
+
+	setETag(c, result.Version)
 	return c.JSON(http.StatusOK, result)
 }
 
+// updateError maps the errors of a full or partial trade update to a response
+func updateError(c echo.Context, err error) error {
+	if errors.Is(err, sql.ErrNoRows) || errors.Is(err, tradedom.ErrNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Trade not found",
+		})
+	}
+	if errors.Is(err, tradedom.ErrVersionConflict) {
+		return preconditionError(c, err)
+	}
+	var validationErr *tradedom.ValidationError
+	if errors.As(err, &validationErr) {
+		return validationError(c, validationErr)
+	}
+	if errors.Is(err, trade.ErrAccountNotFound) {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	if errors.Is(err, trade.ErrFXRateNotFound) || errors.Is(err, tradedom.ErrInvalidStatusTransition) ||
+		errors.Is(err, tradedom.ErrMistakeTypeNotFound) || errors.Is(err, tradedom.ErrRatingDimensionNotFound) ||
+		errors.Is(err, tradedom.ErrStrategyRuleNotFound) || errors.Is(err, tradedom.ErrGradeNotFound) ||
+		errors.Is(err, tradedom.ErrStrategyNotFound) {
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+			"error": err.Error(),
+		})
+	}
+	c.Logger().Error(err)
+	return c.JSON(http.StatusInternalServerError, map[string]string{
+		"error": "Failed to update trade",
+	})
+}
+
 func (h *TradeHandler) DeleteTrade(c echo.Context) error {
 	userID := c.Get("user_id").(int64)
 	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -136,7 +321,20 @@ func (h *TradeHandler) DeleteTrade(c echo.Context) error {
 		})
 	}
 
-	if err := h.service.DeleteTrade(c.Request().Context(), id, userID); err != nil {
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
+	if err := h.service.DeleteTrade(c.Request().Context(), id, userID, version); err != nil {
+		if errors.Is(err, sql.ErrNoRows) || errors.Is(err, tradedom.ErrNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Trade not found",
+			})
+		}
+		if errors.Is(err, tradedom.ErrVersionConflict) {
+			return preconditionError(c, err)
+		}
 		return c.JSON(http.StatusInternalServerError, map[string]string{
 			"error": err.Error(),
 		})
@@ -145,6 +343,89 @@ func (h *TradeHandler) DeleteTrade(c echo.Context) error {
 	return c.NoContent(http.StatusNoContent)
 }
 
+// BulkTrades applies one action to a list of trades in a single transaction. When any trade
+// fails nothing is applied and the per-trade report comes back with 422.
+func (h *TradeHandler) BulkTrades(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req trade.BulkTradeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.service.BulkTrades(c.Request().Context(), userID, req)
+	if err != nil {
+		if errors.Is(err, trade.ErrBulkRejected) {
+			return c.JSON(http.StatusUnprocessableEntity, result)
+		}
+		var validationErr *tradedom.ValidationError
+		if errors.As(err, &validationErr) {
+			return validationError(c, validationErr)
+		}
+		if errors.Is(err, trade.ErrAccountNotFound) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *TradeHandler) RestoreTrade(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	result, err := h.service.RestoreTrade(c.Request().Context(), id, userID)
+	if err != nil {
+		if errors.Is(err, trade.ErrTradeNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": "Trade not found in trash",
+			})
+		}
+		if errors.Is(err, trade.ErrAccountDeleted) {
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	setETag(c, result.Version)
+	return c.JSON(http.StatusOK, result)
+}
+
+func (h *TradeHandler) GetTradeHistory(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	history, err := h.service.GetTradeHistory(c.Request().Context(), id, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, history)
+}
+
 func (h *TradeHandler) UploadChart(c echo.Context) error {
 	userID := c.Get("user_id").(int64)
 	tradeID, err := strconv.ParseInt(c.Param("id"), 10, 64)
@@ -220,3 +501,177 @@ func (h *TradeHandler) UploadChart(c echo.Context) error {
 		"message": fmt.Sprintf("Chart %s uploaded successfully", chartType),
 	})
 }
+
+func (h *TradeHandler) GetExecutions(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	executions, err := h.service.ListExecutions(c.Request().Context(), id, userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Trade not found",
+		})
+	}
+
+	return c.JSON(http.StatusOK, executions)
+}
+
+func (h *TradeHandler) AddExecution(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
+	var req trade.CreateExecutionRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.service.AddExecution(c.Request().Context(), id, userID, req, version)
+	if err != nil {
+		return executionError(c, err)
+	}
+
+	setETag(c, result.Version)
+	return c.JSON(http.StatusCreated, result)
+}
+
+func (h *TradeHandler) DeleteExecution(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid trade ID",
+		})
+	}
+
+	executionID, err := strconv.ParseInt(c.Param("executionId"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid execution ID",
+		})
+	}
+
+	version, err := ifMatchVersion(c, h.currentVersion(c, id, userID))
+	if err != nil {
+		return preconditionError(c, err)
+	}
+
+	result, err := h.service.DeleteExecution(c.Request().Context(), id, executionID, userID, version)
+	if err != nil {
+		return executionError(c, err)
+	}
+
+	setETag(c, result.Version)
+	return c.JSON(http.StatusOK, result)
+}
+
+// executionError maps errors from adding or removing a fill to a response
+func executionError(c echo.Context, err error) error {
+	var validationErr *tradedom.ValidationError
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": "Trade not found",
+		})
+	case errors.Is(err, tradedom.ErrExecutionNotFound):
+		return c.JSON(http.StatusNotFound, map[string]string{
+			"error": err.Error(),
+		})
+	case errors.Is(err, tradedom.ErrVersionConflict):
+		return preconditionError(c, err)
+	case errors.As(err, &validationErr):
+		return validationError(c, validationErr)
+	case errors.Is(err, trade.ErrExecutionsNotSupported), errors.Is(err, trade.ErrExecutionExceedsPosition),
+		errors.Is(err, trade.ErrExecutionWithoutEntry), errors.Is(err, trade.ErrFXRateNotFound),
+		errors.Is(err, tradedom.ErrInvalidStatusTransition):
+		return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+			"error": err.Error(),
+		})
+	default:
+		return c.JSON(http.StatusInternalServerError, map[string]string{
+			"error": err.Error(),
+		})
+	}
+}
+
+func (h *TradeHandler) CalculatePositionSize(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req trade.PositionSizeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{
+			"error": "Invalid request body",
+		})
+	}
+
+	result, err := h.service.CalculatePositionSize(c.Request().Context(), userID, req)
+	if err != nil {
+		switch {
+		case errors.Is(err, trade.ErrAccountNotFound):
+			return c.JSON(http.StatusNotFound, map[string]string{
+				"error": err.Error(),
+			})
+		case errors.Is(err, trade.ErrInvalidEntry), errors.Is(err, trade.ErrInvalidStopLoss), errors.Is(err, trade.ErrInvalidRisk):
+			return c.JSON(http.StatusBadRequest, map[string]string{
+				"error": err.Error(),
+			})
+		case errors.Is(err, trade.ErrPositionTooSmall), errors.Is(err, trade.ErrFXRateNotFound):
+			return c.JSON(http.StatusUnprocessableEntity, map[string]string{
+				"error": err.Error(),
+			})
+		default:
+			return c.JSON(http.StatusInternalServerError, map[string]string{
+				"error": err.Error(),
+			})
+		}
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// fieldError is a single invalid field in a validation error response
+type fieldError struct {
+	Field   string `json:"field"`
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
+// validationError responds with 422 and the invalid fields so forms can show them next to each input
+func validationError(c echo.Context, err *tradedom.ValidationError) error {
+	fields := make([]fieldError, len(err.Fields))
+	for i, f := range err.Fields {
+		fields[i] = fieldError{Field: f.Field, Code: f.Code, Message: f.Message}
+	}
+
+	return c.JSON(http.StatusUnprocessableEntity, map[string]any{
+		"error":  err.Error(),
+		"errors": fields,
+	})
+}
+
+// currentVersion looks up the version of a trade for an If-Match wildcard
+func (h *TradeHandler) currentVersion(c echo.Context, id int64, userID int64) func() (int64, error) {
+	return func() (int64, error) {
+		current, err := h.service.GetTrade(c.Request().Context(), id, userID)
+		if err != nil {
+			return 0, err
+		}
+		return current.Version, nil
+	}
+}
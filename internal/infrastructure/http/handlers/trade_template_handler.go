@@ -0,0 +1,127 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/tradetemplate"
+)
+
+// TradeTemplateHandler handles trade template HTTP requests
+type TradeTemplateHandler struct {
+	templateService *tradetemplate.Service
+}
+
+// NewTradeTemplateHandler creates a new trade template handler
+func NewTradeTemplateHandler(templateService *tradetemplate.Service) *TradeTemplateHandler {
+	return &TradeTemplateHandler{
+		templateService: templateService,
+	}
+}
+
+// CreateTradeTemplate handles trade template creation requests
+func (h *TradeTemplateHandler) CreateTradeTemplate(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	var req tradetemplate.CreateTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.templateService.CreateTemplate(c.Request().Context(), userID, req)
+	if err != nil {
+		if err == tradetemplate.ErrInvalidTemplate || errors.Is(err, tradetemplate.ErrInvalidDefaults) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if err == tradetemplate.ErrTemplateExists {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to create trade template"})
+	}
+
+	return c.JSON(http.StatusCreated, result)
+}
+
+// GetTradeTemplates handles fetching the trade templates of a user
+func (h *TradeTemplateHandler) GetTradeTemplates(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	templates, err := h.templateService.GetUserTemplates(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch trade templates"})
+	}
+
+	return c.JSON(http.StatusOK, templates)
+}
+
+// GetTradeTemplate handles fetching a single trade template
+func (h *TradeTemplateHandler) GetTradeTemplate(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid trade template ID"})
+	}
+
+	result, err := h.templateService.GetTemplate(c.Request().Context(), id, userID)
+	if err != nil {
+		if err == tradetemplate.ErrTemplateNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Trade template not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch trade template"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// UpdateTradeTemplate handles trade template update requests
+func (h *TradeTemplateHandler) UpdateTradeTemplate(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid trade template ID"})
+	}
+
+	var req tradetemplate.UpdateTemplateRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid request"})
+	}
+
+	result, err := h.templateService.UpdateTemplate(c.Request().Context(), id, userID, req)
+	if err != nil {
+		if err == tradetemplate.ErrTemplateNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Trade template not found"})
+		}
+		if err == tradetemplate.ErrInvalidTemplate || errors.Is(err, tradetemplate.ErrInvalidDefaults) {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		}
+		if err == tradetemplate.ErrTemplateExists {
+			return c.JSON(http.StatusConflict, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to update trade template"})
+	}
+
+	return c.JSON(http.StatusOK, result)
+}
+
+// DeleteTradeTemplate handles trade template deletion requests
+func (h *TradeTemplateHandler) DeleteTradeTemplate(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	id, err := strconv.ParseInt(c.Param("id"), 10, 64)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "Invalid trade template ID"})
+	}
+
+	if err := h.templateService.DeleteTemplate(c.Request().Context(), id, userID); err != nil {
+		if err == tradetemplate.ErrTemplateNotFound {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": "Trade template not found"})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to delete trade template"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Trade template deleted successfully"})
+}
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/raihanstark/trade-journal/internal/application/account"
+	"github.com/raihanstark/trade-journal/internal/application/trade"
+)
+
+// TrashHandler handles HTTP requests for deleted trades and accounts
+type TrashHandler struct {
+	tradeService   *trade.Service
+	accountService *account.Service
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(tradeService *trade.Service, accountService *account.Service) *TrashHandler {
+	return &TrashHandler{
+		tradeService:   tradeService,
+		accountService: accountService,
+	}
+}
+
+// GetTrash handles listing the trades and accounts in the user's trash
+func (h *TrashHandler) GetTrash(c echo.Context) error {
+	userID := c.Get("user_id").(int64)
+
+	trades, err := h.tradeService.GetDeletedTrades(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch trash"})
+	}
+
+	accounts, err := h.accountService.GetDeletedAccounts(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": "Failed to fetch trash"})
+	}
+
+	return c.JSON(http.StatusOK, map[string]any{
+		"trades":   trades,
+		"accounts": accounts,
+	})
+}
@@ -2,6 +2,9 @@ package persistence
 
 import (
 	"context"
+	"database/sql"
+	"errors"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 	"github.com/raihanstark/trade-journal/internal/domain/account"
@@ -46,6 +49,7 @@ func (r *AccountRepository) Create(ctx context.Context, acc *account.Account) (*
 		IsActive:       result.IsActive,
 		CreatedAt:      result.CreatedAt.Time,
 		UpdatedAt:      result.UpdatedAt.Time,
+		Version:        int64(result.Version),
 	}, nil
 }
 
@@ -71,6 +75,7 @@ func (r *AccountRepository) GetByID(ctx context.Context, id int64, userID int64)
 		IsActive:       result.IsActive,
 		CreatedAt:      result.CreatedAt.Time,
 		UpdatedAt:      result.UpdatedAt.Time,
+		Version:        int64(result.Version),
 	}, nil
 }
 
@@ -95,6 +100,7 @@ func (r *AccountRepository) GetByUserID(ctx context.Context, userID int64) ([]*a
 			IsActive:       result.IsActive,
 			CreatedAt:      result.CreatedAt.Time,
 			UpdatedAt:      result.UpdatedAt.Time,
+			Version:        int64(result.Version),
 		}
 	}
 
@@ -112,8 +118,12 @@ func (r *AccountRepository) Update(ctx context.Context, acc *account.Account) (*
 		AccountType:   string(acc.AccountType),
 		Currency:      acc.Currency,
 		IsActive:      acc.IsActive,
+		Version:       int32(acc.Version),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.missErr(ctx, acc.ID, acc.UserID)
+		}
 		return nil, err
 	}
 
@@ -129,6 +139,7 @@ func (r *AccountRepository) Update(ctx context.Context, acc *account.Account) (*
 		IsActive:       result.IsActive,
 		CreatedAt:      result.CreatedAt.Time,
 		UpdatedAt:      result.UpdatedAt.Time,
+		Version:        int64(result.Version),
 	}, nil
 }
 
@@ -155,13 +166,115 @@ func (r *AccountRepository) UpdateBalance(ctx context.Context, id int64, userID
 		IsActive:       result.IsActive,
 		CreatedAt:      result.CreatedAt.Time,
 		UpdatedAt:      result.UpdatedAt.Time,
+		Version:        int64(result.Version),
+	}, nil
+}
+
+// Delete moves an account to the trash along with its trades. The trades keep their link to the
+// account and share its deletion time, so restoring the account brings them back.
+func (r *AccountRepository) Delete(ctx context.Context, id int64, userID int64, version int64) error {
+	rowsAffected, err := r.queries.SoftDeleteAccount(ctx, db.SoftDeleteAccountParams{
+		ID:      int32(id),
+		UserID:  int32(userID),
+		Version: int32(version),
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return r.missErr(ctx, id, userID)
+	}
+
+	return r.queries.SoftDeleteAccountTrades(ctx, db.SoftDeleteAccountTradesParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+}
+
+// GetDeletedByUserID retrieves the accounts in the user's trash, most recently deleted first
+func (r *AccountRepository) GetDeletedByUserID(ctx context.Context, userID int64) ([]*account.Account, error) {
+	results, err := r.queries.GetDeletedAccountsByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	accounts := make([]*account.Account, len(results))
+	for i, result := range results {
+		deletedAt := result.DeletedAt.Time
+		accounts[i] = &account.Account{
+			ID:             int64(result.ID),
+			UserID:         int64(result.UserID),
+			Name:           result.Name,
+			Broker:         result.Broker,
+			AccountNumber:  result.AccountNumber,
+			AccountType:    account.AccountType(result.AccountType),
+			Currency:       result.Currency,
+			CurrentBalance: parseFloat(result.CurrentBalance.String),
+			IsActive:       result.IsActive,
+			CreatedAt:      result.CreatedAt.Time,
+			UpdatedAt:      result.UpdatedAt.Time,
+			Version:        int64(result.Version),
+			DeletedAt:      &deletedAt,
+		}
+	}
+
+	return accounts, nil
+}
+
+// Restore takes an account out of the trash along with the trades deleted with it
+func (r *AccountRepository) Restore(ctx context.Context, id int64, userID int64) (*account.Account, error) {
+	// Trades are matched on the account's deletion time, so restore them first
+	err := r.queries.RestoreAccountTrades(ctx, db.RestoreAccountTradesParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.RestoreAccount(ctx, db.RestoreAccountParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, account.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return &account.Account{
+		ID:             int64(result.ID),
+		UserID:         int64(result.UserID),
+		Name:           result.Name,
+		Broker:         result.Broker,
+		AccountNumber:  result.AccountNumber,
+		AccountType:    account.AccountType(result.AccountType),
+		Currency:       result.Currency,
+		CurrentBalance: parseFloat(result.CurrentBalance.String),
+		IsActive:       result.IsActive,
+		CreatedAt:      result.CreatedAt.Time,
+		UpdatedAt:      result.UpdatedAt.Time,
+		Version:        int64(result.Version),
 	}, nil
 }
 
-// Delete deletes an account
-func (r *AccountRepository) Delete(ctx context.Context, id int64, userID int64) error {
-	return r.queries.DeleteAccount(ctx, db.DeleteAccountParams{
+// PurgeDeleted permanently removes accounts moved to the trash before the given instant
+func (r *AccountRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.PurgeDeletedAccounts(ctx, sql.NullTime{Time: before, Valid: true})
+}
+
+// missErr explains why a versioned write matched no row: the account is gone, or it moved past the expected version
+func (r *AccountRepository) missErr(ctx context.Context, id int64, userID int64) error {
+	_, err := r.queries.GetAccountByID(ctx, db.GetAccountByIDParams{
 		ID:     int32(id),
 		UserID: int32(userID),
 	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return account.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return account.ErrVersionConflict
 }
@@ -2,6 +2,7 @@ package persistence
 
 import (
 	"context"
+	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 )
@@ -22,3 +23,41 @@ func (r *AnalyticsRepository) GetUserTrades(ctx context.Context, userID int64) (
 	}
 	return trades, nil
 }
+
+// GetUserTradeTags returns the tag names on each of a user's trades
+func (r *AnalyticsRepository) GetUserTradeTags(ctx context.Context, userID int64) ([]db.GetTradeTagsByUserIDRow, error) {
+	return r.queries.GetTradeTagsByUserID(ctx, int32(userID))
+}
+
+// GetUserTradeMistakes returns the mistake type names on each of a user's trades
+func (r *AnalyticsRepository) GetUserTradeMistakes(ctx context.Context, userID int64) ([]db.GetTradeMistakesByUserIDRow, error) {
+	return r.queries.GetTradeMistakesByUserID(ctx, int32(userID))
+}
+
+// GetUserTradeRatings returns the psychology ratings on each of a user's trades
+func (r *AnalyticsRepository) GetUserTradeRatings(ctx context.Context, userID int64) ([]db.GetTradeRatingsByUserIDRow, error) {
+	return r.queries.GetTradeRatingsByUserID(ctx, int32(userID))
+}
+
+// GetUserTradeRuleAdherence returns how many strategy rules each of a user's trades had and satisfied
+func (r *AnalyticsRepository) GetUserTradeRuleAdherence(ctx context.Context, userID int64) ([]db.GetTradeRuleAdherenceByUserIDRow, error) {
+	return r.queries.GetTradeRuleAdherenceByUserID(ctx, int32(userID))
+}
+
+// GetUserGrades returns the user's grade scale, best grade first
+func (r *AnalyticsRepository) GetUserGrades(ctx context.Context, userID int64) ([]db.Grade, error) {
+	return r.queries.GetGradesByUserID(ctx, int32(userID))
+}
+
+// GetUserTradesByDateRange returns trades opened in [start, end) for a user (raw data only)
+func (r *AnalyticsRepository) GetUserTradesByDateRange(ctx context.Context, userID int64, start, end time.Time) ([]db.Trade, error) {
+	trades, err := r.queries.GetTradesByUserIDAndDateRange(ctx, db.GetTradesByUserIDAndDateRangeParams{
+		UserID:     int32(userID),
+		OpenedAt:   start,
+		OpenedAt_2: end,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return trades, nil
+}
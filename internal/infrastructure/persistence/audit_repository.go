@@ -0,0 +1,77 @@
+package persistence
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/audit"
+)
+
+// AuditRepository implements audit.Repository using sqlc
+type AuditRepository struct {
+	queries *db.Queries
+}
+
+// NewAuditRepository creates a new audit repository
+func NewAuditRepository(queries *db.Queries) *AuditRepository {
+	return &AuditRepository{
+		queries: queries,
+	}
+}
+
+// Record stores an audit event with its field changes as JSON
+func (r *AuditRepository) Record(ctx context.Context, event *audit.Event) error {
+	changes, err := json.Marshal(event.Changes)
+	if err != nil {
+		return err
+	}
+
+	return r.queries.CreateAuditEvent(ctx, db.CreateAuditEventParams{
+		UserID:     int32(event.UserID),
+		EntityType: db.AuditEntityType(event.EntityType),
+		EntityID:   int32(event.EntityID),
+		Action:     db.AuditAction(event.Action),
+		Changes:    changes,
+	})
+}
+
+// GetByEntity retrieves the events of one entity, oldest first
+func (r *AuditRepository) GetByEntity(ctx context.Context, userID int64, entityType audit.EntityType, entityID int64) ([]*audit.Event, error) {
+	results, err := r.queries.GetAuditEventsByEntity(ctx, db.GetAuditEventsByEntityParams{
+		UserID:     int32(userID),
+		EntityType: db.AuditEntityType(entityType),
+		EntityID:   int32(entityID),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	events := make([]*audit.Event, len(results))
+	for i, result := range results {
+		event, err := r.toDomain(&result)
+		if err != nil {
+			return nil, err
+		}
+		events[i] = event
+	}
+	return events, nil
+}
+
+// toDomain converts a database audit event to a domain event
+func (r *AuditRepository) toDomain(e *db.AuditEvent) (*audit.Event, error) {
+	var changes map[string]audit.Change
+	if err := json.Unmarshal(e.Changes, &changes); err != nil {
+		return nil, err
+	}
+
+	return &audit.Event{
+		ID:         int64(e.ID),
+		UserID:     int64(e.UserID),
+		EntityType: audit.EntityType(e.EntityType),
+		EntityID:   int64(e.EntityID),
+		Action:     audit.Action(e.Action),
+		Changes:    changes,
+		CreatedAt:  e.CreatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,107 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"time"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/fx"
+)
+
+// FXRateRepository implements fx.Repository using sqlc
+type FXRateRepository struct {
+	queries *db.Queries
+}
+
+// NewFXRateRepository creates a new FX rate repository
+func NewFXRateRepository(queries *db.Queries) *FXRateRepository {
+	return &FXRateRepository{
+		queries: queries,
+	}
+}
+
+// Upsert creates or replaces the rate for a pair on a given date
+func (r *FXRateRepository) Upsert(ctx context.Context, rate *fx.Rate) (*fx.Rate, error) {
+	result, err := r.queries.UpsertFXRate(ctx, db.UpsertFXRateParams{
+		UserID:        int32(rate.UserID),
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		RateDate:      rate.Date,
+		Rate:          formatFloat(rate.Rate),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetOnOrBefore retrieves the latest rate for a pair that is not after the given date
+func (r *FXRateRepository) GetOnOrBefore(ctx context.Context, userID int64, base, quote string, date time.Time) (*fx.Rate, error) {
+	result, err := r.queries.GetFXRateOnOrBefore(ctx, db.GetFXRateOnOrBeforeParams{
+		UserID:        int32(userID),
+		BaseCurrency:  base,
+		QuoteCurrency: quote,
+		RateDate:      date,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, fx.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByUserID retrieves all rates for a user
+func (r *FXRateRepository) GetByUserID(ctx context.Context, userID int64) ([]*fx.Rate, error) {
+	results, err := r.queries.GetFXRatesByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	rates := make([]*fx.Rate, len(results))
+	for i, result := range results {
+		rates[i] = r.toDomain(&result)
+	}
+
+	return rates, nil
+}
+
+// Delete deletes a rate
+func (r *FXRateRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.queries.DeleteFXRate(ctx, db.DeleteFXRateParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return fx.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *FXRateRepository) toDomain(rate *db.FxRate) *fx.Rate {
+	return &fx.Rate{
+		ID:            int64(rate.ID),
+		UserID:        int64(rate.UserID),
+		BaseCurrency:  rate.BaseCurrency,
+		QuoteCurrency: rate.QuoteCurrency,
+		Date:          rate.RateDate,
+		Rate:          parseFloat(rate.Rate),
+		CreatedAt:     rate.CreatedAt.Time,
+		UpdatedAt:     rate.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,84 @@
+package persistence
+
+import (
+	"context"
+	"slices"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/grade"
+)
+
+// GradeRepository implements grade.Repository using sqlc
+type GradeRepository struct {
+	queries *db.Queries
+}
+
+// NewGradeRepository creates a new grade repository
+func NewGradeRepository(queries *db.Queries) *GradeRepository {
+	return &GradeRepository{
+		queries: queries,
+	}
+}
+
+// GetByUserID retrieves the user's grades, best first
+func (r *GradeRepository) GetByUserID(ctx context.Context, userID int64) ([]*grade.Grade, error) {
+	results, err := r.queries.GetGradesByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	grades := make([]*grade.Grade, len(results))
+	for i, result := range results {
+		grades[i] = r.toDomain(&result)
+	}
+	return grades, nil
+}
+
+// ReplaceScale stores names as the user's scale and ungrades the trades carrying a dropped grade
+func (r *GradeRepository) ReplaceScale(ctx context.Context, userID int64, names []string) ([]*grade.Grade, error) {
+	existing, err := r.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, name := range grade.Scale(existing) {
+		if slices.Contains(names, name) {
+			continue
+		}
+		err := r.queries.ClearTradeGrade(ctx, db.ClearTradeGradeParams{
+			UserID: int32(userID),
+			Grade:  db.StringToNullString(name),
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if err := r.queries.DeleteGradesByUserID(ctx, int32(userID)); err != nil {
+		return nil, err
+	}
+
+	grades := make([]*grade.Grade, len(names))
+	for i, name := range names {
+		result, err := r.queries.CreateGrade(ctx, db.CreateGradeParams{
+			UserID:   int32(userID),
+			Name:     name,
+			Position: int32(i + 1),
+		})
+		if err != nil {
+			return nil, err
+		}
+		grades[i] = r.toDomain(&result)
+	}
+	return grades, nil
+}
+
+func (r *GradeRepository) toDomain(g *db.Grade) *grade.Grade {
+	return &grade.Grade{
+		ID:        int64(g.ID),
+		UserID:    int64(g.UserID),
+		Name:      g.Name,
+		Position:  int(g.Position),
+		CreatedAt: g.CreatedAt.Time,
+	}
+}
@@ -0,0 +1,152 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/instrument"
+)
+
+// InstrumentRepository implements instrument.Repository using sqlc
+type InstrumentRepository struct {
+	queries *db.Queries
+}
+
+// NewInstrumentRepository creates a new instrument repository
+func NewInstrumentRepository(queries *db.Queries) *InstrumentRepository {
+	return &InstrumentRepository{
+		queries: queries,
+	}
+}
+
+// Create creates a new user-owned instrument
+func (r *InstrumentRepository) Create(ctx context.Context, i *instrument.Instrument) (*instrument.Instrument, error) {
+	result, err := r.queries.CreateInstrument(ctx, db.CreateInstrumentParams{
+		UserID:        int32ToNullInt32(i.UserID),
+		Symbol:        i.Symbol,
+		Description:   db.StringToNullString(i.Description),
+		AssetClass:    string(i.AssetClass),
+		PipSize:       formatFloat(i.PipSize),
+		TickSize:      formatFloat(i.TickSize),
+		ContractSize:  formatFloat(i.ContractSize),
+		QuoteCurrency: i.QuoteCurrency,
+		Digits:        int32(i.Digits),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByID retrieves a built-in or user-owned instrument by ID
+func (r *InstrumentRepository) GetByID(ctx context.Context, id int64, userID int64) (*instrument.Instrument, error) {
+	result, err := r.queries.GetInstrumentByID(ctx, db.GetInstrumentByIDParams{
+		ID:     int32(id),
+		UserID: sql.NullInt32{Int32: int32(userID), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, instrument.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetBySymbol retrieves the instrument for a symbol, preferring the user's own override
+func (r *InstrumentRepository) GetBySymbol(ctx context.Context, userID int64, symbol string) (*instrument.Instrument, error) {
+	result, err := r.queries.GetInstrumentBySymbol(ctx, db.GetInstrumentBySymbolParams{
+		Symbol: symbol,
+		UserID: sql.NullInt32{Int32: int32(userID), Valid: true},
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, instrument.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByUserID retrieves every instrument visible to a user, one per symbol
+func (r *InstrumentRepository) GetByUserID(ctx context.Context, userID int64) ([]*instrument.Instrument, error) {
+	results, err := r.queries.GetInstrumentsByUserID(ctx, sql.NullInt32{Int32: int32(userID), Valid: true})
+	if err != nil {
+		return nil, err
+	}
+
+	instruments := make([]*instrument.Instrument, len(results))
+	for i, result := range results {
+		instruments[i] = r.toDomain(&result)
+	}
+
+	return instruments, nil
+}
+
+// Update updates a user-owned instrument
+func (r *InstrumentRepository) Update(ctx context.Context, i *instrument.Instrument) (*instrument.Instrument, error) {
+	result, err := r.queries.UpdateInstrument(ctx, db.UpdateInstrumentParams{
+		ID:            int32(i.ID),
+		UserID:        int32ToNullInt32(i.UserID),
+		Symbol:        i.Symbol,
+		Description:   db.StringToNullString(i.Description),
+		AssetClass:    string(i.AssetClass),
+		PipSize:       formatFloat(i.PipSize),
+		TickSize:      formatFloat(i.TickSize),
+		ContractSize:  formatFloat(i.ContractSize),
+		QuoteCurrency: i.QuoteCurrency,
+		Digits:        int32(i.Digits),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, instrument.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// Delete deletes a user-owned instrument
+func (r *InstrumentRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.queries.DeleteInstrument(ctx, db.DeleteInstrumentParams{
+		ID:     int32(id),
+		UserID: sql.NullInt32{Int32: int32(userID), Valid: true},
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return instrument.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *InstrumentRepository) toDomain(i *db.Instrument) *instrument.Instrument {
+	return &instrument.Instrument{
+		ID:            int64(i.ID),
+		UserID:        nullInt32ToInt64Ptr(i.UserID),
+		Symbol:        i.Symbol,
+		Description:   db.NullStringToString(i.Description),
+		AssetClass:    instrument.AssetClass(i.AssetClass),
+		PipSize:       parseFloat(i.PipSize),
+		TickSize:      parseFloat(i.TickSize),
+		ContractSize:  parseFloat(i.ContractSize),
+		QuoteCurrency: i.QuoteCurrency,
+		Digits:        int(i.Digits),
+		CreatedAt:     i.CreatedAt.Time,
+		UpdatedAt:     i.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,137 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/mistake"
+)
+
+// MistakeRepository implements mistake.Repository using sqlc
+type MistakeRepository struct {
+	queries *db.Queries
+}
+
+// NewMistakeRepository creates a new mistake type repository
+func NewMistakeRepository(queries *db.Queries) *MistakeRepository {
+	return &MistakeRepository{
+		queries: queries,
+	}
+}
+
+// Create creates a new mistake type in the user's catalogue
+func (r *MistakeRepository) Create(ctx context.Context, m *mistake.MistakeType) (*mistake.MistakeType, error) {
+	result, err := r.queries.CreateMistakeType(ctx, db.CreateMistakeTypeParams{
+		UserID:      int32(m.UserID),
+		Name:        m.Name,
+		Description: db.StringToNullString(m.Description),
+		Severity:    db.MistakeSeverity(m.Severity),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByID retrieves a mistake type by ID
+func (r *MistakeRepository) GetByID(ctx context.Context, id int64, userID int64) (*mistake.MistakeType, error) {
+	result, err := r.queries.GetMistakeTypeByID(ctx, db.GetMistakeTypeByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, mistake.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByName retrieves a user's mistake type by its name
+func (r *MistakeRepository) GetByName(ctx context.Context, userID int64, name string) (*mistake.MistakeType, error) {
+	result, err := r.queries.GetMistakeTypeByName(ctx, db.GetMistakeTypeByNameParams{
+		UserID: int32(userID),
+		Name:   name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, mistake.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByUserID retrieves the user's whole catalogue, in name order
+func (r *MistakeRepository) GetByUserID(ctx context.Context, userID int64) ([]*mistake.MistakeType, error) {
+	results, err := r.queries.GetMistakeTypesByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	mistakeTypes := make([]*mistake.MistakeType, len(results))
+	for i, result := range results {
+		mistakeTypes[i] = r.toDomain(&result)
+	}
+
+	return mistakeTypes, nil
+}
+
+// Update updates an existing mistake type
+func (r *MistakeRepository) Update(ctx context.Context, m *mistake.MistakeType) (*mistake.MistakeType, error) {
+	result, err := r.queries.UpdateMistakeType(ctx, db.UpdateMistakeTypeParams{
+		ID:          int32(m.ID),
+		Name:        m.Name,
+		Description: db.StringToNullString(m.Description),
+		Severity:    db.MistakeSeverity(m.Severity),
+		UserID:      int32(m.UserID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, mistake.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// Delete deletes a mistake type, unlinking it from every trade
+func (r *MistakeRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.queries.DeleteMistakeType(ctx, db.DeleteMistakeTypeParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return mistake.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *MistakeRepository) toDomain(m *db.MistakeType) *mistake.MistakeType {
+	return &mistake.MistakeType{
+		ID:          int64(m.ID),
+		UserID:      int64(m.UserID),
+		Name:        m.Name,
+		Description: db.NullStringToString(m.Description),
+		Severity:    mistake.Severity(m.Severity),
+		CreatedAt:   m.CreatedAt.Time,
+		UpdatedAt:   m.UpdatedAt.Time,
+	}
+}
@@ -0,0 +1,134 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/rating"
+)
+
+// RatingRepository implements rating.Repository using sqlc
+type RatingRepository struct {
+	queries *db.Queries
+}
+
+// NewRatingRepository creates a new rating dimension repository
+func NewRatingRepository(queries *db.Queries) *RatingRepository {
+	return &RatingRepository{
+		queries: queries,
+	}
+}
+
+// Create creates a new rating dimension for the user
+func (r *RatingRepository) Create(ctx context.Context, d *rating.Dimension) (*rating.Dimension, error) {
+	result, err := r.queries.CreateRatingDimension(ctx, db.CreateRatingDimensionParams{
+		UserID:      int32(d.UserID),
+		Name:        d.Name,
+		Description: db.StringToNullString(d.Description),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByID retrieves a rating dimension by ID
+func (r *RatingRepository) GetByID(ctx context.Context, id int64, userID int64) (*rating.Dimension, error) {
+	result, err := r.queries.GetRatingDimensionByID(ctx, db.GetRatingDimensionByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, rating.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByName retrieves a user's rating dimension by its name
+func (r *RatingRepository) GetByName(ctx context.Context, userID int64, name string) (*rating.Dimension, error) {
+	result, err := r.queries.GetRatingDimensionByName(ctx, db.GetRatingDimensionByNameParams{
+		UserID: int32(userID),
+		Name:   name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, rating.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByUserID retrieves the user's rating dimensions, in name order
+func (r *RatingRepository) GetByUserID(ctx context.Context, userID int64) ([]*rating.Dimension, error) {
+	results, err := r.queries.GetRatingDimensionsByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	dimensions := make([]*rating.Dimension, len(results))
+	for i, result := range results {
+		dimensions[i] = r.toDomain(&result)
+	}
+
+	return dimensions, nil
+}
+
+// Update updates an existing rating dimension
+func (r *RatingRepository) Update(ctx context.Context, d *rating.Dimension) (*rating.Dimension, error) {
+	result, err := r.queries.UpdateRatingDimension(ctx, db.UpdateRatingDimensionParams{
+		ID:          int32(d.ID),
+		Name:        d.Name,
+		Description: db.StringToNullString(d.Description),
+		UserID:      int32(d.UserID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, rating.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// Delete deletes a rating dimension together with the ratings given on it
+func (r *RatingRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.queries.DeleteRatingDimension(ctx, db.DeleteRatingDimensionParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return rating.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *RatingRepository) toDomain(d *db.RatingDimension) *rating.Dimension {
+	return &rating.Dimension{
+		ID:          int64(d.ID),
+		UserID:      int64(d.UserID),
+		Name:        d.Name,
+		Description: db.NullStringToString(d.Description),
+		CreatedAt:   d.CreatedAt.Time,
+		UpdatedAt:   d.UpdatedAt.Time,
+	}
+}
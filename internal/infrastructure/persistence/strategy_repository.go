@@ -2,6 +2,8 @@ package persistence
 
 import (
 	"context"
+	"database/sql"
+	"errors"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 	"github.com/raihanstark/trade-journal/internal/domain/strategy"
@@ -30,14 +32,20 @@ func (r *StrategyRepository) Create(ctx context.Context, s *strategy.Strategy) (
 		return nil, err
 	}
 
-	return &strategy.Strategy{
-		ID:          int64(result.ID),
-		UserID:      int64(result.UserID),
-		Name:        result.Name,
-		Description: db.NullStringToString(result.Description),
-		CreatedAt:   result.CreatedAt.Time,
-		UpdatedAt:   result.UpdatedAt.Time,
-	}, nil
+	created := r.toDomain(&result)
+	for i, rule := range s.Rules {
+		stored, err := r.queries.CreateStrategyRule(ctx, db.CreateStrategyRuleParams{
+			StrategyID:  result.ID,
+			Position:    int32(i + 1),
+			Description: rule.Description,
+		})
+		if err != nil {
+			return nil, err
+		}
+		created.Rules = append(created.Rules, ruleToDomain(stored))
+	}
+
+	return created, nil
 }
 
 // GetByID retrieves a strategy by ID
@@ -50,14 +58,7 @@ func (r *StrategyRepository) GetByID(ctx context.Context, id int64, userID int64
 		return nil, err
 	}
 
-	return &strategy.Strategy{
-		ID:          int64(result.ID),
-		UserID:      int64(result.UserID),
-		Name:        result.Name,
-		Description: db.NullStringToString(result.Description),
-		CreatedAt:   result.CreatedAt.Time,
-		UpdatedAt:   result.UpdatedAt.Time,
-	}, nil
+	return r.withRules(ctx, result)
 }
 
 // GetByUserID retrieves all strategies for a user
@@ -69,46 +70,94 @@ func (r *StrategyRepository) GetByUserID(ctx context.Context, userID int64) ([]*
 
 	strategies := make([]*strategy.Strategy, len(results))
 	for i, result := range results {
-		strategies[i] = &strategy.Strategy{
-			ID:          int64(result.ID),
-			UserID:      int64(result.UserID),
-			Name:        result.Name,
-			Description: db.NullStringToString(result.Description),
-			CreatedAt:   result.CreatedAt.Time,
-			UpdatedAt:   result.UpdatedAt.Time,
+		strategies[i], err = r.withRules(ctx, result)
+		if err != nil {
+			return nil, err
 		}
 	}
 
 	return strategies, nil
 }
 
-// Update updates an existing strategy
+// Update updates an existing strategy and syncs its rules: rules with an ID are renumbered
+// and reworded, rules without one are added and stored rules left out are removed
 func (r *StrategyRepository) Update(ctx context.Context, s *strategy.Strategy) (*strategy.Strategy, error) {
+	existing, err := r.queries.GetStrategyRules(ctx, int32(s.ID))
+	if err != nil {
+		return nil, err
+	}
+
+	kept := make(map[int64]bool, len(s.Rules))
+	for _, rule := range s.Rules {
+		if rule.ID != 0 {
+			kept[rule.ID] = true
+		}
+	}
+	stale := make([]int32, 0, len(existing))
+	for _, rule := range existing {
+		if kept[int64(rule.ID)] {
+			delete(kept, int64(rule.ID))
+		} else {
+			stale = append(stale, rule.ID)
+		}
+	}
+	if len(kept) > 0 {
+		return nil, strategy.ErrRuleNotFound
+	}
+
 	result, err := r.queries.UpdateStrategy(ctx, db.UpdateStrategyParams{
 		ID:          int32(s.ID),
 		Name:        s.Name,
 		Description: db.StringToNullString(s.Description),
 		UserID:      int32(s.UserID),
+		Version:     int32(s.Version),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.missErr(ctx, s.ID, s.UserID)
+		}
 		return nil, err
 	}
 
-	return &strategy.Strategy{
-		ID:          int64(result.ID),
-		UserID:      int64(result.UserID),
-		Name:        result.Name,
-		Description: db.NullStringToString(result.Description),
-		CreatedAt:   result.CreatedAt.Time,
-		UpdatedAt:   result.UpdatedAt.Time,
-	}, nil
+	for _, id := range stale {
+		err := r.queries.DeleteStrategyRule(ctx, db.DeleteStrategyRuleParams{
+			ID:         id,
+			StrategyID: result.ID,
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	for i, rule := range s.Rules {
+		if rule.ID == 0 {
+			_, err = r.queries.CreateStrategyRule(ctx, db.CreateStrategyRuleParams{
+				StrategyID:  result.ID,
+				Position:    int32(i + 1),
+				Description: rule.Description,
+			})
+		} else {
+			_, err = r.queries.UpdateStrategyRule(ctx, db.UpdateStrategyRuleParams{
+				ID:          int32(rule.ID),
+				Position:    int32(i + 1),
+				Description: rule.Description,
+				StrategyID:  result.ID,
+			})
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return r.withRules(ctx, result)
 }
 
-// Delete deletes a strategy
-func (r *StrategyRepository) Delete(ctx context.Context, id int64, userID int64) error {
+// Delete deletes a strategy, provided it is still at version
+func (r *StrategyRepository) Delete(ctx context.Context, id int64, userID int64, version int64) error {
 	result, err := r.queries.DeleteStrategy(ctx, db.DeleteStrategyParams{
-		ID:     int32(id),
-		UserID: int32(userID),
+		ID:      int32(id),
+		UserID:  int32(userID),
+		Version: int32(version),
 	})
 	if err != nil {
 		return err
@@ -120,8 +169,57 @@ func (r *StrategyRepository) Delete(ctx context.Context, id int64, userID int64)
 	}
 
 	if rowsAffected == 0 {
-		return strategy.ErrNotFound
+		return r.missErr(ctx, id, userID)
 	}
 
 	return nil
 }
+
+// missErr explains why a versioned write matched no row: the strategy is gone, or it moved past the expected version
+func (r *StrategyRepository) missErr(ctx context.Context, id int64, userID int64) error {
+	_, err := r.queries.GetStrategyByID(ctx, db.GetStrategyByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return strategy.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return strategy.ErrVersionConflict
+}
+
+// withRules maps a strategy row to the domain together with its rules
+func (r *StrategyRepository) withRules(ctx context.Context, result db.Strategy) (*strategy.Strategy, error) {
+	rules, err := r.queries.GetStrategyRules(ctx, result.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	s := r.toDomain(&result)
+	for _, rule := range rules {
+		s.Rules = append(s.Rules, ruleToDomain(rule))
+	}
+	return s, nil
+}
+
+func (r *StrategyRepository) toDomain(result *db.Strategy) *strategy.Strategy {
+	return &strategy.Strategy{
+		ID:          int64(result.ID),
+		UserID:      int64(result.UserID),
+		Name:        result.Name,
+		Description: db.NullStringToString(result.Description),
+		CreatedAt:   result.CreatedAt.Time,
+		UpdatedAt:   result.UpdatedAt.Time,
+		Version:     int64(result.Version),
+	}
+}
+
+func ruleToDomain(rule db.StrategyRule) strategy.Rule {
+	return strategy.Rule{
+		ID:          int64(rule.ID),
+		Position:    int(rule.Position),
+		Description: rule.Description,
+	}
+}
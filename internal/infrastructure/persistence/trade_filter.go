@@ -0,0 +1,351 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/base64"
+	"encoding/json"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+)
+
+// orderColumn is a column a listing is ordered by, with how to read its value off a row
+// so the next page can start right after that row
+type orderColumn struct {
+	expr       string
+	descending bool
+	value      func(t *db.Trade) *string
+}
+
+// sortColumns maps each sort key to the columns it orders by
+var sortColumns = map[trade.SortKey][]orderColumn{
+	trade.SortByDate: {
+		{expr: "trades.date", value: func(t *db.Trade) *string { return formatDate(t) }},
+		{expr: "trades.time", value: func(t *db.Trade) *string { return formatTime(t) }},
+	},
+	trade.SortByPair:   {{expr: "trades.pair", value: func(t *db.Trade) *string { return nullString(t.Pair) }}},
+	trade.SortByType:   {{expr: "trades.type", value: func(t *db.Trade) *string { v := string(t.Type); return &v }}},
+	trade.SortByStatus: {{expr: "trades.status", value: func(t *db.Trade) *string { v := string(t.Status); return &v }}},
+	trade.SortByLots:   {{expr: "trades.lots", value: func(t *db.Trade) *string { return nullString(t.Lots) }}},
+	trade.SortByPips:   {{expr: "trades.pips", value: func(t *db.Trade) *string { return nullString(t.Pips) }}},
+	trade.SortByPL:     {{expr: netPL, value: func(t *db.Trade) *string { return nullString(settledPL(t)) }}},
+	trade.SortByR:      {{expr: "trades.realized_r", value: func(t *db.Trade) *string { return nullString(t.RealizedR) }}},
+}
+
+// netPL orders and bounds trades on the P/L booked to the balance, as trade.SettledPL reads it
+const netPL = "COALESCE(trades.net_pl, trades.pl)"
+
+// tiebreakColumns end every ordering so that no two trades tie and pages stay stable
+var tiebreakColumns = []orderColumn{
+	{expr: "trades.date", descending: true, value: func(t *db.Trade) *string { return formatDate(t) }},
+	{expr: "trades.time", descending: true, value: func(t *db.Trade) *string { return formatTime(t) }},
+	{expr: "trades.id", descending: true, value: func(t *db.Trade) *string { v := strconv.Itoa(int(t.ID)); return &v }},
+}
+
+// cursor is the position after which a page starts: the sort it was issued for and the
+// values of the order columns on the last trade of the previous page
+type cursor struct {
+	Sort   string    `json:"s"`
+	Values []*string `json:"v"`
+}
+
+// Find lists a page of the user's trades matching filter. The conditions are assembled into one
+// query, so every combination of filters runs in the database rather than in memory. Pages are
+// keyset based: a page starts after the order column values of the previous page's last trade,
+// so trades added or removed meanwhile neither shift nor repeat the ones that follow.
+func (r *TradeRepository) Find(ctx context.Context, userID int64, filter trade.Filter, page trade.Page) ([]*trade.Trade, string, error) {
+	columns := orderColumns(filter.Sort)
+	signature := sortSignature(filter.Sort)
+
+	q := &tradeQuery{}
+	q.where("trades.user_id = " + q.arg(userID))
+	q.where("trades.deleted_at IS NULL")
+	q.filter(userID, filter)
+	if page.Cursor != "" {
+		values, err := decodeCursor(page.Cursor, signature, len(columns))
+		if err != nil {
+			return nil, "", err
+		}
+		q.after(columns, values)
+	}
+	q.orderBy(columns)
+	// One trade past the page tells whether another page follows
+	if page.Limit > 0 {
+		q.limit = q.arg(page.Limit + 1)
+	}
+
+	results, err := r.listTrades(ctx, q)
+	if err != nil {
+		return nil, "", err
+	}
+
+	next := ""
+	if page.Limit > 0 && len(results) > page.Limit {
+		results = results[:page.Limit]
+		last := &results[len(results)-1]
+		values := make([]*string, len(columns))
+		for i, column := range columns {
+			values[i] = column.value(last)
+		}
+		next = encodeCursor(cursor{Sort: signature, Values: values})
+	}
+
+	trades := make([]*trade.Trade, len(results))
+	for i, result := range results {
+		t, err := r.loadTrade(ctx, &result)
+		if err != nil {
+			return nil, "", err
+		}
+		trades[i] = t
+	}
+
+	return trades, next, nil
+}
+
+// tradeColumns selects the columns of trades in the order of the fields of db.Trade, whose json
+// tags sqlc names after the columns, so the listing scans into the generated row type
+var tradeColumns = func() string {
+	row := reflect.TypeFor[db.Trade]()
+	columns := make([]string, row.NumField())
+	for i := range columns {
+		columns[i] = "trades." + row.Field(i).Tag.Get("json")
+	}
+	return strings.Join(columns, ", ")
+}()
+
+// tradeFields returns pointers to the fields of t in the order of tradeColumns
+func tradeFields(t *db.Trade) []any {
+	row := reflect.ValueOf(t).Elem()
+	fields := make([]any, row.NumField())
+	for i := range fields {
+		fields[i] = row.Field(i).Addr().Interface()
+	}
+	return fields
+}
+
+// listTrades runs a listing assembled at runtime, which one sqlc query per combination of
+// filters cannot cover
+func (r *TradeRepository) listTrades(ctx context.Context, q *tradeQuery) ([]db.Trade, error) {
+	rows, err := r.conn.QueryContext(ctx, "SELECT "+tradeColumns+" FROM trades "+q.String(), q.args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []db.Trade
+	for rows.Next() {
+		var result db.Trade
+		if err := rows.Scan(tradeFields(&result)...); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// orderColumns lists the columns of the requested sort followed by the tiebreak
+func orderColumns(sorts []trade.Sort) []orderColumn {
+	var columns []orderColumn
+	for _, sort := range sorts {
+		for _, column := range sortColumns[sort.Key] {
+			column.descending = sort.Descending
+			columns = append(columns, column)
+		}
+	}
+	return append(columns, tiebreakColumns...)
+}
+
+// sortSignature identifies a sort so a cursor is only accepted for the sort it was issued for
+func sortSignature(sorts []trade.Sort) string {
+	keys := make([]string, len(sorts))
+	for i, sort := range sorts {
+		keys[i] = string(sort.Key)
+		if sort.Descending {
+			keys[i] = "-" + keys[i]
+		}
+	}
+	return strings.Join(keys, ",")
+}
+
+func encodeCursor(c cursor) string {
+	data, _ := json.Marshal(c)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+func decodeCursor(encoded string, signature string, columns int) ([]*string, error) {
+	data, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return nil, trade.ErrInvalidCursor
+	}
+	var c cursor
+	if err := json.Unmarshal(data, &c); err != nil || c.Sort != signature || len(c.Values) != columns {
+		return nil, trade.ErrInvalidCursor
+	}
+	// The tiebreak columns are never null
+	for _, value := range c.Values[columns-len(tiebreakColumns):] {
+		if value == nil {
+			return nil, trade.ErrInvalidCursor
+		}
+	}
+	return c.Values, nil
+}
+
+func formatDate(t *db.Trade) *string {
+	v := t.Date.Format("2006-01-02")
+	return &v
+}
+
+func formatTime(t *db.Trade) *string {
+	v := t.Time.Format("15:04:05.999999")
+	return &v
+}
+
+// settledPL reads the value netPL orders by off a row
+func settledPL(t *db.Trade) sql.NullString {
+	if t.NetPl.Valid {
+		return t.NetPl
+	}
+	return t.Pl
+}
+
+func nullString(s sql.NullString) *string {
+	if !s.Valid {
+		return nil
+	}
+	return &s.String
+}
+
+// tradeQuery builds the clauses of a trade listing that follow FROM trades. Values are only
+// ever bound as placeholders; the SQL text itself comes from constants.
+type tradeQuery struct {
+	conditions []string
+	order      []string
+	limit      string
+	args       []any
+}
+
+// arg binds a value and returns its placeholder
+func (q *tradeQuery) arg(value any) string {
+	q.args = append(q.args, value)
+	return "$" + strconv.Itoa(len(q.args))
+}
+
+// list binds each value and returns the placeholders as a parenthesized list for IN
+func (q *tradeQuery) list(n int, value func(i int) any) string {
+	placeholders := make([]string, n)
+	for i := range n {
+		placeholders[i] = q.arg(value(i))
+	}
+	return "(" + strings.Join(placeholders, ", ") + ")"
+}
+
+func (q *tradeQuery) where(condition string) {
+	q.conditions = append(q.conditions, condition)
+}
+
+// between adds the bounds that are set on column
+func (q *tradeQuery) between(column string, min, max *float64) {
+	if min != nil {
+		q.where(column + " >= " + q.arg(*min))
+	}
+	if max != nil {
+		q.where(column + " <= " + q.arg(*max))
+	}
+}
+
+func (q *tradeQuery) filter(userID int64, f trade.Filter) {
+	if f.AccountID != nil {
+		q.where("trades.account_id = " + q.arg(*f.AccountID))
+	}
+	if f.OpenedFrom != nil {
+		q.where("trades.opened_at >= " + q.arg(*f.OpenedFrom))
+	}
+	if f.OpenedTo != nil {
+		q.where("trades.opened_at < " + q.arg(*f.OpenedTo))
+	}
+	if len(f.Pairs) > 0 {
+		q.where("trades.pair IN " + q.list(len(f.Pairs), func(i int) any { return f.Pairs[i] }))
+	}
+	if len(f.Types) > 0 {
+		q.where("trades.type::text IN " + q.list(len(f.Types), func(i int) any { return string(f.Types[i]) }))
+	}
+	if len(f.Statuses) > 0 {
+		q.where("trades.status::text IN " + q.list(len(f.Statuses), func(i int) any { return string(f.Statuses[i]) }))
+	}
+	if len(f.StrategyIDs) > 0 {
+		q.where("EXISTS (SELECT 1 FROM trade_strategies ts WHERE ts.trade_id = trades.id AND ts.strategy_id IN " +
+			q.list(len(f.StrategyIDs), func(i int) any { return f.StrategyIDs[i] }) + ")")
+	}
+	// Each tag needs its own match since a trade must carry all of them
+	for _, tag := range trade.NewTags(f.Tags) {
+		q.where("EXISTS (SELECT 1 FROM trade_tags tt INNER JOIN tags t ON t.id = tt.tag_id WHERE tt.trade_id = trades.id AND t.user_id = " +
+			q.arg(userID) + " AND t.name = " + q.arg(tag.Name) + ")")
+	}
+	if f.Grade != "" {
+		q.where("trades.grade = " + q.arg(f.Grade))
+	}
+	q.between(netPL, f.MinPL, f.MaxPL)
+	q.between("trades.realized_r", f.MinR, f.MaxR)
+	q.between("trades.lots", f.MinLots, f.MaxLots)
+	// The open instant is read on the wall clock of the timezone; DOW counts from Sunday as time.Weekday does
+	timezone := "UTC"
+	if f.Location != nil {
+		timezone = f.Location.String()
+	}
+	if len(f.Weekdays) > 0 {
+		q.where("EXTRACT(DOW FROM trades.opened_at AT TIME ZONE " + q.arg(timezone) + ")::int IN " +
+			q.list(len(f.Weekdays), func(i int) any { return int(f.Weekdays[i]) }))
+	}
+	if len(f.Hours) > 0 {
+		q.where("EXTRACT(HOUR FROM trades.opened_at AT TIME ZONE " + q.arg(timezone) + ")::int IN " +
+			q.list(len(f.Hours), func(i int) any { return f.Hours[i] }))
+	}
+}
+
+// after keeps the trades that come after the given order column values. A trade comes after when
+// it ties on the leading columns and is past the value on the next one; nulls sort last, so a
+// null value is only tied, never passed.
+func (q *tradeQuery) after(columns []orderColumn, values []*string) {
+	var alternatives, tied []string
+	for i, column := range columns {
+		if values[i] == nil {
+			tied = append(tied, column.expr+" IS NULL")
+			continue
+		}
+
+		placeholder := q.arg(*values[i])
+		op := " > "
+		if column.descending {
+			op = " < "
+		}
+		past := "(" + column.expr + op + placeholder + " OR " + column.expr + " IS NULL)"
+		alternatives = append(alternatives, "("+strings.Join(append(slices.Clone(tied), past), " AND ")+")")
+		tied = append(tied, column.expr+" = "+placeholder)
+	}
+	q.where("(" + strings.Join(alternatives, " OR ") + ")")
+}
+
+// orderBy orders by the columns in turn; trades without a value (such as open trades on P/L) come last
+func (q *tradeQuery) orderBy(columns []orderColumn) {
+	for _, column := range columns {
+		direction := " ASC NULLS LAST"
+		if column.descending {
+			direction = " DESC NULLS LAST"
+		}
+		q.order = append(q.order, column.expr+direction)
+	}
+}
+
+// String renders the clauses that follow FROM trades
+func (q *tradeQuery) String() string {
+	clauses := "WHERE " + strings.Join(q.conditions, " AND ") + " ORDER BY " + strings.Join(q.order, ", ")
+	if q.limit != "" {
+		clauses += " LIMIT " + q.limit
+	}
+	return clauses
+}
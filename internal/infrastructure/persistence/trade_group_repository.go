@@ -0,0 +1,142 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/tradegroup"
+)
+
+// TradeGroupRepository implements tradegroup.Repository using sqlc
+type TradeGroupRepository struct {
+	queries *db.Queries
+}
+
+// NewTradeGroupRepository creates a new trade group repository
+func NewTradeGroupRepository(queries *db.Queries) *TradeGroupRepository {
+	return &TradeGroupRepository{
+		queries: queries,
+	}
+}
+
+// Create creates a new, empty trade group
+func (r *TradeGroupRepository) Create(ctx context.Context, g *tradegroup.Group) (*tradegroup.Group, error) {
+	result, err := r.queries.CreateTradeGroup(ctx, db.CreateTradeGroupParams{
+		UserID:      int32(g.UserID),
+		Name:        g.Name,
+		Description: db.StringToNullString(g.Description),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByID retrieves a trade group by ID
+func (r *TradeGroupRepository) GetByID(ctx context.Context, id int64, userID int64) (*tradegroup.Group, error) {
+	result, err := r.queries.GetTradeGroupByID(ctx, db.GetTradeGroupByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tradegroup.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// GetByUserID retrieves the user's trade groups, newest first
+func (r *TradeGroupRepository) GetByUserID(ctx context.Context, userID int64) ([]*tradegroup.Group, error) {
+	results, err := r.queries.GetTradeGroupsByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	groups := make([]*tradegroup.Group, len(results))
+	for i, result := range results {
+		groups[i] = r.toDomain(&result)
+	}
+
+	return groups, nil
+}
+
+// Update updates the name and description of a trade group
+func (r *TradeGroupRepository) Update(ctx context.Context, g *tradegroup.Group) (*tradegroup.Group, error) {
+	result, err := r.queries.UpdateTradeGroup(ctx, db.UpdateTradeGroupParams{
+		ID:          int32(g.ID),
+		Name:        g.Name,
+		Description: db.StringToNullString(g.Description),
+		UserID:      int32(g.UserID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tradegroup.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result), nil
+}
+
+// Delete deletes a trade group; the database ungroups its trades
+func (r *TradeGroupRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.queries.DeleteTradeGroup(ctx, db.DeleteTradeGroupParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return tradegroup.ErrNotFound
+	}
+
+	return nil
+}
+
+// SetTrades replaces the members of a group with the given trades of the user
+func (r *TradeGroupRepository) SetTrades(ctx context.Context, groupID int64, userID int64, tradeIDs []int64) error {
+	id := sql.NullInt32{Int32: int32(groupID), Valid: true}
+	if err := r.queries.ClearTradeGroup(ctx, id); err != nil {
+		return err
+	}
+
+	for _, tradeID := range tradeIDs {
+		rowsAffected, err := r.queries.AssignTradeGroup(ctx, db.AssignTradeGroupParams{
+			GroupID: id,
+			ID:      int32(tradeID),
+			UserID:  int32(userID),
+		})
+		if err != nil {
+			return err
+		}
+		if rowsAffected == 0 {
+			return tradegroup.ErrTradeNotFound
+		}
+	}
+
+	return nil
+}
+
+func (r *TradeGroupRepository) toDomain(g *db.TradeGroup) *tradegroup.Group {
+	return &tradegroup.Group{
+		ID:          int64(g.ID),
+		UserID:      int64(g.UserID),
+		Name:        g.Name,
+		Description: db.NullStringToString(g.Description),
+		CreatedAt:   g.CreatedAt.Time,
+		UpdatedAt:   g.UpdatedAt.Time,
+	}
+}
@@ -3,26 +3,36 @@ package persistence
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"slices"
 	"strconv"
 	"time"
 
 	"github.com/raihanstark/trade-journal/internal/db"
 	infradb "github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/grade"
 	"github.com/raihanstark/trade-journal/internal/domain/trade"
 )
 
 type TradeRepository struct {
+	conn    db.DBTX
 	queries *db.Queries
 }
 
-func NewTradeRepository(queries *db.Queries) *TradeRepository {
-	return &TradeRepository{queries: queries}
+// NewTradeRepository creates a trade repository; conn runs the listings assembled at runtime
+// and must be the connection or transaction queries is bound to
+func NewTradeRepository(conn db.DBTX, queries *db.Queries) *TradeRepository {
+	return &TradeRepository{
+		conn:    conn,
+		queries: queries,
+	}
 }
 
-func (r *TradeRepository) GetByAccountID(ctx context.Context, accountID int64, userID int64) ([]*trade.Trade, error) {
-	results, err := r.queries.GetTradesByAccountID(ctx, db.GetTradesByAccountIDParams{
-		AccountID: sql.NullInt32{Int32: int32(accountID), Valid: true},
-		UserID:    int32(userID),
+// GetByGroupID returns the trades of a group in open order
+func (r *TradeRepository) GetByGroupID(ctx context.Context, groupID int64, userID int64) ([]*trade.Trade, error) {
+	results, err := r.queries.GetTradesByGroupID(ctx, db.GetTradesByGroupIDParams{
+		GroupID: sql.NullInt32{Int32: int32(groupID), Valid: true},
+		UserID:  int32(userID),
 	})
 	if err != nil {
 		return nil, err
@@ -30,17 +40,34 @@ func (r *TradeRepository) GetByAccountID(ctx context.Context, accountID int64, u
 
 	trades := make([]*trade.Trade, len(results))
 	for i, result := range results {
-		strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
+		t, err := r.loadTrade(ctx, &result)
 		if err != nil {
 			return nil, err
 		}
-		trades[i] = r.toDomain(&result, strategies)
+		trades[i] = t
 	}
 
 	return trades, nil
 }
 
 func (r *TradeRepository) Create(ctx context.Context, t *trade.Trade) (*trade.Trade, error) {
+	if err := r.checkMistakeTypes(ctx, t.UserID, t.MistakeTypes); err != nil {
+		return nil, err
+	}
+	if err := r.checkRatingDimensions(ctx, t.UserID, t.Ratings); err != nil {
+		return nil, err
+	}
+	if err := r.checkGrade(ctx, t.UserID, t.Grade); err != nil {
+		return nil, err
+	}
+	if err := r.checkStrategies(ctx, t.UserID, t.Strategies); err != nil {
+		return nil, err
+	}
+	ruleStrategies, err := r.checkStrategyRules(ctx, 0, t.Strategies, t.SatisfiedRuleIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := r.queries.CreateTrade(ctx, db.CreateTradeParams{
 		UserID:     int32(t.UserID),
 		AccountID:  int32ToNullInt32(t.AccountID),
@@ -55,11 +82,25 @@ func (r *TradeRepository) Create(ctx context.Context, t *trade.Trade) (*trade.Tr
 		Pl:         floatPtrToNullString(t.PL),
 		Rr:         infradb.StringToNullString(t.RR),
 		Status:     db.TradeStatus(t.Status),
+		OrderType:  db.OrderType(t.OrderType),
+		Grade:      infradb.StringToNullString(t.Grade),
 		StopLoss:   floatPtrToNullString(t.StopLoss),
 		TakeProfit: floatPtrToNullString(t.TakeProfit),
 		Notes:      infradb.StringToNullString(t.Notes),
 		Mistakes:   infradb.StringToNullString(t.Mistakes),
 		Amount:     floatPtrToNullString(t.Amount),
+		FxRate:     floatPtrToNullString(t.FXRate),
+		Commission: formatFloat(t.Commission),
+		Swap:       formatFloat(t.Swap),
+		Fees:       formatFloat(t.Fees),
+		NetPl:      floatPtrToNullString(t.NetPL),
+		PlannedRr:  floatPtrToNullString(t.PlannedRR),
+		RealizedR:  floatPtrToNullString(t.RealizedR),
+		RiskAmount: floatPtrToNullString(t.RiskAmount),
+		OpenedAt:   t.OpenedAt,
+		ClosedAt:   timePtrToNullTime(t.ClosedAt),
+		CloseDate:  timePtrToNullTime(t.CloseDate),
+		CloseTime:  timePtrToNullTime(t.CloseTime),
 	})
 	if err != nil {
 		return nil, err
@@ -76,96 +117,84 @@ func (r *TradeRepository) Create(ctx context.Context, t *trade.Trade) (*trade.Tr
 		}
 	}
 
-	// Fetch strategies for the created trade
-	strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
-	if err != nil {
+	if err := r.addStrategyRules(ctx, result.ID, ruleStrategies, t.SatisfiedRuleIDs); err != nil {
 		return nil, err
 	}
 
-	return r.toDomain(&result, strategies), nil
-}
-
-func (r *TradeRepository) GetByID(ctx context.Context, id int64, userID int64) (*trade.Trade, error) {
-	result, err := r.queries.GetTradeByID(ctx, db.GetTradeByIDParams{
-		ID:     int32(id),
-		UserID: int32(userID),
-	})
-	if err != nil {
+	if err := r.addTags(ctx, result.ID, result.UserID, t.Tags); err != nil {
 		return nil, err
 	}
 
-	strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
-	if err != nil {
+	if err := r.addMistakes(ctx, result.ID, t.MistakeTypes); err != nil {
 		return nil, err
 	}
 
-	return r.toDomain(&result, strategies), nil
-}
-
-func (r *TradeRepository) GetByUserID(ctx context.Context, userID int64) ([]*trade.Trade, error) {
-	results, err := r.queries.GetTradesByUserID(ctx, int32(userID))
-	if err != nil {
+	if err := r.addRatings(ctx, result.ID, t.Ratings); err != nil {
 		return nil, err
 	}
 
-	trades := make([]*trade.Trade, len(results))
-	for i, result := range results {
-		strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
-		if err != nil {
-			return nil, err
-		}
-		trades[i] = r.toDomain(&result, strategies)
-	}
-
-	return trades, nil
+	return r.loadTrade(ctx, &result)
 }
 
-func (r *TradeRepository) GetByUserIDAndDateRange(ctx context.Context, userID int64, startDate, endDate time.Time) ([]*trade.Trade, error) {
-	results, err := r.queries.GetTradesByUserIDAndDateRange(ctx, db.GetTradesByUserIDAndDateRangeParams{
-		UserID:   int32(userID),
-		Date:     startDate,
-		Date_2:   endDate,
+func (r *TradeRepository) GetByID(ctx context.Context, id int64, userID int64) (*trade.Trade, error) {
+	result, err := r.queries.GetTradeByID(ctx, db.GetTradeByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	trades := make([]*trade.Trade, len(results))
-	for i, result := range results {
-		strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
-		if err != nil {
-			return nil, err
-		}
-		trades[i] = r.toDomain(&result, strategies)
-	}
-
-	return trades, nil
+	return r.loadTrade(ctx, &result)
 }
 
-func (r *TradeRepository) GetByAccountIDAndDateRange(ctx context.Context, accountID int64, userID int64, startDate, endDate time.Time) ([]*trade.Trade, error) {
-	results, err := r.queries.GetTradesByAccountIDAndDateRange(ctx, db.GetTradesByAccountIDAndDateRangeParams{
-		AccountID: sql.NullInt32{Int32: int32(accountID), Valid: true},
-		UserID:    int32(userID),
-		Date:      startDate,
-		Date_2:    endDate,
+// Search ranks the user's trades against a websearch_to_tsquery query. The pair, notes and mistakes are
+// matched through the indexed trade_search_document, strategy names are added to the document per query.
+func (r *TradeRepository) Search(ctx context.Context, userID int64, query string, limit int) ([]*trade.SearchResult, error) {
+	results, err := r.queries.SearchTrades(ctx, db.SearchTradesParams{
+		Query:       query,
+		UserID:      int32(userID),
+		ResultLimit: int32(limit),
 	})
 	if err != nil {
 		return nil, err
 	}
 
-	trades := make([]*trade.Trade, len(results))
+	hits := make([]*trade.SearchResult, len(results))
 	for i, result := range results {
-		strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
+		t, err := r.loadTrade(ctx, &result.Trade)
 		if err != nil {
 			return nil, err
 		}
-		trades[i] = r.toDomain(&result, strategies)
+		hits[i] = &trade.SearchResult{
+			Trade:           t,
+			Rank:            float64(result.Rank),
+			NotesSnippet:    result.NotesSnippet,
+			MistakesSnippet: result.MistakesSnippet,
+		}
 	}
 
-	return trades, nil
+	return hits, nil
 }
 
 func (r *TradeRepository) Update(ctx context.Context, t *trade.Trade) (*trade.Trade, error) {
+	if err := r.checkMistakeTypes(ctx, t.UserID, t.MistakeTypes); err != nil {
+		return nil, err
+	}
+	if err := r.checkRatingDimensions(ctx, t.UserID, t.Ratings); err != nil {
+		return nil, err
+	}
+	if err := r.checkGrade(ctx, t.UserID, t.Grade); err != nil {
+		return nil, err
+	}
+	if err := r.checkStrategies(ctx, t.UserID, t.Strategies); err != nil {
+		return nil, err
+	}
+	ruleStrategies, err := r.checkStrategyRules(ctx, t.ID, t.Strategies, t.SatisfiedRuleIDs)
+	if err != nil {
+		return nil, err
+	}
+
 	result, err := r.queries.UpdateTrade(ctx, db.UpdateTradeParams{
 		ID:         int32(t.ID),
 		AccountID:  int32ToNullInt32(t.AccountID),
@@ -180,14 +209,32 @@ func (r *TradeRepository) Update(ctx context.Context, t *trade.Trade) (*trade.Tr
 		Pl:         floatPtrToNullString(t.PL),
 		Rr:         infradb.StringToNullString(t.RR),
 		Status:     db.TradeStatus(t.Status),
+		OrderType:  db.OrderType(t.OrderType),
+		Grade:      infradb.StringToNullString(t.Grade),
 		StopLoss:   floatPtrToNullString(t.StopLoss),
 		TakeProfit: floatPtrToNullString(t.TakeProfit),
 		Notes:      infradb.StringToNullString(t.Notes),
 		Mistakes:   infradb.StringToNullString(t.Mistakes),
 		Amount:     floatPtrToNullString(t.Amount),
+		FxRate:     floatPtrToNullString(t.FXRate),
+		Commission: formatFloat(t.Commission),
+		Swap:       formatFloat(t.Swap),
+		Fees:       formatFloat(t.Fees),
+		NetPl:      floatPtrToNullString(t.NetPL),
+		PlannedRr:  floatPtrToNullString(t.PlannedRR),
+		RealizedR:  floatPtrToNullString(t.RealizedR),
+		RiskAmount: floatPtrToNullString(t.RiskAmount),
+		OpenedAt:   t.OpenedAt,
+		ClosedAt:   timePtrToNullTime(t.ClosedAt),
+		CloseDate:  timePtrToNullTime(t.CloseDate),
+		CloseTime:  timePtrToNullTime(t.CloseTime),
 		UserID:     int32(t.UserID),
+		Version:    int32(t.Version),
 	})
 	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, r.missErr(ctx, t.ID, t.UserID)
+		}
 		return nil, err
 	}
 
@@ -207,56 +254,492 @@ func (r *TradeRepository) Update(ctx context.Context, t *trade.Trade) (*trade.Tr
 		}
 	}
 
-	strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
+	// Re-linking the strategies dropped the recorded rules along with the old links
+	if err := r.addStrategyRules(ctx, result.ID, ruleStrategies, t.SatisfiedRuleIDs); err != nil {
+		return nil, err
+	}
+
+	// Replace the tags
+	if err := r.queries.DeleteTradeTags(ctx, result.ID); err != nil {
+		return nil, err
+	}
+	if err := r.addTags(ctx, result.ID, result.UserID, t.Tags); err != nil {
+		return nil, err
+	}
+
+	// Replace the mistake types
+	if err := r.queries.DeleteTradeMistakes(ctx, result.ID); err != nil {
+		return nil, err
+	}
+	if err := r.addMistakes(ctx, result.ID, t.MistakeTypes); err != nil {
+		return nil, err
+	}
+
+	// Replace the ratings
+	if err := r.queries.DeleteTradeRatings(ctx, result.ID); err != nil {
+		return nil, err
+	}
+	if err := r.addRatings(ctx, result.ID, t.Ratings); err != nil {
+		return nil, err
+	}
+
+	return r.loadTrade(ctx, &result)
+}
+
+// addTags links tags to a trade, creating the user's tags that do not exist yet
+func (r *TradeRepository) addTags(ctx context.Context, tradeID int32, userID int32, tags []trade.Tag) error {
+	for _, tag := range tags {
+		stored, err := r.queries.UpsertTag(ctx, db.UpsertTagParams{
+			UserID: userID,
+			Name:   tag.Name,
+		})
+		if err != nil {
+			return err
+		}
+
+		err = r.queries.AddTradeTag(ctx, db.AddTradeTagParams{
+			TradeID: tradeID,
+			TagID:   stored.ID,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkStrategies makes sure every linked strategy belongs to the user
+func (r *TradeRepository) checkStrategies(ctx context.Context, userID int64, strategies []trade.Strategy) error {
+	if len(strategies) == 0 {
+		return nil
+	}
+
+	owned, err := r.queries.GetStrategiesByUserID(ctx, int32(userID))
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int64]bool, len(owned))
+	for _, s := range owned {
+		known[int64(s.ID)] = true
+	}
+	for _, s := range strategies {
+		if !known[s.ID] {
+			return trade.ErrStrategyNotFound
+		}
+	}
+	return nil
+}
+
+// checkStrategyRules returns the rules a trade is checked against, mapped to the strategy each
+// belongs to, and makes sure every satisfied rule is among them. A strategy the trade was already
+// linked to keeps the rules recorded for it, so rules added to the strategy since do not count
+// against the trade; a newly linked strategy is checked against its current rules.
+func (r *TradeRepository) checkStrategyRules(ctx context.Context, tradeID int64, strategies []trade.Strategy, ruleIDs []int64) (map[int64]int32, error) {
+	linked := make(map[int32]bool)
+	owners := make(map[int64]int32)
+	if tradeID != 0 {
+		previous, err := r.queries.GetTradeStrategies(ctx, int32(tradeID))
+		if err != nil {
+			return nil, err
+		}
+		for _, strategy := range previous {
+			linked[strategy.ID] = true
+		}
+
+		recorded, err := r.queries.GetTradeStrategyRules(ctx, int32(tradeID))
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range recorded {
+			if slices.ContainsFunc(strategies, func(s trade.Strategy) bool { return s.ID == int64(rule.StrategyID) }) {
+				owners[int64(rule.ID)] = rule.StrategyID
+			}
+		}
+	}
+
+	for _, strategy := range strategies {
+		if linked[int32(strategy.ID)] {
+			continue
+		}
+		rules, err := r.queries.GetStrategyRules(ctx, int32(strategy.ID))
+		if err != nil {
+			return nil, err
+		}
+		for _, rule := range rules {
+			owners[int64(rule.ID)] = rule.StrategyID
+		}
+	}
+
+	for _, id := range ruleIDs {
+		if _, ok := owners[id]; !ok {
+			return nil, trade.ErrStrategyRuleNotFound
+		}
+	}
+	return owners, nil
+}
+
+// addStrategyRules records the rules a trade was checked against and whether it satisfied each
+func (r *TradeRepository) addStrategyRules(ctx context.Context, tradeID int32, owners map[int64]int32, satisfied []int64) error {
+	for id, strategyID := range owners {
+		err := r.queries.AddTradeStrategyRule(ctx, db.AddTradeStrategyRuleParams{
+			TradeID:    tradeID,
+			StrategyID: strategyID,
+			RuleID:     int32(id),
+			Satisfied:  slices.Contains(satisfied, id),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkMistakeTypes makes sure every mistake type exists in the user's catalogue
+func (r *TradeRepository) checkMistakeTypes(ctx context.Context, userID int64, mistakes []trade.MistakeType) error {
+	if len(mistakes) == 0 {
+		return nil
+	}
+
+	catalogue, err := r.queries.GetMistakeTypesByUserID(ctx, int32(userID))
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int64]bool, len(catalogue))
+	for _, m := range catalogue {
+		known[int64(m.ID)] = true
+	}
+	for _, m := range mistakes {
+		if !known[m.ID] {
+			return trade.ErrMistakeTypeNotFound
+		}
+	}
+	return nil
+}
+
+// addMistakes links mistake types to a trade
+func (r *TradeRepository) addMistakes(ctx context.Context, tradeID int32, mistakes []trade.MistakeType) error {
+	for _, m := range mistakes {
+		err := r.queries.AddTradeMistake(ctx, db.AddTradeMistakeParams{
+			TradeID:       tradeID,
+			MistakeTypeID: int32(m.ID),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// checkRatingDimensions makes sure every rating is given on one of the user's dimensions
+func (r *TradeRepository) checkRatingDimensions(ctx context.Context, userID int64, ratings []trade.Rating) error {
+	if len(ratings) == 0 {
+		return nil
+	}
+
+	dimensions, err := r.queries.GetRatingDimensionsByUserID(ctx, int32(userID))
+	if err != nil {
+		return err
+	}
+
+	known := make(map[int64]bool, len(dimensions))
+	for _, d := range dimensions {
+		known[int64(d.ID)] = true
+	}
+	for _, rating := range ratings {
+		if !known[rating.DimensionID] {
+			return trade.ErrRatingDimensionNotFound
+		}
+	}
+	return nil
+}
+
+// checkGrade makes sure the grade is on the user's scale
+func (r *TradeRepository) checkGrade(ctx context.Context, userID int64, name string) error {
+	if name == "" {
+		return nil
+	}
+
+	grades, err := r.queries.GetGradesByUserID(ctx, int32(userID))
+	if err != nil {
+		return err
+	}
+
+	names := make([]string, len(grades))
+	for i, g := range grades {
+		names[i] = g.Name
+	}
+	if !slices.Contains(grade.ScaleOrDefault(names), name) {
+		return trade.ErrGradeNotFound
+	}
+	return nil
+}
+
+// addRatings stores the psychology ratings of a trade
+func (r *TradeRepository) addRatings(ctx context.Context, tradeID int32, ratings []trade.Rating) error {
+	for _, rating := range ratings {
+		err := r.queries.AddTradeRating(ctx, db.AddTradeRatingParams{
+			TradeID:     tradeID,
+			DimensionID: int32(rating.DimensionID),
+			Phase:       db.RatingPhase(rating.Phase),
+			Value:       int16(rating.Value),
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// GetTags returns the tags of a user in name order
+func (r *TradeRepository) GetTags(ctx context.Context, userID int64) ([]trade.Tag, error) {
+	results, err := r.queries.GetTagsByUserID(ctx, int32(userID))
 	if err != nil {
 		return nil, err
 	}
 
-	return r.toDomain(&result, strategies), nil
+	tags := make([]trade.Tag, len(results))
+	for i, result := range results {
+		tags[i] = trade.Tag{ID: int64(result.ID), Name: result.Name}
+	}
+	return tags, nil
 }
 
-func (r *TradeRepository) Delete(ctx context.Context, id int64, userID int64) error {
-	return r.queries.DeleteTrade(ctx, db.DeleteTradeParams{
+// Delete moves a trade to the trash, provided it is still at version; it keeps its links until it is purged
+func (r *TradeRepository) Delete(ctx context.Context, id int64, userID int64, version int64) error {
+	rowsAffected, err := r.queries.SoftDeleteTrade(ctx, db.SoftDeleteTradeParams{
+		ID:      int32(id),
+		UserID:  int32(userID),
+		Version: int32(version),
+	})
+	if err != nil {
+		return err
+	}
+	if rowsAffected == 0 {
+		return r.missErr(ctx, id, userID)
+	}
+	return nil
+}
+
+// missErr explains why a versioned write matched no row: the trade is gone, or it moved past the expected version
+func (r *TradeRepository) missErr(ctx context.Context, id int64, userID int64) error {
+	_, err := r.queries.GetTradeByID(ctx, db.GetTradeByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if errors.Is(err, sql.ErrNoRows) {
+		return trade.ErrNotFound
+	}
+	if err != nil {
+		return err
+	}
+	return trade.ErrVersionConflict
+}
+
+// GetDeletedByID retrieves a trade from the trash
+func (r *TradeRepository) GetDeletedByID(ctx context.Context, id int64, userID int64) (*trade.Trade, error) {
+	result, err := r.queries.GetDeletedTradeByID(ctx, db.GetDeletedTradeByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, trade.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.loadTrade(ctx, &result)
+}
+
+// GetDeletedByUserID retrieves the trades in the user's trash, most recently deleted first
+func (r *TradeRepository) GetDeletedByUserID(ctx context.Context, userID int64) ([]*trade.Trade, error) {
+	results, err := r.queries.GetDeletedTradesByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	trades := make([]*trade.Trade, len(results))
+	for i, result := range results {
+		t, err := r.loadTrade(ctx, &result)
+		if err != nil {
+			return nil, err
+		}
+		trades[i] = t
+	}
+
+	return trades, nil
+}
+
+// Restore takes a trade out of the trash
+func (r *TradeRepository) Restore(ctx context.Context, id int64, userID int64) (*trade.Trade, error) {
+	result, err := r.queries.RestoreTrade(ctx, db.RestoreTradeParams{
 		ID:     int32(id),
 		UserID: int32(userID),
 	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, trade.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.loadTrade(ctx, &result)
 }
 
-func (r *TradeRepository) toDomain(t *db.Trade, strategies []db.Strategy) *trade.Trade {
+// PurgeDeleted permanently removes trades moved to the trash before the given instant
+func (r *TradeRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return r.queries.PurgeDeletedTrades(ctx, sql.NullTime{Time: before, Valid: true})
+}
+
+// loadTrade fetches the strategies with their rules, executions, tags, mistake types and ratings of a trade row and maps it to the domain
+func (r *TradeRepository) loadTrade(ctx context.Context, t *db.Trade) (*trade.Trade, error) {
+	strategies, err := r.queries.GetTradeStrategies(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	rules, err := r.queries.GetTradeStrategyRules(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	executions, err := r.queries.GetTradeExecutions(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	tags, err := r.queries.GetTradeTags(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	mistakes, err := r.queries.GetTradeMistakes(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	ratings, err := r.queries.GetTradeRatings(ctx, t.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(t, strategies, rules, executions, tags, mistakes, ratings), nil
+}
+
+func (r *TradeRepository) toDomain(t *db.Trade, strategies []db.Strategy, rules []db.GetTradeStrategyRulesRow, executions []db.Execution, tags []db.Tag, mistakes []db.MistakeType, ratings []db.GetTradeRatingsRow) *trade.Trade {
+	strategyRules := make(map[int32][]trade.StrategyRule)
+	var satisfied []int64
+	for _, rule := range rules {
+		strategyRules[rule.StrategyID] = append(strategyRules[rule.StrategyID], trade.StrategyRule{
+			ID:          int64(rule.ID),
+			Description: rule.Description,
+			Satisfied:   rule.Satisfied,
+		})
+		if rule.Satisfied {
+			satisfied = append(satisfied, int64(rule.ID))
+		}
+	}
+
 	domainStrategies := make([]trade.Strategy, len(strategies))
 	for i, s := range strategies {
 		domainStrategies[i] = trade.Strategy{
 			ID:          int64(s.ID),
 			Name:        s.Name,
 			Description: infradb.NullStringToString(s.Description),
+			Rules:       strategyRules[s.ID],
+		}
+	}
+
+	domainExecutions := make([]trade.Execution, len(executions))
+	for i, e := range executions {
+		domainExecutions[i] = executionToDomain(&e)
+	}
+
+	domainTags := make([]trade.Tag, len(tags))
+	for i, tag := range tags {
+		domainTags[i] = trade.Tag{ID: int64(tag.ID), Name: tag.Name}
+	}
+
+	domainMistakes := make([]trade.MistakeType, len(mistakes))
+	for i, m := range mistakes {
+		domainMistakes[i] = trade.MistakeType{
+			ID:       int64(m.ID),
+			Name:     m.Name,
+			Severity: string(m.Severity),
+		}
+	}
+
+	domainRatings := make([]trade.Rating, len(ratings))
+	for i, rating := range ratings {
+		domainRatings[i] = trade.Rating{
+			DimensionID: int64(rating.DimensionID),
+			Dimension:   rating.Name,
+			Phase:       trade.RatingPhase(rating.Phase),
+			Value:       int(rating.Value),
 		}
 	}
 
 	return &trade.Trade{
-		ID:          int64(t.ID),
-		UserID:      int64(t.UserID),
-		AccountID:   nullInt32ToInt64Ptr(t.AccountID),
-		Date:        t.Date,
-		Time:        t.Time,
-		Pair:        infradb.NullStringToString(t.Pair),
-		Type:        trade.TradeType(t.Type),
-		Entry:       nullStringToFloat(t.Entry),
-		Exit:        nullStringToFloatPtr(t.Exit),
-		Lots:        nullStringToFloat(t.Lots),
-		Pips:        nullStringToFloatPtr(t.Pips),
-		PL:          nullStringToFloatPtr(t.Pl),
-		RR:          infradb.NullStringToString(t.Rr),
-		Status:      trade.TradeStatus(t.Status),
-		StopLoss:    nullStringToFloatPtr(t.StopLoss),
-		TakeProfit:  nullStringToFloatPtr(t.TakeProfit),
-		Notes:       infradb.NullStringToString(t.Notes),
-		Mistakes:    infradb.NullStringToString(t.Mistakes),
-		Amount:      nullStringToFloatPtr(t.Amount),
-		ChartBefore: infradb.NullStringToStringPtr(t.ChartBefore),
-		ChartAfter:  infradb.NullStringToStringPtr(t.ChartAfter),
-		Strategies:  domainStrategies,
-		CreatedAt:   t.CreatedAt.Time,
-		UpdatedAt:   t.UpdatedAt.Time,
+		ID:               int64(t.ID),
+		UserID:           int64(t.UserID),
+		AccountID:        nullInt32ToInt64Ptr(t.AccountID),
+		Date:             t.Date,
+		Time:             t.Time,
+		Pair:             infradb.NullStringToString(t.Pair),
+		Type:             trade.TradeType(t.Type),
+		Entry:            nullStringToFloat(t.Entry),
+		Exit:             nullStringToFloatPtr(t.Exit),
+		Lots:             nullStringToFloat(t.Lots),
+		Pips:             nullStringToFloatPtr(t.Pips),
+		PL:               nullStringToFloatPtr(t.Pl),
+		RR:               infradb.NullStringToString(t.Rr),
+		PlannedRR:        nullStringToFloatPtr(t.PlannedRr),
+		RealizedR:        nullStringToFloatPtr(t.RealizedR),
+		RiskAmount:       nullStringToFloatPtr(t.RiskAmount),
+		Status:           trade.TradeStatus(t.Status),
+		OrderType:        trade.OrderType(t.OrderType),
+		StopLoss:         nullStringToFloatPtr(t.StopLoss),
+		TakeProfit:       nullStringToFloatPtr(t.TakeProfit),
+		Notes:            infradb.NullStringToString(t.Notes),
+		Mistakes:         infradb.NullStringToString(t.Mistakes),
+		Grade:            infradb.NullStringToString(t.Grade),
+		GroupID:          nullInt32ToInt64Ptr(t.GroupID),
+		Amount:           nullStringToFloatPtr(t.Amount),
+		FXRate:           nullStringToFloatPtr(t.FxRate),
+		Commission:       parseFloat(t.Commission),
+		Swap:             parseFloat(t.Swap),
+		Fees:             parseFloat(t.Fees),
+		NetPL:            nullStringToFloatPtr(t.NetPl),
+		OpenedAt:         t.OpenedAt,
+		ClosedAt:         nullTimeToTimePtr(t.ClosedAt),
+		CloseDate:        nullTimeToTimePtr(t.CloseDate),
+		CloseTime:        nullTimeToTimePtr(t.CloseTime),
+		ChartBefore:      infradb.NullStringToStringPtr(t.ChartBefore),
+		ChartAfter:       infradb.NullStringToStringPtr(t.ChartAfter),
+		Strategies:       domainStrategies,
+		SatisfiedRuleIDs: satisfied,
+		Tags:             domainTags,
+		MistakeTypes:     domainMistakes,
+		Ratings:          domainRatings,
+		Executions:       domainExecutions,
+		CreatedAt:        t.CreatedAt.Time,
+		UpdatedAt:        t.UpdatedAt.Time,
+		DeletedAt:        nullTimeToTimePtr(t.DeletedAt),
+		Version:          int64(t.Version),
+	}
+}
+
+func executionToDomain(e *db.Execution) trade.Execution {
+	return trade.Execution{
+		ID:         int64(e.ID),
+		TradeID:    int64(e.TradeID),
+		Side:       trade.TradeType(e.Side),
+		Price:      parseFloat(e.Price),
+		Lots:       parseFloat(e.Lots),
+		ExecutedAt: e.ExecutedAt,
+		CreatedAt:  e.CreatedAt.Time,
 	}
 }
 
@@ -305,6 +788,20 @@ func nullStringToFloatPtr(n sql.NullString) *float64 {
 	return &v
 }
 
+func timePtrToNullTime(t *time.Time) sql.NullTime {
+	if t == nil {
+		return sql.NullTime{Valid: false}
+	}
+	return sql.NullTime{Time: *t, Valid: true}
+}
+
+func nullTimeToTimePtr(n sql.NullTime) *time.Time {
+	if !n.Valid {
+		return nil
+	}
+	return &n.Time
+}
+
 func formatFloat(f float64) string {
 	return strconv.FormatFloat(f, 'f', -1, 64)
 }
@@ -324,12 +821,7 @@ func (r *TradeRepository) UpdateChartBefore(ctx context.Context, id int64, userI
 		return nil, err
 	}
 
-	strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
-	if err != nil {
-		return nil, err
-	}
-
-	return r.toDomain(&result, strategies), nil
+	return r.loadTrade(ctx, &result)
 }
 
 func (r *TradeRepository) UpdateChartAfter(ctx context.Context, id int64, userID int64, chartURL string) (*trade.Trade, error) {
@@ -342,10 +834,42 @@ func (r *TradeRepository) UpdateChartAfter(ctx context.Context, id int64, userID
 		return nil, err
 	}
 
-	strategies, err := r.queries.GetTradeStrategies(ctx, result.ID)
+	return r.loadTrade(ctx, &result)
+}
+
+func (r *TradeRepository) AddExecution(ctx context.Context, e *trade.Execution) (*trade.Execution, error) {
+	result, err := r.queries.CreateExecution(ctx, db.CreateExecutionParams{
+		TradeID:    int32(e.TradeID),
+		Side:       db.TradeType(e.Side),
+		Price:      formatFloat(e.Price),
+		Lots:       formatFloat(e.Lots),
+		ExecutedAt: e.ExecutedAt,
+	})
 	if err != nil {
 		return nil, err
 	}
 
-	return r.toDomain(&result, strategies), nil
+	execution := executionToDomain(&result)
+	return &execution, nil
+}
+
+func (r *TradeRepository) DeleteExecution(ctx context.Context, id int64, tradeID int64) error {
+	result, err := r.queries.DeleteExecution(ctx, db.DeleteExecutionParams{
+		ID:      int32(id),
+		TradeID: int32(tradeID),
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return trade.ErrExecutionNotFound
+	}
+
+	return nil
 }
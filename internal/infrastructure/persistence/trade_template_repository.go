@@ -0,0 +1,154 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/tradetemplate"
+)
+
+// TradeTemplateRepository implements tradetemplate.Repository using sqlc
+type TradeTemplateRepository struct {
+	queries *db.Queries
+}
+
+// NewTradeTemplateRepository creates a new trade template repository
+func NewTradeTemplateRepository(queries *db.Queries) *TradeTemplateRepository {
+	return &TradeTemplateRepository{
+		queries: queries,
+	}
+}
+
+// Create creates a new trade template
+func (r *TradeTemplateRepository) Create(ctx context.Context, t *tradetemplate.Template) (*tradetemplate.Template, error) {
+	defaults, err := json.Marshal(t.Defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.CreateTradeTemplate(ctx, db.CreateTradeTemplateParams{
+		UserID:   int32(t.UserID),
+		Name:     t.Name,
+		Defaults: defaults,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return r.toDomain(&result)
+}
+
+// GetByID retrieves a trade template by ID
+func (r *TradeTemplateRepository) GetByID(ctx context.Context, id int64, userID int64) (*tradetemplate.Template, error) {
+	result, err := r.queries.GetTradeTemplateByID(ctx, db.GetTradeTemplateByIDParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tradetemplate.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result)
+}
+
+// GetByName retrieves a user's trade template by its name
+func (r *TradeTemplateRepository) GetByName(ctx context.Context, userID int64, name string) (*tradetemplate.Template, error) {
+	result, err := r.queries.GetTradeTemplateByName(ctx, db.GetTradeTemplateByNameParams{
+		UserID: int32(userID),
+		Name:   name,
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tradetemplate.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result)
+}
+
+// GetByUserID retrieves the user's trade templates, in name order
+func (r *TradeTemplateRepository) GetByUserID(ctx context.Context, userID int64) ([]*tradetemplate.Template, error) {
+	results, err := r.queries.GetTradeTemplatesByUserID(ctx, int32(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	templates := make([]*tradetemplate.Template, len(results))
+	for i, result := range results {
+		template, err := r.toDomain(&result)
+		if err != nil {
+			return nil, err
+		}
+		templates[i] = template
+	}
+
+	return templates, nil
+}
+
+// Update updates an existing trade template
+func (r *TradeTemplateRepository) Update(ctx context.Context, t *tradetemplate.Template) (*tradetemplate.Template, error) {
+	defaults, err := json.Marshal(t.Defaults)
+	if err != nil {
+		return nil, err
+	}
+
+	result, err := r.queries.UpdateTradeTemplate(ctx, db.UpdateTradeTemplateParams{
+		ID:       int32(t.ID),
+		Name:     t.Name,
+		Defaults: defaults,
+		UserID:   int32(t.UserID),
+	})
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, tradetemplate.ErrNotFound
+		}
+		return nil, err
+	}
+
+	return r.toDomain(&result)
+}
+
+// Delete deletes a trade template
+func (r *TradeTemplateRepository) Delete(ctx context.Context, id int64, userID int64) error {
+	result, err := r.queries.DeleteTradeTemplate(ctx, db.DeleteTradeTemplateParams{
+		ID:     int32(id),
+		UserID: int32(userID),
+	})
+	if err != nil {
+		return err
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return err
+	}
+
+	if rowsAffected == 0 {
+		return tradetemplate.ErrNotFound
+	}
+
+	return nil
+}
+
+func (r *TradeTemplateRepository) toDomain(t *db.TradeTemplate) (*tradetemplate.Template, error) {
+	defaults := map[string]any{}
+	if err := json.Unmarshal(t.Defaults, &defaults); err != nil {
+		return nil, err
+	}
+
+	return &tradetemplate.Template{
+		ID:        int64(t.ID),
+		UserID:    int64(t.UserID),
+		Name:      t.Name,
+		Defaults:  defaults,
+		CreatedAt: t.CreatedAt.Time,
+		UpdatedAt: t.UpdatedAt.Time,
+	}, nil
+}
@@ -0,0 +1,52 @@
+package persistence
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/raihanstark/trade-journal/internal/db"
+	"github.com/raihanstark/trade-journal/internal/domain/account"
+	"github.com/raihanstark/trade-journal/internal/domain/trade"
+	"github.com/raihanstark/trade-journal/internal/domain/tradegroup"
+)
+
+// Transactor implements trade.Transactor and tradegroup.Transactor on top of a database/sql transaction
+type Transactor struct {
+	conn    *sql.DB
+	queries *db.Queries
+}
+
+// NewTransactor creates a transactor that begins its transactions on conn
+func NewTransactor(conn *sql.DB, queries *db.Queries) *Transactor {
+	return &Transactor{
+		conn:    conn,
+		queries: queries,
+	}
+}
+
+// WithinTx hands fn repositories bound to a new transaction, committing it when fn succeeds
+func (t *Transactor) WithinTx(ctx context.Context, fn func(trades trade.Repository, accounts account.Repository) error) error {
+	return t.within(ctx, func(tx *sql.Tx, queries *db.Queries) error {
+		return fn(NewTradeRepository(tx, queries), NewAccountRepository(queries))
+	})
+}
+
+// WithinGroupTx hands fn a trade group repository bound to a new transaction, committing it when fn succeeds
+func (t *Transactor) WithinGroupTx(ctx context.Context, fn func(groups tradegroup.Repository) error) error {
+	return t.within(ctx, func(tx *sql.Tx, queries *db.Queries) error {
+		return fn(NewTradeGroupRepository(queries))
+	})
+}
+
+func (t *Transactor) within(ctx context.Context, fn func(tx *sql.Tx, queries *db.Queries) error) error {
+	tx, err := t.conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := fn(tx, t.queries.WithTx(tx)); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -33,6 +33,7 @@ func (r *UserRepository) Create(ctx context.Context, u *user.User) (*user.User,
 		ID:           int64(result.ID),
 		Email:        result.Email,
 		PasswordHash: u.PasswordHash,
+		Timezone:     result.Timezone,
 		CreatedAt:    result.CreatedAt.Time,
 		UpdatedAt:    result.UpdatedAt.Time,
 	}, nil
@@ -49,6 +50,7 @@ func (r *UserRepository) GetByEmail(ctx context.Context, email string) (*user.Us
 		ID:           int64(result.ID),
 		Email:        result.Email,
 		PasswordHash: result.PasswordHash,
+		Timezone:     result.Timezone,
 		CreatedAt:    result.CreatedAt.Time,
 		UpdatedAt:    result.UpdatedAt.Time,
 	}, nil
@@ -64,6 +66,26 @@ func (r *UserRepository) GetByID(ctx context.Context, id int64) (*user.User, err
 	return &user.User{
 		ID:        int64(result.ID),
 		Email:     result.Email,
+		Timezone:  result.Timezone,
+		CreatedAt: result.CreatedAt.Time,
+		UpdatedAt: result.UpdatedAt.Time,
+	}, nil
+}
+
+// UpdateTimezone sets the timezone used to interpret the user's dates
+func (r *UserRepository) UpdateTimezone(ctx context.Context, id int64, timezone string) (*user.User, error) {
+	result, err := r.queries.UpdateUserTimezone(ctx, db.UpdateUserTimezoneParams{
+		ID:       int32(id),
+		Timezone: timezone,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return &user.User{
+		ID:        int64(result.ID),
+		Email:     result.Email,
+		Timezone:  result.Timezone,
 		CreatedAt: result.CreatedAt.Time,
 		UpdatedAt: result.UpdatedAt.Time,
 	}, nil
@@ -0,0 +1,53 @@
+package seed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	fxapp "github.com/raihanstark/trade-journal/internal/application/fx"
+)
+
+// FXRateSeeder handles seeding FX rate data
+type FXRateSeeder struct {
+	fxService *fxapp.Service
+}
+
+// NewFXRateSeeder creates a new FXRateSeeder instance
+func NewFXRateSeeder(fxService *fxapp.Service) *FXRateSeeder {
+	return &FXRateSeeder{
+		fxService: fxService,
+	}
+}
+
+// DefaultRates returns reference rates against USD for the seeded currency pairs
+var DefaultRates = []struct {
+	Base  string
+	Quote string
+	Rate  float64
+}{
+	{"EUR", "USD", 1.0850},
+	{"GBP", "USD", 1.2650},
+	{"AUD", "USD", 0.6550},
+	{"NZD", "USD", 0.6050},
+	{"USD", "JPY", 150.00},
+	{"USD", "CAD", 1.3550},
+	{"USD", "CHF", 0.8850},
+}
+
+// SeedForUser records the default rates for a user, dated so they cover every seeded trade
+func (s *FXRateSeeder) SeedForUser(ctx context.Context, userID int64, from time.Time) error {
+	for _, rate := range DefaultRates {
+		_, err := s.fxService.CreateRate(ctx, userID, fxapp.CreateRateRequest{
+			BaseCurrency:  rate.Base,
+			QuoteCurrency: rate.Quote,
+			Date:          from.Format("2006-01-02"),
+			Rate:          rate.Rate,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to create fx rate: %w", err)
+		}
+	}
+
+	return nil
+}
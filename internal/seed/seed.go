@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	accountapp "github.com/raihanstark/trade-journal/internal/application/account"
+	fxapp "github.com/raihanstark/trade-journal/internal/application/fx"
 	strategyapp "github.com/raihanstark/trade-journal/internal/application/strategy"
 	tradeapp "github.com/raihanstark/trade-journal/internal/application/trade"
 	"github.com/raihanstark/trade-journal/internal/db"
@@ -37,6 +39,7 @@ type Seeder struct {
 	accountSeeder  *AccountSeeder
 	strategySeeder *StrategySeeder
 	tradeSeeder    *TradeSeeder
+	fxRateSeeder   *FXRateSeeder
 	dbConn         *sql.DB
 }
 
@@ -46,18 +49,24 @@ func NewSeeder(dbConn *sql.DB) *Seeder {
 	userRepository := persistence.NewUserRepository(queries)
 	accountRepository := persistence.NewAccountRepository(queries)
 	strategyRepository := persistence.NewStrategyRepository(queries)
-	tradeRepository := persistence.NewTradeRepository(queries)
+	tradeRepository := persistence.NewTradeRepository(dbConn, queries)
+	instrumentRepository := persistence.NewInstrumentRepository(queries)
+	fxRateRepository := persistence.NewFXRateRepository(queries)
+	auditRepository := persistence.NewAuditRepository(queries)
+	transactor := persistence.NewTransactor(dbConn, queries)
 
 	// Initialize services
-	accountService := accountapp.NewService(accountRepository)
-	strategyService := strategyapp.NewService(strategyRepository)
-	tradeService := tradeapp.NewService(tradeRepository, accountRepository)
+	strategyService := strategyapp.NewService(strategyRepository, auditRepository)
+	tradeService := tradeapp.NewService(tradeRepository, accountRepository, instrumentRepository, fxRateRepository, userRepository, auditRepository, transactor)
+	accountService := accountapp.NewService(accountRepository, auditRepository, transactor, tradeService)
+	fxService := fxapp.NewService(fxRateRepository)
 
 	return &Seeder{
 		userSeeder:     NewUserSeeder(userRepository),
 		accountSeeder:  NewAccountSeeder(accountService),
 		strategySeeder: NewStrategySeeder(strategyService),
 		tradeSeeder:    NewTradeSeeder(tradeService),
+		fxRateSeeder:   NewFXRateSeeder(fxService),
 		dbConn:         dbConn,
 	}
 }
@@ -98,6 +107,16 @@ func (s *Seeder) Run(ctx context.Context, config Config) error {
 	}
 	log.Printf("Created %d strategies per user", config.StrategiesPerUser)
 
+	// Seed FX rates so non-USD quoted trades can be converted to the account currency
+	log.Println("Seeding FX rates...")
+	ratesFrom := time.Now().AddDate(0, 0, -91)
+	for _, userID := range userIDs {
+		if err := s.fxRateSeeder.SeedForUser(ctx, userID, ratesFrom); err != nil {
+			return fmt.Errorf("failed to seed fx rates for user %d: %w", userID, err)
+		}
+	}
+	log.Printf("Created %d FX rates per user", len(DefaultRates))
+
 	// Seed trades for each account
 	log.Println("Seeding trades...")
 	totalTrades := 0
@@ -126,7 +145,8 @@ func (s *Seeder) ClearData(ctx context.Context) error {
 		"TRUNCATE TABLE trades CASCADE",
 		"TRUNCATE TABLE strategies CASCADE",
 		"TRUNCATE TABLE accounts CASCADE",
-		"TRUNCATE TABLE users CASCADE",
+		// DELETE instead of TRUNCATE so the built-in instrument registry survives
+		"DELETE FROM users",
 	}
 
 	for _, query := range queries {
@@ -159,3 +179,8 @@ func (s *Seeder) Strategies() *StrategySeeder {
 func (s *Seeder) Trades() *TradeSeeder {
 	return s.tradeSeeder
 }
+
+// FXRates returns the FX rate seeder
+func (s *Seeder) FXRates() *FXRateSeeder {
+	return s.fxRateSeeder
+}
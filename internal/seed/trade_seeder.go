@@ -3,6 +3,7 @@ package seed
 import (
 	"context"
 	"fmt"
+	"math"
 	"math/rand"
 	"time"
 
@@ -61,7 +62,10 @@ func (s *TradeSeeder) SeedForAccount(ctx context.Context, userID, accountID int6
 		entry := gofakeit.Float64Range(1.0, 1.5)
 		lots := gofakeit.Float64Range(0.01, 2.0)
 
+		openedAt := time.Date(tradeDate.Year(), tradeDate.Month(), tradeDate.Day(), rand.Intn(24), rand.Intn(60), 0, 0, time.UTC)
+
 		var exitPtr *float64
+		var closeDate, closeTime string
 
 		if rand.Float64() < 0.95 {
 			// 55% win rate
@@ -79,6 +83,10 @@ func (s *TradeSeeder) SeedForAccount(ctx context.Context, userID, accountID int6
 				exitPrice = entry - (pipValue * 0.0001)
 			}
 			exitPtr = &exitPrice
+
+			// Held between 5 minutes and 2 days
+			closedAt := openedAt.Add(time.Duration(gofakeit.Number(5, 48*60)) * time.Minute)
+			closeDate, closeTime = closedAt.Format("2006-01-02"), closedAt.Format("15:04")
 		}
 
 		stopLoss := entry - gofakeit.Float64Range(0.001, 0.01)
@@ -101,13 +109,20 @@ func (s *TradeSeeder) SeedForAccount(ctx context.Context, userID, accountID int6
 			}
 		}
 
+		// Round-turn commission of $7 per lot, plus swap on some positions held overnight
+		commission := math.Round(lots*7*100) / 100
+		swap := 0.0
+		if rand.Float64() < 0.2 {
+			swap = math.Round(gofakeit.Float64Range(-5, 1)*100) / 100
+		}
+
 		// Select 1-2 random strategies
 		selectedStrategyIDs := selectRandomStrategies(strategyIDs)
 
 		tradeDTO, err := s.tradeService.CreateTrade(ctx, userID, tradeapp.CreateTradeRequest{
 			AccountID:   &accountID,
-			Date:        tradeDate.Format("2006-01-02"),
-			Time:        fmt.Sprintf("%02d:%02d", rand.Intn(24), rand.Intn(60)),
+			Date:        openedAt.Format("2006-01-02"),
+			Time:        openedAt.Format("15:04"),
 			Pair:        pair,
 			Type:        tradeType,
 			Entry:       entry,
@@ -117,6 +132,10 @@ func (s *TradeSeeder) SeedForAccount(ctx context.Context, userID, accountID int6
 			TakeProfit:  &takeProfit,
 			Notes:       notes,
 			Mistakes:    mistakes,
+			Commission:  commission,
+			Swap:        swap,
+			CloseDate:   closeDate,
+			CloseTime:   closeTime,
 			StrategyIDs: selectedStrategyIDs,
 		})
 		if err != nil {
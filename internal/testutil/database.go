@@ -174,11 +174,22 @@ func TruncateTables(t *testing.T, db *sql.DB) {
 	t.Helper()
 
 	tables := []string{
+		"audit_events",
+		"trade_strategy_rules",
 		"trade_strategies",
+		"trade_tags",
+		"trade_mistakes",
+		"trade_ratings",
 		"trades",
+		"trade_groups",
+		"trade_templates",
+		"strategy_rules",
 		"strategies",
+		"tags",
+		"mistake_types",
+		"rating_dimensions",
+		"grades",
 		"accounts",
-		"users",
 	}
 
 	for _, table := range tables {
@@ -187,4 +198,13 @@ func TruncateTables(t *testing.T, db *sql.DB) {
 			t.Fatalf("failed to truncate table %s: %v", table, err)
 		}
 	}
+
+	// Users are deleted rather than truncated so the cascade only removes
+	// user-owned rows and the built-in instrument registry survives
+	if _, err := db.Exec("DELETE FROM users"); err != nil {
+		t.Fatalf("failed to delete users: %v", err)
+	}
+	if _, err := db.Exec("ALTER SEQUENCE users_id_seq RESTART"); err != nil {
+		t.Fatalf("failed to reset users sequence: %v", err)
+	}
 }
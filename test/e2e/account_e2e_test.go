@@ -141,6 +141,7 @@ func TestE2E_Account_UpdateAndDelete(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/accounts/%d", accountID), bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"1"`)
 		rec := httptest.NewRecorder()
 
 		e.ServeHTTP(rec, req)
@@ -148,6 +149,9 @@ func TestE2E_Account_UpdateAndDelete(t *testing.T) {
 		if rec.Code != http.StatusOK {
 			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
 		}
+		if etag := rec.Header().Get("ETag"); etag != `"2"` {
+			t.Errorf("expected ETag \"2\", got %s", etag)
+		}
 
 		var response map[string]any
 		json.Unmarshal(rec.Body.Bytes(), &response)
@@ -178,10 +182,45 @@ func TestE2E_Account_UpdateAndDelete(t *testing.T) {
 		}
 	})
 
+	t.Run("update without If-Match is rejected", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]any{
+			"name":           "Other Name",
+			"broker":         "New Broker",
+			"account_number": "87654321",
+			"account_type":   "live",
+			"currency":       "EUR",
+		})
+
+		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/accounts/%d", accountID), bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPreconditionRequired {
+			t.Fatalf("expected status 428, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
+	t.Run("delete with a stale version is rejected", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/accounts/%d", accountID), nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPreconditionFailed {
+			t.Fatalf("expected status 412, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+
 	// Delete account
 	t.Run("delete account", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/accounts/%d", accountID), nil)
 		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"2"`)
 		rec := httptest.NewRecorder()
 
 		e.ServeHTTP(rec, req)
@@ -192,7 +231,7 @@ func TestE2E_Account_UpdateAndDelete(t *testing.T) {
 
 		// Verify deleted from database
 		var count int
-		err := pg.DB.QueryRow("SELECT COUNT(*) FROM accounts WHERE id = $1", accountID).Scan(&count)
+		err := pg.DB.QueryRow("SELECT COUNT(*) FROM accounts WHERE id = $1 AND deleted_at IS NULL", accountID).Scan(&count)
 		if err != nil {
 			t.Fatalf("failed to query account count: %v", err)
 		}
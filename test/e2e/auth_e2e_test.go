@@ -78,6 +78,44 @@ func TestE2E_Auth_RegisterAndLogin(t *testing.T) {
 			t.Log("tokens match (this is fine - just different generation)")
 		}
 	})
+
+	// Settings
+	t.Run("update timezone setting", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"timezone": "Europe/Berlin"})
+
+		req := httptest.NewRequest(http.MethodPut, "/api/me/settings", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var response map[string]any
+		json.Unmarshal(rec.Body.Bytes(), &response)
+
+		if response["timezone"] != "Europe/Berlin" {
+			t.Errorf("expected timezone Europe/Berlin, got %v", response["timezone"])
+		}
+	})
+
+	t.Run("unknown timezone is rejected", func(t *testing.T) {
+		body, _ := json.Marshal(map[string]string{"timezone": "Europe/Atlantis"})
+
+		req := httptest.NewRequest(http.MethodPut, "/api/me/settings", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
 }
 
 func TestE2E_Auth_InvalidCredentials(t *testing.T) {
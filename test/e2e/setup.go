@@ -18,8 +18,15 @@ import (
 	accountapp "github.com/raihanstark/trade-journal/internal/application/account"
 	analyticsapp "github.com/raihanstark/trade-journal/internal/application/analytics"
 	"github.com/raihanstark/trade-journal/internal/application/auth"
+	fxapp "github.com/raihanstark/trade-journal/internal/application/fx"
+	gradeapp "github.com/raihanstark/trade-journal/internal/application/grade"
+	instrumentapp "github.com/raihanstark/trade-journal/internal/application/instrument"
+	mistakeapp "github.com/raihanstark/trade-journal/internal/application/mistake"
+	ratingapp "github.com/raihanstark/trade-journal/internal/application/rating"
 	strategyapp "github.com/raihanstark/trade-journal/internal/application/strategy"
 	tradeapp "github.com/raihanstark/trade-journal/internal/application/trade"
+	tradegroupapp "github.com/raihanstark/trade-journal/internal/application/tradegroup"
+	tradetemplateapp "github.com/raihanstark/trade-journal/internal/application/tradetemplate"
 	"github.com/raihanstark/trade-journal/internal/db"
 	"github.com/raihanstark/trade-journal/internal/infrastructure/http/handlers"
 	custommiddleware "github.com/raihanstark/trade-journal/internal/infrastructure/http/middleware"
@@ -29,23 +36,39 @@ import (
 )
 
 // setupTestServer creates a fully configured Echo server for e2e tests
-func setupTestServer(t *testing.T, _ *sql.DB, queries *db.Queries) *echo.Echo {
+func setupTestServer(t *testing.T, conn *sql.DB, queries *db.Queries) *echo.Echo {
 	t.Helper()
 
 	// Initialize infrastructure
 	userRepository := persistence.NewUserRepository(queries)
 	accountRepository := persistence.NewAccountRepository(queries)
 	strategyRepository := persistence.NewStrategyRepository(queries)
-	tradeRepository := persistence.NewTradeRepository(queries)
+	tradeRepository := persistence.NewTradeRepository(conn, queries)
 	analyticsRepository := persistence.NewAnalyticsRepository(queries)
+	instrumentRepository := persistence.NewInstrumentRepository(queries)
+	fxRateRepository := persistence.NewFXRateRepository(queries)
+	mistakeRepository := persistence.NewMistakeRepository(queries)
+	ratingRepository := persistence.NewRatingRepository(queries)
+	gradeRepository := persistence.NewGradeRepository(queries)
+	tradeGroupRepository := persistence.NewTradeGroupRepository(queries)
+	tradeTemplateRepository := persistence.NewTradeTemplateRepository(queries)
+	auditRepository := persistence.NewAuditRepository(queries)
+	transactor := persistence.NewTransactor(conn, queries)
 	tokenGenerator := security.NewJWTTokenGenerator("test-secret-key")
 
 	// Initialize application layer
 	authService := auth.NewService(userRepository, tokenGenerator)
-	accountService := accountapp.NewService(accountRepository)
-	strategyService := strategyapp.NewService(strategyRepository)
-	tradeService := tradeapp.NewService(tradeRepository, accountRepository)
-	analyticsService := analyticsapp.NewService(analyticsRepository)
+	strategyService := strategyapp.NewService(strategyRepository, auditRepository)
+	tradeService := tradeapp.NewService(tradeRepository, accountRepository, instrumentRepository, fxRateRepository, userRepository, auditRepository, transactor)
+	accountService := accountapp.NewService(accountRepository, auditRepository, transactor, tradeService)
+	analyticsService := analyticsapp.NewService(analyticsRepository, userRepository)
+	instrumentService := instrumentapp.NewService(instrumentRepository)
+	fxService := fxapp.NewService(fxRateRepository)
+	mistakeService := mistakeapp.NewService(mistakeRepository)
+	ratingService := ratingapp.NewService(ratingRepository)
+	gradeService := gradeapp.NewService(gradeRepository)
+	tradeGroupService := tradegroupapp.NewService(tradeGroupRepository, tradeRepository, transactor)
+	tradeTemplateService := tradetemplateapp.NewService(tradeTemplateRepository)
 
 	// Initialize storage (MinIO for tests)
 	minioStorage, err := storage.NewMinIOStorage("localhost:9000", "minioadmin", "minioadmin123", "trade-journal", false)
@@ -66,6 +89,14 @@ func setupTestServer(t *testing.T, _ *sql.DB, queries *db.Queries) *echo.Echo {
 	strategyHandler := handlers.NewStrategyHandler(strategyService)
 	tradeHandler := handlers.NewTradeHandler(tradeService, minioStorage)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService)
+	instrumentHandler := handlers.NewInstrumentHandler(instrumentService)
+	fxRateHandler := handlers.NewFXRateHandler(fxService)
+	mistakeHandler := handlers.NewMistakeHandler(mistakeService)
+	ratingHandler := handlers.NewRatingHandler(ratingService)
+	gradeHandler := handlers.NewGradeHandler(gradeService)
+	tradeGroupHandler := handlers.NewTradeGroupHandler(tradeGroupService)
+	tradeTemplateHandler := handlers.NewTradeTemplateHandler(tradeTemplateService)
+	trashHandler := handlers.NewTrashHandler(tradeService, accountService)
 
 	// Create Echo instance
 	e := echo.New()
@@ -79,12 +110,17 @@ func setupTestServer(t *testing.T, _ *sql.DB, queries *db.Queries) *echo.Echo {
 	protected := e.Group("/api")
 	protected.Use(custommiddleware.JWTAuth(tokenGenerator))
 
+	// User routes
+	protected.GET("/me/settings", authHandler.GetSettings)
+	protected.PUT("/me/settings", authHandler.UpdateSettings)
+
 	// Account routes
 	protected.POST("/accounts", accountHandler.CreateAccount)
 	protected.GET("/accounts", accountHandler.GetAccounts)
 	protected.GET("/accounts/:id", accountHandler.GetAccount)
 	protected.PUT("/accounts/:id", accountHandler.UpdateAccount)
 	protected.DELETE("/accounts/:id", accountHandler.DeleteAccount)
+	protected.POST("/accounts/:id/restore", accountHandler.RestoreAccount)
 
 	// Strategy routes
 	protected.POST("/strategies", strategyHandler.CreateStrategy)
@@ -96,10 +132,73 @@ func setupTestServer(t *testing.T, _ *sql.DB, queries *db.Queries) *echo.Echo {
 	// Trade routes
 	protected.POST("/trades", tradeHandler.CreateTrade)
 	protected.GET("/trades", tradeHandler.GetTrades)
+	protected.POST("/trades/bulk", tradeHandler.BulkTrades)
+	protected.GET("/trades/search", tradeHandler.SearchTrades)
 	protected.GET("/trades/:id", tradeHandler.GetTrade)
 	protected.PUT("/trades/:id", tradeHandler.UpdateTrade)
+	protected.PATCH("/trades/:id", tradeHandler.PatchTrade)
 	protected.DELETE("/trades/:id", tradeHandler.DeleteTrade)
+	protected.POST("/trades/:id/restore", tradeHandler.RestoreTrade)
+	protected.POST("/trades/:id/duplicate", tradeHandler.DuplicateTrade)
+	protected.GET("/trades/:id/history", tradeHandler.GetTradeHistory)
 	protected.POST("/trades/:id/chart/:type", tradeHandler.UploadChart)
+	protected.GET("/trades/:id/executions", tradeHandler.GetExecutions)
+	protected.POST("/trades/:id/executions", tradeHandler.AddExecution)
+	protected.DELETE("/trades/:id/executions/:executionId", tradeHandler.DeleteExecution)
+
+	// Tag routes
+	protected.GET("/tags", tradeHandler.GetTags)
+
+	// Mistake type routes
+	protected.POST("/mistake-types", mistakeHandler.CreateMistakeType)
+	protected.GET("/mistake-types", mistakeHandler.GetMistakeTypes)
+	protected.GET("/mistake-types/:id", mistakeHandler.GetMistakeType)
+	protected.PUT("/mistake-types/:id", mistakeHandler.UpdateMistakeType)
+	protected.DELETE("/mistake-types/:id", mistakeHandler.DeleteMistakeType)
+
+	// Rating dimension routes
+	protected.POST("/rating-dimensions", ratingHandler.CreateDimension)
+	protected.GET("/rating-dimensions", ratingHandler.GetDimensions)
+	protected.GET("/rating-dimensions/:id", ratingHandler.GetDimension)
+	protected.PUT("/rating-dimensions/:id", ratingHandler.UpdateDimension)
+	protected.DELETE("/rating-dimensions/:id", ratingHandler.DeleteDimension)
+
+	// Grade scale routes
+	protected.GET("/grades", gradeHandler.GetScale)
+	protected.PUT("/grades", gradeHandler.UpdateScale)
+
+	// Trade group routes
+	protected.POST("/trade-groups", tradeGroupHandler.CreateTradeGroup)
+	protected.GET("/trade-groups", tradeGroupHandler.GetTradeGroups)
+	protected.GET("/trade-groups/:id", tradeGroupHandler.GetTradeGroup)
+	protected.PUT("/trade-groups/:id", tradeGroupHandler.UpdateTradeGroup)
+	protected.DELETE("/trade-groups/:id", tradeGroupHandler.DeleteTradeGroup)
+
+	// Trade template routes
+	protected.POST("/trade-templates", tradeTemplateHandler.CreateTradeTemplate)
+	protected.GET("/trade-templates", tradeTemplateHandler.GetTradeTemplates)
+	protected.GET("/trade-templates/:id", tradeTemplateHandler.GetTradeTemplate)
+	protected.PUT("/trade-templates/:id", tradeTemplateHandler.UpdateTradeTemplate)
+	protected.DELETE("/trade-templates/:id", tradeTemplateHandler.DeleteTradeTemplate)
+
+	// Trash routes
+	protected.GET("/trash", trashHandler.GetTrash)
+
+	// Tool routes
+	protected.POST("/tools/position-size", tradeHandler.CalculatePositionSize)
+
+	// Instrument routes
+	protected.POST("/instruments", instrumentHandler.CreateInstrument)
+	protected.GET("/instruments", instrumentHandler.GetInstruments)
+	protected.GET("/instruments/:id", instrumentHandler.GetInstrument)
+	protected.PUT("/instruments/:id", instrumentHandler.UpdateInstrument)
+	protected.DELETE("/instruments/:id", instrumentHandler.DeleteInstrument)
+
+	// FX rate routes
+	protected.POST("/fx-rates", fxRateHandler.CreateRate)
+	protected.POST("/fx-rates/import", fxRateHandler.ImportRates)
+	protected.GET("/fx-rates", fxRateHandler.GetRates)
+	protected.DELETE("/fx-rates/:id", fxRateHandler.DeleteRate)
 
 	// Analytics routes
 	protected.GET("/analytics", analyticsHandler.GetUserAnalytics)
@@ -149,6 +149,7 @@ func TestE2E_Strategy_UpdateAndDelete(t *testing.T) {
 		req := httptest.NewRequest(http.MethodPut, fmt.Sprintf("/api/strategies/%d", strategyID), bytes.NewReader(body))
 		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
 		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"1"`)
 		rec := httptest.NewRecorder()
 
 		e.ServeHTTP(rec, req)
@@ -187,6 +188,7 @@ func TestE2E_Strategy_UpdateAndDelete(t *testing.T) {
 	t.Run("delete strategy", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/strategies/%d", strategyID), nil)
 		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"2"`)
 		rec := httptest.NewRecorder()
 
 		e.ServeHTTP(rec, req)
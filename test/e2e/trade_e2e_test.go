@@ -6,7 +6,10 @@ import (
 	"fmt"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"reflect"
 	"strconv"
+	"strings"
 	"testing"
 
 	"github.com/labstack/echo/v4"
@@ -43,8 +46,8 @@ func TestE2E_Trade_Validation(t *testing.T) {
 
 		e.ServeHTTP(rec, req)
 
-		if rec.Code != http.StatusInternalServerError {
-			t.Errorf("expected status 500, got %d", rec.Code)
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Errorf("expected status 422, got %d", rec.Code)
 		}
 
 		var response map[string]any
@@ -54,6 +57,60 @@ func TestE2E_Trade_Validation(t *testing.T) {
 		if errorMsg != "account_id is required" {
 			t.Errorf("expected error 'account_id is required', got '%s'", errorMsg)
 		}
+
+		fieldErrors := response["errors"].([]any)
+		if len(fieldErrors) != 1 {
+			t.Fatalf("expected 1 field error, got %v", fieldErrors)
+		}
+		fieldError := fieldErrors[0].(map[string]any)
+		if fieldError["field"] != "account_id" || fieldError["code"] != "required" {
+			t.Errorf("expected account_id/required, got %v", fieldError)
+		}
+	})
+
+	t.Run("invalid BUY lists each invalid field", func(t *testing.T) {
+		accountID := createAccount(t, e, authToken, "Validation Account")
+
+		payload := map[string]any{
+			"account_id": accountID,
+			"date":       "2025-01-15",
+			"time":       "10:00",
+			"pair":       "",
+			"type":       "BUY",
+			"entry":      1.1000,
+			"lots":       -1,
+			"stop_loss":  1.1050,
+		}
+		body, _ := json.Marshal(payload)
+
+		req := httptest.NewRequest(http.MethodPost, "/api/trades", bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d", rec.Code)
+		}
+
+		var response struct {
+			Errors []struct {
+				Field   string `json:"field"`
+				Code    string `json:"code"`
+				Message string `json:"message"`
+			} `json:"errors"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &response)
+
+		var fields []string
+		for _, f := range response.Errors {
+			fields = append(fields, f.Field+"/"+f.Code)
+		}
+		want := "pair/required lots/must_be_positive stop_loss/wrong_side"
+		if strings.Join(fields, " ") != want {
+			t.Errorf("expected %s, got %v", want, fields)
+		}
 	})
 }
 
@@ -217,10 +274,50 @@ func TestE2E_Trade_ClosedTradeWithProfitAndLoss(t *testing.T) {
 		}
 	})
 
-	// Delete first trade (the +$500 one) and verify balance reverts
+	// Patch the exit of the first trade, doubling its P/L to $1000
+	t.Run("patching exit applies P/L difference to balance", func(t *testing.T) {
+		body := []byte(`{"exit": 1.1100}`)
+
+		req := httptest.NewRequest(http.MethodPatch, fmt.Sprintf("/api/trades/%d", tradeID), bytes.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, "application/merge-patch+json")
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"1"`)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+		if etag := rec.Header().Get("ETag"); etag != `"2"` {
+			t.Errorf("expected ETag \"2\", got %s", etag)
+		}
+
+		var response map[string]any
+		json.Unmarshal(rec.Body.Bytes(), &response)
+		if pl := response["pl"].(float64); pl != 1000.0 {
+			t.Errorf("expected P/L 1000, got %.2f", pl)
+		}
+		if pair := response["pair"].(string); pair != "EUR/USD" {
+			t.Errorf("expected pair to be kept, got %s", pair)
+		}
+
+		// Verify balance moved by the difference to -48000
+		var balance float64
+		err := pg.DB.QueryRow("SELECT current_balance FROM accounts WHERE id = $1", accountID).Scan(&balance)
+		if err != nil {
+			t.Fatalf("failed to query balance: %v", err)
+		}
+		if balance != -48000.0 {
+			t.Errorf("expected balance -48000, got %.2f", balance)
+		}
+	})
+
+	// Delete first trade (now the +$1000 one) and verify balance reverts
 	t.Run("deleting trade reverts balance", func(t *testing.T) {
 		req := httptest.NewRequest(http.MethodDelete, fmt.Sprintf("/api/trades/%d", tradeID), nil)
 		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		req.Header.Set("If-Match", `"2"`)
 		rec := httptest.NewRecorder()
 
 		e.ServeHTTP(rec, req)
@@ -229,7 +326,7 @@ func TestE2E_Trade_ClosedTradeWithProfitAndLoss(t *testing.T) {
 			t.Fatalf("expected status 204, got %d: %s", rec.Code, rec.Body.String())
 		}
 
-		// Verify balance reverted to -49000 (1000 - 50000, after removing the +500 trade)
+		// Verify balance reverted to -49000 (1000 - 50000, after removing the +1000 trade)
 		var balance float64
 		err := pg.DB.QueryRow("SELECT current_balance FROM accounts WHERE id = $1", accountID).Scan(&balance)
 		if err != nil {
@@ -239,6 +336,48 @@ func TestE2E_Trade_ClosedTradeWithProfitAndLoss(t *testing.T) {
 			t.Errorf("expected balance -49000 after delete, got %.2f", balance)
 		}
 	})
+
+	t.Run("deleted trade is listed in the trash", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/trash", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		var trash struct {
+			Trades []map[string]any `json:"trades"`
+		}
+		json.Unmarshal(rec.Body.Bytes(), &trash)
+		if len(trash.Trades) != 1 || int(trash.Trades[0]["id"].(float64)) != tradeID {
+			t.Errorf("expected the deleted trade in the trash, got %s", rec.Body.String())
+		}
+	})
+
+	t.Run("restoring trade re-applies balance", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodPost, fmt.Sprintf("/api/trades/%d/restore", tradeID), nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		rec := httptest.NewRecorder()
+
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+		}
+
+		// Verify balance back to -48000 with the +1000 trade restored
+		var balance float64
+		err := pg.DB.QueryRow("SELECT current_balance FROM accounts WHERE id = $1", accountID).Scan(&balance)
+		if err != nil {
+			t.Fatalf("failed to query balance: %v", err)
+		}
+		if balance != -48000.0 {
+			t.Errorf("expected balance -48000 after restore, got %.2f", balance)
+		}
+	})
 }
 
 func TestE2E_Trade_FilterTradesByAccount(t *testing.T) {
@@ -273,8 +412,9 @@ func TestE2E_Trade_FilterTradesByAccount(t *testing.T) {
 			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
 		}
 
-		var trades []map[string]any
-		json.Unmarshal(rec.Body.Bytes(), &trades)
+		var page tradePage
+		json.Unmarshal(rec.Body.Bytes(), &page)
+		trades := page.Trades
 		if len(trades) != 1 {
 			t.Fatalf("expected 1 trade, got %d", len(trades))
 		}
@@ -295,8 +435,9 @@ func TestE2E_Trade_FilterTradesByAccount(t *testing.T) {
 			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
 		}
 
-		var trades []map[string]any
-		json.Unmarshal(rec.Body.Bytes(), &trades)
+		var page tradePage
+		json.Unmarshal(rec.Body.Bytes(), &page)
+		trades := page.Trades
 		if len(trades) != 1 {
 			t.Fatalf("expected 1 trade, got %d", len(trades))
 		}
@@ -317,8 +458,9 @@ func TestE2E_Trade_FilterTradesByAccount(t *testing.T) {
 			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
 		}
 
-		var trades []map[string]any
-		json.Unmarshal(rec.Body.Bytes(), &trades)
+		var page tradePage
+		json.Unmarshal(rec.Body.Bytes(), &page)
+		trades := page.Trades
 		if len(trades) != 0 {
 			t.Fatalf("expected 0 trades, got %d", len(trades))
 		}
@@ -355,8 +497,9 @@ func TestE2E_Trade_FilterTradesByDate(t *testing.T) {
 			t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
 		}
 
-		var trades []map[string]any
-		json.Unmarshal(rec.Body.Bytes(), &trades)
+		var page tradePage
+		json.Unmarshal(rec.Body.Bytes(), &page)
+		trades := page.Trades
 
 		if len(trades) != 2 {
 			t.Fatalf("expected 2 trades, got %d", len(trades))
@@ -369,6 +512,55 @@ func TestE2E_Trade_FilterTradesByDate(t *testing.T) {
 		}
 	})
 
+	t.Run("pages through trades with next_cursor", func(t *testing.T) {
+		var dates []any
+		path := "/api/trades?limit=2"
+		for {
+			req := httptest.NewRequest(http.MethodGet, path, nil)
+			req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			if rec.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", rec.Code, rec.Body.String())
+			}
+
+			var page tradePage
+			json.Unmarshal(rec.Body.Bytes(), &page)
+			for _, trade := range page.Trades {
+				dates = append(dates, trade["date"])
+			}
+			if page.NextCursor == nil {
+				break
+			}
+			if len(page.Trades) != 2 {
+				t.Fatalf("expected a full page of 2 trades before the last page, got %d", len(page.Trades))
+			}
+			path = "/api/trades?limit=2&cursor=" + url.QueryEscape(*page.NextCursor)
+		}
+
+		expected := []any{"2025-01-16", "2025-01-15", "2025-01-14"}
+		if !reflect.DeepEqual(dates, expected) {
+			t.Fatalf("expected trades on %v, got %v", expected, dates)
+		}
+	})
+
+	t.Run("rejects an invalid cursor", func(t *testing.T) {
+		req := httptest.NewRequest(http.MethodGet, "/api/trades?cursor=not-a-cursor", nil)
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+authToken)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("expected status 422, got %d: %s", rec.Code, rec.Body.String())
+		}
+	})
+}
+
+// tradePage is the envelope of a trade listing
+type tradePage struct {
+	Trades     []map[string]any `json:"trades"`
+	NextCursor *string          `json:"next_cursor"`
 }
 
 // Helper functions
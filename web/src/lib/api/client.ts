@@ -35,6 +35,7 @@ export interface Account {
 	is_active: boolean;
 	created_at: string;
 	updated_at: string;
+	version: number;
 }
 
 export interface CreateAccountRequest {
@@ -61,6 +62,7 @@ export interface Strategy {
 	description: string;
 	created_at: string;
 	updated_at: string;
+	version: number;
 }
 
 export interface CreateStrategyRequest {
@@ -85,6 +87,8 @@ export interface Trade {
 	lots: number;
 	pips: number | null;
 	pl: number | null;
+	// No FX rate was available, so pl is in the quote currency rather than the account's
+	pl_unconverted: boolean;
 	rr: string;
 	status: 'open' | 'closed';
 	stop_loss: number | null;
@@ -97,6 +101,7 @@ export interface Trade {
 	strategies: Array<{ id: number; name: string }>;
 	created_at: string;
 	updated_at: string;
+	version: number;
 }
 
 export interface CreateTradeRequest {
@@ -133,6 +138,84 @@ export interface UpdateTradeRequest {
 	strategy_ids: number[];
 }
 
+export type TradeSortField = 'date' | 'pair' | 'type' | 'status' | 'lots' | 'pips' | 'pl' | 'r';
+
+export interface TradeListFilters {
+	pairs?: string[];
+	types?: Trade['type'][];
+	statuses?: string[];
+	strategy_ids?: number[];
+	tags?: string[];
+	grade?: string;
+	min_pl?: number;
+	max_pl?: number;
+	min_r?: number;
+	max_r?: number;
+	min_lots?: number;
+	max_lots?: number;
+	weekdays?: string[];
+	hours?: number[];
+	// Most significant first; prefix a field with - to sort descending
+	sort?: (TradeSortField | `-${TradeSortField}`)[];
+	// Page size, 50 by default and at most 200
+	limit?: number;
+	// next_cursor of the previous page, issued for the same sort
+	cursor?: string;
+}
+
+export interface TradePage {
+	trades: Trade[];
+	// Fetches the following page; null on the last page
+	next_cursor: string | null;
+}
+
+export type BulkTradeAction =
+	| 'delete'
+	| 'move_account'
+	| 'add_strategy'
+	| 'remove_strategy'
+	| 'add_tag'
+	| 'remove_tag';
+
+export interface BulkTradeRequest {
+	action: BulkTradeAction;
+	trade_ids: number[];
+	account_id?: number;
+	strategy_id?: number;
+	tag?: string;
+}
+
+export interface BulkTradeResult {
+	applied: boolean;
+	items: {
+		trade_id: number;
+		status: 'deleted' | 'updated' | 'unchanged' | 'failed';
+		error?: string;
+		trade?: Trade;
+	}[];
+	balance_changes: { account_id: number; amount: number }[];
+}
+
+export interface TradeSearchResult {
+	trade: Trade;
+	rank: number;
+	notes_snippet?: string;
+	mistakes_snippet?: string;
+}
+
+export interface TradeTemplate {
+	id: number;
+	name: string;
+	defaults: Partial<CreateTradeRequest>;
+	created_at: string;
+	updated_at: string;
+}
+
+export interface TradeTemplateRequest {
+	name: string;
+	defaults: Partial<CreateTradeRequest>;
+}
+
 export interface Analytics {
 	total_pl: number;
 	win_rate: number;
@@ -248,22 +331,29 @@ class ApiClient {
 	async updateAccount(
 		id: number,
 		req: UpdateAccountRequest,
+		version: number,
 		token: string
 	): Promise<{ data?: Account; error?: string }> {
 		return this.request<Account>(`/api/accounts/${id}`, {
 			method: 'PUT',
 			headers: {
-				Authorization: `Bearer ${token}`
+				Authorization: `Bearer ${token}`,
+				'If-Match': `"${version}"`
 			},
 			body: JSON.stringify(req)
 		});
 	}
 
-	async deleteAccount(id: number, token: string): Promise<{ data?: any; error?: string }> {
+	async deleteAccount(
+		id: number,
+		version: number,
+		token: string
+	): Promise<{ data?: any; error?: string }> {
 		return this.request<any>(`/api/accounts/${id}`, {
 			method: 'DELETE',
 			headers: {
-				Authorization: `Bearer ${token}`
+				Authorization: `Bearer ${token}`,
+				'If-Match': `"${version}"`
 			}
 		});
 	}
@@ -303,22 +393,29 @@ class ApiClient {
 	async updateStrategy(
 		id: number,
 		req: UpdateStrategyRequest,
+		version: number,
 		token: string
 	): Promise<{ data?: Strategy; error?: string }> {
 		return this.request<Strategy>(`/api/strategies/${id}`, {
 			method: 'PUT',
 			headers: {
-				Authorization: `Bearer ${token}`
+				Authorization: `Bearer ${token}`,
+				'If-Match': `"${version}"`
 			},
 			body: JSON.stringify(req)
 		});
 	}
 
-	async deleteStrategy(id: number, token: string): Promise<{ data?: any; error?: string }> {
+	async deleteStrategy(
+		id: number,
+		version: number,
+		token: string
+	): Promise<{ data?: any; error?: string }> {
 		return this.request<any>(`/api/strategies/${id}`, {
 			method: 'DELETE',
 			headers: {
-				Authorization: `Bearer ${token}`
+				Authorization: `Bearer ${token}`,
+				'If-Match': `"${version}"`
 			}
 		});
 	}
@@ -328,8 +425,9 @@ class ApiClient {
 		token: string,
 		accountId?: number,
 		startDate?: string,
-		endDate?: string
-	): Promise<{ data?: Trade[]; error?: string }> {
+		endDate?: string,
+		filters: TradeListFilters = {}
+	): Promise<{ data?: TradePage; error?: string }> {
 		const params = new URLSearchParams();
 
 		if (accountId) {
@@ -344,10 +442,95 @@ class ApiClient {
 			params.append('end_date', endDate);
 		}
 
+		const lists: [string, (string | number)[] | undefined][] = [
+			['pair', filters.pairs],
+			['type', filters.types],
+			['status', filters.statuses],
+			['strategy_id', filters.strategy_ids],
+			['tag', filters.tags],
+			['weekday', filters.weekdays],
+			['hour', filters.hours],
+			['sort', filters.sort]
+		];
+		for (const [name, values] of lists) {
+			values?.forEach((value) => params.append(name, value.toString()));
+		}
+
+		const bounds: [string, number | undefined][] = [
+			['min_pl', filters.min_pl],
+			['max_pl', filters.max_pl],
+			['min_r', filters.min_r],
+			['max_r', filters.max_r],
+			['min_lots', filters.min_lots],
+			['max_lots', filters.max_lots]
+		];
+		for (const [name, value] of bounds) {
+			if (value !== undefined) {
+				params.append(name, value.toString());
+			}
+		}
+
+		if (filters.grade) {
+			params.append('grade', filters.grade);
+		}
+
+		if (filters.limit) {
+			params.append('limit', filters.limit.toString());
+		}
+
+		if (filters.cursor) {
+			params.append('cursor', filters.cursor);
+		}
+
 		const queryString = params.toString();
 		const url = queryString ? `/api/trades?${queryString}` : '/api/trades';
 
-		return this.request<Trade[]>(url, {
+		return this.request<TradePage>(url, {
+			method: 'GET',
+			headers: {
+				Authorization: `Bearer ${token}`
+			}
+		});
+	}
+
+	// Follows next_cursor to collect every open BUY/SELL trade, however many pages they span
+	async getOpenTrades(
+		token: string,
+		accountId?: number,
+		startDate?: string,
+		endDate?: string
+	): Promise<{ data?: Trade[]; error?: string }> {
+		const trades: Trade[] = [];
+		let cursor: string | undefined;
+		do {
+			const { data, error } = await this.getTrades(token, accountId, startDate, endDate, {
+				types: ['BUY', 'SELL'],
+				statuses: ['open'],
+				limit: 200,
+				cursor
+			});
+			if (error || !data) {
+				return { error: error || 'Failed to load open trades' };
+			}
+			trades.push(...data.trades);
+			cursor = data.next_cursor ?? undefined;
+		} while (cursor);
+
+		return { data: trades };
+	}
+
+	async searchTrades(
+		query: string,
+		token: string,
+		limit?: number
+	): Promise<{ data?: TradeSearchResult[]; error?: string }> {
+		const params = new URLSearchParams({ q: query });
+
+		if (limit) {
+			params.append('limit', limit.toString());
+		}
+
+		return this.request<TradeSearchResult[]>(`/api/trades/search?${params.toString()}`, {
 			method: 'GET',
 			headers: {
 				Authorization: `Bearer ${token}`
@@ -380,10 +563,42 @@ class ApiClient {
 	async updateTrade(
 		id: number,
 		req: UpdateTradeRequest,
+		version: number,
 		token: string
 	): Promise<{ data?: Trade; error?: string }> {
 		return this.request<Trade>(`/api/trades/${id}`, {
 			method: 'PUT',
+			headers: {
+				Authorization: `Bearer ${token}`,
+				'If-Match': `"${version}"`
+			},
+			body: JSON.stringify(req)
+		});
+	}
+
+	async patchTrade(
+		id: number,
+		patch: Partial<Record<keyof UpdateTradeRequest, unknown>>,
+		version: number,
+		token: string
+	): Promise<{ data?: Trade; error?: string }> {
+		return this.request<Trade>(`/api/trades/${id}`, {
+			method: 'PATCH',
+			headers: {
+				Authorization: `Bearer ${token}`,
+				'Content-Type': 'application/merge-patch+json',
+				'If-Match': `"${version}"`
+			},
+			body: JSON.stringify(patch)
+		});
+	}
+
+	async bulkTrades(
+		req: BulkTradeRequest,
+		token: string
+	): Promise<{ data?: BulkTradeResult; error?: string }> {
+		return this.request<BulkTradeResult>('/api/trades/bulk', {
+			method: 'POST',
 			headers: {
 				Authorization: `Bearer ${token}`
 			},
@@ -391,8 +606,68 @@ class ApiClient {
 		});
 	}
 
-	async deleteTrade(id: number, token: string): Promise<{ data?: any; error?: string }> {
+	async duplicateTrade(id: number, token: string): Promise<{ data?: Trade; error?: string }> {
+		return this.request<Trade>(`/api/trades/${id}/duplicate`, {
+			method: 'POST',
+			headers: {
+				Authorization: `Bearer ${token}`
+			}
+		});
+	}
+
+	async deleteTrade(
+		id: number,
+		version: number,
+		token: string
+	): Promise<{ data?: any; error?: string }> {
 		return this.request<any>(`/api/trades/${id}`, {
+			method: 'DELETE',
+			headers: {
+				Authorization: `Bearer ${token}`,
+				'If-Match': `"${version}"`
+			}
+		});
+	}
+
+	// Trade Template APIs
+	async getTradeTemplates(token: string): Promise<{ data?: TradeTemplate[]; error?: string }> {
+		return this.request<TradeTemplate[]>('/api/trade-templates', {
+			method: 'GET',
+			headers: {
+				Authorization: `Bearer ${token}`
+			}
+		});
+	}
+
+	async createTradeTemplate(
+		req: TradeTemplateRequest,
+		token: string
+	): Promise<{ data?: TradeTemplate; error?: string }> {
+		return this.request<TradeTemplate>('/api/trade-templates', {
+			method: 'POST',
+			headers: {
+				Authorization: `Bearer ${token}`
+			},
+			body: JSON.stringify(req)
+		});
+	}
+
+	async updateTradeTemplate(
+		id: number,
+		req: TradeTemplateRequest,
+		token: string
+	): Promise<{ data?: TradeTemplate; error?: string }> {
+		return this.request<TradeTemplate>(`/api/trade-templates/${id}`, {
+			method: 'PUT',
+			headers: {
+				Authorization: `Bearer ${token}`
+			},
+			body: JSON.stringify(req)
+		});
+	}
+
+	async deleteTradeTemplate(id: number, token: string): Promise<{ data?: any; error?: string }> {
+		return this.request<any>(`/api/trade-templates/${id}`, {
 			method: 'DELETE',
 			headers: {
 				Authorization: `Bearer ${token}`
@@ -2,6 +2,7 @@ import { apiClient } from '$lib/api/client';
 import { authStore } from '$lib/stores/auth.svelte';
 import { accountsStore } from '$lib/stores/accounts.svelte';
 import { strategiesStore } from '$lib/stores/strategies.svelte';
+import { emptyDashboardTrades, loadDashboardTrades } from './dashboard';
 import type { PageLoad } from './$types';
 
 export const load: PageLoad = async () => {
@@ -15,14 +16,8 @@ export const load: PageLoad = async () => {
 
 	// Return trades and analytics promises
 	const tradesPromise = token
-		? apiClient.getTrades(token).then(({ data, error }) => {
-				if (error) {
-					console.error('Failed to load trades:', error);
-					return [];
-				}
-				return data || [];
-		  })
-		: Promise.resolve([]);
+		? loadDashboardTrades(token)
+		: Promise.resolve(emptyDashboardTrades);
 
 	const analyticsPromise = token
 		? apiClient.getAnalytics(token).then(({ data, error }) => {
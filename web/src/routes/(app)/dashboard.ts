@@ -0,0 +1,69 @@
+import { apiClient, type Trade } from '$lib/api/client';
+
+export interface DashboardFilters {
+	accountId?: number;
+	startDate?: string;
+	endDate?: string;
+}
+
+export interface DashboardTrades {
+	// Every open BUY/SELL trade; these are pinned to the top whatever page they fall on
+	open: Trade[];
+	// The pages of trades loaded so far, newest first
+	history: Trade[];
+	// Fetches the following page of history; null once it is all loaded
+	nextCursor: string | null;
+}
+
+// Loads the open trades along with the first page of history
+export async function loadDashboardTrades(
+	token: string,
+	filters: DashboardFilters = {}
+): Promise<DashboardTrades> {
+	const [open, history] = await Promise.all([
+		apiClient.getOpenTrades(token, filters.accountId, filters.startDate, filters.endDate),
+		apiClient.getTrades(token, filters.accountId, filters.startDate, filters.endDate)
+	]);
+
+	if (open.error) {
+		console.error('Failed to load open trades:', open.error);
+	}
+	if (history.error) {
+		console.error('Failed to load trades:', history.error);
+	}
+
+	return {
+		open: open.data || [],
+		history: history.data?.trades || [],
+		nextCursor: history.data?.next_cursor ?? null
+	};
+}
+
+// Appends the next page of history; the trades are returned unchanged if it fails to load
+export async function loadMoreDashboardTrades(
+	token: string,
+	trades: DashboardTrades,
+	filters: DashboardFilters = {}
+): Promise<DashboardTrades> {
+	if (!trades.nextCursor) return trades;
+
+	const { data, error } = await apiClient.getTrades(
+		token,
+		filters.accountId,
+		filters.startDate,
+		filters.endDate,
+		{ cursor: trades.nextCursor }
+	);
+	if (error || !data) {
+		console.error('Failed to load more trades:', error);
+		return trades;
+	}
+
+	return {
+		...trades,
+		history: [...trades.history, ...data.trades],
+		nextCursor: data.next_cursor
+	};
+}
+
+export const emptyDashboardTrades: DashboardTrades = { open: [], history: [], nextCursor: null };